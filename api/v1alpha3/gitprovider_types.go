@@ -3,6 +3,8 @@
 package v1alpha3
 
 import (
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -18,6 +20,9 @@ import (
 // commit identity/signing.
 //
 // +kubebuilder:validation:XValidation:rule="self.url == oldSelf.url",message="spec.url is immutable; delete and recreate the GitProvider to point at a different repository"
+// +kubebuilder:validation:XValidation:rule="!has(self.clientCertSecretRef) || self.url.startsWith('https://')",message="spec.clientCertSecretRef requires an https:// url"
+// +kubebuilder:validation:XValidation:rule="!has(self.oidc) || self.url.startsWith('https://')",message="spec.oidc requires an https:// url"
+// +kubebuilder:validation:XValidation:rule="!(has(self.oidc) && has(self.secretRef))",message="spec.oidc and spec.secretRef are mutually exclusive"
 type GitProviderSpec struct {
 	// URL of the repository (HTTP/SSH).
 	// Immutable: delete and recreate the GitProvider to point at a different repository.
@@ -36,6 +41,20 @@ type GitProviderSpec struct {
 	// +optional
 	KnownHostsRef *KnownHostsReference `json:"knownHostsRef,omitempty"`
 
+	// ClientCertSecretRef optionally points at a namespace-local Secret holding a client
+	// certificate for mutual TLS with an HTTPS remote, in the kubernetes.io/tls layout: tls.crt and
+	// tls.key, plus an optional ca.crt trusted in addition to the system roots. It combines with the
+	// HTTP credentials in secretRef, or stands alone when the certificate is the only credential.
+	// +optional
+	ClientCertSecretRef *LocalSecretReference `json:"clientCertSecretRef,omitempty"`
+
+	// OIDC authenticates to an HTTPS remote with a short-lived, audience-bound token for a
+	// ServiceAccount in this namespace, minted through the Kubernetes TokenRequest API and sent as
+	// a Bearer token. It is the credential for Git hosts that federate with the cluster's service
+	// account issuer (workload identity), and replaces secretRef rather than combining with it.
+	// +optional
+	OIDC *OIDCAuthSpec `json:"oidc,omitempty"`
+
 	// AllowedBranches restricts which branches can be written to.
 	// +required
 	// +kubebuilder:validation:MinItems=1
@@ -49,6 +68,126 @@ type GitProviderSpec struct {
 	// Commit configures commit identity, message formatting, and signing behavior.
 	// +optional
 	Commit *CommitSpec `json:"commit,omitempty"`
+
+	// ConnectionTimeout bounds each connectivity check and each repository preparation (listing
+	// refs, then fetching) against the remote, so an unreachable URL fails the attempt instead of
+	// hanging the reconciler or worker. The first fetch into an empty local clone is bounded by
+	// CloneTimeout instead. Must be between 5s and 5m. Defaults to "30s".
+	// +optional
+	// +kubebuilder:validation:MaxLength=32
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('5s') && duration(self) <= duration('5m')",message="connectionTimeout must be between 5s and 5m"
+	ConnectionTimeout *string `json:"connectionTimeout,omitempty"`
+
+	// CloneTimeout bounds the first fetch into an empty local clone, which downloads the branch's
+	// whole history and can take far longer than a later incremental fetch. Must be between 5s and
+	// 2h. Defaults to "10m".
+	// +optional
+	// +kubebuilder:validation:MaxLength=32
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('5s') && duration(self) <= duration('2h')",message="cloneTimeout must be between 5s and 2h"
+	CloneTimeout *string `json:"cloneTimeout,omitempty"`
+
+	// PushTimeout bounds each push to the remote, from opening the receive-pack session to the
+	// server's report, so a server that stops responding mid-transfer fails the push instead of
+	// stalling the branch worker. Must be between 5s and 30m. Defaults to "60s".
+	// +optional
+	// +kubebuilder:validation:MaxLength=32
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('5s') && duration(self) <= duration('30m')",message="pushTimeout must be between 5s and 30m"
+	PushTimeout *string `json:"pushTimeout,omitempty"`
+
+	// CheckInterval is how often the repository connectivity check is repeated. Must be at least
+	// 10s. Defaults to "5m", the control plane's steady reconcile interval.
+	// +optional
+	// +kubebuilder:validation:MaxLength=32
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('10s')",message="checkInterval must be at least 10s"
+	CheckInterval *string `json:"checkInterval,omitempty"`
+
+	// Mirrors names other GitProviders in this namespace that receive every branch this provider
+	// pushes, right after each successful push. Each mirror is reached with its own url and credentials,
+	// and only receives branches its allowedBranches admit. A mirror push is best-effort: a failure
+	// leaves the primary write in place, is recorded as a MirrorPushFailed Event and the MirrorsPushed
+	// condition on the GitTargets the push carried, and is retried by the next push.
+	// +optional
+	Mirrors []GitProviderMirror `json:"mirrors,omitempty"`
+}
+
+// GitProviderMirror names a GitProvider that receives every branch another GitProvider pushes.
+type GitProviderMirror struct {
+	GitProviderReference `json:",inline"`
+
+	// Force lets the push overwrite the mirror's branch when it has diverged from the primary's.
+	// Off by default: a mirror that holds commits the primary does not is reported as a failed
+	// mirror push and left as it is.
+	// +optional
+	Force bool `json:"force,omitempty"`
+}
+
+// DefaultConnectionTimeout is the network timeout used when spec.connectionTimeout is omitted.
+const DefaultConnectionTimeout = 30 * time.Second
+
+// EffectiveConnectionTimeout returns spec.connectionTimeout, or DefaultConnectionTimeout when it is
+// omitted or unparseable. The CRD rejects unparseable values, so the fallback only covers objects
+// stored before the field was validated.
+func (s *GitProviderSpec) EffectiveConnectionTimeout() time.Duration {
+	return parsePositiveDuration(s.ConnectionTimeout, DefaultConnectionTimeout)
+}
+
+// DefaultCloneTimeout is the first-fetch timeout used when spec.cloneTimeout is omitted.
+const DefaultCloneTimeout = 10 * time.Minute
+
+// EffectiveCloneTimeout returns spec.cloneTimeout, or DefaultCloneTimeout when it is omitted or
+// unparseable.
+func (s *GitProviderSpec) EffectiveCloneTimeout() time.Duration {
+	return parsePositiveDuration(s.CloneTimeout, DefaultCloneTimeout)
+}
+
+// DefaultPushTimeout is the push timeout used when spec.pushTimeout is omitted.
+const DefaultPushTimeout = 60 * time.Second
+
+// EffectivePushTimeout returns spec.pushTimeout, or DefaultPushTimeout when it is omitted or
+// unparseable.
+func (s *GitProviderSpec) EffectivePushTimeout() time.Duration {
+	return parsePositiveDuration(s.PushTimeout, DefaultPushTimeout)
+}
+
+// EffectiveCheckInterval returns spec.checkInterval, or fallback when it is omitted or unparseable.
+// The fallback is the caller's: the default is the controller's steady reconcile interval, which
+// this package does not own.
+func (s *GitProviderSpec) EffectiveCheckInterval(fallback time.Duration) time.Duration {
+	return parsePositiveDuration(s.CheckInterval, fallback)
+}
+
+func parsePositiveDuration(value *string, fallback time.Duration) time.Duration {
+	if value == nil {
+		return fallback
+	}
+	d, err := time.ParseDuration(*value)
+	if err != nil || d <= 0 {
+		return fallback
+	}
+	return d
+}
+
+// OIDCAuthSpec configures ServiceAccount token authentication for a GitProvider.
+type OIDCAuthSpec struct {
+	// ServiceAccountName is the ServiceAccount, in the GitProvider's namespace, the token is
+	// issued for.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	ServiceAccountName string `json:"serviceAccountName"`
+
+	// Audience is the single audience the token is bound to: the value the Git host's identity
+	// federation expects in the token's aud claim. It must be one of the controller's
+	// --oidc-allowed-audiences.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Audience string `json:"audience"`
+
+	// TokenExpirySeconds is the requested token lifetime. The API server may issue a shorter one;
+	// the token is renewed once less than a tenth of its actual lifetime remains.
+	// +optional
+	// +kubebuilder:default=3600
+	// +kubebuilder:validation:Minimum=600
+	TokenExpirySeconds int64 `json:"tokenExpirySeconds,omitempty"`
 }
 
 // LocalSecretReference is a typed reference to a Secret in the same namespace.
@@ -100,6 +239,45 @@ type EncryptionSpec struct {
 	// Age configures age-specific encryption behavior for SOPS.
 	// +optional
 	Age *AgeEncryptionSpec `json:"age,omitempty"`
+
+	// AWSKMS adds AWS KMS keys SOPS encrypts the data key to. sops authenticates with the
+	// controller Pod's AWS credentials (e.g. IRSA), never with Secret data.
+	// +optional
+	AWSKMS *AWSKMSEncryptionSpec `json:"awsKms,omitempty"`
+
+	// Vault adds HashiCorp Vault transit keys SOPS encrypts the data key to. sops authenticates
+	// with the controller Pod's Vault environment (VAULT_TOKEN or VAULT_ADDR-based auth).
+	// +optional
+	Vault *VaultEncryptionSpec `json:"vault,omitempty"`
+
+	// EncryptedKeys limits encryption to the listed data and stringData keys, so the rest of a
+	// Secret stays readable in review. sops matches the names at any depth of the manifest, so
+	// they must not collide with manifest structure fields such as name or labels. Empty
+	// encrypts all of data and stringData.
+	// +optional
+	// +kubebuilder:validation:items:MinLength=1
+	EncryptedKeys []string `json:"encryptedKeys,omitempty"`
+}
+
+// AWSKMSEncryptionSpec lists the AWS KMS keys a SOPS-encrypted document can be decrypted with.
+type AWSKMSEncryptionSpec struct {
+	// KeyARNs are AWS KMS key or alias ARNs (arn:aws:kms:<region>:<account>:key/<id>). Any one
+	// of them, or any configured age or Vault key, decrypts a document.
+	// +required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:items:MinLength=1
+	KeyARNs []string `json:"keyARNs"`
+}
+
+// VaultEncryptionSpec lists the Vault transit keys a SOPS-encrypted document can be decrypted with.
+type VaultEncryptionSpec struct {
+	// TransitURIs are full Vault transit key URIs, as sops expects them:
+	// https://<vault-address>/v1/<transit-mount>/keys/<key-name>. Any one of them, or any
+	// configured age or AWS KMS key, decrypts a document.
+	// +required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:items:MinLength=1
+	TransitURIs []string `json:"transitURIs"`
 }
 
 // AgeEncryptionSpec configures age recipient resolution behavior.
@@ -150,6 +328,30 @@ type GitProviderStatus struct {
 	// Only populated when commit.signing is configured and a signing key is available.
 	// +optional
 	SigningPublicKey string `json:"signingPublicKey,omitempty"`
+
+	// DefaultBranch is the branch the remote's HEAD pointed at on the last successful
+	// connectivity check. Empty for an empty repository, whose default branch is not yet known.
+	// +optional
+	DefaultBranch string `json:"defaultBranch,omitempty"`
+
+	// RemoteBranchCount is the number of branches the remote advertised on the last successful
+	// connectivity check.
+	// +optional
+	RemoteBranchCount int `json:"remoteBranchCount,omitempty"`
+
+	// LastCheckedAt is when the last successful connectivity check completed.
+	// +optional
+	LastCheckedAt *metav1.Time `json:"lastCheckedAt,omitempty"`
+
+	// ConsecutiveFailures counts connectivity checks that failed in a row since the last success.
+	// It is reset to 0 by a successful check.
+	// +optional
+	ConsecutiveFailures int `json:"consecutiveFailures,omitempty"`
+
+	// TokenExpiresAt is when the spec.oidc ServiceAccount token used by the last connectivity
+	// check expires. Unset when spec.oidc is not configured.
+	// +optional
+	TokenExpiresAt *metav1.Time `json:"tokenExpiresAt,omitempty"`
 }
 
 // CommitSpec configures how gitops-reverser creates commits for a GitProvider.
@@ -187,7 +389,7 @@ type CommitMessageSpec struct {
 	// EventTemplate is a Go text/template string for per-event commit messages
 	// (used when commitWindow is "0s"; one event per commit).
 	// Available variables: Operation, Group, Version, Resource, Namespace, Name,
-	// APIVersion, Username, GitTarget.
+	// APIVersion, Username, Groups, GitTarget.
 	// +optional
 	EventTemplate string `json:"eventTemplate,omitempty"`
 
@@ -204,7 +406,7 @@ type CommitMessageSpec struct {
 	// GroupTemplate is a Go text/template string for grouped commit messages
 	// (the commit-window path; one commit per (author, gitTarget) group
 	// produced by the batching pipeline).
-	// Available variables: Author, GitTarget, Count, Operations (map of
+	// Available variables: Author, Groups, GitTarget, Count, Operations (map of
 	// CREATE/UPDATE/DELETE counts), Resources (slice of {Group, Version,
 	// Resource, Namespace, Name}).
 	// +optional
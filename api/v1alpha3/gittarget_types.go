@@ -3,6 +3,7 @@
 package v1alpha3
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -51,6 +52,7 @@ type GitProviderReference struct {
 // providerRef/branch/path above — and defaults to a ClusterProvider named "default", so it is
 // always populated (never nil) and always jumpable.
 // +kubebuilder:validation:XValidation:rule="self.clusterProviderRef == oldSelf.clusterProviderRef",message="spec.clusterProviderRef is immutable; delete and recreate the GitTarget to change the cluster it mirrors"
+// +kubebuilder:validation:XValidation:rule="!has(self.pullRequest) || self.pullRequest.baseBranch != self.branch",message="spec.pullRequest.baseBranch must differ from spec.branch, the branch the pull request is opened from"
 type GitTargetSpec struct {
 	// ProviderRef references the GitProvider that backs this target.
 	// Immutable: delete and recreate the GitTarget to change its destination.
@@ -87,6 +89,26 @@ type GitTargetSpec struct {
 	// +optional
 	Placement *GitTargetPlacementSpec `json:"placement,omitempty"`
 
+	// YAML declares how NEW documents are rendered: block or flow style. Like placement it has
+	// no effect on a document that already exists in Git, which is edited in place and keeps its
+	// style. Omitted, new documents are block style.
+	// +optional
+	YAML *YAMLOutputSpec `json:"yaml,omitempty"`
+
+	// OutputFormat is the file format of NEW documents: `YAML`, or `JSON` for pretty-printed,
+	// key-sorted JSON in a .json file. A document that already exists keeps the format of its
+	// file, and a Secret written under spec.encryption is always SOPS YAML. Omitted, it is `YAML`.
+	// +optional
+	// +kubebuilder:validation:Enum=YAML;JSON
+	OutputFormat OutputFormat `json:"outputFormat,omitempty"`
+
+	// MaxResourceBytes caps the size of one resource's rendered document. A resource whose
+	// document is larger is not written: the write is skipped and logged, and what Git already
+	// holds for it is left as it is. Omitted or 0, resources are written at any size.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxResourceBytes int64 `json:"maxResourceBytes,omitempty"`
+
 	// Design rationale, kept out of the generated CRD description by the blank line below.
 	//
 	// It defaults to a concrete {name: "default"} rather than an implicit nil so a target that omits
@@ -144,6 +166,225 @@ type GitTargetSpec struct {
 	// ones are not — for a stored GitTarget as well as a new one.
 	// +optional
 	Prune *PrunePolicy `json:"prune,omitempty"`
+
+	// Design rationale, kept out of the generated CRD description by the blank line below.
+	//
+	// Only live UPDATEs are throttled. A CREATE or DELETE changes which documents exist, and
+	// dropping one would leave the folder wrong until the next replay; an UPDATE dropped here is
+	// superseded by the next one for the same object, which is exactly the churn this bounds.
+
+	// PerGVRThrottle caps how many live UPDATE events per second are routed to Git for one
+	// resource type, keyed by the same "[group/]version/resource" type key as placement.byType
+	// (e.g. "v1/pods", "apps/v1/deployments"). Updates above the rate are dropped, not queued: the
+	// folder catches up on the object's next unthrottled update or on the next replay. CREATE and
+	// DELETE events are never throttled. Types without an entry are not throttled.
+	// +optional
+	PerGVRThrottle map[string]RateLimitSpec `json:"perGVRThrottle,omitempty"`
+
+	// Design rationale, kept out of the generated CRD description by the blank line below.
+	//
+	// This lives on the GitTarget rather than on the WatchRules that feed it: the document a type
+	// renders to is a property of the folder, and two rules covering the same type with different
+	// rules would otherwise flip the document between them on every event.
+
+	// SanitizePerGVR adjusts what is written to Git for one resource type, keyed by the same
+	// "[group/]version/resource" type key as placement.byType. keepAnnotations and keepLabels
+	// exempt bookkeeping keys from the built-in sanitization; every other rule applies after it
+	// and can only remove more. It lives here rather than on a WatchRule because a document's
+	// shape is a property of the folder it is written to: two WatchRules feeding one GitTarget
+	// with different rules for a type would make its documents flip between them.
+	// +optional
+	SanitizePerGVR map[string]GVRSanitizeSpec `json:"sanitizePerGVR,omitempty"`
+
+	// PreserveManagedFields keeps metadata.managedFields, the server-side-apply field ownership,
+	// in every document this GitTarget writes. Entries are written in a stable order. Every
+	// write to an object, /status included, updates a managedFields timestamp, so updates that
+	// would otherwise be dropped as unchanged become commits. Omitted, managedFields are
+	// stripped.
+	// +optional
+	PreserveManagedFields bool `json:"preserveManagedFields,omitempty"`
+
+	// DedupStrategy selects how a live UPDATE is recognized as carrying nothing new, so it is
+	// dropped before it reaches Git. `ContentHash` compares a hash of the sanitized object; it is
+	// the only strategy that also drops /status-only updates, whose resourceVersion changes but
+	// whose Git content does not. `ResourceVersion` compares metadata.resourceVersion only, which
+	// skips hashing but drops nothing except a redelivered event. `Both` drops on an unchanged
+	// resourceVersion without hashing and hashes the rest. Omitted, it is `ContentHash`.
+	// +optional
+	// +kubebuilder:validation:Enum=ContentHash;ResourceVersion;Both
+	// +kubebuilder:default=ContentHash
+	DedupStrategy DedupStrategy `json:"dedupStrategy,omitempty"`
+
+	// UserMapping rewrites the git author of commits attributed to a Kubernetes user, for users
+	// whose cluster identity is not the name and email they commit under. Unmapped users keep the
+	// author attribution already derives.
+	// +optional
+	UserMapping *UserMappingSpec `json:"userMapping,omitempty"`
+
+	// PullRequest changes how this target's writes reach a protected branch: instead of pushing
+	// to it, the operator pushes to spec.branch as usual and keeps a pull request (GitHub) or merge
+	// request (GitLab) open from spec.branch into pullRequest.baseBranch. The base branch is never
+	// pushed, so only spec.branch has to be in the GitProvider's allowedBranches. The API token is
+	// the password or bearer token of the GitProvider's HTTPS credentials. The open request is
+	// reported in status.pullRequest. Omitted, writes are pushed to spec.branch and nothing more.
+	// +optional
+	PullRequest *GitTargetPullRequestSpec `json:"pullRequest,omitempty"`
+}
+
+// PullRequestAPI names the hosting provider API a GitTarget opens its pull request through.
+type PullRequestAPI string
+
+const (
+	// PullRequestAPIGitHub is the GitHub and GitHub Enterprise Server pulls API.
+	PullRequestAPIGitHub PullRequestAPI = "GitHub"
+	// PullRequestAPIGitLab is the GitLab merge requests API.
+	PullRequestAPIGitLab PullRequestAPI = "GitLab"
+)
+
+// GitTargetPullRequestSpec declares the pull request a GitTarget's writes are proposed through.
+type GitTargetPullRequestSpec struct {
+	// BaseBranch is the branch the pull request merges into. It must differ from spec.branch.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	BaseBranch string `json:"baseBranch"`
+
+	// API is the hosting provider's pull request API. Omitted, it is `GitHub`.
+	// +optional
+	// +kubebuilder:validation:Enum=GitHub;GitLab
+	// +kubebuilder:default=GitHub
+	API PullRequestAPI `json:"api,omitempty"`
+
+	// APIURL overrides the REST API root derived from the GitProvider URL's host:
+	// https://api.github.com for github.com, https://{host}/api/v3 for any other GitHub host, and
+	// https://{host}/api/v4 for GitLab. The GitProvider's API token is sent to it, so it must be
+	// https, and its host must be the GitProvider URL's host or the derived root's; the branch
+	// worker refuses any other host.
+	// +optional
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:XValidation:rule="isURL(self) && url(self).getScheme() == 'https'",message="apiURL must be an https URL"
+	APIURL string `json:"apiURL,omitempty"`
+}
+
+// DedupStrategy enumerates how the live event path recognizes an UPDATE that changes nothing.
+type DedupStrategy string
+
+const (
+	// DedupContentHash compares the sanitized object's hash. It is the effective default.
+	DedupContentHash DedupStrategy = "ContentHash"
+	// DedupResourceVersion compares metadata.resourceVersion and never hashes.
+	DedupResourceVersion DedupStrategy = "ResourceVersion"
+	// DedupBoth compares metadata.resourceVersion first and hashes only when it changed.
+	DedupBoth DedupStrategy = "Both"
+)
+
+// EffectiveDedupStrategy is the strategy the live event path applies, with the omitted-field
+// default applied. An unrecognized value also resolves to ContentHash, the strategy that drops
+// the most no-op updates without ever dropping a content change.
+func (g *GitTarget) EffectiveDedupStrategy() DedupStrategy {
+	if s := g.Spec.DedupStrategy; s == DedupResourceVersion || s == DedupBoth {
+		return s
+	}
+	return DedupContentHash
+}
+
+// UserMappingSecretKey is the Secret key UserMappingSpec reads the mapping from.
+const UserMappingSecretKey = "users.json"
+
+// UserMappingSpec maps Kubernetes usernames to git commit authors. An exact entry in the Secret
+// wins over the patterns.
+// +kubebuilder:validation:XValidation:rule="has(self.secretRef) || (has(self.patterns) && size(self.patterns) > 0)",message="userMapping needs a secretRef, patterns, or both"
+type UserMappingSpec struct {
+	// SecretRef names a namespace-local Secret whose "users.json" key holds a JSON object from
+	// Kubernetes username to git author in "Name <email>" form, e.g.
+	// {"alice@company.com": "Alice Smith <alice@company.com>"}. The Secret is read for every
+	// commit, so edits apply from the next commit without restarting anything.
+	// +optional
+	SecretRef *LocalSecretReference `json:"secretRef,omitempty"`
+
+	// Patterns map usernames with no exact Secret entry, in order: the first pattern that matches
+	// decides the author.
+	// +optional
+	// +kubebuilder:validation:MaxItems=64
+	Patterns []UserMappingPattern `json:"patterns,omitempty"`
+}
+
+// UserMappingPattern maps every username matching a regular expression to one git author.
+type UserMappingPattern struct {
+	// UsernamePattern is a Go regular expression matched against the whole username, e.g.
+	// "system:serviceaccount:(.+):(.+)".
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	UsernamePattern string `json:"usernamePattern"`
+	// GitName is the author name. It may reference capture groups as $1 or ${1}; use ${1} when
+	// the reference is followed by a letter, digit or underscore.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	GitName string `json:"gitName"`
+	// GitEmail is the author email, with the same capture group references as GitName, e.g.
+	// "${2}@serviceaccounts.internal". A match whose expanded email is not a valid address keeps
+	// the author attribution derives.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	GitEmail string `json:"gitEmail"`
+}
+
+// GVRSanitizeSpec lists what to keep and what to remove in one resource type's documents. A list
+// entry is an exact key, or a prefix when it ends in "*" (e.g. "example.com/*").
+type GVRSanitizeSpec struct {
+	// KeepAnnotations keeps the annotations it matches even when the built-in sanitization strips
+	// them as controller bookkeeping, e.g. "deployment.kubernetes.io/revision". A kept annotation
+	// still has to pass annotationAllowlist and annotationBlocklist.
+	// +optional
+	KeepAnnotations []string `json:"keepAnnotations,omitempty"`
+	// KeepLabels keeps the labels it matches even when the built-in sanitization strips them as
+	// controller bookkeeping. A kept label still has to pass labelAllowlist and labelBlocklist.
+	// +optional
+	KeepLabels []string `json:"keepLabels,omitempty"`
+	// AnnotationAllowlist, when set, keeps only the annotations it matches.
+	// +optional
+	AnnotationAllowlist []string `json:"annotationAllowlist,omitempty"`
+	// AnnotationBlocklist removes the annotations it matches, after the allowlist.
+	// +optional
+	AnnotationBlocklist []string `json:"annotationBlocklist,omitempty"`
+	// LabelAllowlist, when set, keeps only the labels it matches.
+	// +optional
+	LabelAllowlist []string `json:"labelAllowlist,omitempty"`
+	// LabelBlocklist removes the labels it matches, after the allowlist.
+	// +optional
+	LabelBlocklist []string `json:"labelBlocklist,omitempty"`
+	// StripFields are JSONPath field references removed from the document, e.g. ".spec.replicas"
+	// for a Deployment scaled by an autoscaler, or ".metadata.annotations['example.com/x']" for a
+	// key holding a dot. "$." and "{...}" forms are accepted, as is a plain "spec.replicas".
+	// Wildcards, indexes and filters are not. A path may not remove apiVersion, kind, metadata,
+	// metadata.name or metadata.namespace.
+	// +optional
+	StripFields []string `json:"stripFields,omitempty"`
+	// SortListFields are dot-separated paths to lists written in a stable order, so a list the
+	// cluster reorders without changing it does not show as a diff. Elements that all carry a
+	// string "name" are sorted by it; any other list is sorted by each element's JSON. Only list
+	// order that carries no meaning belongs here: env (which may reference earlier variables)
+	// and initContainers (which run in order) do not.
+	// +optional
+	SortListFields []string `json:"sortListFields,omitempty"`
+	// RedactFields are dot-separated field paths whose values are written as "***REDACTED***",
+	// e.g. "spec.template.spec.containers[*].env[*].value". A segment ending in "[*]" applies the
+	// rest of the path to every list element. A path ending on an object or list redacts every
+	// value inside it and keeps its keys. A path that is absent redacts nothing. A path may not
+	// redact apiVersion, kind, metadata, metadata.name or metadata.namespace.
+	// +optional
+	RedactFields []string `json:"redactFields,omitempty"`
+}
+
+// RateLimitSpec is a token-bucket rate for one resource type.
+type RateLimitSpec struct {
+	// MaxEventsPerSecond is the sustained rate of events admitted, as a positive quantity: "5",
+	// or "500m" for one event every two seconds. Bursts of up to one second's worth of events (at
+	// least one) are admitted before the rate applies.
+	// +required
+	MaxEventsPerSecond resource.Quantity `json:"maxEventsPerSecond"`
 }
 
 // GitTargetPlacementSpec declares where NEW resources are written when no document
@@ -223,6 +464,54 @@ type GitTargetStatus struct {
 	// never a fault, and no condition changes state because of it.
 	// +optional
 	Retention *GitTargetRetentionStatus `json:"retention,omitempty"`
+
+	// PullRequest is the open pull request spec.pullRequest keeps for this target's writes. It is
+	// set by the first push after the request is found or opened, and kept until spec.pullRequest
+	// is removed.
+	// +optional
+	PullRequest *GitTargetPullRequestStatus `json:"pullRequest,omitempty"`
+
+	// WorkerState is what the branch worker writing spec.branch is doing, as of the last reconcile.
+	// One worker serves every GitTarget on a branch, so they all report the same state.
+	// +optional
+	// +kubebuilder:validation:Enum=Idle;Fetching;Committing;Pushing;Conflicting;Errored
+	WorkerState string `json:"workerState,omitempty"`
+
+	// LastStateTransition is when the branch worker last changed state.
+	// +optional
+	LastStateTransition *metav1.Time `json:"lastStateTransition,omitempty"`
+
+	// StateHistory is the branch worker's latest state transitions, newest first. A worker stuck
+	// in Pushing against an unreachable remote shows one old transition; one cycling through
+	// Committing under load shows many recent ones.
+	// +optional
+	// +kubebuilder:validation:MaxItems=10
+	StateHistory []StateTransition `json:"stateHistory,omitempty"`
+}
+
+// StateTransition is one branch worker state change.
+type StateTransition struct {
+	// From is the state the worker left.
+	// +optional
+	From string `json:"from,omitempty"`
+
+	// To is the state the worker entered.
+	To string `json:"to"`
+
+	// Reason is why the worker changed state, e.g. PushFailed.
+	Reason string `json:"reason"`
+
+	// Timestamp is when the worker changed state.
+	Timestamp metav1.Time `json:"timestamp"`
+}
+
+// GitTargetPullRequestStatus identifies the pull request a GitTarget's writes are proposed through.
+type GitTargetPullRequestStatus struct {
+	// Number is the pull request number (GitHub) or merge request IID (GitLab).
+	Number int32 `json:"number"`
+
+	// URL is the pull request's web page.
+	URL string `json:"url"`
 }
 
 // GitTargetStreamsStatus is a bounded roll-up of the stream readiness state for the
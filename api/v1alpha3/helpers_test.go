@@ -4,6 +4,7 @@ package v1alpha3
 
 import (
 	"testing"
+	"time"
 
 	meta "github.com/fluxcd/pkg/apis/meta"
 	"github.com/stretchr/testify/assert"
@@ -323,3 +324,44 @@ func TestIsLocalSource_IsANameTest(t *testing.T) {
 		})
 	}
 }
+
+func TestGitProviderSpec_EffectiveTimings(t *testing.T) {
+	t.Parallel()
+
+	str := func(s string) *string { return &s }
+
+	var spec GitProviderSpec
+	assert.Equal(t, DefaultConnectionTimeout, spec.EffectiveConnectionTimeout())
+	assert.Equal(t, DefaultPushTimeout, spec.EffectivePushTimeout())
+	assert.Equal(t, DefaultCloneTimeout, spec.EffectiveCloneTimeout())
+	assert.Equal(t, 5*time.Minute, spec.EffectiveCheckInterval(5*time.Minute))
+
+	spec.ConnectionTimeout = str("10s")
+	spec.PushTimeout = str("2m")
+	spec.CheckInterval = str("1m")
+	spec.CloneTimeout = str("1h")
+	assert.Equal(t, 10*time.Second, spec.EffectiveConnectionTimeout())
+	assert.Equal(t, time.Hour, spec.EffectiveCloneTimeout())
+	assert.Equal(t, 2*time.Minute, spec.EffectivePushTimeout())
+	assert.Equal(t, time.Minute, spec.EffectiveCheckInterval(5*time.Minute))
+
+	spec.ConnectionTimeout = str("soon")
+	spec.CheckInterval = str("-1m")
+	assert.Equal(t, DefaultConnectionTimeout, spec.EffectiveConnectionTimeout(), "unparseable falls back")
+	assert.Equal(t, 5*time.Minute, spec.EffectiveCheckInterval(5*time.Minute), "non-positive falls back")
+}
+
+func TestGitTarget_EffectiveDedupStrategy(t *testing.T) {
+	t.Parallel()
+
+	for declared, want := range map[DedupStrategy]DedupStrategy{
+		"":                   DedupContentHash,
+		DedupContentHash:     DedupContentHash,
+		DedupResourceVersion: DedupResourceVersion,
+		DedupBoth:            DedupBoth,
+		"Sometimes":          DedupContentHash,
+	} {
+		target := &GitTarget{Spec: GitTargetSpec{DedupStrategy: declared}}
+		assert.Equal(t, want, target.EffectiveDedupStrategy(), "declared %q", declared)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 // OperationType specifies the type of operation that triggers a watch event.
@@ -23,6 +24,12 @@ const (
 	OperationAll OperationType = "*"
 )
 
+// ExcludeAnnotation on a watched object, set to "true", keeps that one object out of Git without
+// narrowing the WatchRule that matches it. Its live creates and updates are routed as a delete,
+// so setting it on an object already in Git removes that document, and snapshots leave it out of
+// the desired set. It is the default for the controller's --exclude-annotation flag.
+const ExcludeAnnotation = "configbutler.ai/gitops-exclude"
+
 type LocalTargetReference struct {
 	// API Group of the referent.
 	// +kubebuilder:default=configbutler.ai
@@ -120,6 +127,52 @@ type ResourceRule struct {
 	// +kubebuilder:validation:items:Pattern=`^[^/]*$`
 	Resources []string `json:"resources"`
 
+	// ObjectSelector narrows this item to objects whose labels match, e.g.
+	// matchLabels: {gitops.io/export: "true"}. If omitted, every object of the matched types is
+	// watched. An object whose labels stop matching is removed from Git, exactly as if it had
+	// been deleted; one whose labels start matching is written.
+	//
+	// When every item selecting a type in a namespace carries the same selector, it is handed to
+	// the API server, so non-matching objects are never listed or streamed. Differing selectors
+	// on one type are ORed and evaluated in the controller.
+	// +optional
+	ObjectSelector *metav1.LabelSelector `json:"objectSelector,omitempty"`
+
+	// NameIncludes narrows this item to objects whose name matches at least one of these globs,
+	// e.g. "app-*". "*" matches any run of characters and "?" any single one; character classes
+	// are not supported. If omitted, every name is included.
+	// +optional
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:Pattern=`^[^/\\\[\]]+$`
+	NameIncludes []string `json:"nameIncludes,omitempty"`
+
+	// NameExcludes leaves out objects whose name matches any of these globs, e.g. "*-token" or
+	// "sh.helm.release.*", with the same syntax as nameIncludes. An exclude wins over an include.
+	// Excluding a name that is already in Git removes its document, exactly as if the object had
+	// been deleted.
+	//
+	// Name globs are evaluated in the controller: the API server has no name-pattern filter, so
+	// every object of the matched types is still listed and streamed.
+	// +optional
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:Pattern=`^[^/\\\[\]]+$`
+	NameExcludes []string `json:"nameExcludes,omitempty"`
+
+	// IncludeStatus keeps each matched object's status in Git, after its spec. By default status
+	// is stripped, so a status-only change (a Certificate's expiry, a Deployment's replica count)
+	// creates no commit; with this set it does, for the types this item matches. When several
+	// items select a type in a namespace, status is kept if any of them sets this.
+	// +optional
+	IncludeStatus bool `json:"includeStatus,omitempty"`
+
+	// AggregateByKind writes each matched object as an item of one `kind: List` document per
+	// type and namespace ({namespace}/{group}/{resource}.yaml) instead of a file of its own. Items
+	// are kept sorted by name, and the file is deleted with its last item. It decides where NEW
+	// objects go: an object already in Git is edited where it is. A sensitive type, such as a
+	// Secret, is never aggregated.
+	// +optional
+	AggregateByKind bool `json:"aggregateByKind,omitempty"`
+
 	// Design rationale, kept out of the generated CRD description by the blank line below.
 	//
 	// Every item's outcome is aggregated into the ONE SourceNamespaceAuthorized condition, so
@@ -184,6 +237,21 @@ func (r *ResourceRule) OverridesSourceNamespace(ruleNamespace string) bool {
 	return r.IsSourceNamespaceWildcard() || r.EffectiveSourceNamespace(ruleNamespace) != ruleNamespace
 }
 
+// CompileObjectSelector converts this item's objectSelector into a labels.Selector. It returns nil
+// when no selector is declared, which means every object. A malformed selector is an error rather
+// than a silent allow or deny: dropping it would widen the mirror, and matching nothing would
+// sweep the item's documents out of Git.
+func (r *ResourceRule) CompileObjectSelector() (labels.Selector, error) {
+	if r.ObjectSelector == nil {
+		return nil, nil
+	}
+	sel, err := metav1.LabelSelectorAsSelector(r.ObjectSelector)
+	if err != nil {
+		return nil, fmt.Errorf("objectSelector: %w", err)
+	}
+	return sel, nil
+}
+
 // DescribeSourceNamespace renders this item's requested source namespace for an operator-facing
 // message. An omitted value is spelled out rather than shown as an empty string.
 func (r *ResourceRule) DescribeSourceNamespace(ruleNamespace string) string {
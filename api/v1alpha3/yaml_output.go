@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha3
+
+// YAMLStyle selects how the operator renders a resource's payload when it writes a new file.
+type YAMLStyle string
+
+const (
+	// YAMLStyleBlock renders the payload in indented block style. It is the effective default.
+	YAMLStyleBlock YAMLStyle = "Block"
+	// YAMLStyleFlow renders each top-level payload field in flow style ({...} / [...]).
+	YAMLStyleFlow YAMLStyle = "Flow"
+	// YAMLStyleAuto renders block style unless the document would exceed the auto-switch
+	// threshold, and flow style above it.
+	YAMLStyleAuto YAMLStyle = "Auto"
+)
+
+// YAMLKeyOrder selects the order of a new document's top-level keys after metadata.
+type YAMLKeyOrder string
+
+const (
+	// YAMLKeyOrderCanonical renders spec first and sorts the other keys, as kubectl does. It is
+	// the effective default.
+	YAMLKeyOrderCanonical YAMLKeyOrder = "Canonical"
+	// YAMLKeyOrderAlphabetical sorts every key after metadata, spec included.
+	YAMLKeyOrderAlphabetical YAMLKeyOrder = "Alphabetical"
+)
+
+// OutputFormat selects the file format the operator writes a new document in.
+type OutputFormat string
+
+const (
+	// OutputFormatYAML writes .yaml files. It is the effective default.
+	OutputFormatYAML OutputFormat = "YAML"
+	// OutputFormatJSON writes pretty-printed, key-sorted JSON to .json files.
+	OutputFormatJSON OutputFormat = "JSON"
+)
+
+// DefaultYAMLAutoSwitchThreshold is the Auto threshold, in bytes, when none is declared.
+const DefaultYAMLAutoSwitchThreshold = 10 * 1024
+
+// Design rationale, kept out of the generated CRD description by the blank line below.
+//
+// This lives on the GitTarget, not on WatchRule, because rendering is a property of the folder
+// being written, like placement: several WatchRules can select the same type into one target, and
+// per-rule styles would have no single answer for that type's file. It only applies where the
+// operator renders a document from scratch; an existing document is edited in place and keeps
+// whatever style it has, so changing this field never rewrites a folder.
+
+// YAMLOutputSpec declares how new documents are rendered.
+type YAMLOutputSpec struct {
+	// Style is the rendering style for the document's payload (everything after metadata):
+	// `Block`, `Flow`, or `Auto`, which uses block style unless the rendered document would exceed
+	// autoSwitchThreshold bytes. apiVersion, kind and metadata are always block style.
+	// Omitted, it is `Block`.
+	// +optional
+	// +kubebuilder:validation:Enum=Block;Flow;Auto
+	Style YAMLStyle `json:"style,omitempty"`
+
+	// AutoSwitchThreshold is the rendered size in bytes above which `Auto` switches to flow style.
+	// Ignored by the other styles. Omitted, it is 10240 (10KiB).
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	AutoSwitchThreshold int32 `json:"autoSwitchThreshold,omitempty"`
+
+	// KeyOrder is the order of the top-level keys after metadata: `Canonical` writes spec first
+	// and sorts the rest, `Alphabetical` sorts them all. apiVersion, kind and metadata always come
+	// first, and a kept status last. Omitted, it is `Canonical`.
+	// +optional
+	// +kubebuilder:validation:Enum=Canonical;Alphabetical
+	KeyOrder YAMLKeyOrder `json:"keyOrder,omitempty"`
+}
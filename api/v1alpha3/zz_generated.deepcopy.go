@@ -12,6 +12,26 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSKMSEncryptionSpec) DeepCopyInto(out *AWSKMSEncryptionSpec) {
+	*out = *in
+	if in.KeyARNs != nil {
+		in, out := &in.KeyARNs, &out.KeyARNs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSKMSEncryptionSpec.
+func (in *AWSKMSEncryptionSpec) DeepCopy() *AWSKMSEncryptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSKMSEncryptionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AgeEncryptionSpec) DeepCopyInto(out *AgeEncryptionSpec) {
 	*out = *in
@@ -525,6 +545,21 @@ func (in *EncryptionSpec) DeepCopyInto(out *EncryptionSpec) {
 		*out = new(AgeEncryptionSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AWSKMS != nil {
+		in, out := &in.AWSKMS, &out.AWSKMS
+		*out = new(AWSKMSEncryptionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(VaultEncryptionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EncryptedKeys != nil {
+		in, out := &in.EncryptedKeys, &out.EncryptedKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionSpec.
@@ -537,6 +572,66 @@ func (in *EncryptionSpec) DeepCopy() *EncryptionSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GVRSanitizeSpec) DeepCopyInto(out *GVRSanitizeSpec) {
+	*out = *in
+	if in.KeepAnnotations != nil {
+		in, out := &in.KeepAnnotations, &out.KeepAnnotations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.KeepLabels != nil {
+		in, out := &in.KeepLabels, &out.KeepLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AnnotationAllowlist != nil {
+		in, out := &in.AnnotationAllowlist, &out.AnnotationAllowlist
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AnnotationBlocklist != nil {
+		in, out := &in.AnnotationBlocklist, &out.AnnotationBlocklist
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LabelAllowlist != nil {
+		in, out := &in.LabelAllowlist, &out.LabelAllowlist
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LabelBlocklist != nil {
+		in, out := &in.LabelBlocklist, &out.LabelBlocklist
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StripFields != nil {
+		in, out := &in.StripFields, &out.StripFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SortListFields != nil {
+		in, out := &in.SortListFields, &out.SortListFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RedactFields != nil {
+		in, out := &in.RedactFields, &out.RedactFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GVRSanitizeSpec.
+func (in *GVRSanitizeSpec) DeepCopy() *GVRSanitizeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GVRSanitizeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitProvider) DeepCopyInto(out *GitProvider) {
 	*out = *in
@@ -596,6 +691,22 @@ func (in *GitProviderList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitProviderMirror) DeepCopyInto(out *GitProviderMirror) {
+	*out = *in
+	out.GitProviderReference = in.GitProviderReference
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitProviderMirror.
+func (in *GitProviderMirror) DeepCopy() *GitProviderMirror {
+	if in == nil {
+		return nil
+	}
+	out := new(GitProviderMirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitProviderReference) DeepCopyInto(out *GitProviderReference) {
 	*out = *in
@@ -624,6 +735,16 @@ func (in *GitProviderSpec) DeepCopyInto(out *GitProviderSpec) {
 		*out = new(KnownHostsReference)
 		**out = **in
 	}
+	if in.ClientCertSecretRef != nil {
+		in, out := &in.ClientCertSecretRef, &out.ClientCertSecretRef
+		*out = new(LocalSecretReference)
+		**out = **in
+	}
+	if in.OIDC != nil {
+		in, out := &in.OIDC, &out.OIDC
+		*out = new(OIDCAuthSpec)
+		**out = **in
+	}
 	if in.AllowedBranches != nil {
 		in, out := &in.AllowedBranches, &out.AllowedBranches
 		*out = make([]string, len(*in))
@@ -639,6 +760,31 @@ func (in *GitProviderSpec) DeepCopyInto(out *GitProviderSpec) {
 		*out = new(CommitSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ConnectionTimeout != nil {
+		in, out := &in.ConnectionTimeout, &out.ConnectionTimeout
+		*out = new(string)
+		**out = **in
+	}
+	if in.CloneTimeout != nil {
+		in, out := &in.CloneTimeout, &out.CloneTimeout
+		*out = new(string)
+		**out = **in
+	}
+	if in.PushTimeout != nil {
+		in, out := &in.PushTimeout, &out.PushTimeout
+		*out = new(string)
+		**out = **in
+	}
+	if in.CheckInterval != nil {
+		in, out := &in.CheckInterval, &out.CheckInterval
+		*out = new(string)
+		**out = **in
+	}
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make([]GitProviderMirror, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitProviderSpec.
@@ -661,6 +807,14 @@ func (in *GitProviderStatus) DeepCopyInto(out *GitProviderStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastCheckedAt != nil {
+		in, out := &in.LastCheckedAt, &out.LastCheckedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.TokenExpiresAt != nil {
+		in, out := &in.TokenExpiresAt, &out.TokenExpiresAt
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitProviderStatus.
@@ -754,6 +908,36 @@ func (in *GitTargetPlacementSpec) DeepCopy() *GitTargetPlacementSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitTargetPullRequestSpec) DeepCopyInto(out *GitTargetPullRequestSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitTargetPullRequestSpec.
+func (in *GitTargetPullRequestSpec) DeepCopy() *GitTargetPullRequestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GitTargetPullRequestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitTargetPullRequestStatus) DeepCopyInto(out *GitTargetPullRequestStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitTargetPullRequestStatus.
+func (in *GitTargetPullRequestStatus) DeepCopy() *GitTargetPullRequestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GitTargetPullRequestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitTargetRetentionStatus) DeepCopyInto(out *GitTargetRetentionStatus) {
 	*out = *in
@@ -787,6 +971,11 @@ func (in *GitTargetSpec) DeepCopyInto(out *GitTargetSpec) {
 		*out = new(GitTargetPlacementSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.YAML != nil {
+		in, out := &in.YAML, &out.YAML
+		*out = new(YAMLOutputSpec)
+		**out = **in
+	}
 	if in.ClusterProviderRef != nil {
 		in, out := &in.ClusterProviderRef, &out.ClusterProviderRef
 		*out = new(ClusterProviderReference)
@@ -802,6 +991,30 @@ func (in *GitTargetSpec) DeepCopyInto(out *GitTargetSpec) {
 		*out = new(PrunePolicy)
 		**out = **in
 	}
+	if in.PerGVRThrottle != nil {
+		in, out := &in.PerGVRThrottle, &out.PerGVRThrottle
+		*out = make(map[string]RateLimitSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.SanitizePerGVR != nil {
+		in, out := &in.SanitizePerGVR, &out.SanitizePerGVR
+		*out = make(map[string]GVRSanitizeSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.UserMapping != nil {
+		in, out := &in.UserMapping, &out.UserMapping
+		*out = new(UserMappingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PullRequest != nil {
+		in, out := &in.PullRequest, &out.PullRequest
+		*out = new(GitTargetPullRequestSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitTargetSpec.
@@ -839,6 +1052,22 @@ func (in *GitTargetStatus) DeepCopyInto(out *GitTargetStatus) {
 		*out = new(GitTargetRetentionStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PullRequest != nil {
+		in, out := &in.PullRequest, &out.PullRequest
+		*out = new(GitTargetPullRequestStatus)
+		**out = **in
+	}
+	if in.LastStateTransition != nil {
+		in, out := &in.LastStateTransition, &out.LastStateTransition
+		*out = (*in).DeepCopy()
+	}
+	if in.StateHistory != nil {
+		in, out := &in.StateHistory, &out.StateHistory
+		*out = make([]StateTransition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitTargetStatus.
@@ -955,6 +1184,21 @@ func (in *NamespacedTargetReference) DeepCopy() *NamespacedTargetReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCAuthSpec) DeepCopyInto(out *OIDCAuthSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCAuthSpec.
+func (in *OIDCAuthSpec) DeepCopy() *OIDCAuthSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCAuthSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrunePolicy) DeepCopyInto(out *PrunePolicy) {
 	*out = *in
@@ -990,6 +1234,22 @@ func (in *PushStrategy) DeepCopy() *PushStrategy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitSpec) DeepCopyInto(out *RateLimitSpec) {
+	*out = *in
+	out.MaxEventsPerSecond = in.MaxEventsPerSecond.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitSpec.
+func (in *RateLimitSpec) DeepCopy() *RateLimitSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceRule) DeepCopyInto(out *ResourceRule) {
 	*out = *in
@@ -1013,6 +1273,21 @@ func (in *ResourceRule) DeepCopyInto(out *ResourceRule) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ObjectSelector != nil {
+		in, out := &in.ObjectSelector, &out.ObjectSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NameIncludes != nil {
+		in, out := &in.NameIncludes, &out.NameIncludes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NameExcludes != nil {
+		in, out := &in.NameExcludes, &out.NameExcludes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRule.
@@ -1025,6 +1300,82 @@ func (in *ResourceRule) DeepCopy() *ResourceRule {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StateTransition) DeepCopyInto(out *StateTransition) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StateTransition.
+func (in *StateTransition) DeepCopy() *StateTransition {
+	if in == nil {
+		return nil
+	}
+	out := new(StateTransition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserMappingPattern) DeepCopyInto(out *UserMappingPattern) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserMappingPattern.
+func (in *UserMappingPattern) DeepCopy() *UserMappingPattern {
+	if in == nil {
+		return nil
+	}
+	out := new(UserMappingPattern)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserMappingSpec) DeepCopyInto(out *UserMappingSpec) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(LocalSecretReference)
+		**out = **in
+	}
+	if in.Patterns != nil {
+		in, out := &in.Patterns, &out.Patterns
+		*out = make([]UserMappingPattern, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserMappingSpec.
+func (in *UserMappingSpec) DeepCopy() *UserMappingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(UserMappingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultEncryptionSpec) DeepCopyInto(out *VaultEncryptionSpec) {
+	*out = *in
+	if in.TransitURIs != nil {
+		in, out := &in.TransitURIs, &out.TransitURIs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultEncryptionSpec.
+func (in *VaultEncryptionSpec) DeepCopy() *VaultEncryptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultEncryptionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WatchRule) DeepCopyInto(out *WatchRule) {
 	*out = *in
@@ -1157,3 +1508,18 @@ func (in *WatchRuleStreamsStatus) DeepCopy() *WatchRuleStreamsStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *YAMLOutputSpec) DeepCopyInto(out *YAMLOutputSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new YAMLOutputSpec.
+func (in *YAMLOutputSpec) DeepCopy() *YAMLOutputSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(YAMLOutputSpec)
+	in.DeepCopyInto(out)
+	return out
+}
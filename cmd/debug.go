@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Diagnostics for the controller process itself: the opt-in --debug-bind-address server
+// (net/http/pprof and expvar) and the goroutine-count warning behind --debug-goroutine-threshold.
+
+package main
+
+import (
+	"context"
+	"expvar"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"time"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	defaultDebugGoroutineThreshold = 1000
+	// debugGoroutineCheckInterval is how often the goroutine count is sampled. Leaks grow over
+	// minutes to hours, so a coarse sample costs nothing and still catches them.
+	debugGoroutineCheckInterval = 30 * time.Second
+	debugServerShutdownTimeout  = 5 * time.Second
+	debugServerReadTimeout      = 15 * time.Second
+)
+
+// newDebugServer builds the --debug-bind-address server. It is plain HTTP and unauthenticated
+// by design: profiles are for a port-forward or a loopback bind, never for a Service. Its write
+// timeout is left unset because /debug/pprof/profile and /debug/pprof/trace stream for as long as
+// the caller's ?seconds= asks.
+func newDebugServer(addr string) *manager.Server {
+	shutdownTimeout := debugServerShutdownTimeout
+	return &manager.Server{
+		Name: "debug",
+		Server: &http.Server{
+			Addr:              addr,
+			Handler:           buildDebugServeMux(),
+			ReadHeaderTimeout: debugServerReadTimeout,
+		},
+		ShutdownTimeout: &shutdownTimeout,
+	}
+}
+
+func buildDebugServeMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	// pprof.Index also serves every named runtime profile below it: heap, goroutine, allocs,
+	// block, mutex and threadcreate.
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.Handle("/debug/vars", expvar.Handler())
+	return mux
+}
+
+// goroutineWatch logs a warning when the process's goroutine count crosses threshold, and once
+// more when it falls back below it. It logs transitions rather than every sample, so a process
+// that sits above the threshold does not flood the log; a leak shows as a warning that is never
+// followed by its recovery.
+type goroutineWatch struct {
+	threshold int
+	interval  time.Duration
+	count     func() int
+	log       logr.Logger
+	above     bool
+}
+
+func newGoroutineWatch(threshold int, log logr.Logger) *goroutineWatch {
+	return &goroutineWatch{
+		threshold: threshold,
+		interval:  debugGoroutineCheckInterval,
+		count:     runtime.NumGoroutine,
+		log:       log,
+	}
+}
+
+// Start samples until ctx is done. It runs on every replica, leader or not.
+func (g *goroutineWatch) Start(ctx context.Context) error {
+	ticker := time.NewTicker(g.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			g.check()
+		}
+	}
+}
+
+func (g *goroutineWatch) NeedLeaderElection() bool {
+	return false
+}
+
+func (g *goroutineWatch) check() {
+	count := g.count()
+	switch {
+	case count > g.threshold && !g.above:
+		g.above = true
+		g.log.Info("Warning: goroutine count exceeds --debug-goroutine-threshold; "+
+			"take a /debug/pprof/goroutine profile to find the leak",
+			"goroutines", count, "threshold", g.threshold)
+	case count <= g.threshold && g.above:
+		g.above = false
+		g.log.Info("Goroutine count is back under --debug-goroutine-threshold",
+			"goroutines", count, "threshold", g.threshold)
+	}
+}
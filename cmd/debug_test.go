@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr/funcr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildDebugServeMux_ServesProfilesAndVars(t *testing.T) {
+	mux := buildDebugServeMux()
+
+	for _, path := range []string{"/debug/pprof/", "/debug/pprof/heap", "/debug/pprof/goroutine?debug=1", "/debug/vars"} {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusOK, rec.Code, path)
+	}
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code, "the debug port serves diagnostics only")
+}
+
+func TestGoroutineWatch_LogsTransitionsOnly(t *testing.T) {
+	var logged []string
+	watch := newGoroutineWatch(10, funcr.New(func(_, args string) { logged = append(logged, args) }, funcr.Options{}))
+	count := 5
+	watch.count = func() int { return count }
+
+	watch.check()
+	assert.Empty(t, logged, "under the threshold nothing is logged")
+
+	count = 11
+	watch.check()
+	watch.check()
+	require.Len(t, logged, 1, "a process that stays above the threshold warns once")
+	assert.Contains(t, logged[0], "exceeds --debug-goroutine-threshold")
+
+	count = 10
+	watch.check()
+	require.Len(t, logged, 2)
+	assert.Contains(t, logged[1], "back under")
+}
+
+func TestParseFlags_DebugGoroutineThreshold(t *testing.T) {
+	base := []string{"--redis-addr=", "--author-attribution=false"}
+
+	cfg, err := parseArgs(t, base...)
+	require.NoError(t, err)
+	assert.Equal(t, defaultDebugGoroutineThreshold, cfg.debugGoroutineThreshold)
+	assert.Empty(t, cfg.debugBindAddress, "the debug server is off by default")
+
+	_, err = parseArgs(t, append(base, "--debug-goroutine-threshold=-1")...)
+	require.ErrorContains(t, err, "--debug-goroutine-threshold must be >= 0")
+}
@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Per-logger verbosity. --zap-log-level sets one level for the whole process; each
+// --log-level-override raises or lowers it for the loggers under one name, so an operator can
+// debug the branch workers without also turning on every watch and controller debug line.
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	zaplog "go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+// logLevelOverrides is the repeatable --log-level-override flag: logger name to level.
+type logLevelOverrides map[string]zapcore.Level
+
+// overrideLevels are the levels --log-level-override accepts. Anything more verbose than debug
+// stays on --zap-log-level, which already takes numeric verbosities.
+var overrideLevels = map[string]zapcore.Level{ //nolint:gochecknoglobals // read-only lookup table
+	"debug": zapcore.DebugLevel,
+	"info":  zapcore.InfoLevel,
+	"warn":  zapcore.WarnLevel,
+	"error": zapcore.ErrorLevel,
+}
+
+func (o logLevelOverrides) String() string {
+	pairs := make([]string, 0, len(o))
+	for name, level := range o {
+		pairs = append(pairs, name+"="+level.String())
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func (o logLevelOverrides) Set(value string) error {
+	name, levelName, ok := strings.Cut(value, "=")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" {
+		return fmt.Errorf("%q must be <logger-name>=<level>", value)
+	}
+	level, ok := overrideLevels[strings.TrimSpace(levelName)]
+	if !ok {
+		return fmt.Errorf("%q: level must be one of debug, info, warn or error", value)
+	}
+	o[name] = level
+	return nil
+}
+
+// levelFor returns the level for a dotted logger name: the override of its longest matching
+// name prefix, matched on whole name segments, or ok=false when no override applies.
+func (o logLevelOverrides) levelFor(loggerName string) (zapcore.Level, bool) {
+	best, found := "", false
+	var level zapcore.Level
+	for name, l := range o {
+		if loggerName != name && !strings.HasPrefix(loggerName, name+".") {
+			continue
+		}
+		if !found || len(name) > len(best) {
+			best, level, found = name, l, true
+		}
+	}
+	return level, found
+}
+
+// levelOverrideCore filters entries by their logger's override, falling back to base. The
+// wrapped core is built to admit anything base or any override admits, so the filtering here
+// is the only place an entry's level is judged.
+type levelOverrideCore struct {
+	zapcore.Core
+	base      zapcore.LevelEnabler
+	overrides logLevelOverrides
+}
+
+func (c *levelOverrideCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelOverrideCore{Core: c.Core.With(fields), base: c.base, overrides: c.overrides}
+}
+
+func (c *levelOverrideCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if level, ok := c.overrides.levelFor(entry.LoggerName); ok {
+		if !level.Enabled(entry.Level) {
+			return checked
+		}
+	} else if !c.base.Enabled(entry.Level) {
+		return checked
+	}
+	return c.Core.Check(entry, checked)
+}
+
+// newLogger builds the process logger from the zap flags and any --log-level-override.
+func newLogger(opts zap.Options, overrides logLevelOverrides) logr.Logger {
+	if len(overrides) > 0 {
+		base := opts.Level
+		if base == nil {
+			base = zapcore.InfoLevel
+		}
+		opts.Level = zaplog.LevelEnablerFunc(func(level zapcore.Level) bool {
+			if base.Enabled(level) {
+				return true
+			}
+			for _, l := range overrides {
+				if l.Enabled(level) {
+					return true
+				}
+			}
+			return false
+		})
+		opts.ZapOpts = append(opts.ZapOpts, zaplog.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return &levelOverrideCore{Core: core, base: base, overrides: overrides}
+		}))
+	}
+	return zap.New(zap.UseFlagOptions(&opts))
+}
@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+func TestLogLevelOverrides_Set(t *testing.T) {
+	overrides := logLevelOverrides{}
+	require.NoError(t, overrides.Set("worker-manager=debug"))
+	require.NoError(t, overrides.Set(" watch = warn "))
+	assert.Equal(t, logLevelOverrides{"worker-manager": zapcore.DebugLevel, "watch": zapcore.WarnLevel}, overrides)
+	assert.Equal(t, "watch=warn,worker-manager=debug", overrides.String())
+
+	for _, bad := range []string{"worker-manager", "=debug", "watch=trace", "watch=2"} {
+		assert.Error(t, overrides.Set(bad), bad)
+	}
+}
+
+func TestLogLevelOverrides_LongestWholeSegmentPrefixWins(t *testing.T) {
+	overrides := logLevelOverrides{"worker-manager": zapcore.WarnLevel, "worker-manager.branch-worker": zapcore.DebugLevel}
+
+	level, ok := overrides.levelFor("worker-manager.branch-worker")
+	require.True(t, ok)
+	assert.Equal(t, zapcore.DebugLevel, level)
+	level, ok = overrides.levelFor("worker-manager")
+	require.True(t, ok)
+	assert.Equal(t, zapcore.WarnLevel, level)
+
+	_, ok = overrides.levelFor("worker-manager-extra")
+	assert.False(t, ok, "a name matches on whole segments only")
+	_, ok = overrides.levelFor("")
+	assert.False(t, ok)
+}
+
+func TestNewLogger_AppliesOverridesPerLoggerName(t *testing.T) {
+	var out bytes.Buffer
+	opts := zap.Options{Level: zapcore.InfoLevel, DestWriter: &out}
+	root := newLogger(opts, logLevelOverrides{"worker-manager": zapcore.DebugLevel, "watch": zapcore.ErrorLevel})
+
+	root.WithName("worker-manager").WithName("branch-worker").V(1).Info("worker debug")
+	root.WithName("event-router").V(1).Info("router debug")
+	root.WithName("event-router").Info("router info")
+	root.WithName("watch").Info("watch info")
+	root.WithName("watch").Error(nil, "watch error")
+
+	logged := out.String()
+	assert.Contains(t, logged, "worker debug", "an override can lower the level below --zap-log-level")
+	assert.NotContains(t, logged, "router debug", "loggers without an override keep the global level")
+	assert.Contains(t, logged, "router info")
+	assert.NotContains(t, logged, "watch info", "an override can also raise the level")
+	assert.Contains(t, logged, "watch error")
+}
+
+func TestParseFlags_LogLevelOverrideIsRepeatable(t *testing.T) {
+	cfg, err := parseArgs(t, "--redis-addr=", "--author-attribution=false",
+		"--log-level-override=worker-manager=debug", "--log-level-override=watch=warn")
+	require.NoError(t, err)
+	assert.Equal(t, logLevelOverrides{"worker-manager": zapcore.DebugLevel, "watch": zapcore.WarnLevel},
+		cfg.logLevelOverrides)
+
+	_, err = parseArgs(t, "--redis-addr=", "--author-attribution=false", "--log-level-override=watch=loud")
+	require.ErrorContains(t, err, "level must be one of debug, info, warn or error")
+}
@@ -27,7 +27,9 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -43,6 +45,7 @@ import (
 	"github.com/ConfigButler/gitops-reverser/internal/kubeconfig"
 	"github.com/ConfigButler/gitops-reverser/internal/queue"
 	"github.com/ConfigButler/gitops-reverser/internal/rulestore"
+	"github.com/ConfigButler/gitops-reverser/internal/sanitize"
 	"github.com/ConfigButler/gitops-reverser/internal/telemetry"
 	"github.com/ConfigButler/gitops-reverser/internal/types"
 	"github.com/ConfigButler/gitops-reverser/internal/watch"
@@ -65,11 +68,25 @@ const (
 	defaultAuditIdleTimeout         = 60 * time.Second
 	defaultAuditShutdownTimeout     = 10 * time.Second
 	defaultBranchBufferMaxSizeStr   = "8Mi"
+	// shutdownHeadroom is added to --drain-timeout to bound the manager's whole graceful
+	// shutdown, leaving the other runnables time to stop once the branch workers have drained.
+	shutdownHeadroom = 10 * time.Second
 	// defaultSourceClusterQPS / -Burst are the client-side throttle for a remote source
 	// cluster reached via GitTarget.spec.kubeConfig — a conservative default since a remote is
 	// reached over a network the in-cluster config is not, and is only read (list/watch/get).
 	defaultSourceClusterQPS   = 20.0
 	defaultSourceClusterBurst = 30
+	// defaultCommitAuditLogMaxSizeStr / -MaxBackups bound the --commit-audit-log=file output on
+	// disk: at most (1 + backups) files of about this size each.
+	defaultCommitAuditLogMaxSizeStr = "100Mi"
+	defaultCommitAuditLogMaxBackups = 5
+)
+
+// Values of --commit-audit-log.
+const (
+	commitAuditLogOff    = ""
+	commitAuditLogStdout = "stdout"
+	commitAuditLogFile   = "file"
 )
 
 func init() {
@@ -82,7 +99,7 @@ func init() {
 func main() {
 	// Parse flags and configure logger
 	cfg := parseFlags()
-	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&cfg.zapOpts)))
+	ctrl.SetLogger(newLogger(cfg.zapOpts, cfg.logLevelOverrides))
 
 	bi := currentBuildInfo()
 	setupLog.Info("Starting gitops-reverser",
@@ -123,6 +140,15 @@ func main() {
 	fatalIfErr(mgr.AddMetricsServerExtraHandler("/build-info", buildInfoHandler()),
 		"unable to register build-info endpoint")
 
+	if cfg.debugBindAddress != "" {
+		setupLog.Info("Debug server enabled; it is unauthenticated", "address", cfg.debugBindAddress)
+		fatalIfErr(mgr.Add(newDebugServer(cfg.debugBindAddress)), "unable to add debug server")
+	}
+	if cfg.debugGoroutineThreshold > 0 {
+		fatalIfErr(mgr.Add(newGoroutineWatch(cfg.debugGoroutineThreshold, ctrl.Log.WithName("goroutine-watch"))),
+			"unable to add goroutine watch")
+	}
+
 	// Initialize rule store for watch rules
 	ruleStore := rulestore.NewStore()
 
@@ -134,15 +160,27 @@ func main() {
 		cfg.sensitiveResources,
 	)
 	workerManager.SetSSHHostKeyConfig(cfg.sshHostKeys)
+	git.SetOIDCAllowedAudiences(cfg.oidcAllowedAudiences)
+	commitAuditLogger, err := newCommitAuditLogger(cfg)
+	fatalIfErr(err, "unable to open commit audit log")
+	workerManager.SetCommitAuditLogger(commitAuditLogger)
+	workerManager.SetEventRecorder(mgr.GetEventRecorder("gitops-reverser"))
+	workerManager.SetDrainTimeout(cfg.drainTimeout)
+	workerManager.SetFetchDepth(cfg.fetchDepth)
 	fatalIfErr(mgr.Add(workerManager), "unable to add worker manager to manager")
 
 	// Watch ingestion manager (placeholder, will get EventRouter set later)
 	watchMgr := &watch.Manager{
-		Client:             mgr.GetClient(),
-		Log:                ctrl.Log.WithName("watch"),
-		RuleStore:          ruleStore,
-		EventRouter:        nil, // Will be set below
-		SensitiveResources: cfg.sensitiveResources,
+		Client:               mgr.GetClient(),
+		Log:                  ctrl.Log.WithName("watch"),
+		RuleStore:            ruleStore,
+		EventRouter:          nil, // Will be set below
+		SensitiveResources:   cfg.sensitiveResources,
+		ReconcileInterval:    cfg.watchReconcileInterval,
+		HeartbeatInterval:    cfg.watchHeartbeatInterval,
+		ExcludeAnnotationKey: cfg.excludeAnnotationKey,
+		StripAnnotations:     cfg.stripAnnotations,
+		DedupCacheSize:       cfg.dedupCacheSize,
 		// Resolve a source cluster (named by a GitTarget.spec.clusterProviderRef) into a
 		// rest.Config: look up the ClusterProvider by name, read its kubeConfig Secret from the
 		// operator namespace, and build the client. The manager client bypasses its cache for
@@ -282,6 +320,9 @@ func main() {
 		Client:      mgr.GetClient(),
 		Scheme:      mgr.GetScheme(),
 		SSHHostKeys: cfg.sshHostKeys,
+		Recorder:    mgr.GetEventRecorder("gitprovider-controller"),
+
+		RequeueJitterFactor: cfg.reconcileJitterFactor,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "GitProvider")
 		os.Exit(1)
@@ -300,6 +341,8 @@ func main() {
 		Scheme:        mgr.GetScheme(),
 		WorkerManager: workerManager,
 		EventRouter:   eventRouter,
+
+		RequeueJitterFactor: cfg.reconcileJitterFactor,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "GitTarget")
 		os.Exit(1)
@@ -395,18 +438,52 @@ type appConfig struct {
 	attributionGrace            time.Duration
 	auditRouteAnnotationKey     string
 	branchBufferMaxBytes        int64
-	sensitiveResources          types.SensitiveResourcePolicy
-	sshHostKeys                 git.SSHHostKeyConfig
+	// drainTimeout bounds how long each branch worker keeps committing and pushing its queued
+	// events once shutdown begins (leader rotation, SIGTERM) before it abandons the rest.
+	drainTimeout time.Duration
+	// fetchDepth is how many commits of history each branch worker fetches; 0 fetches all of it.
+	fetchDepth         int
+	sensitiveResources types.SensitiveResourcePolicy
+	sshHostKeys        git.SSHHostKeyConfig
+	// oidcAllowedAudiences are the audiences a GitProvider's spec.oidc may request a token for.
+	// Empty refuses every spec.oidc.
+	oidcAllowedAudiences []string
 	// sourceClusterQPS / sourceClusterBurst bound the rate at which the operator talks to a
 	// source cluster reached through a GitTarget.spec.kubeConfig. A remote is reached over a
 	// network the in-cluster config is not, so it carries client-side throttling by default.
 	sourceClusterQPS   float64
 	sourceClusterBurst int
+	// reconcileJitterFactor stretches each GitProvider's and GitTarget's steady requeue by a
+	// fixed per-object fraction, so objects reconciled together at startup drift apart.
+	reconcileJitterFactor float64
+	// watchReconcileInterval / watchHeartbeatInterval are the watch manager's periodic
+	// discovery-refresh and liveness-log cadences.
+	watchReconcileInterval time.Duration
+	watchHeartbeatInterval time.Duration
+	// excludeAnnotationKey is the annotation that, set to "true", keeps a watched object out of Git.
+	excludeAnnotationKey string
+	// stripAnnotations are extra annotation keys or "prefix*" patterns removed from every object
+	// before it is written, on top of sanitize's built-in list.
+	stripAnnotations []string
+	// dedupCacheSize caps how many objects the live UPDATE dedup cache remembers.
+	dedupCacheSize int
 	// kubeConfigSafety is the exec / insecure-TLS opt-in for source-cluster kubeconfigs. Both
 	// default OFF: an operator-supplied kubeconfig is attacker-adjacent input, so unsafe
 	// kubeconfigs are REJECTED (a legible Validated=False), diverging from Flux's silent strip.
 	kubeConfigSafety kubeconfig.SafetyPolicy
-	zapOpts          zap.Options
+	// debugBindAddress serves pprof and expvar when set; debugGoroutineThreshold warns on a
+	// goroutine count above it (0 disables the warning).
+	debugBindAddress        string
+	debugGoroutineThreshold int
+	// commitAuditLog selects where pushed commits are recorded as JSON lines: off (""), stdout,
+	// or a size-rotated file at commitAuditLogPath.
+	commitAuditLog           string
+	commitAuditLogPath       string
+	commitAuditLogMaxBytes   int64
+	commitAuditLogMaxBackups int
+	zapOpts                  zap.Options
+	// logLevelOverrides sets the level of individual named loggers, over zapOpts' global level.
+	logLevelOverrides logLevelOverrides
 }
 
 // parseFlags parses CLI flags and returns the application configuration.
@@ -520,6 +597,13 @@ func parseFlagsWithArgs(fs *flag.FlagSet, args []string) (appConfig, error) {
 	fs.StringVar(&branchBufferMaxSizeFlag, "branch-buffer-max-size", branchBufferMaxSizeStr,
 		"Maximum in-memory event buffer per branch worker, as a Kubernetes resource quantity "+
 			"(e.g. 8Mi, 1Gi; default 8Mi). Bounds pod memory under bursty workloads; not user-facing.")
+	fs.DurationVar(&cfg.drainTimeout, "drain-timeout", git.DefaultDrainTimeout,
+		"How long each branch worker may keep committing and pushing its queued events on shutdown "+
+			"before the rest is left to the next leader's resync (duration string; default 10s). "+
+			"Keep it below the Pod's terminationGracePeriodSeconds. 0 drops queued events at once.")
+	fs.IntVar(&cfg.fetchDepth, "fetch-depth", git.DefaultFetchDepth,
+		"How many commits of history each branch worker fetches per branch (default 1, the tip only). "+
+			"0 fetches the full history, trading clone time and disk for local history.")
 	var additionalSensitiveResources string
 	fs.StringVar(
 		&additionalSensitiveResources,
@@ -531,6 +615,24 @@ func parseFlagsWithArgs(fs *flag.FlagSet, args []string) (appConfig, error) {
 		"Client-side QPS limit for talking to a source cluster reached via GitTarget.spec.kubeConfig.")
 	fs.IntVar(&cfg.sourceClusterBurst, "source-cluster-burst", defaultSourceClusterBurst,
 		"Client-side burst limit for talking to a source cluster reached via GitTarget.spec.kubeConfig.")
+	fs.Float64Var(&cfg.reconcileJitterFactor, "reconcile-jitter-factor", controller.DefaultRequeueJitterFactor,
+		"Fraction (0.0-0.5) by which each GitProvider's and GitTarget's periodic recheck is stretched, "+
+			"fixed per object, so objects reconciled together at startup do not recheck together. 0 disables it.")
+	fs.DurationVar(&cfg.watchReconcileInterval, "watch-reconcile-interval", watch.DefaultReconcileInterval,
+		"How often the watch manager refreshes API discovery and re-applies watch rules to catch "+
+			"CRDs and rule changes it was not notified of. Minimum 5s.")
+	fs.DurationVar(&cfg.watchHeartbeatInterval, "watch-heartbeat-interval", watch.DefaultHeartbeatInterval,
+		"How often the watch manager logs its liveness heartbeat (at V(1)). Minimum 5s.")
+	fs.StringVar(&cfg.excludeAnnotationKey, "exclude-annotation", configbutleraiv1alpha3.ExcludeAnnotation,
+		"Annotation that, set to \"true\" on a watched object, keeps it out of Git. Setting it on an "+
+			"object already in Git removes its document.")
+	var stripAnnotations string
+	fs.StringVar(&stripAnnotations, "strip-annotations", "",
+		"Comma-separated annotation keys, or prefixes ending in \"*\" (e.g. operator.example.com/*), removed "+
+			"from every object written to Git in addition to the built-in operational annotations.")
+	fs.IntVar(&cfg.dedupCacheSize, "dedup-cache-size", watch.DefaultDedupCacheSize,
+		"How many watched objects the live UPDATE dedup cache remembers across all GitTargets. Past it, "+
+			"the least recently seen are evicted and their next UPDATE is routed to Git rather than deduped.")
 	fs.BoolVar(&cfg.kubeConfigSafety.AllowExec, "insecure-kubeconfig-exec", false,
 		"Allow a source-cluster kubeconfig to use an exec auth provider (runs a binary in the "+
 			"operator Pod). Rejected by default; enabling this is a deliberate trust decision.")
@@ -543,12 +645,37 @@ func parseFlagsWithArgs(fs *flag.FlagSet, args []string) (appConfig, error) {
 	fs.BoolVar(&cfg.sshHostKeys.AllowMissingKnownHosts, "insecure-allow-missing-known-hosts", false,
 		"INSECURE, dev/throwaway clusters only: permit SSH when no host-key source produced any "+
 			"known_hosts at all. A present-but-unparseable known_hosts is always a hard error.")
+	var oidcAllowedAudiences string
+	fs.StringVar(&oidcAllowedAudiences, "oidc-allowed-audiences", "",
+		"Comma-separated audiences a GitProvider's spec.oidc may request a ServiceAccount token for. "+
+			"Empty (the default) refuses every spec.oidc.")
+	fs.StringVar(&cfg.debugBindAddress, "debug-bind-address", "",
+		"Address of an unauthenticated plain-HTTP server exposing /debug/pprof/ profiles and /debug/vars. "+
+			"Empty (the default) disables it. Bind it to loopback and reach it with a port-forward.")
+	fs.IntVar(&cfg.debugGoroutineThreshold, "debug-goroutine-threshold", defaultDebugGoroutineThreshold,
+		"Log a warning when the process runs more goroutines than this, and again when it drops back. "+
+			"0 disables the check.")
+	fs.StringVar(&cfg.commitAuditLog, "commit-audit-log", commitAuditLogOff,
+		"Record every pushed commit as one JSON line per resource change: \"stdout\" or \"file\" "+
+			"(see --commit-audit-log-path). Empty (the default) disables the commit audit log.")
+	fs.StringVar(&cfg.commitAuditLogPath, "commit-audit-log-path", "",
+		"File the commit audit log is appended to when --commit-audit-log=file.")
+	var commitAuditLogMaxSizeFlag string
+	fs.StringVar(&commitAuditLogMaxSizeFlag, "commit-audit-log-max-size", defaultCommitAuditLogMaxSizeStr,
+		"Size, as a Kubernetes resource quantity, at which the commit audit log file is rotated.")
+	fs.IntVar(&cfg.commitAuditLogMaxBackups, "commit-audit-log-max-backups", defaultCommitAuditLogMaxBackups,
+		"Number of rotated commit audit log files kept next to the current one.")
 	cfg.zapOpts = zap.Options{
 		// Production mode defaults to JSON encoding, which is easier for log processors to parse.
 		Development: false,
 		Level:       zapcore.InfoLevel,
 	}
 	cfg.zapOpts.BindFlags(fs)
+	cfg.logLevelOverrides = logLevelOverrides{}
+	fs.Var(cfg.logLevelOverrides, "log-level-override",
+		"Set the level (debug, info, warn or error) of the loggers under one name, over --zap-log-level, as "+
+			"<logger-name>=<level>; repeatable. A name covers its children, e.g. worker-manager covers "+
+			"worker-manager.branch-worker. The logger name is the \"logger\" field of each log line.")
 
 	if err := fs.Parse(args); err != nil {
 		return appConfig{}, err
@@ -573,6 +700,34 @@ func parseFlagsWithArgs(fs *flag.FlagSet, args []string) (appConfig, error) {
 		return appConfig{}, err
 	}
 
+	if cfg.reconcileJitterFactor < 0 || cfg.reconcileJitterFactor > controller.MaxRequeueJitterFactor {
+		return appConfig{}, fmt.Errorf("--reconcile-jitter-factor must be between 0 and %v, got %v",
+			controller.MaxRequeueJitterFactor, cfg.reconcileJitterFactor)
+	}
+
+	if cfg.watchReconcileInterval < watch.MinTickerInterval {
+		return appConfig{}, fmt.Errorf("--watch-reconcile-interval must be >= %s, got %s",
+			watch.MinTickerInterval, cfg.watchReconcileInterval)
+	}
+	if cfg.watchHeartbeatInterval < watch.MinTickerInterval {
+		return appConfig{}, fmt.Errorf("--watch-heartbeat-interval must be >= %s, got %s",
+			watch.MinTickerInterval, cfg.watchHeartbeatInterval)
+	}
+
+	if cfg.dedupCacheSize < 1 {
+		return appConfig{}, fmt.Errorf("--dedup-cache-size must be >= 1, got %d", cfg.dedupCacheSize)
+	}
+
+	cfg.excludeAnnotationKey = strings.TrimSpace(cfg.excludeAnnotationKey)
+	if errs := validation.IsQualifiedName(cfg.excludeAnnotationKey); len(errs) > 0 {
+		return appConfig{}, fmt.Errorf("invalid --exclude-annotation %q: %s",
+			cfg.excludeAnnotationKey, strings.Join(errs, "; "))
+	}
+	cfg.stripAnnotations, err = sanitize.ParseKeyPatterns(stripAnnotations)
+	if err != nil {
+		return appConfig{}, fmt.Errorf("invalid --strip-annotations: %w", err)
+	}
+
 	bufferQuantity, err := resource.ParseQuantity(branchBufferMaxSizeFlag)
 	if err != nil {
 		return appConfig{}, fmt.Errorf("invalid --branch-buffer-max-size %q: %w", branchBufferMaxSizeFlag, err)
@@ -582,10 +737,30 @@ func parseFlagsWithArgs(fs *flag.FlagSet, args []string) (appConfig, error) {
 		return appConfig{}, fmt.Errorf("--branch-buffer-max-size must be > 0, got %s", branchBufferMaxSizeFlag)
 	}
 
+	if cfg.drainTimeout < 0 {
+		return appConfig{}, fmt.Errorf("--drain-timeout must be >= 0, got %s", cfg.drainTimeout)
+	}
+
+	if cfg.fetchDepth < 0 {
+		return appConfig{}, fmt.Errorf("--fetch-depth must be >= 0, got %d", cfg.fetchDepth)
+	}
+
 	cfg.sensitiveResources, err = types.ParseSensitiveResourcePolicy(additionalSensitiveResources)
 	if err != nil {
 		return appConfig{}, err
 	}
+	for _, audience := range strings.Split(oidcAllowedAudiences, ",") {
+		if audience = strings.TrimSpace(audience); audience != "" {
+			cfg.oidcAllowedAudiences = append(cfg.oidcAllowedAudiences, audience)
+		}
+	}
+
+	if err := parseCommitAuditLogFlags(&cfg, commitAuditLogMaxSizeFlag); err != nil {
+		return appConfig{}, err
+	}
+	if cfg.debugGoroutineThreshold < 0 {
+		return appConfig{}, fmt.Errorf("--debug-goroutine-threshold must be >= 0, got %d", cfg.debugGoroutineThreshold)
+	}
 
 	// The install-level default known-hosts ConfigMap lives in the controller's own namespace,
 	// supplied via the downward API. Without it, that resolution layer is simply unavailable.
@@ -674,6 +849,51 @@ func validateAdmissionWebhookConfig(cfg appConfig) error {
 	return nil
 }
 
+// parseCommitAuditLogFlags validates the --commit-audit-log flags and resolves the rotation size.
+func parseCommitAuditLogFlags(cfg *appConfig, maxSizeFlag string) error {
+	cfg.commitAuditLogPath = strings.TrimSpace(cfg.commitAuditLogPath)
+	switch cfg.commitAuditLog {
+	case commitAuditLogOff, commitAuditLogStdout:
+		return nil
+	case commitAuditLogFile:
+	default:
+		return fmt.Errorf("--commit-audit-log must be empty, %q or %q, got %q",
+			commitAuditLogStdout, commitAuditLogFile, cfg.commitAuditLog)
+	}
+	if cfg.commitAuditLogPath == "" {
+		return errors.New("--commit-audit-log-path is required when --commit-audit-log=file")
+	}
+	maxSize, err := resource.ParseQuantity(maxSizeFlag)
+	if err != nil {
+		return fmt.Errorf("invalid --commit-audit-log-max-size %q: %w", maxSizeFlag, err)
+	}
+	cfg.commitAuditLogMaxBytes, _ = maxSize.AsInt64()
+	if cfg.commitAuditLogMaxBytes <= 0 {
+		return fmt.Errorf("--commit-audit-log-max-size must be > 0, got %s", maxSizeFlag)
+	}
+	if cfg.commitAuditLogMaxBackups < 0 {
+		return fmt.Errorf("--commit-audit-log-max-backups must be >= 0, got %d", cfg.commitAuditLogMaxBackups)
+	}
+	return nil
+}
+
+// newCommitAuditLogger builds the commit audit log the flags select; nil when it is disabled.
+func newCommitAuditLogger(cfg appConfig) (git.CommitAuditLogger, error) {
+	switch cfg.commitAuditLog {
+	case commitAuditLogStdout:
+		return git.NewJSONLinesCommitAuditLogger(os.Stdout), nil
+	case commitAuditLogFile:
+		file, err := git.NewRotatingFile(cfg.commitAuditLogPath, cfg.commitAuditLogMaxBytes,
+			cfg.commitAuditLogMaxBackups)
+		if err != nil {
+			return nil, err
+		}
+		return git.NewJSONLinesCommitAuditLogger(file), nil
+	default:
+		return nil, nil //nolint:nilnil // nil means the commit audit log is disabled
+	}
+}
+
 // fatalIfErr logs and exits the process if err is not nil.
 func fatalIfErr(err error, msg string, keysAndValues ...any) {
 	if err != nil {
@@ -971,6 +1191,9 @@ func newManager(
 		Metrics:                metricsOptions,
 		HealthProbeBindAddress: probeAddr,
 		WebhookServer:          webhookServer,
+		// The branch workers drain for up to --drain-timeout once the manager's context ends;
+		// controller-runtime's own 30s default would cut a longer drain short.
+		GracefulShutdownTimeout: ptr.To(cfg.drainTimeout + shutdownHeadroom),
 		// Never cache Secret values. The control plane reads a small set of named
 		// Secrets (Git credentials, signing keys, age keys) directly by name; caching
 		// them would start a cluster-wide Secret informer that retains every Secret
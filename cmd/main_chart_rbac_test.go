@@ -8,6 +8,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"sigs.k8s.io/yaml"
@@ -165,6 +166,38 @@ func TestChartRBAC_ManagerRoleNeverCarriesTheWildcard(t *testing.T) {
 	}
 }
 
+// Token minting for spec.oidc is never cluster-wide: a `create` on serviceaccounts/token in the
+// manager role would let any GitProvider author have tokens issued for any ServiceAccount.
+func TestChartRBAC_ServiceAccountTokensAreNamespacedAndNamed(t *testing.T) {
+	roles := renderClusterRoles(t)
+	for _, role := range roles {
+		for _, rule := range role.Rules {
+			require.Falsef(t, covers(rule.APIGroups, "") && covers(rule.Resources, "serviceaccounts/token"),
+				"ClusterRole %s must not grant serviceaccounts/token", role.Name)
+		}
+	}
+
+	out, err := helmTemplateRBAC(
+		"rbac.serviceAccountTokens[0].namespace=team-a",
+		"rbac.serviceAccountTokens[0].serviceAccountNames[0]=git-writer",
+	)
+	require.NoErrorf(t, err, "helm template failed: %s", out)
+	var tokenRoles []rbacv1.Role
+	for _, doc := range strings.Split(string(out), "\n---") {
+		var obj rbacv1.Role
+		require.NoError(t, yaml.Unmarshal([]byte(doc), &obj))
+		if obj.Kind == "Role" {
+			tokenRoles = append(tokenRoles, obj)
+		}
+	}
+	require.Len(t, tokenRoles, 1)
+	assert.Equal(t, "team-a", tokenRoles[0].Namespace)
+	require.Len(t, tokenRoles[0].Rules, 1)
+	assert.Equal(t, []string{"serviceaccounts/token"}, tokenRoles[0].Rules[0].Resources)
+	assert.Equal(t, []string{"git-writer"}, tokenRoles[0].Rules[0].ResourceNames)
+	assert.Equal(t, []string{"create"}, tokenRoles[0].Rules[0].Verbs)
+}
+
 // The API-resource catalog and its trigger informers read these three. Without them a
 // least-privilege install 403s on every reflector retry, which is why a `selected` user must
 // not have to restate them.
@@ -0,0 +1,166 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	configbutleraiv1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
+	"github.com/ConfigButler/gitops-reverser/internal/controller"
+	"github.com/ConfigButler/gitops-reverser/internal/git"
+	"github.com/ConfigButler/gitops-reverser/internal/watch"
+)
+
+func TestParseFlags_ReconcileJitterFactorRange(t *testing.T) {
+	base := []string{"--redis-addr=", "--author-attribution=false"}
+
+	cfg, err := parseArgs(t, base...)
+	require.NoError(t, err)
+	assert.InDelta(t, controller.DefaultRequeueJitterFactor, cfg.reconcileJitterFactor, 0)
+
+	cfg, err = parseArgs(t, append(base, "--reconcile-jitter-factor=0")...)
+	require.NoError(t, err)
+	assert.Zero(t, cfg.reconcileJitterFactor)
+
+	for _, bad := range []string{"-0.1", "0.6"} {
+		_, err := parseArgs(t, append(base, "--reconcile-jitter-factor="+bad)...)
+		require.ErrorContains(t, err, "--reconcile-jitter-factor must be between 0 and 0.5", bad)
+	}
+}
+
+func TestParseFlags_WatchIntervalsHaveAFloor(t *testing.T) {
+	base := []string{"--redis-addr=", "--author-attribution=false"}
+
+	cfg, err := parseArgs(t, base...)
+	require.NoError(t, err)
+	assert.Equal(t, watch.DefaultReconcileInterval, cfg.watchReconcileInterval)
+	assert.Equal(t, watch.DefaultHeartbeatInterval, cfg.watchHeartbeatInterval)
+
+	cfg, err = parseArgs(t, append(base, "--watch-reconcile-interval=2m", "--watch-heartbeat-interval=5s")...)
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Minute, cfg.watchReconcileInterval)
+	assert.Equal(t, 5*time.Second, cfg.watchHeartbeatInterval)
+
+	_, err = parseArgs(t, append(base, "--watch-reconcile-interval=1s")...)
+	require.ErrorContains(t, err, "--watch-reconcile-interval must be >= 5s")
+	_, err = parseArgs(t, append(base, "--watch-heartbeat-interval=0s")...)
+	require.ErrorContains(t, err, "--watch-heartbeat-interval must be >= 5s")
+}
+
+func TestParseFlags_ExcludeAnnotation(t *testing.T) {
+	base := []string{"--redis-addr=", "--author-attribution=false"}
+
+	cfg, err := parseArgs(t, base...)
+	require.NoError(t, err)
+	assert.Equal(t, configbutleraiv1alpha3.ExcludeAnnotation, cfg.excludeAnnotationKey)
+
+	cfg, err = parseArgs(t, append(base, "--exclude-annotation= configbutler.ai/skip ")...)
+	require.NoError(t, err)
+	assert.Equal(t, "configbutler.ai/skip", cfg.excludeAnnotationKey)
+
+	for _, bad := range []string{"", "not a key", "a/b/c"} {
+		_, err := parseArgs(t, append(base, "--exclude-annotation="+bad)...)
+		require.ErrorContains(t, err, "invalid --exclude-annotation", bad)
+	}
+}
+
+func TestParseFlags_StripAnnotations(t *testing.T) {
+	base := []string{"--redis-addr=", "--author-attribution=false"}
+
+	cfg, err := parseArgs(t, base...)
+	require.NoError(t, err)
+	assert.Empty(t, cfg.stripAnnotations, "only the built-in operational annotations are stripped by default")
+
+	cfg, err = parseArgs(t, append(base, "--strip-annotations=operator.foo/*, example.com/build")...)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"operator.foo/*", "example.com/build"}, cfg.stripAnnotations)
+
+	_, err = parseArgs(t, append(base, "--strip-annotations=operator.*/generation")...)
+	require.ErrorContains(t, err, "invalid --strip-annotations")
+}
+
+func TestParseFlags_CommitAuditLog(t *testing.T) {
+	base := []string{"--redis-addr=", "--author-attribution=false"}
+
+	cfg, err := parseArgs(t, base...)
+	require.NoError(t, err)
+	assert.Empty(t, cfg.commitAuditLog, "the commit audit log is off by default")
+
+	cfg, err = parseArgs(t, append(base, "--commit-audit-log=file", "--commit-audit-log-path=/var/log/commits.jsonl",
+		"--commit-audit-log-max-size=1Mi", "--commit-audit-log-max-backups=0")...)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1<<20), cfg.commitAuditLogMaxBytes)
+	assert.Zero(t, cfg.commitAuditLogMaxBackups)
+
+	for args, wantErr := range map[string]string{
+		"--commit-audit-log=syslog": "--commit-audit-log must be empty",
+		"--commit-audit-log=file":   "--commit-audit-log-path is required",
+		"--commit-audit-log=file --commit-audit-log-path=x --commit-audit-log-max-size=0":     "must be > 0",
+		"--commit-audit-log=file --commit-audit-log-path=x --commit-audit-log-max-backups=-1": "must be >= 0",
+	} {
+		_, err := parseArgs(t, append(base, strings.Fields(args)...)...)
+		require.ErrorContains(t, err, wantErr, args)
+	}
+}
+
+func TestParseFlags_DrainTimeout(t *testing.T) {
+	base := []string{"--redis-addr=", "--author-attribution=false"}
+
+	cfg, err := parseArgs(t, base...)
+	require.NoError(t, err)
+	assert.Equal(t, git.DefaultDrainTimeout, cfg.drainTimeout)
+
+	cfg, err = parseArgs(t, append(base, "--drain-timeout=0s")...)
+	require.NoError(t, err)
+	assert.Zero(t, cfg.drainTimeout, "0 drops queued events at once")
+
+	_, err = parseArgs(t, append(base, "--drain-timeout=-1s")...)
+	require.ErrorContains(t, err, "--drain-timeout must be >= 0")
+}
+
+func TestParseFlags_FetchDepth(t *testing.T) {
+	base := []string{"--redis-addr=", "--author-attribution=false"}
+
+	cfg, err := parseArgs(t, base...)
+	require.NoError(t, err)
+	assert.Equal(t, git.DefaultFetchDepth, cfg.fetchDepth)
+
+	cfg, err = parseArgs(t, append(base, "--fetch-depth=0")...)
+	require.NoError(t, err)
+	assert.Zero(t, cfg.fetchDepth, "0 fetches the full history")
+
+	_, err = parseArgs(t, append(base, "--fetch-depth=-1")...)
+	require.ErrorContains(t, err, "--fetch-depth must be >= 0")
+}
+
+func TestParseFlags_DedupCacheSize(t *testing.T) {
+	base := []string{"--redis-addr=", "--author-attribution=false"}
+
+	cfg, err := parseArgs(t, base...)
+	require.NoError(t, err)
+	assert.Equal(t, watch.DefaultDedupCacheSize, cfg.dedupCacheSize)
+
+	cfg, err = parseArgs(t, append(base, "--dedup-cache-size=5000")...)
+	require.NoError(t, err)
+	assert.Equal(t, 5000, cfg.dedupCacheSize)
+
+	_, err = parseArgs(t, append(base, "--dedup-cache-size=0")...)
+	require.ErrorContains(t, err, "--dedup-cache-size must be >= 1")
+}
+
+func TestParseFlags_OIDCAllowedAudiences(t *testing.T) {
+	base := []string{"--redis-addr=", "--author-attribution=false"}
+
+	cfg, err := parseArgs(t, base...)
+	require.NoError(t, err)
+	assert.Empty(t, cfg.oidcAllowedAudiences, "no audience is allowed unless an admin lists it")
+
+	cfg, err = parseArgs(t, append(base, "--oidc-allowed-audiences= git.example.com, ,sts.example.com")...)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"git.example.com", "sts.example.com"}, cfg.oidcAllowedAudiences)
+}
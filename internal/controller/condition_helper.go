@@ -44,6 +44,17 @@ func upsertCondition(
 	return result
 }
 
+// removeCondition drops every condition of conditionType, for a condition that no longer applies.
+func removeCondition(conditions []metav1.Condition, conditionType string) []metav1.Condition {
+	result := conditions[:0]
+	for _, cond := range conditions {
+		if cond.Type != conditionType {
+			result = append(result, cond)
+		}
+	}
+	return result
+}
+
 func conditionByType(conditions []metav1.Condition, conditionType string) *metav1.Condition {
 	for i := range conditions {
 		if conditions[i].Type == conditionType {
@@ -83,6 +83,11 @@ const (
 	// ConditionTypePushed indicates whether a CommitRequest's commit reached the
 	// remote repository.
 	ConditionTypePushed = "Pushed"
+	// ConditionTypeConnected reports the outcome of a GitProvider's latest periodic connectivity
+	// check: True when the repository answered, False with AuthenticationFailed,
+	// RepositoryNotFound, or ConnectionFailed when it did not, and Unknown (NotChecked) when the
+	// check could not run because an earlier step, such as reading credentials, failed.
+	ConditionTypeConnected = "Connected"
 
 	// ClusterProviderConditionValidated reports whether a ClusterProvider's inputs are safe and
 	// resolvable: the in-cluster "default" provider is trivially Validated; a remote provider is
@@ -115,6 +120,15 @@ const (
 	// has streams pending replay completion. Stream status is computed during reconcile, so
 	// this keeps status.streams fresh while watches converge.
 	RequeueStreamSettleInterval = 10 * time.Second
+	// DefaultRequeueJitterFactor is the default fraction by which a steady requeue is stretched,
+	// per object, so that objects reconciled together at startup do not requeue together.
+	DefaultRequeueJitterFactor = 0.1
+	// MaxRequeueJitterFactor bounds --reconcile-jitter-factor.
+	MaxRequeueJitterFactor = 0.5
+
+	// GitProviderConnectionFailureWarningThreshold is the number of consecutive failed
+	// connectivity checks after which the GitProvider reconciler emits a Warning Event.
+	GitProviderConnectionFailureWarningThreshold = 3
 
 	// RetryInitialDuration is the initial duration for exponential backoff retry.
 	RetryInitialDuration = 100 * time.Millisecond
@@ -137,8 +151,18 @@ const (
 	ReasonSecretNotFound = "SecretNotFound"
 	// ReasonSecretMalformed indicates that the referenced secret is invalid.
 	ReasonSecretMalformed = "SecretMalformed"
+	// ReasonTokenRequestFailed indicates that a ServiceAccount token could not be obtained for spec.oidc.
+	ReasonTokenRequestFailed = "TokenRequestFailed"
 	// ReasonConnectionFailed indicates that the connection to the provider failed.
 	ReasonConnectionFailed = "ConnectionFailed"
+	// ReasonAuthenticationFailed indicates that the provider was reached but refused the credentials.
+	ReasonAuthenticationFailed = "AuthenticationFailed"
+	// ReasonRepositoryNotFound indicates that the provider was reached but reported no such repository.
+	ReasonRepositoryNotFound = "RepositoryNotFound"
+	// ReasonReachable is the Connected=True reason.
+	ReasonReachable = "Reachable"
+	// ReasonNotChecked is the Connected=Unknown reason when the connectivity check did not run.
+	ReasonNotChecked = "NotChecked"
 	// ReasonCommitConfigInvalid indicates the commit configuration is invalid.
 	ReasonCommitConfigInvalid = "CommitConfigInvalid"
 	// ReasonEncryptionConfigInvalid indicates encryption configuration is invalid.
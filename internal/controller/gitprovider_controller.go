@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -15,6 +16,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -26,6 +28,7 @@ import (
 
 	configbutleraiv1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
 	gitpkg "github.com/ConfigButler/gitops-reverser/internal/git"
+	"github.com/ConfigButler/gitops-reverser/internal/telemetry"
 )
 
 // GitProviderReconciler reconciles a GitProvider object.
@@ -39,6 +42,16 @@ type GitProviderReconciler struct {
 	// dev-only missing-key opt-out) for the connectivity check's credential read, so it matches
 	// what the write path uses.
 	SSHHostKeys gitpkg.SSHHostKeyConfig
+
+	// Recorder emits Kubernetes Events on the GitProvider. Optional: when nil, no Events are emitted.
+	Recorder events.EventRecorder
+
+	// RequeueJitterFactor stretches each GitProvider's check interval by a fixed per-object
+	// fraction of up to this value; see jitteredRequeue. Zero disables jitter.
+	RequeueJitterFactor float64
+
+	// checkRepo replaces gitpkg.CheckRepo in tests.
+	checkRepo func(ctx context.Context, repoURL string, auth transport.AuthMethod) (*gitpkg.RepoInfo, error)
 }
 
 // gitProviderLogFirsts keeps startup progress visible without turning every
@@ -55,6 +68,7 @@ type gitProviderLogFirsts struct {
 // +kubebuilder:rbac:groups=configbutler.ai,resources=gitproviders/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=configbutler.ai,resources=gitproviders/finalizers,verbs=update
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;create;update
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -67,6 +81,7 @@ func (r *GitProviderReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	if err := r.Get(ctx, req.NamespacedName, &gitProvider); err != nil {
 		if client.IgnoreNotFound(err) == nil {
 			log.Info("GitProvider not found, was likely deleted", "namespacedName", req.NamespacedName)
+			telemetry.ForgetProviderReachable(req.Namespace, req.Name)
 			return ctrl.Result{}, nil
 		}
 		log.Error(err, "unable to fetch GitProvider", "namespacedName", req.NamespacedName)
@@ -112,15 +127,11 @@ func (r *GitProviderReconciler) reconcileGitProvider(
 		return result, nil
 	}
 
-	// Fetch and validate secret
-	secret, shouldReturn := r.fetchAndValidateSecret(ctx, log, gitProvider)
+	auth, result, shouldReturn := r.resolveCredentials(ctx, log, gitProvider)
 	if shouldReturn {
-		result, _ := r.updateStatusAndRequeue(ctx, gitProvider)
 		return result, nil
 	}
-
-	// Extract credentials
-	auth, result, shouldReturn := r.getAuthFromSecret(ctx, log, gitProvider, secret)
+	auth, result, shouldReturn = r.addClientCertificate(ctx, log, gitProvider, auth)
 	if shouldReturn {
 		return result, nil
 	}
@@ -129,6 +140,47 @@ func (r *GitProviderReconciler) reconcileGitProvider(
 	return r.validateAndUpdateStatus(ctx, log, gitProvider, auth)
 }
 
+// resolveCredentials resolves the GitProvider's primary credential: a spec.oidc ServiceAccount
+// token, or the spec.secretRef Secret (nil auth for anonymous access).
+// Returns (auth, result, shouldReturn). If shouldReturn is true, caller should return the result immediately.
+func (r *GitProviderReconciler) resolveCredentials(
+	ctx context.Context,
+	log logr.Logger,
+	gitProvider *configbutleraiv1alpha3.GitProvider,
+) (transport.AuthMethod, ctrl.Result, bool) {
+	if gitProvider.Spec.OIDC != nil {
+		return r.getOIDCAuth(ctx, log, gitProvider)
+	}
+	gitProvider.Status.TokenExpiresAt = nil
+
+	secret, shouldReturn := r.fetchAndValidateSecret(ctx, log, gitProvider)
+	if shouldReturn {
+		result, _ := r.updateStatusAndRequeue(ctx, gitProvider)
+		return nil, result, true
+	}
+	return r.getAuthFromSecret(ctx, log, gitProvider, secret)
+}
+
+// getOIDCAuth obtains the spec.oidc ServiceAccount token and records its expiry in status.
+// Returns (auth, result, shouldReturn). If shouldReturn is true, caller should return the result immediately.
+func (r *GitProviderReconciler) getOIDCAuth(
+	ctx context.Context,
+	log logr.Logger,
+	gitProvider *configbutleraiv1alpha3.GitProvider,
+) (transport.AuthMethod, ctrl.Result, bool) {
+	auth, expiresAt, err := gitpkg.OIDCTokenAuth(ctx, r.Client, gitProvider)
+	if err != nil {
+		log.Error(err, "Failed to obtain ServiceAccount token",
+			"serviceAccount", gitProvider.Spec.OIDC.ServiceAccountName)
+		gitProvider.Status.TokenExpiresAt = nil
+		r.setStalledConditions(gitProvider, ReasonTokenRequestFailed, fmt.Sprintf("OIDC: %v", err))
+		result, _ := r.updateStatusAndRequeue(ctx, gitProvider)
+		return nil, result, true
+	}
+	gitProvider.Status.TokenExpiresAt = &metav1.Time{Time: expiresAt}
+	return auth, ctrl.Result{}, false
+}
+
 // fetchAndValidateSecret fetches the secret if specified.
 // Returns (secret, shouldReturn). If shouldReturn is true, caller should return immediately.
 func (r *GitProviderReconciler) fetchAndValidateSecret(
@@ -207,6 +259,28 @@ func (r *GitProviderReconciler) getAuthFromSecret(
 	return auth, ctrl.Result{}, false
 }
 
+// addClientCertificate wraps auth with the clientCertSecretRef certificate, if one is set.
+// Returns (auth, result, shouldReturn). If shouldReturn is true, caller should return the result immediately.
+func (r *GitProviderReconciler) addClientCertificate(
+	ctx context.Context,
+	log logr.Logger,
+	gitProvider *configbutleraiv1alpha3.GitProvider,
+	auth transport.AuthMethod,
+) (transport.AuthMethod, ctrl.Result, bool) {
+	withCert, err := gitpkg.WithClientCertificate(ctx, r.Client, gitProvider, auth)
+	if err != nil {
+		log.Error(err, "Failed to load client certificate")
+		reason := ReasonSecretMalformed
+		if apierrors.IsNotFound(err) {
+			reason = ReasonSecretNotFound
+		}
+		r.setStalledConditions(gitProvider, reason, fmt.Sprintf("Client certificate: %v", err))
+		result, _ := r.updateStatusAndRequeue(ctx, gitProvider)
+		return nil, result, true
+	}
+	return withCert, ctrl.Result{}, false
+}
+
 // validateAndUpdateStatus validates repository connectivity and updates the status.
 func (r *GitProviderReconciler) validateAndUpdateStatus(
 	ctx context.Context,
@@ -217,19 +291,30 @@ func (r *GitProviderReconciler) validateAndUpdateStatus(
 	log.V(1).Info("Validating repository connectivity",
 		"url", gitProvider.Spec.URL)
 
-	// Check repository connectivity and get branch count
-	branchCount, err := r.checkRemoteConnectivity(ctx, gitProvider.Spec.URL, auth)
+	// Check repository connectivity and gather repository metadata. The timeout keeps an
+	// unreachable URL from pinning this reconcile worker.
+	checkCtx, cancel := context.WithTimeout(ctx, gitProvider.Spec.EffectiveConnectionTimeout())
+	defer cancel()
+	repoInfo, err := r.checkRemoteConnectivity(checkCtx, gitProvider.Spec.URL, auth)
 	if err != nil {
 		log.Error(err, "Repository connectivity check failed",
 			"url", gitProvider.Spec.URL)
-		r.setStalledConditions(gitProvider, ReasonConnectionFailed,
-			fmt.Sprintf("Failed to connect to repository: %v", err))
+		reason := connectivityFailureReason(err)
+		message := fmt.Sprintf("Failed to connect to repository: %v", err)
+		r.setStalledConditions(gitProvider, reason, message)
+		r.setCondition(gitProvider, ConditionTypeConnected, metav1.ConditionFalse, reason, message)
+		r.recordConnectionFailure(gitProvider, err)
+		recordProviderReachable(gitProvider, false)
 		return r.updateStatusAndRequeue(ctx, gitProvider)
 	}
 
+	branchCount := repoInfo.RemoteBranchCount
+	r.recordRepoInfo(gitProvider, repoInfo)
+	recordProviderReachable(gitProvider, true)
 	log.V(1).Info("Repository connectivity validated successfully", "branchCount", branchCount)
 	message := fmt.Sprintf("Repository connectivity validated for %s", gitProvider.Spec.URL)
 	r.setReadyConditions(gitProvider, message)
+	r.setCondition(gitProvider, ConditionTypeConnected, metav1.ConditionTrue, ReasonReachable, message)
 
 	log.V(1).Info("GitProvider validation successful", "name", gitProvider.Name)
 	log.V(1).Info("Updating status with success condition")
@@ -245,8 +330,9 @@ func (r *GitProviderReconciler) validateAndUpdateStatus(
 			"namespace", gitProvider.Namespace,
 			"branchCount", branchCount)
 	})
-	log.V(1).Info("Status update completed successfully, scheduling requeue", "requeueAfter", RequeueSteadyInterval)
-	return ctrl.Result{RequeueAfter: RequeueSteadyInterval}, nil
+	requeueAfter := r.checkInterval(gitProvider)
+	log.V(1).Info("Status update completed successfully, scheduling requeue", "requeueAfter", requeueAfter)
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
 // fetchSecret retrieves the secret containing Git credentials.
@@ -278,23 +364,83 @@ func (r *GitProviderReconciler) extractCredentials(
 	return gitpkg.AuthFromSecretData(ctx, r.Client, gitProvider, secret, r.SSHHostKeys)
 }
 
-// checkRemoteConnectivity performs a lightweight check of repository connectivity and returns branch count.
+// checkRemoteConnectivity performs a lightweight check of repository connectivity and returns the
+// repository metadata it gathered.
 func (r *GitProviderReconciler) checkRemoteConnectivity(
 	ctx context.Context, repoURL string, auth transport.AuthMethod,
-) (int, error) {
+) (*gitpkg.RepoInfo, error) {
 	log := logf.FromContext(ctx).WithName("checkRemoteConnectivity")
 
 	log.V(1).Info("Checking remote repository connectivity", "repoURL", repoURL)
 
-	// Use new CheckRepo abstraction from git package
-	repoInfo, err := gitpkg.CheckRepo(ctx, repoURL, auth)
+	checkRepo := r.checkRepo
+	if checkRepo == nil {
+		checkRepo = gitpkg.CheckRepo
+	}
+	repoInfo, err := checkRepo(ctx, repoURL, auth)
 	if err != nil {
 		log.Error(err, "Remote connectivity check failed", "repoURL", repoURL)
-		return 0, fmt.Errorf("failed to connect to repository: %w", err)
+		return nil, fmt.Errorf("failed to connect to repository: %w", err)
 	}
 
 	log.V(1).Info("Remote connectivity check successful", "repoURL", repoURL, "branchCount", repoInfo.RemoteBranchCount)
-	return repoInfo.RemoteBranchCount, nil
+	return repoInfo, nil
+}
+
+// connectivityFailureReason classifies a failed connectivity check, so the Connected condition
+// tells a revoked or wrong credential apart from a remote that could not be reached at all.
+func connectivityFailureReason(err error) string {
+	switch {
+	case errors.Is(err, transport.ErrAuthenticationRequired),
+		errors.Is(err, transport.ErrAuthorizationFailed),
+		errors.Is(err, transport.ErrInvalidAuthMethod),
+		// The SSH transport reports a refused key only as a handshake error string.
+		strings.Contains(err.Error(), "unable to authenticate"):
+		return ReasonAuthenticationFailed
+	case errors.Is(err, transport.ErrRepositoryNotFound):
+		return ReasonRepositoryNotFound
+	default:
+		return ReasonConnectionFailed
+	}
+}
+
+// recordProviderReachable records the outcome of a connectivity check on the
+// gitopsreverser_provider_reachable gauge. Reconcile forgets the series once the GitProvider is gone.
+func recordProviderReachable(gitProvider *configbutleraiv1alpha3.GitProvider, reachable bool) {
+	telemetry.RecordProviderReachable(gitProvider.Namespace, gitProvider.Name, reachable)
+}
+
+// recordRepoInfo stores a successful connectivity check's repository metadata on the status and
+// resets the failure streak.
+func (r *GitProviderReconciler) recordRepoInfo(
+	gitProvider *configbutleraiv1alpha3.GitProvider,
+	repoInfo *gitpkg.RepoInfo,
+) {
+	gitProvider.Status.DefaultBranch = ""
+	if repoInfo.DefaultBranch != nil {
+		gitProvider.Status.DefaultBranch = repoInfo.DefaultBranch.ShortName
+	}
+	gitProvider.Status.RemoteBranchCount = repoInfo.RemoteBranchCount
+	now := metav1.Now()
+	gitProvider.Status.LastCheckedAt = &now
+	gitProvider.Status.ConsecutiveFailures = 0
+}
+
+// recordConnectionFailure extends the failure streak and, once it reaches
+// GitProviderConnectionFailureWarningThreshold, emits a Warning Event so the outage is visible to
+// operators watching Events rather than only to those reading the Ready condition. The Event is
+// re-emitted on every failing recheck past the threshold; the Event API aggregates the repeats.
+func (r *GitProviderReconciler) recordConnectionFailure(
+	gitProvider *configbutleraiv1alpha3.GitProvider,
+	err error,
+) {
+	gitProvider.Status.ConsecutiveFailures++
+	if r.Recorder == nil || gitProvider.Status.ConsecutiveFailures < GitProviderConnectionFailureWarningThreshold {
+		return
+	}
+	r.Recorder.Eventf(gitProvider, nil, corev1.EventTypeWarning, ReasonConnectionFailed, "CheckRepository",
+		"Repository %s unreachable for %d consecutive checks: %v",
+		gitProvider.Spec.URL, gitProvider.Status.ConsecutiveFailures, err)
 }
 
 func (r *GitProviderReconciler) validateCommitConfiguration(
@@ -363,6 +509,9 @@ func (r *GitProviderReconciler) setStalledConditions(
 	r.setCondition(gitProvider, ConditionTypeReady, metav1.ConditionFalse, reason, message)
 	r.setCondition(gitProvider, ConditionTypeReconciling, metav1.ConditionFalse, reason, "Reconciliation is stalled")
 	r.setCondition(gitProvider, ConditionTypeStalled, metav1.ConditionTrue, reason, message)
+	// A failed connectivity check overwrites this with Connected=False right after.
+	r.setCondition(gitProvider, ConditionTypeConnected, metav1.ConditionUnknown, ReasonNotChecked,
+		"The connectivity check did not run: "+message)
 }
 
 // setCondition sets or updates one condition by type.
@@ -384,9 +533,9 @@ func (r *GitProviderReconciler) setCondition(
 	)
 }
 
-// updateStatusAndRequeue updates the status and requeues on the unified control-plane steady
-// interval. The control plane no longer watches Secrets, so every status outcome falls back to
-// this single cadence; see docs/rbac.md.
+// updateStatusAndRequeue updates the status and requeues on the jittered spec.checkInterval, which
+// defaults to the unified control-plane steady interval. The control plane no longer watches Secrets,
+// so every status outcome falls back to this single cadence; see docs/rbac.md.
 func (r *GitProviderReconciler) updateStatusAndRequeue(
 	ctx context.Context,
 	gitProvider *configbutleraiv1alpha3.GitProvider,
@@ -394,7 +543,16 @@ func (r *GitProviderReconciler) updateStatusAndRequeue(
 	if err := r.updateStatusWithRetry(ctx, gitProvider); err != nil {
 		return ctrl.Result{}, err
 	}
-	return ctrl.Result{RequeueAfter: RequeueSteadyInterval}, nil
+	return ctrl.Result{RequeueAfter: r.checkInterval(gitProvider)}, nil
+}
+
+// checkInterval is the jittered spec.checkInterval, defaulting to the steady interval.
+func (r *GitProviderReconciler) checkInterval(gitProvider *configbutleraiv1alpha3.GitProvider) time.Duration {
+	return jitteredRequeue(
+		gitProvider.Spec.EffectiveCheckInterval(RequeueSteadyInterval),
+		r.RequeueJitterFactor,
+		client.ObjectKeyFromObject(gitProvider),
+	)
 }
 
 // updateStatusWithRetry updates the status with retry logic to handle race conditions.
@@ -4,20 +4,29 @@ package controller
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	configbutleraiv1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
 	gitpkg "github.com/ConfigButler/gitops-reverser/internal/git"
+	"github.com/ConfigButler/gitops-reverser/internal/telemetry"
 )
 
 func TestValidateCommitConfiguration_InvalidTemplate(t *testing.T) {
@@ -155,6 +164,150 @@ func TestEnsureSigningKey_MissingSecretWithoutGeneration(t *testing.T) {
 	assert.Empty(t, provider.Status.SigningPublicKey)
 }
 
+func TestRecordRepoInfo_StoresMetadataAndResetsFailures(t *testing.T) {
+	reconciler := &GitProviderReconciler{}
+	provider := &configbutleraiv1alpha3.GitProvider{
+		Status: configbutleraiv1alpha3.GitProviderStatus{ConsecutiveFailures: 4},
+	}
+
+	reconciler.recordRepoInfo(provider, &gitpkg.RepoInfo{
+		DefaultBranch:     &gitpkg.BranchInfo{ShortName: "main", Sha: "abc"},
+		RemoteBranchCount: 3,
+	})
+
+	assert.Equal(t, "main", provider.Status.DefaultBranch)
+	assert.Equal(t, 3, provider.Status.RemoteBranchCount)
+	assert.NotNil(t, provider.Status.LastCheckedAt)
+	assert.Zero(t, provider.Status.ConsecutiveFailures)
+
+	// An empty repository has no default branch yet; a stale one must not survive.
+	reconciler.recordRepoInfo(provider, &gitpkg.RepoInfo{})
+	assert.Empty(t, provider.Status.DefaultBranch)
+	assert.Zero(t, provider.Status.RemoteBranchCount)
+}
+
+func TestRecordConnectionFailure_WarnsFromThreshold(t *testing.T) {
+	recorder := events.NewFakeRecorder(10)
+	reconciler := &GitProviderReconciler{Recorder: recorder}
+	provider := &configbutleraiv1alpha3.GitProvider{
+		Spec: configbutleraiv1alpha3.GitProviderSpec{URL: "https://example.com/repo.git"},
+	}
+
+	for range GitProviderConnectionFailureWarningThreshold - 1 {
+		reconciler.recordConnectionFailure(provider, errors.New("unreachable"))
+	}
+	assert.Empty(t, recorder.Events, "no Warning before the threshold")
+
+	reconciler.recordConnectionFailure(provider, errors.New("unreachable"))
+	assert.Equal(t, GitProviderConnectionFailureWarningThreshold, provider.Status.ConsecutiveFailures)
+	require.Len(t, recorder.Events, 1)
+	event := <-recorder.Events
+	assert.Contains(t, event, corev1.EventTypeWarning+" "+ReasonConnectionFailed)
+	assert.Contains(t, event, "https://example.com/repo.git")
+}
+
+func TestConnectivityFailureReason(t *testing.T) {
+	assert.Equal(t, ReasonAuthenticationFailed,
+		connectivityFailureReason(fmt.Errorf("list: %w", transport.ErrAuthenticationRequired)))
+	assert.Equal(t, ReasonAuthenticationFailed,
+		connectivityFailureReason(fmt.Errorf("list: %w", transport.ErrAuthorizationFailed)))
+	assert.Equal(t, ReasonAuthenticationFailed, connectivityFailureReason(errors.New(
+		"ssh: handshake failed: ssh: unable to authenticate, attempted methods [none publickey]")))
+	assert.Equal(t, ReasonRepositoryNotFound,
+		connectivityFailureReason(fmt.Errorf("list: %w", transport.ErrRepositoryNotFound)))
+	assert.Equal(t, ReasonConnectionFailed,
+		connectivityFailureReason(errors.New("dial tcp 10.0.0.1:443: i/o timeout")))
+}
+
+func TestReconcile_PeriodicCheckTracksConnectivity(t *testing.T) {
+	reader, err := telemetry.InitTestExporter()
+	require.NoError(t, err)
+
+	provider := &configbutleraiv1alpha3.GitProvider{
+		ObjectMeta: metav1.ObjectMeta{Name: "provider", Namespace: "default"},
+		Spec: configbutleraiv1alpha3.GitProviderSpec{
+			URL:           "https://example.com/repo.git",
+			CheckInterval: ptr.To("30s"),
+		},
+	}
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, configbutleraiv1alpha3.AddToScheme(scheme))
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(provider).
+		WithStatusSubresource(&configbutleraiv1alpha3.GitProvider{}).
+		Build()
+
+	// The fake remote alternates: reachable, token revoked, reachable, network down.
+	outcomes := []error{
+		nil,
+		fmt.Errorf("failed to list remote references: %w", transport.ErrAuthenticationRequired),
+		nil,
+		errors.New("dial tcp: lookup example.com: no such host"),
+	}
+	calls := 0
+	reconciler := &GitProviderReconciler{
+		Client: k8sClient,
+		checkRepo: func(context.Context, string, transport.AuthMethod) (*gitpkg.RepoInfo, error) {
+			err := outcomes[calls]
+			calls++
+			if err != nil {
+				return nil, err
+			}
+			return &gitpkg.RepoInfo{RemoteBranchCount: 1}, nil
+		},
+	}
+	key := types.NamespacedName{Name: "provider", Namespace: "default"}
+	labels := map[string]string{"provider_namespace": "default", "provider_name": "provider"}
+
+	for i, want := range []struct {
+		status    metav1.ConditionStatus
+		reason    string
+		reachable int64
+	}{
+		{metav1.ConditionTrue, ReasonReachable, 1},
+		{metav1.ConditionFalse, ReasonAuthenticationFailed, 0},
+		{metav1.ConditionTrue, ReasonReachable, 1},
+		{metav1.ConditionFalse, ReasonConnectionFailed, 0},
+	} {
+		result, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+		require.NoError(t, err)
+		assert.Equal(t, 30*time.Second, result.RequeueAfter, "check %d must requeue on spec.checkInterval", i)
+
+		var got configbutleraiv1alpha3.GitProvider
+		require.NoError(t, k8sClient.Get(context.Background(), key, &got))
+		connected := apimeta.FindStatusCondition(got.Status.Conditions, ConditionTypeConnected)
+		require.NotNil(t, connected, "check %d", i)
+		assert.Equal(t, want.status, connected.Status, "check %d", i)
+		assert.Equal(t, want.reason, connected.Reason, "check %d", i)
+
+		reachable, ok := telemetry.CollectInt64Sum(reader, "gitopsreverser_provider_reachable", labels)
+		require.True(t, ok, "check %d", i)
+		assert.Equal(t, want.reachable, reachable, "check %d", i)
+	}
+	assert.Equal(t, 4, calls)
+
+	// Deleting the GitProvider drops its series rather than freezing the last outcome.
+	require.NoError(t, k8sClient.Delete(context.Background(), provider))
+	_, err = reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+	_, ok := telemetry.CollectInt64Sum(reader, "gitopsreverser_provider_reachable", labels)
+	assert.False(t, ok, "a deleted GitProvider must not keep a provider_reachable series")
+}
+
+func TestSetStalledConditions_MarksConnectivityNotChecked(t *testing.T) {
+	reconciler := &GitProviderReconciler{}
+	provider := &configbutleraiv1alpha3.GitProvider{}
+
+	reconciler.setStalledConditions(provider, ReasonSecretNotFound, "secret missing")
+
+	connected := apimeta.FindStatusCondition(provider.Status.Conditions, ConditionTypeConnected)
+	require.NotNil(t, connected)
+	assert.Equal(t, metav1.ConditionUnknown, connected.Status)
+	assert.Equal(t, ReasonNotChecked, connected.Reason)
+}
+
 func newGitProviderTestClient(t *testing.T, objects ...runtime.Object) ctrlclient.Client {
 	t.Helper()
 
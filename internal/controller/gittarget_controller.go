@@ -46,6 +46,16 @@ const (
 	// GitTargetConditionStreamsRunning is the source data-plane axis: True when every tracked type's
 	// watch has crossed its replay watermark or resumed from a durable cursor.
 	GitTargetConditionStreamsRunning = ConditionTypeStreamsRunning
+	// GitTargetConditionPushed reports how the latest push carrying the target's writes ended:
+	// True (CommitPushed), or False with the failure's reason (CommitFailed, PushRejected or
+	// BranchProtected) and the push error, including what the remote's hooks printed, as message.
+	// It does not affect Ready: a failed push is retried with the writes kept.
+	GitTargetConditionPushed = ConditionTypePushed
+	// GitTargetConditionMirrorsPushed reports whether the latest successful push carrying the
+	// target's writes also reached every one of the GitProvider's spec.mirrors: True
+	// (MirrorsPushed), or False (MirrorPushFailed) naming each mirror it missed and why. It is absent
+	// when the GitProvider lists no mirrors, and does not affect Ready.
+	GitTargetConditionMirrorsPushed = "MirrorsPushed"
 )
 
 // GitTargetReasonReady is a backward-compatible alias used by existing tests.
@@ -86,6 +96,8 @@ const (
 	GitTargetReasonRenderMatchesLive      = "RenderMatchesLive"
 	GitTargetReasonRenderDoesNotMatchLive = "RenderDoesNotMatchLive"
 	GitTargetReasonRenderRechecking       = "Rechecking"
+	// GitTargetReasonMirrorsPushed is the MirrorsPushed reason for a push that reached every mirror.
+	GitTargetReasonMirrorsPushed = GitTargetConditionMirrorsPushed
 
 	GitTargetReadyReasonValidationFailed        = "ValidationFailed"
 	GitTargetReadyReasonEncryptionNotConfigured = "EncryptionNotConfigured"
@@ -107,6 +119,10 @@ type GitTargetReconciler struct {
 	Scheme        *runtime.Scheme
 	WorkerManager *git.WorkerManager
 	EventRouter   *watch.EventRouter
+
+	// RequeueJitterFactor stretches each GitTarget's steady requeue by a fixed per-object
+	// fraction of up to this value; see jitteredRequeue. Zero disables jitter.
+	RequeueJitterFactor float64
 }
 
 // +kubebuilder:rbac:groups=configbutler.ai,resources=gittargets,verbs=get;list;watch;create;update;patch;delete
@@ -160,12 +176,12 @@ func (r *GitTargetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			if err := r.updateStatusWithRetry(ctx, &target); err != nil {
 				return ctrl.Result{}, err
 			}
-			return *validationResult, nil
+			return r.jittered(&target, *validationResult), nil
 		}
 		if err := r.updateStatusWithRetry(ctx, &target); err != nil {
 			return ctrl.Result{}, err
 		}
-		return ctrl.Result{RequeueAfter: RequeueSteadyInterval}, nil
+		return r.jittered(&target, ctrl.Result{RequeueAfter: RequeueSteadyInterval}), nil
 	}
 
 	encryptionReady, encryptionMessage, encryptionRequeueAfter := r.evaluateEncryptionGate(ctx, &target, log)
@@ -180,7 +196,7 @@ func (r *GitTargetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		if err := r.updateStatusWithRetry(ctx, &target); err != nil {
 			return ctrl.Result{}, err
 		}
-		return ctrl.Result{RequeueAfter: encryptionRequeueAfter}, nil
+		return r.jittered(&target, ctrl.Result{RequeueAfter: encryptionRequeueAfter}), nil
 	}
 
 	// Ensure the branch worker exists and register the GitTarget's event stream before declaring
@@ -198,7 +214,7 @@ func (r *GitTargetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		if err := r.updateStatusWithRetry(ctx, &target); err != nil {
 			return ctrl.Result{}, err
 		}
-		return ctrl.Result{RequeueAfter: RequeueSteadyInterval}, nil
+		return r.jittered(&target, ctrl.Result{RequeueAfter: RequeueSteadyInterval}), nil
 	}
 
 	// One read of the source ClusterProvider serves everything below it: the audit route captured on
@@ -222,14 +238,16 @@ func (r *GitTargetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	}
 	if r.EventRouter != nil && r.EventRouter.WatchManager != nil {
 		gitDest := types.NewResourceReference(target.Name, target.Namespace).WithUID(string(target.UID))
-		if declareErr := r.EventRouter.WatchManager.DeclareForGitTarget(
-			ctx,
-			gitDest,
-			target.SourceCluster(),
-			sourceProvider.AuditRoute(),
-			target.EffectivePruneMode(),
-			gitPathWasRefused,
-		); declareErr != nil {
+		if declareErr := r.EventRouter.WatchManager.DeclareForGitTarget(ctx, gitDest, watch.GitTargetDeclaration{
+			ClusterID:             target.SourceCluster(),
+			AuditRoute:            sourceProvider.AuditRoute(),
+			PruneMode:             target.EffectivePruneMode(),
+			Throttles:             target.Spec.PerGVRThrottle,
+			DedupStrategy:         target.EffectiveDedupStrategy(),
+			SanitizePerGVR:        target.Spec.SanitizePerGVR,
+			PreserveManagedFields: target.Spec.PreserveManagedFields,
+			ForceRecheck:          gitPathWasRefused,
+		}); declareErr != nil {
 			log.V(1).Info("stream declaration skipped; surface not observable",
 				"gitDest", gitDest.String(), "err", declareErr.Error())
 			streamsSettling = true
@@ -272,6 +290,9 @@ func (r *GitTargetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		cpStatus, cpReason, cpMessage)
 	streamsSettling = streamsSettling || sourceReach.State != "True" ||
 		providerStatus != metav1.ConditionTrue || cpStatus == metav1.ConditionFalse
+	r.projectPullRequest(&target, providerNS)
+	r.projectPushOutcome(&target, providerNS)
+	r.projectWorkerState(&target, providerNS)
 
 	if err := r.updateStatusWithRetry(ctx, &target); err != nil {
 		return ctrl.Result{}, err
@@ -280,7 +301,117 @@ func (r *GitTargetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	if streamsSettling {
 		return ctrl.Result{RequeueAfter: RequeueStreamSettleInterval}, nil
 	}
-	return ctrl.Result{RequeueAfter: RequeueSteadyInterval}, nil
+	return r.jittered(&target, ctrl.Result{RequeueAfter: RequeueSteadyInterval}), nil
+}
+
+// projectPullRequest reports the pull request the branch worker last found or opened for a target
+// with spec.pullRequest. Until a push has run since the worker started there is nothing new to
+// report, and the previously reported request is kept.
+func (r *GitTargetReconciler) projectPullRequest(target *configbutleraiv1alpha3.GitTarget, providerNS string) {
+	if target.Spec.PullRequest == nil {
+		target.Status.PullRequest = nil
+		return
+	}
+	if r.WorkerManager == nil {
+		return
+	}
+	worker, ok := r.WorkerManager.GetWorkerForTarget(target.Spec.ProviderRef.Name, providerNS, target.Spec.Branch)
+	if !ok {
+		return
+	}
+	if pr, found := worker.PullRequestFor(target.Name, target.Namespace); found {
+		target.Status.PullRequest = &configbutleraiv1alpha3.GitTargetPullRequestStatus{
+			Number: clampIntToInt32(pr.Number),
+			URL:    pr.URL,
+		}
+	}
+}
+
+// projectPushOutcome reports how the latest push carrying the target's writes ended as the Pushed
+// condition. Until a push has carried them since the worker started there is nothing new to
+// report, and the condition is left as it was.
+func (r *GitTargetReconciler) projectPushOutcome(target *configbutleraiv1alpha3.GitTarget, providerNS string) {
+	if r.WorkerManager == nil {
+		return
+	}
+	worker, ok := r.WorkerManager.GetWorkerForTarget(target.Spec.ProviderRef.Name, providerNS, target.Spec.Branch)
+	if !ok {
+		return
+	}
+	if outcome, found := worker.LastPushFor(target.Name, target.Namespace); found {
+		r.setPushedCondition(target, outcome)
+	}
+	if outcome, found := worker.LastMirrorPushFor(target.Name, target.Namespace); found {
+		r.setMirrorsPushedCondition(target, outcome)
+	}
+}
+
+// projectWorkerState reports the state and latest transitions of the branch worker writing the
+// target's branch. Without a worker the state is cleared, and so is a history that no longer
+// describes a running worker.
+func (r *GitTargetReconciler) projectWorkerState(target *configbutleraiv1alpha3.GitTarget, providerNS string) {
+	target.Status.WorkerState = ""
+	target.Status.LastStateTransition = nil
+	target.Status.StateHistory = nil
+	if r.WorkerManager == nil {
+		return
+	}
+	worker, ok := r.WorkerManager.GetWorkerForTarget(target.Spec.ProviderRef.Name, providerNS, target.Spec.Branch)
+	if !ok {
+		return
+	}
+	state, history := worker.StateReport()
+	if state == "" {
+		return
+	}
+	target.Status.WorkerState = state
+	lastTransition := metav1.NewTime(history[0].At)
+	target.Status.LastStateTransition = &lastTransition
+	for _, transition := range history {
+		target.Status.StateHistory = append(target.Status.StateHistory, configbutleraiv1alpha3.StateTransition{
+			From:      transition.From,
+			To:        transition.To,
+			Reason:    transition.Reason,
+			Timestamp: metav1.NewTime(transition.At),
+		})
+	}
+}
+
+func (r *GitTargetReconciler) setPushedCondition(target *configbutleraiv1alpha3.GitTarget, outcome git.PushOutcome) {
+	if outcome.Failed() {
+		r.setCondition(target, GitTargetConditionPushed, metav1.ConditionFalse, outcome.Reason, outcome.LastPushError)
+		return
+	}
+	r.setCondition(target, GitTargetConditionPushed, metav1.ConditionTrue, outcome.Reason,
+		fmt.Sprintf("The latest push to %s carried the target's writes", target.Spec.Branch))
+}
+
+func (r *GitTargetReconciler) setMirrorsPushedCondition(
+	target *configbutleraiv1alpha3.GitTarget,
+	outcome git.MirrorOutcome,
+) {
+	switch {
+	case outcome.Mirrors == 0:
+		target.Status.Conditions = removeCondition(target.Status.Conditions, GitTargetConditionMirrorsPushed)
+	case len(outcome.Failures) > 0:
+		r.setCondition(target, GitTargetConditionMirrorsPushed, metav1.ConditionFalse, git.ReasonMirrorPushFailed,
+			fmt.Sprintf("The latest push missed %d of %d mirrors: %s",
+				len(outcome.Failures), outcome.Mirrors, strings.Join(outcome.Failures, "; ")))
+	default:
+		r.setCondition(target, GitTargetConditionMirrorsPushed, metav1.ConditionTrue, GitTargetReasonMirrorsPushed,
+			fmt.Sprintf("The latest push to %s reached all %d mirrors", target.Spec.Branch, outcome.Mirrors))
+	}
+}
+
+// jittered stretches a steady-cadence requeue by the target's fixed jitter. The stream-settle
+// requeue is left alone: it only runs while a target converges and is meant to be prompt.
+func (r *GitTargetReconciler) jittered(
+	target *configbutleraiv1alpha3.GitTarget,
+	result ctrl.Result,
+) ctrl.Result {
+	key := client.ObjectKeyFromObject(target)
+	result.RequeueAfter = jitteredRequeue(result.RequeueAfter, r.RequeueJitterFactor, key)
+	return result
 }
 
 func (r *GitTargetReconciler) evaluateValidatedGate(
@@ -306,6 +437,39 @@ func (r *GitTargetReconciler) evaluateValidatedGate(
 		return false, fmt.Sprintf("Validated gate failed: %s", conflictReason), &conflictResult, nil
 	}
 
+	if throttleOK, throttleMsg := validatePerGVRThrottle(target.Spec.PerGVRThrottle); !throttleOK {
+		r.setCondition(
+			target,
+			GitTargetConditionValidated,
+			metav1.ConditionFalse,
+			GitTargetReasonInvalidConfig,
+			throttleMsg,
+		)
+		return false, fmt.Sprintf("Validated gate failed: %s", GitTargetReasonInvalidConfig), nil, nil
+	}
+
+	if sanitizeOK, sanitizeMsg := validateSanitizePerGVR(target.Spec.SanitizePerGVR); !sanitizeOK {
+		r.setCondition(
+			target,
+			GitTargetConditionValidated,
+			metav1.ConditionFalse,
+			GitTargetReasonInvalidConfig,
+			sanitizeMsg,
+		)
+		return false, fmt.Sprintf("Validated gate failed: %s", GitTargetReasonInvalidConfig), nil, nil
+	}
+
+	if mappingOK, mappingMsg := validateUserMapping(target.Spec.UserMapping); !mappingOK {
+		r.setCondition(
+			target,
+			GitTargetConditionValidated,
+			metav1.ConditionFalse,
+			GitTargetReasonInvalidConfig,
+			mappingMsg,
+		)
+		return false, fmt.Sprintf("Validated gate failed: %s", GitTargetReasonInvalidConfig), nil, nil
+	}
+
 	if placementOK, placementMsg := validatePlacementPolicy(target.Spec.Placement); !placementOK {
 		r.setCondition(
 			target,
@@ -350,13 +514,13 @@ func (r *GitTargetReconciler) evaluateEncryptionGate(
 	target *configbutleraiv1alpha3.GitTarget,
 	log logr.Logger,
 ) (bool, string, time.Duration) {
-	if !isTargetAgeEncryptionEnabled(target) {
+	if !isTargetEncryptionEnabled(target) {
 		r.setCondition(
 			target,
 			GitTargetConditionEncryptionConfigured,
 			metav1.ConditionTrue,
 			GitTargetReasonNotRequired,
-			"SOPS age encryption is not enabled for this GitTarget",
+			"SOPS encryption is not enabled for this GitTarget",
 		)
 		return true, "", 0
 	}
@@ -1003,6 +1167,16 @@ func logEncryptionBackupWarning(log logr.Logger, secretKey k8stypes.NamespacedNa
 		"annotation", encryptionSecretBackupWarningAnno)
 }
 
+// isTargetEncryptionEnabled reports whether the GitTarget configures any SOPS key backend: age,
+// AWS KMS or Vault.
+func isTargetEncryptionEnabled(target *configbutleraiv1alpha3.GitTarget) bool {
+	if isTargetAgeEncryptionEnabled(target) {
+		return true
+	}
+	return target != nil && target.Spec.Encryption != nil &&
+		(target.Spec.Encryption.AWSKMS != nil || target.Spec.Encryption.Vault != nil)
+}
+
 func isTargetAgeEncryptionEnabled(target *configbutleraiv1alpha3.GitTarget) bool {
 	if target == nil || target.Spec.Encryption == nil {
 		return false
@@ -1046,6 +1220,10 @@ func (r *GitTargetReconciler) cleanupDeletedGitTarget(
 	namespacedName k8stypes.NamespacedName,
 	log logr.Logger,
 ) {
+	recordReconcileHistoryGauge(namespacedName.Namespace, namespacedName.Name, 0)
+	if r.WorkerManager != nil {
+		r.WorkerManager.ForgetGitTarget(namespacedName.Name, namespacedName.Namespace)
+	}
 	if r.EventRouter == nil {
 		return
 	}
@@ -1119,7 +1297,7 @@ func (r *GitTargetReconciler) updateStatusWithRetry(
 ) error {
 	log := logf.FromContext(ctx).WithName("updateStatusWithRetry")
 
-	return wait.ExponentialBackoff(wait.Backoff{
+	if err := wait.ExponentialBackoff(wait.Backoff{
 		Duration: RetryInitialDuration,
 		Factor:   RetryBackoffFactor,
 		Jitter:   RetryBackoffJitter,
@@ -1144,13 +1322,20 @@ func (r *GitTargetReconciler) updateStatusWithRetry(
 		}
 
 		return true, nil
-	})
+	}); err != nil {
+		return err
+	}
+
+	if err := r.recordReconcileHistory(ctx, target); err != nil {
+		log.V(1).Info("Reconcile history not recorded", "err", err.Error())
+	}
+	return nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *GitTargetReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	b := ctrl.NewControllerManagedBy(mgr).
-		For(&configbutleraiv1alpha3.GitTarget{}).
+		For(&configbutleraiv1alpha3.GitTarget{}, builder.WithPredicates(ignoreReconcileHistoryUpdates())).
 		// No control-plane Secret watch. Reacting to age-key Secret changes with a
 		// full-object Secret watch made the process retain every Secret value in the
 		// cluster. Generated-age-Secret recovery and out-of-band age-key updates are
@@ -1212,6 +1397,14 @@ func (r *GitTargetReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			&handler.EnqueueRequestForObject{},
 		))
 	}
+	// React to a branch worker entering or leaving the errored state, so status.workerState shows
+	// a failing push within one reconcile. The event names the worker's GitProvider.
+	if r.WorkerManager != nil {
+		b = b.WatchesRawSource(source.Channel(
+			r.WorkerManager.StateEvents(),
+			handler.EnqueueRequestsFromMapFunc(r.gitProviderToGitTargets),
+		))
+	}
 
 	return b.Complete(r)
 }
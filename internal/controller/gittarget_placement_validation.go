@@ -4,10 +4,14 @@ package controller
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	configbutleraiv1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
+	"github.com/ConfigButler/gitops-reverser/internal/git"
 	"github.com/ConfigButler/gitops-reverser/internal/manifestanalyzer"
+	"github.com/ConfigButler/gitops-reverser/internal/sanitize"
 )
 
 // coreSecretsTypeKey is the placement byType key for core Kubernetes Secrets — the
@@ -88,6 +92,62 @@ func validateSecretSafety(spec *configbutleraiv1alpha3.GitTargetPlacementSpec) (
 	return true, ""
 }
 
+// validatePerGVRThrottle statically validates spec.perGVRThrottle. Its keys share
+// placement.byType's "[group/]version/resource" key syntax, and for the same reason: the live
+// event path matches them against the watched GVR by exact string equality, so a malformed key
+// would never throttle anything instead of failing loudly. Each maxEventsPerSecond must be
+// positive: a quantity the CRD cannot bound, and a zero rate would drop every update.
+func validatePerGVRThrottle(throttles map[string]configbutleraiv1alpha3.RateLimitSpec) (bool, string) {
+	for _, key := range slices.Sorted(maps.Keys(throttles)) {
+		if !validPlacementTypeKeySyntax(key) {
+			return false, fmt.Sprintf(
+				"perGVRThrottle key %q is not a valid \"[group/]version/resource\" type key", key,
+			)
+		}
+		if limit := throttles[key].MaxEventsPerSecond; limit.Sign() <= 0 {
+			return false, fmt.Sprintf("perGVRThrottle[%q].maxEventsPerSecond must be positive, got %s",
+				key, limit.String())
+		}
+	}
+	return true, ""
+}
+
+// validateSanitizePerGVR statically validates spec.sanitizePerGVR: its keys share
+// perGVRThrottle's type-key syntax, every stripFields and redactFields path must parse and leave
+// the document's identity in place, and every sortListFields path must parse. A path that cannot be
+// applied is refused here rather than skipped on every write.
+func validateSanitizePerGVR(rules map[string]configbutleraiv1alpha3.GVRSanitizeSpec) (bool, string) {
+	for _, key := range slices.Sorted(maps.Keys(rules)) {
+		if !validPlacementTypeKeySyntax(key) {
+			return false, fmt.Sprintf(
+				"sanitizePerGVR key %q is not a valid \"[group/]version/resource\" type key", key,
+			)
+		}
+		compiled := sanitize.Rules{
+			StripFields:    rules[key].StripFields,
+			SortListFields: rules[key].SortListFields,
+			RedactFields:   rules[key].RedactFields,
+		}
+		if err := compiled.Validate(); err != nil {
+			return false, fmt.Sprintf("sanitizePerGVR[%q]: %v", key, err)
+		}
+	}
+	return true, ""
+}
+
+// validateUserMapping statically validates spec.userMapping.patterns: each usernamePattern must
+// compile. A pattern that does not is refused here, once, rather than disabling the mapping on
+// every commit. The Secret is not read here; it may change at any time and is resolved per commit.
+func validateUserMapping(spec *configbutleraiv1alpha3.UserMappingSpec) (bool, string) {
+	if spec == nil {
+		return true, ""
+	}
+	if err := git.ValidateUserMappingPatterns(spec.Patterns); err != nil {
+		return false, err.Error()
+	}
+	return true, ""
+}
+
 // validatePlacementTemplate checks one template string against the two purely
 // structural rules every placement template must satisfy: its variables are all
 // known (ValidPlacementTemplateSyntax) and its literal text cannot escape the
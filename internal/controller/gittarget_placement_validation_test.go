@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -189,3 +190,93 @@ func TestEvaluateValidatedGate_InvalidPlacementPolicy(t *testing.T) {
 	assert.Equal(t, GitTargetReasonInvalidConfig, cond.Reason)
 	assert.Contains(t, cond.Message, "bogus")
 }
+
+func TestValidatePerGVRThrottle(t *testing.T) {
+	rate := configbutleraiv1alpha3.RateLimitSpec{MaxEventsPerSecond: resource.MustParse("5")}
+	halt := configbutleraiv1alpha3.RateLimitSpec{MaxEventsPerSecond: resource.MustParse("0")}
+	cases := []struct {
+		name      string
+		throttles map[string]configbutleraiv1alpha3.RateLimitSpec
+		ok        bool
+	}{
+		{"nil", nil, true},
+		{"core and grouped keys", map[string]configbutleraiv1alpha3.RateLimitSpec{
+			"v1/pods": rate, "apps/v1/deployments": rate,
+		}, true},
+		{"malformed key", map[string]configbutleraiv1alpha3.RateLimitSpec{"pods": rate}, false},
+		{"padded key", map[string]configbutleraiv1alpha3.RateLimitSpec{"v1/pods ": rate}, false},
+		{"fractional rate", map[string]configbutleraiv1alpha3.RateLimitSpec{
+			"v1/pods": {MaxEventsPerSecond: resource.MustParse("500m")},
+		}, true},
+		{"zero rate", map[string]configbutleraiv1alpha3.RateLimitSpec{"v1/pods": halt}, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, msg := validatePerGVRThrottle(tc.throttles)
+			if ok != tc.ok {
+				t.Errorf("validatePerGVRThrottle() = (%v, %q), want ok=%v", ok, msg, tc.ok)
+			}
+			if !tc.ok && msg == "" {
+				t.Errorf("an invalid throttle must carry a message")
+			}
+		})
+	}
+}
+
+func TestValidateSanitizePerGVR(t *testing.T) {
+	cases := []struct {
+		name  string
+		rules map[string]configbutleraiv1alpha3.GVRSanitizeSpec
+		ok    bool
+	}{
+		{"nil", nil, true},
+		{"valid", map[string]configbutleraiv1alpha3.GVRSanitizeSpec{
+			"apps/v1/deployments": {StripFields: []string{"spec.replicas"}, AnnotationBlocklist: []string{"a/*"}},
+		}, true},
+		{"malformed key", map[string]configbutleraiv1alpha3.GVRSanitizeSpec{"deployments": {}}, false},
+		{"identity path", map[string]configbutleraiv1alpha3.GVRSanitizeSpec{
+			"v1/configmaps": {StripFields: []string{"metadata.name"}},
+		}, false},
+		{"empty segment", map[string]configbutleraiv1alpha3.GVRSanitizeSpec{
+			"v1/configmaps": {StripFields: []string{"data..key"}},
+		}, false},
+		{"redact path", map[string]configbutleraiv1alpha3.GVRSanitizeSpec{
+			"apps/v1/deployments": {RedactFields: []string{"spec.template.spec.containers[*].env[*].value"}},
+		}, true},
+		{"redact identity path", map[string]configbutleraiv1alpha3.GVRSanitizeSpec{
+			"v1/configmaps": {RedactFields: []string{"metadata.namespace"}},
+		}, false},
+		{"redact index segment", map[string]configbutleraiv1alpha3.GVRSanitizeSpec{
+			"v1/configmaps": {RedactFields: []string{"spec.items[0].value"}},
+		}, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, msg := validateSanitizePerGVR(tc.rules)
+			if ok != tc.ok {
+				t.Errorf("validateSanitizePerGVR() = (%v, %q), want ok=%v", ok, msg, tc.ok)
+			}
+			if !tc.ok && msg == "" {
+				t.Errorf("an invalid rule must carry a message")
+			}
+		})
+	}
+}
+
+func TestValidateUserMapping(t *testing.T) {
+	ok, msg := validateUserMapping(nil)
+	assert.True(t, ok, msg)
+
+	spec := &configbutleraiv1alpha3.UserMappingSpec{Patterns: []configbutleraiv1alpha3.UserMappingPattern{
+		{UsernamePattern: "system:serviceaccount:(.+):(.+)", GitName: "$2", GitEmail: "$2@example.com"},
+	}}
+	ok, msg = validateUserMapping(spec)
+	assert.True(t, ok, msg)
+
+	spec.Patterns = append(spec.Patterns, configbutleraiv1alpha3.UserMappingPattern{
+		UsernamePattern: "bot-(.+", GitName: "bot", GitEmail: "bot@example.com",
+	})
+	ok, msg = validateUserMapping(spec)
+	assert.False(t, ok)
+	assert.Contains(t, msg, `userMapping pattern 1 "bot-(.+"`)
+}
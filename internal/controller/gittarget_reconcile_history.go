@@ -0,0 +1,220 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	configbutleraiv1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
+	"github.com/ConfigButler/gitops-reverser/internal/git"
+	"github.com/ConfigButler/gitops-reverser/internal/telemetry"
+)
+
+// The reconcile history is kept in an annotation, not in status: it is a bounded operator aid, and
+// growing the status subresource on every cycle would also grow every status write and watch event.
+//
+// Only a cycle that differs from the newest entry is recorded — a different result or reason, or
+// something pushed since. The stream-settle requeue runs every few seconds; recording every cycle
+// would flush a transient failure out of the window before anyone looked, which is the one thing
+// the history exists to keep.
+//
+// Writing the annotation is an update of the GitTarget itself, so the controller's own watch
+// filters it out (ignoreReconcileHistoryUpdates); otherwise every recorded cycle would trigger the
+// next one. What the branch worker pushed is taken before the patch and handed back when the patch
+// fails, so a failed write does not lose it.
+
+const (
+	// ReconcileHistoryAnnotation holds a GitTarget's reconcile history as a JSON array of
+	// ReconcileHistoryEntry, newest first.
+	ReconcileHistoryAnnotation = "configbutler.ai/reconcile-history"
+	// ReconcileHistoryLimit caps the number of entries kept in ReconcileHistoryAnnotation.
+	ReconcileHistoryLimit = 20
+
+	// ReconcileHistoryResultSuccess marks a cycle that ended Ready=True.
+	ReconcileHistoryResultSuccess = "success"
+	// ReconcileHistoryResultFailure marks a cycle that ended Stalled=True.
+	ReconcileHistoryResultFailure = "failure"
+	// ReconcileHistoryResultProgressing marks a cycle that ended neither Ready nor Stalled.
+	ReconcileHistoryResultProgressing = "progressing"
+)
+
+// ReconcileHistoryEntry is one recorded GitTarget reconcile cycle.
+type ReconcileHistoryEntry struct {
+	Timestamp metav1.Time `json:"timestamp"`
+	Result    string      `json:"result"`
+	Reason    string      `json:"reason,omitempty"`
+	// CommitSHA is the latest commit pushed for this GitTarget since the previous entry.
+	CommitSHA string `json:"commitSHA,omitempty"`
+	// EventCount is the number of resource events pushed for this GitTarget since the previous entry.
+	EventCount int `json:"eventCount,omitempty"`
+}
+
+// sameOutcome reports whether two entries describe the same steady state: nothing pushed in
+// between and the same result for the same reason.
+func (e ReconcileHistoryEntry) sameOutcome(other ReconcileHistoryEntry) bool {
+	return e.Result == other.Result && e.Reason == other.Reason && e.CommitSHA == "" && e.EventCount == 0
+}
+
+// recordReconcileHistory prepends this cycle to the GitTarget's reconcile history annotation.
+// It is best effort: a failure is returned for logging and never fails the reconcile.
+func (r *GitTargetReconciler) recordReconcileHistory(
+	ctx context.Context,
+	target *configbutleraiv1alpha3.GitTarget,
+) error {
+	entry := r.reconcileHistoryEntry(target)
+	history := parseReconcileHistory(target.Annotations[ReconcileHistoryAnnotation])
+	if len(history) > 0 && entry.sameOutcome(history[0]) {
+		return nil
+	}
+	history = append([]ReconcileHistoryEntry{entry}, history...)
+	if len(history) > ReconcileHistoryLimit {
+		history = history[:ReconcileHistoryLimit]
+	}
+
+	if err := r.patchReconcileHistory(ctx, target, history); err != nil {
+		r.restorePushedStats(target, entry)
+		return client.IgnoreNotFound(err)
+	}
+	recordReconcileHistoryGauge(target.Namespace, target.Name, len(history))
+	return nil
+}
+
+func (r *GitTargetReconciler) patchReconcileHistory(
+	ctx context.Context,
+	target *configbutleraiv1alpha3.GitTarget,
+	history []ReconcileHistoryEntry,
+) error {
+	encoded, err := json.Marshal(history)
+	if err != nil {
+		return fmt.Errorf("encode reconcile history: %w", err)
+	}
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]string{ReconcileHistoryAnnotation: string(encoded)},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("encode reconcile history patch: %w", err)
+	}
+	return r.Patch(ctx, target, client.RawPatch(k8stypes.MergePatchType, patch))
+}
+
+// reconcileHistoryEntry summarizes the cycle whose status was just written, and takes what the
+// branch worker pushed for this target since the previous cycle.
+func (r *GitTargetReconciler) reconcileHistoryEntry(
+	target *configbutleraiv1alpha3.GitTarget,
+) ReconcileHistoryEntry {
+	entry := ReconcileHistoryEntry{
+		Timestamp: target.Status.LastReconcileTime,
+		Result:    ReconcileHistoryResultProgressing,
+	}
+	switch {
+	case conditionIsTrue(target.Status.Conditions, ConditionTypeReady):
+		entry.Result = ReconcileHistoryResultSuccess
+	case conditionIsTrue(target.Status.Conditions, ConditionTypeStalled):
+		entry.Result = ReconcileHistoryResultFailure
+	}
+	if ready := conditionByType(target.Status.Conditions, ConditionTypeReady); ready != nil {
+		entry.Reason = ready.Reason
+	}
+
+	if worker, ok := r.historyWorker(target); ok {
+		pushed := worker.TakePushedStats(target.Name, target.Namespace)
+		entry.CommitSHA = pushed.CommitSHA
+		entry.EventCount = pushed.Events
+	}
+	return entry
+}
+
+// restorePushedStats hands what an unrecorded entry took back to the branch worker, so the next
+// recorded cycle reports it.
+func (r *GitTargetReconciler) restorePushedStats(
+	target *configbutleraiv1alpha3.GitTarget,
+	entry ReconcileHistoryEntry,
+) {
+	if entry.CommitSHA == "" && entry.EventCount == 0 {
+		return
+	}
+	if worker, ok := r.historyWorker(target); ok {
+		worker.RestorePushedStats(target.Name, target.Namespace,
+			git.PushedTargetStats{Events: entry.EventCount, CommitSHA: entry.CommitSHA})
+	}
+}
+
+func (r *GitTargetReconciler) historyWorker(target *configbutleraiv1alpha3.GitTarget) (*git.BranchWorker, bool) {
+	if r.WorkerManager == nil {
+		return nil, false
+	}
+	return r.WorkerManager.GetWorkerForTarget(target.Spec.ProviderRef.Name, target.Namespace, target.Spec.Branch)
+}
+
+// ignoreReconcileHistoryUpdates drops a GitTarget update that changed nothing but the reconcile
+// history annotation: that is this controller's own bookkeeping write, and reconciling on it would
+// loop. Every other event passes.
+func ignoreReconcileHistoryUpdates() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldGT, ok1 := e.ObjectOld.(*configbutleraiv1alpha3.GitTarget)
+			newGT, ok2 := e.ObjectNew.(*configbutleraiv1alpha3.GitTarget)
+			if !ok1 || !ok2 {
+				return true
+			}
+			if oldGT.Annotations[ReconcileHistoryAnnotation] == newGT.Annotations[ReconcileHistoryAnnotation] {
+				return true
+			}
+			return !onlyReconcileHistoryChanged(oldGT, newGT)
+		},
+	}
+}
+
+func onlyReconcileHistoryChanged(oldGT, newGT *configbutleraiv1alpha3.GitTarget) bool {
+	withoutHistory := func(annotations map[string]string) map[string]string {
+		out := maps.Clone(annotations)
+		delete(out, ReconcileHistoryAnnotation)
+		return out
+	}
+	return oldGT.Generation == newGT.Generation &&
+		maps.Equal(withoutHistory(oldGT.Annotations), withoutHistory(newGT.Annotations)) &&
+		maps.Equal(oldGT.Labels, newGT.Labels) &&
+		slices.Equal(oldGT.Finalizers, newGT.Finalizers) &&
+		oldGT.DeletionTimestamp.Equal(newGT.DeletionTimestamp) &&
+		equality.Semantic.DeepEqual(oldGT.Status, newGT.Status)
+}
+
+// parseReconcileHistory decodes the annotation value. A missing or hand-mangled value starts a
+// fresh history rather than failing the reconcile.
+func parseReconcileHistory(value string) []ReconcileHistoryEntry {
+	if value == "" {
+		return nil
+	}
+	var history []ReconcileHistoryEntry
+	if err := json.Unmarshal([]byte(value), &history); err != nil {
+		return nil
+	}
+	return history
+}
+
+// recordReconcileHistoryGauge publishes a GitTarget's current history length; a deleted target
+// records 0 so its series stops reporting a stale length.
+func recordReconcileHistoryGauge(namespace, name string, entries int) {
+	if telemetry.ReconcileHistoryEntries == nil {
+		return
+	}
+	telemetry.ReconcileHistoryEntries.Record(context.Background(), int64(entries), metric.WithAttributes(
+		attribute.String("gittarget_namespace", namespace),
+		attribute.String("gittarget_name", name),
+	))
+}
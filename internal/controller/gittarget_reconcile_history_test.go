@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	configbutleraiv1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
+)
+
+func historyTestTarget(readyStatus metav1.ConditionStatus, reason string) *configbutleraiv1alpha3.GitTarget {
+	target := &configbutleraiv1alpha3.GitTarget{
+		ObjectMeta: metav1.ObjectMeta{Name: "target", Namespace: "default"},
+	}
+	target.Status.LastReconcileTime = metav1.Now()
+	target.Status.Conditions = []metav1.Condition{{Type: ConditionTypeReady, Status: readyStatus, Reason: reason}}
+	if readyStatus == metav1.ConditionFalse {
+		target.Status.Conditions = append(target.Status.Conditions,
+			metav1.Condition{Type: ConditionTypeStalled, Status: metav1.ConditionTrue, Reason: reason})
+	}
+	return target
+}
+
+func readHistory(t *testing.T, c client.Client) []ReconcileHistoryEntry {
+	t.Helper()
+	var stored configbutleraiv1alpha3.GitTarget
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: "target", Namespace: "default"}, &stored))
+	return parseReconcileHistory(stored.Annotations[ReconcileHistoryAnnotation])
+}
+
+// A transient failure and its recovery must both survive a run of steady requeues: only a
+// changed outcome is recorded, so the repeats do not push the failure out of the window.
+func TestRecordReconcileHistory_RecordsOnlyChangedOutcomes(t *testing.T) {
+	ctx := context.Background()
+	c := newGitProviderTestClient(t, historyTestTarget(metav1.ConditionTrue, ConditionTypeReady))
+	r := &GitTargetReconciler{Client: c}
+
+	record := func(status metav1.ConditionStatus, reason string) {
+		var stored configbutleraiv1alpha3.GitTarget
+		require.NoError(t, c.Get(ctx, client.ObjectKey{Name: "target", Namespace: "default"}, &stored))
+		cycle := historyTestTarget(status, reason)
+		cycle.ObjectMeta = stored.ObjectMeta
+		require.NoError(t, r.recordReconcileHistory(ctx, cycle))
+	}
+
+	record(metav1.ConditionTrue, ConditionTypeReady)
+	record(metav1.ConditionFalse, ReasonConnectionFailed)
+	for range 5 {
+		record(metav1.ConditionTrue, ConditionTypeReady)
+	}
+
+	history := readHistory(t, c)
+	require.Len(t, history, 3)
+	assert.Equal(t, ReconcileHistoryResultSuccess, history[0].Result)
+	assert.Equal(t, ReconcileHistoryResultFailure, history[1].Result)
+	assert.Equal(t, ReasonConnectionFailed, history[1].Reason)
+	assert.Equal(t, ReconcileHistoryResultSuccess, history[2].Result)
+}
+
+func TestRecordReconcileHistory_CapsEntries(t *testing.T) {
+	ctx := context.Background()
+	c := newGitProviderTestClient(t, historyTestTarget(metav1.ConditionTrue, ConditionTypeReady))
+	r := &GitTargetReconciler{Client: c}
+
+	for i := range ReconcileHistoryLimit + 5 {
+		var stored configbutleraiv1alpha3.GitTarget
+		require.NoError(t, c.Get(ctx, client.ObjectKey{Name: "target", Namespace: "default"}, &stored))
+		status, reason := metav1.ConditionTrue, ConditionTypeReady
+		if i%2 == 1 {
+			status, reason = metav1.ConditionFalse, ReasonConnectionFailed
+		}
+		cycle := historyTestTarget(status, reason)
+		cycle.ObjectMeta = stored.ObjectMeta
+		require.NoError(t, r.recordReconcileHistory(ctx, cycle))
+	}
+
+	assert.Len(t, readHistory(t, c), ReconcileHistoryLimit)
+}
+
+func TestParseReconcileHistory_MangledValueStartsFresh(t *testing.T) {
+	assert.Nil(t, parseReconcileHistory(""))
+	assert.Nil(t, parseReconcileHistory("{not json"))
+}
+
+// The history patch is the controller's own write; reconciling on it would loop. Any other change
+// that rides along with it still reconciles.
+func TestIgnoreReconcileHistoryUpdates(t *testing.T) {
+	pred := ignoreReconcileHistoryUpdates()
+	old := historyTestTarget(metav1.ConditionTrue, ConditionTypeReady)
+	old.Annotations = map[string]string{"team": "a"}
+
+	recorded := old.DeepCopy()
+	recorded.Annotations[ReconcileHistoryAnnotation] = `[{"result":"success"}]`
+	recorded.ResourceVersion = "2"
+	assert.False(t, pred.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: recorded}))
+
+	respecced := recorded.DeepCopy()
+	respecced.Generation++
+	assert.True(t, pred.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: respecced}))
+
+	relabelled := recorded.DeepCopy()
+	relabelled.Annotations["team"] = "b"
+	assert.True(t, pred.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: relabelled}))
+
+	statusOnly := old.DeepCopy()
+	statusOnly.Status.Conditions[0].Reason = "Other"
+	assert.True(t, pred.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: statusOnly}),
+		"an update that leaves the annotation alone is not filtered")
+}
@@ -383,8 +383,12 @@ func TestReconcile_UnauthorizedNamespaceStartsNoWatch(t *testing.T) {
 	// before it opens any watch, so it records even though opening watches fails here (no discovery
 	// client is wired) — which is exactly the capture a refused GitTarget must not produce.
 	other := types.NewResourceReference("authorized", ns).WithUID("other-uid")
-	_ = watchManager.DeclareForGitTarget(
-		context.Background(), other, providerName, providerName, configbutleraiv1alpha3.PruneOnEvent)
+	_ = watchManager.DeclareForGitTarget(context.Background(), other, watch.GitTargetDeclaration{
+		ClusterID:     providerName,
+		AuditRoute:    providerName,
+		PruneMode:     configbutleraiv1alpha3.PruneOnEvent,
+		DedupStrategy: configbutleraiv1alpha3.DedupContentHash,
+	})
 	id, declaredOther := watchManager.DeclaredSourceCluster(other)
 	require.True(t, declaredOther, "the positive control must declare, or the assertion above proves nothing")
 	assert.Equal(t, providerName, id)
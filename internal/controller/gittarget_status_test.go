@@ -8,9 +8,11 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	configbutleraiv1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
+	"github.com/ConfigButler/gitops-reverser/internal/git"
 	"github.com/ConfigButler/gitops-reverser/internal/watch"
 )
 
@@ -204,3 +206,93 @@ func TestGitTargetRetentionStatus_ReportsTheEffectiveMode(t *testing.T) {
 	assert.Equal(t, configbutleraiv1alpha3.PruneOnEvent, projected.Mode)
 	assert.Equal(t, observed, projected.ObservedTime.Time)
 }
+
+func TestProjectPullRequest_KeepsReportedRequestUntilSpecIsRemoved(t *testing.T) {
+	r := &GitTargetReconciler{}
+	reported := &configbutleraiv1alpha3.GitTargetPullRequestStatus{
+		Number: 7, URL: "https://github.com/acme/live/pull/7",
+	}
+	target := &configbutleraiv1alpha3.GitTarget{
+		Spec: configbutleraiv1alpha3.GitTargetSpec{
+			PullRequest: &configbutleraiv1alpha3.GitTargetPullRequestSpec{BaseBranch: "main"},
+		},
+		Status: configbutleraiv1alpha3.GitTargetStatus{PullRequest: reported},
+	}
+
+	r.projectPullRequest(target, "default")
+	assert.Equal(t, reported, target.Status.PullRequest, "no push since start has nothing newer to report")
+
+	target.Spec.PullRequest = nil
+	r.projectPullRequest(target, "default")
+	assert.Nil(t, target.Status.PullRequest)
+}
+
+// Without a branch worker for the target there is no state to report, and what an earlier
+// worker reported is cleared rather than left to read as current.
+func TestProjectWorkerState_ClearsWithoutAWorker(t *testing.T) {
+	r := &GitTargetReconciler{}
+	transitioned := metav1.Now()
+	target := &configbutleraiv1alpha3.GitTarget{
+		Status: configbutleraiv1alpha3.GitTargetStatus{
+			WorkerState:         "Errored",
+			LastStateTransition: &transitioned,
+			StateHistory: []configbutleraiv1alpha3.StateTransition{
+				{From: "Pushing", To: "Errored", Reason: "PushFailed", Timestamp: transitioned},
+			},
+		},
+	}
+
+	r.projectWorkerState(target, "default")
+	assert.Empty(t, target.Status.WorkerState)
+	assert.Nil(t, target.Status.LastStateTransition)
+	assert.Nil(t, target.Status.StateHistory)
+}
+
+// A rejected push reports the remote's hook output on the Pushed condition; the next successful
+// push clears it.
+func TestSetPushedCondition_ReportsRejectionUntilNextPush(t *testing.T) {
+	r := &GitTargetReconciler{}
+	target := &configbutleraiv1alpha3.GitTarget{
+		Spec: configbutleraiv1alpha3.GitTargetSpec{Branch: "main"},
+	}
+
+	r.setPushedCondition(target, git.PushOutcome{
+		Reason:        git.ReasonPushRejected,
+		LastPushError: "remote rejected refs/heads/main: pre-receive hook declined: commits must be signed",
+	})
+	pushed := meta.FindStatusCondition(target.Status.Conditions, GitTargetConditionPushed)
+	require.NotNil(t, pushed)
+	assert.Equal(t, metav1.ConditionFalse, pushed.Status)
+	assert.Equal(t, git.ReasonPushRejected, pushed.Reason)
+	assert.Contains(t, pushed.Message, "commits must be signed")
+
+	r.setPushedCondition(target, git.PushOutcome{Reason: git.ReasonCommitPushed})
+	pushed = meta.FindStatusCondition(target.Status.Conditions, GitTargetConditionPushed)
+	require.NotNil(t, pushed)
+	assert.Equal(t, metav1.ConditionTrue, pushed.Status)
+	assert.Equal(t, git.ReasonCommitPushed, pushed.Reason)
+}
+
+// A push that missed a mirror reports it on MirrorsPushed; a GitProvider without mirrors drops the
+// condition.
+func TestSetMirrorsPushedCondition(t *testing.T) {
+	r := &GitTargetReconciler{}
+	target := &configbutleraiv1alpha3.GitTarget{
+		Spec: configbutleraiv1alpha3.GitTargetSpec{Branch: "main"},
+	}
+
+	r.setMirrorsPushedCondition(target, git.MirrorOutcome{Mirrors: 2, Failures: []string{"dr: non-fast-forward update"}})
+	mirrored := meta.FindStatusCondition(target.Status.Conditions, GitTargetConditionMirrorsPushed)
+	require.NotNil(t, mirrored)
+	assert.Equal(t, metav1.ConditionFalse, mirrored.Status)
+	assert.Equal(t, git.ReasonMirrorPushFailed, mirrored.Reason)
+	assert.Equal(t, "The latest push missed 1 of 2 mirrors: dr: non-fast-forward update", mirrored.Message)
+
+	r.setMirrorsPushedCondition(target, git.MirrorOutcome{Mirrors: 2})
+	mirrored = meta.FindStatusCondition(target.Status.Conditions, GitTargetConditionMirrorsPushed)
+	require.NotNil(t, mirrored)
+	assert.Equal(t, metav1.ConditionTrue, mirrored.Status)
+
+	r.setMirrorsPushedCondition(target, git.MirrorOutcome{})
+	assert.Nil(t, meta.FindStatusCondition(target.Status.Conditions, GitTargetConditionMirrorsPushed))
+}
@@ -0,0 +1,26 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"time"
+
+	k8stypes "k8s.io/apimachinery/pkg/types"
+)
+
+// jitteredRequeue stretches a steady requeue interval to base * (1 + factor * j), where j in [0, 1)
+// is derived from the object's key. After a restart every object is reconciled at once; without
+// jitter they would all requeue, and hit the Git server, at once again on every cycle. The value
+// is fixed per object so its cadence stays stable across cycles instead of drifting randomly.
+func jitteredRequeue(base time.Duration, factor float64, key k8stypes.NamespacedName) time.Duration {
+	if base <= 0 || factor <= 0 {
+		return base
+	}
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key.String()))
+	//nolint:gosec // Spreads requeues over time; not a security decision.
+	j := rand.New(rand.NewSource(int64(h.Sum64()))).Float64()
+	return base + time.Duration(float64(base)*factor*j)
+}
@@ -0,0 +1,26 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+)
+
+func TestJitteredRequeue_StableAndBoundedPerObject(t *testing.T) {
+	base := 5 * time.Minute
+	a := k8stypes.NamespacedName{Namespace: "team-a", Name: "provider"}
+	b := k8stypes.NamespacedName{Namespace: "team-b", Name: "provider"}
+
+	first := jitteredRequeue(base, 0.5, a)
+	assert.Equal(t, first, jitteredRequeue(base, 0.5, a), "the same object keeps its cadence")
+	assert.GreaterOrEqual(t, first, base)
+	assert.Less(t, first, base+base/2)
+	assert.NotEqual(t, first, jitteredRequeue(base, 0.5, b), "different objects are spread apart")
+
+	assert.Equal(t, base, jitteredRequeue(base, 0, a), "a zero factor disables jitter")
+	assert.Equal(t, time.Duration(0), jitteredRequeue(0, 0.5, a), "no requeue stays no requeue")
+}
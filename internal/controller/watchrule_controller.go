@@ -36,6 +36,8 @@ const (
 	WatchRuleReasonReady                 = "Ready"
 	WatchRuleReasonResourcesResolved     = "Resolved"
 	WatchRuleReasonUnresolvedResources   = "UnresolvedResources"
+	WatchRuleReasonInvalidObjectSelector = "InvalidObjectSelector"
+	WatchRuleReasonInvalidNameFilter     = "InvalidNameFilter"
 )
 
 // WatchRuleReconciler reconciles a WatchRule object.
@@ -4,6 +4,7 @@ package controller
 
 import (
 	"context"
+	"errors"
 
 	"github.com/go-logr/logr"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -64,6 +65,16 @@ func (r *WatchRuleReconciler) gateSourceNamespace(
 ) (bool, ctrl.Result, error) {
 	resolved, err := watch.CompileWatchRule(
 		ctx, r.Client, r.RuleStore, r.sourceScope(), *watchRule, target, provider)
+	if errors.Is(err, watch.ErrInvalidObjectSelector) {
+		result, refuseErr := r.refuseInvalidObjectFilter(
+			ctx, watchRule, WatchRuleReasonInvalidObjectSelector, "objectSelector", err, log)
+		return true, result, refuseErr
+	}
+	if errors.Is(err, watch.ErrInvalidNameFilter) {
+		result, refuseErr := r.refuseInvalidObjectFilter(
+			ctx, watchRule, WatchRuleReasonInvalidNameFilter, "name glob", err, log)
+		return true, result, refuseErr
+	}
 	if err != nil {
 		// A transient apiserver failure must NOT tear down a running stream: CompileWatchRule left
 		// the compiled rule in place, so requeue with the error and re-run the gate on real data.
@@ -150,6 +161,41 @@ func (r *WatchRuleReconciler) refuseSourceNamespace(
 	return r.updateStatusAndRequeue(ctx, watchRule)
 }
 
+// refuseInvalidObjectFilter refuses a rule with an item whose objectSelector or name globs (named
+// by field) do not compile. CompileWatchRule has already removed the compiled rule; this replans
+// the watch manager and then publishes the terminal status, in the same order as
+// refuseSourceNamespace. Only an edit to the rule can change the verdict, so it is Stalled rather
+// than retried.
+func (r *WatchRuleReconciler) refuseInvalidObjectFilter(
+	ctx context.Context,
+	watchRule *configbutleraiv1alpha3.WatchRule,
+	reason string,
+	field string,
+	compileErr error,
+	log logr.Logger,
+) (ctrl.Result, error) {
+	log.Info("Refusing WatchRule: "+field+" is invalid",
+		"name", watchRule.Name, "namespace", watchRule.Namespace, "error", compileErr.Error())
+
+	if r.WatchManager != nil {
+		if err := r.WatchManager.ReconcileForRuleChange(ctx); err != nil {
+			log.Error(err, "Failed to reconcile watch manager after refusing WatchRule",
+				"name", watchRule.Name, "namespace", watchRule.Namespace)
+		}
+	}
+
+	r.setTypedCondition(
+		watchRule,
+		ConditionTypeStreamsRunning,
+		metav1.ConditionFalse,
+		reason,
+		"No streams: the rule's "+field+" is invalid",
+	)
+	r.setRuleStalled(watchRule, reason, compileErr.Error())
+
+	return r.updateStatusAndRequeue(ctx, watchRule)
+}
+
 // holdSourceNamespaceUnknown publishes the "cannot say yet" state: SourceNamespaceAuthorized is
 // Unknown and the rule is Reconciling, never Stalled.
 //
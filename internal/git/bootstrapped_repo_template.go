@@ -28,7 +28,18 @@ const (
 var bootstrapTemplateFS embed.FS
 
 type bootstrapTemplateData struct {
-	AgeRecipients []string
+	AgeRecipients    []string
+	AWSKMSKeyARNs    []string
+	VaultTransitURIs []string
+}
+
+// newBootstrapTemplateData carries every resolved SOPS key into the .sops.yaml template.
+func newBootstrapTemplateData(cfg *ResolvedEncryptionConfig) bootstrapTemplateData {
+	return bootstrapTemplateData{
+		AgeRecipients:    cfg.AgeRecipients,
+		AWSKMSKeyARNs:    cfg.AWSKMSKeyARNs,
+		VaultTransitURIs: cfg.VaultTransitURIs,
+	}
 }
 
 type pathBootstrapOptions struct {
@@ -173,12 +184,16 @@ func stageBootstrapFile(worktree *gogit.Worktree, targetPath, entryName string)
 }
 
 func renderSOPSBootstrapTemplate(raw []byte, data bootstrapTemplateData) ([]byte, error) {
-	if len(data.AgeRecipients) == 0 {
-		return nil, fmt.Errorf("failed to render bootstrap file %s: missing age recipients", sopsConfigFileName)
-	}
-	for _, recipient := range data.AgeRecipients {
-		if strings.TrimSpace(recipient) == "" {
-			return nil, fmt.Errorf("failed to render bootstrap file %s: empty age recipient", sopsConfigFileName)
+	if len(data.AgeRecipients) == 0 && len(data.AWSKMSKeyARNs) == 0 && len(data.VaultTransitURIs) == 0 {
+		return nil, fmt.Errorf(
+			"failed to render bootstrap file %s: missing age recipients, AWS KMS keys and Vault transit keys",
+			sopsConfigFileName)
+	}
+	for _, keys := range [][]string{data.AgeRecipients, data.AWSKMSKeyARNs, data.VaultTransitURIs} {
+		for _, key := range keys {
+			if strings.TrimSpace(key) == "" {
+				return nil, fmt.Errorf("failed to render bootstrap file %s: empty encryption key", sopsConfigFileName)
+			}
 		}
 	}
 
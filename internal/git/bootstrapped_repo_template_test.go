@@ -8,6 +8,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
 )
 
 func TestRenderSOPSBootstrapTemplate_MultipleRecipients(t *testing.T) {
@@ -33,3 +34,48 @@ func TestRenderSOPSBootstrapTemplate_MissingRecipients(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "missing age recipients")
 }
+
+func TestRenderSOPSBootstrapTemplate_KeyBackends(t *testing.T) {
+	raw, err := bootstrapTemplateFS.ReadFile(path.Join(bootstrapTemplateDir, sopsConfigFileName))
+	require.NoError(t, err)
+	const (
+		recipient = "age1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq7k8m6"
+		keyARN    = "arn:aws:kms:eu-west-1:111122223333:key/1234abcd"
+		transit   = "https://vault.example.com:8200/v1/sops/keys/gitops"
+	)
+	type keyGroup struct {
+		Age   []string            `json:"age"`
+		KMS   []map[string]string `json:"kms"`
+		Vault []string            `json:"hc_vault"`
+	}
+	render := func(data bootstrapTemplateData) []keyGroup {
+		t.Helper()
+		rendered, err := renderSOPSBootstrapTemplate(raw, data)
+		require.NoError(t, err)
+		var cfg struct {
+			CreationRules []struct {
+				KeyGroups []keyGroup `json:"key_groups"`
+			} `json:"creation_rules"`
+		}
+		require.NoError(t, yaml.Unmarshal(rendered, &cfg), string(rendered))
+		require.Len(t, cfg.CreationRules, 1)
+		return cfg.CreationRules[0].KeyGroups
+	}
+
+	groups := render(bootstrapTemplateData{
+		AgeRecipients: []string{recipient}, AWSKMSKeyARNs: []string{keyARN}, VaultTransitURIs: []string{transit},
+	})
+	require.Len(t, groups, 1, "every backend shares one key group, so any one key decrypts")
+	assert.Equal(t, []string{recipient}, groups[0].Age)
+	assert.Equal(t, []map[string]string{{"arn": keyARN}}, groups[0].KMS)
+	assert.Equal(t, []string{transit}, groups[0].Vault)
+
+	groups = render(bootstrapTemplateData{AWSKMSKeyARNs: []string{keyARN}})
+	require.Len(t, groups, 1)
+	assert.Empty(t, groups[0].Age)
+	assert.Equal(t, []map[string]string{{"arn": keyARN}}, groups[0].KMS)
+
+	groups = render(bootstrapTemplateData{VaultTransitURIs: []string{transit}})
+	require.Len(t, groups, 1)
+	assert.Equal(t, []string{transit}, groups[0].Vault)
+}
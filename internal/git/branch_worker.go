@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -20,11 +21,16 @@ import (
 	"github.com/go-logr/logr"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/events"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 
 	configv1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
+	"github.com/ConfigButler/gitops-reverser/internal/pullrequest"
 	"github.com/ConfigButler/gitops-reverser/internal/sanitize"
 	"github.com/ConfigButler/gitops-reverser/internal/telemetry"
 	itypes "github.com/ConfigButler/gitops-reverser/internal/types"
@@ -49,6 +55,20 @@ const (
 	// is intentionally fixed: commit cadence is a user concern (commitWindow on
 	// the CRD); push cadence is an implementation/politeness concern.
 	PushCooldown = 5 * time.Second
+
+	// pushRetryBaseDelay and pushRetryMaxDelay bound the backoff before a failed push is retried:
+	// the delay doubles per consecutive failure up to the max, plus up to pushRetryJitter of it.
+	pushRetryBaseDelay = 100 * time.Millisecond
+	pushRetryMaxDelay  = 10 * time.Second
+	pushRetryJitter    = 0.1
+
+	// pushParkedRecheck is how often a push parked on a permanent failure (pushFailurePermanent)
+	// checks whether the GitProvider or its credentials Secret changed. Past pushRetryMaxDelay its
+	// backoff keeps doubling up to pushParkedMaxDelay, so a fix made on the remote itself (a
+	// protection rule relaxed, a token granted write access), which the worker cannot see, is
+	// still picked up.
+	pushParkedRecheck  = 30 * time.Second
+	pushParkedMaxDelay = 30 * time.Minute
 )
 
 var (
@@ -104,6 +124,33 @@ type BranchWorker struct {
 	// Set by WorkerManager before Start, alongside pathRefusal.
 	renderFidelityGate *RenderFidelityGate
 
+	// commitAudit records every pushed commit. Set by the WorkerManager before Start; nil
+	// disables the commit audit log.
+	commitAudit CommitAuditLogger
+
+	// recorder records CommitPushed and CommitFailed Events on the GitTargets a push carries. Set
+	// by the WorkerManager before Start; nil records no Events.
+	recorder events.EventRecorder
+
+	// stateEvents is where the worker asks the GitTarget controller to re-project its state
+	// (notifyStateChange). Set by the WorkerManager before Start; nil sends nothing.
+	stateEvents chan<- event.GenericEvent
+
+	// drainTimeout bounds how long a stopping worker keeps handling queued work, committing, and
+	// pushing before it abandons what is left. Set by the WorkerManager before Start; zero
+	// abandons queued work and in-flight git operations at once.
+	drainTimeout time.Duration
+
+	// fetchDepth is how many commits of history each fetch keeps per branch; 0 keeps all of it.
+	// NewBranchWorker defaults it to DefaultFetchDepth and the WorkerManager sets it before Start.
+	fetchDepth int
+
+	// newPullRequestOpener builds the API client for GitTargets with spec.pullRequest.
+	newPullRequestOpener pullRequestOpenerFunc
+	// pullRequestQueue carries pushed GitTargets to the goroutine that keeps their pull requests
+	// open (queuePullRequests).
+	pullRequestQueue pullRequestQueue
+
 	// Event processing
 	eventQueue chan WorkItem
 	ctx        context.Context
@@ -112,11 +159,31 @@ type BranchWorker struct {
 	started    bool
 	mu         sync.Mutex
 
+	// stopCh is closed when the worker starts stopping: on Stop, or when the parent context
+	// ends. ctx outlives it by up to drainTimeout, so the drain's git operations can still run.
+	stopCh   chan struct{}
+	stopOnce sync.Once
+
 	// Branch metadata (protected by metaMu)
 	metaMu        sync.RWMutex
 	branchExists  bool
 	lastCommitSHA string
 	lastFetchTime time.Time
+	// pushedStats accumulates, per GitTarget, what successful pushes published since the
+	// GitTarget reconciler last took it (TakePushedStats).
+	pushedStats map[pendingTargetKey]PushedTargetStats
+	// pullRequests is the pull request the latest push found or opened, per GitTarget with
+	// spec.pullRequest (PullRequestFor).
+	pullRequests map[pendingTargetKey]pullrequest.PullRequest
+	// pushOutcomes is how the latest push carrying its writes ended, per GitTarget (LastPushFor).
+	pushOutcomes map[pendingTargetKey]PushOutcome
+	// mirrorOutcomes is how the latest successful push carrying its writes reached the mirrors,
+	// per GitTarget (LastMirrorPushFor).
+	mirrorOutcomes map[pendingTargetKey]MirrorOutcome
+	// stateHistory is the worker's latest state transitions, newest first (StateReport).
+	stateHistory []WorkerStateTransition
+	// authorPatterns is each GitTarget's compiled spec.userMapping.patterns. It has its own lock.
+	authorPatterns authorPatternCache
 
 	// repoMu serializes repository/worktree operations within this worker.
 	repoMu sync.Mutex
@@ -164,6 +231,16 @@ type BranchWorker struct {
 	// handled and nothing is retained.
 	inflightItems atomic.Int64
 
+	// queued records when each item still on eventQueue was accepted, for the oldest-pending
+	// gauge. Every send to eventQueue goes through it.
+	queued queueArrivals
+
+	// state is what the worker is doing now, published by setState; pushFailing records that the
+	// last push failed, so the worker settles as errored rather than idle until a push succeeds.
+	// Both are owned by the event loop goroutine, so they carry no lock.
+	state       workerState
+	pushFailing bool
+
 	// crOutcomes holds resolved CommitRequest outcomes for the controller to poll
 	// via LookupCommitRequestOutcome. The event loop is the only writer (on its
 	// goroutine), the controller the only reader (on a reconcile goroutine), so the
@@ -233,6 +310,8 @@ func NewBranchWorker(
 		contentWriter:        writer,
 		eventQueue:           make(chan WorkItem, branchWorkerQueueSize),
 		branchBufferMaxBytes: branchBufferMaxBytes,
+		fetchDepth:           DefaultFetchDepth,
+		newPullRequestOpener: pullrequest.New,
 	}
 }
 
@@ -263,22 +342,33 @@ func (w *BranchWorker) Start(parentCtx context.Context) error {
 		w.mu.Unlock()
 		return errors.New("worker already started")
 	}
-	w.ctx, w.cancelFunc = context.WithCancel(parentCtx)
+	// The worker's own context is detached from the parent's cancellation: when the parent
+	// ends, the event loop drains for up to drainTimeout and cancels it itself. It carries the
+	// worker's named logger, so the package's log.FromContext call sites log as
+	// worker-manager.branch-worker and --log-level-override reaches them.
+	w.ctx, w.cancelFunc = context.WithCancel(logr.NewContext(context.WithoutCancel(parentCtx), w.Log))
+	w.stopCh = make(chan struct{})
 	w.started = true
 	w.mu.Unlock()
 
 	w.Log.Info("Starting branch worker")
+	w.setState(workerStateIdle)
 
+	stopOnParentDone := context.AfterFunc(parentCtx, w.beginStop)
 	w.wg.Add(1)
 	go func() {
 		defer w.wg.Done()
+		defer w.cancelFunc()
+		defer stopOnParentDone()
+		defer forgetUnpushedCommits(w)
 		w.processEvents()
 	}()
 
 	return nil
 }
 
-// Stop gracefully shuts down the worker.
+// Stop gracefully shuts down the worker: it refuses new work, lets the event loop drain what is
+// queued and retained for up to drainTimeout, and returns once the loop has exited.
 func (w *BranchWorker) Stop() {
 	w.mu.Lock()
 	if !w.started {
@@ -287,12 +377,22 @@ func (w *BranchWorker) Stop() {
 	}
 	w.mu.Unlock()
 
-	w.Log.Info("Stopping branch worker")
-	w.cancelFunc()
+	w.Log.Info("Stopping branch worker", "drainTimeout", w.drainTimeout.String())
+	w.beginStop()
 	w.wg.Wait()
+	w.cancelFunc()
+	w.recordState("")
 	w.Log.Info("Branch worker stopped")
 }
 
+// beginStop refuses new work and signals the event loop to drain. Safe to call more than once.
+func (w *BranchWorker) beginStop() {
+	w.stopOnce.Do(func() {
+		w.queued.stop()
+		close(w.stopCh)
+	})
+}
+
 // Enqueue adds a single live event to this worker's queue. It reports whether the
 // event entered the FIFO; a false return means the queue was full and the event was
 // dropped, so a caller advancing a durable watch cursor past this event must not treat
@@ -320,23 +420,26 @@ func (w *BranchWorker) EnqueueAttach(req *AttachCommitRequest) {
 		return
 	}
 	// Increment before the send so inflightItems can never lag the loop's
-	// receive; roll back if the queue is full and the item is dropped.
+	// receive; roll back if the item is refused or dropped.
 	w.inflightItems.Add(1)
-	select {
-	case w.eventQueue <- WorkItem{Attach: req}:
-		w.Log.Info("CommitRequest attach enqueued",
-			"request", req.Namespace+"/"+req.Name,
-			"author", req.Author,
-			"target", req.GitTargetNamespace+"/"+req.GitTargetName,
-			"closeDelaySeconds", req.CloseDelaySeconds,
-			"messageOverride", req.Message != "")
-		// Depth is published only from the loop goroutine (syncQueueDepthMetric);
-		// the loop republishes on every received item, so the gauge converges
-		// without an enqueue-side write that could latch a stale value.
-	default:
+	if err := w.queued.send(w.eventQueue, WorkItem{Attach: req}); err != nil {
 		w.inflightItems.Add(-1)
+		if errors.Is(err, ErrWorkerStopping) {
+			w.Log.V(1).Info("Worker is stopping, CommitRequest attach refused (controller will re-send)")
+			return
+		}
 		w.Log.Error(nil, "Event queue full, CommitRequest attach dropped (controller will re-send)")
+		return
 	}
+	w.Log.Info("CommitRequest attach enqueued",
+		"request", req.Namespace+"/"+req.Name,
+		"author", req.Author,
+		"target", req.GitTargetNamespace+"/"+req.GitTargetName,
+		"closeDelaySeconds", req.CloseDelaySeconds,
+		"messageOverride", req.Message != "")
+	// Depth is published only from the loop goroutine (syncQueueDepthMetric);
+	// the loop republishes on every received item, so the gauge converges
+	// without an enqueue-side write that could latch a stale value.
 }
 
 // EnqueueResync adds a resync request to this worker's queue. Like a finalize
@@ -354,19 +457,22 @@ func (w *BranchWorker) EnqueueResync(request *ResyncRequest) bool {
 		return false
 	}
 	w.inflightItems.Add(1)
-	select {
-	case w.eventQueue <- WorkItem{Resync: request}:
-		w.Log.V(1).Info("Resync request enqueued",
-			"resources", len(request.Desired),
-			"gitTarget", request.GitTargetNamespace+"/"+request.GitTargetName)
-		return true
-	default:
+	if err := w.queued.send(w.eventQueue, WorkItem{Resync: request}); err != nil {
 		w.inflightItems.Add(-1)
-		w.Log.Error(nil, "Event queue full, resync request dropped",
-			"gitTarget", request.GitTargetNamespace+"/"+request.GitTargetName)
-		request.reply(ResyncResult{Err: ErrFinalizeQueueFull})
+		if errors.Is(err, ErrWorkerStopping) {
+			w.Log.V(1).Info("Worker is stopping, resync request refused",
+				"gitTarget", request.GitTargetNamespace+"/"+request.GitTargetName)
+		} else {
+			w.Log.Error(nil, "Event queue full, resync request dropped",
+				"gitTarget", request.GitTargetNamespace+"/"+request.GitTargetName)
+		}
+		request.reply(ResyncResult{Err: err})
 		return false
 	}
+	w.Log.V(1).Info("Resync request enqueued",
+		"resources", len(request.Desired),
+		"gitTarget", request.GitTargetNamespace+"/"+request.GitTargetName)
+	return true
 }
 
 // enqueueRequest places a write request on the FIFO and reports whether it was
@@ -379,26 +485,30 @@ func (w *BranchWorker) enqueueRequest(request *WriteRequest) bool {
 	}
 	item := WorkItem{Request: request}
 	// Increment before the send so inflightItems can never lag the loop's
-	// receive; roll back if the queue is full and the item is dropped.
+	// receive; roll back if the item is refused or dropped.
 	w.inflightItems.Add(1)
-	select {
-	case w.eventQueue <- item:
-		w.Log.V(1).Info("Write request enqueued",
-			"events", len(request.Events),
-			"mode", request.CommitMode,
-			"gitTarget", request.GitTargetName)
-		// Depth is published only from the loop goroutine (syncQueueDepthMetric);
-		// the loop republishes on every received item, so the gauge converges
-		// without an enqueue-side write that could latch a stale value.
-		return true
-	default:
+	if err := w.queued.send(w.eventQueue, item); err != nil {
 		w.inflightItems.Add(-1)
+		if errors.Is(err, ErrWorkerStopping) {
+			w.Log.V(1).Info("Worker is stopping, request refused",
+				"events", len(request.Events),
+				"gitTarget", request.GitTargetName)
+			return false
+		}
 		w.Log.Error(nil, "Event queue full, request dropped",
 			"events", len(request.Events),
 			"mode", request.CommitMode,
 			"gitTarget", request.GitTargetName)
 		return false
 	}
+	w.Log.V(1).Info("Write request enqueued",
+		"events", len(request.Events),
+		"mode", request.CommitMode,
+		"gitTarget", request.GitTargetName)
+	// Depth is published only from the loop goroutine (syncQueueDepthMetric);
+	// the loop republishes on every received item, so the gauge converges
+	// without an enqueue-side write that could latch a stale value.
+	return true
 }
 
 // recordQueueDepth publishes the current pending-work depth for this worker:
@@ -455,10 +565,11 @@ func (w *BranchWorker) EnsurePathBootstrapped(path, targetName, targetNamespace
 		return err
 	}
 
-	repoPath, err := w.prepareBootstrapRepository(ctx)
+	repoPath, lock, err := w.prepareBootstrapRepository(ctx)
 	if err != nil {
 		return err
 	}
+	defer lock.Unlock()
 
 	if err := w.bootstrapPathIfNeeded(repoPath, normalizedPath, bootstrapOptions); err != nil {
 		return err
@@ -504,8 +615,8 @@ func (w *BranchWorker) resolveBootstrapOptions(
 		return pathBootstrapOptions{Enabled: true}, nil
 	}
 
-	if len(encryptionConfig.AgeRecipients) == 0 {
-		w.Log.Info("Skipping SOPS bootstrap due to missing resolved age recipients",
+	if !encryptionConfig.HasKeys() {
+		w.Log.Info("Skipping SOPS bootstrap due to missing resolved encryption keys",
 			"target", targetKey.String())
 		return pathBootstrapOptions{Enabled: true}, nil
 	}
@@ -513,33 +624,36 @@ func (w *BranchWorker) resolveBootstrapOptions(
 	return pathBootstrapOptions{
 		Enabled:           true,
 		IncludeSOPSConfig: true,
-		TemplateData: bootstrapTemplateData{
-			AgeRecipients: encryptionConfig.AgeRecipients,
-		},
+		TemplateData:      newBootstrapTemplateData(encryptionConfig),
 	}, nil
 }
 
+// prepareBootstrapRepository prepares the worker's clone and returns its path with the path's
+// lock held, so the bootstrap files are written before anything else touches the clone. The
+// caller releases the lock.
 func (w *BranchWorker) prepareBootstrapRepository(
 	ctx context.Context,
-) (string, error) {
+) (string, *repoPathLock, error) {
 	provider, err := w.getGitProvider(ctx)
 	if err != nil {
-		return "", fmt.Errorf("failed to get GitProvider: %w", err)
+		return "", nil, fmt.Errorf("failed to get GitProvider: %w", err)
 	}
 
 	auth, err := getAuthFromSecret(ctx, w.Client, provider, w.sshHostKeys)
 	if err != nil {
-		return "", fmt.Errorf("failed to get auth: %w", err)
+		return "", nil, fmt.Errorf("failed to get auth: %w", err)
 	}
 
 	repoPath := w.repoPathForRemote(provider.Spec.URL)
-	pullReport, err := PrepareBranch(ctx, provider.Spec.URL, repoPath, w.Branch, auth)
+	lock := lockRepoPath(repoPath)
+	pullReport, err := w.prepareBranch(ctx, provider, repoPath, auth)
 	if err != nil {
-		return "", fmt.Errorf("failed to prepare repository: %w", err)
+		lock.Unlock()
+		return "", nil, fmt.Errorf("failed to prepare repository: %w", err)
 	}
 	w.updateBranchMetadataFromPullReport(pullReport)
 
-	return repoPath, nil
+	return repoPath, lock, nil
 }
 
 func (w *BranchWorker) bootstrapPathIfNeeded(
@@ -604,6 +718,18 @@ type branchWorkerEventLoop struct {
 	lastPushAt  time.Time
 	commitTimer *time.Timer
 	pushTimer   *time.Timer
+	// pushFailures counts consecutive failed pushes. While it is non-zero, pushTimer is the
+	// backoff retry and a new commit waits for it instead of pushing straight away.
+	pushFailures int
+	// pushParked is set while the last push failed permanently. The retry then waits until
+	// pushParkedUntil, or until pushSpecFingerprint no longer matches pushParkedSpec, and pushTimer
+	// only fires to check for that.
+	pushParked      bool
+	pushParkedSpec  string
+	pushParkedUntil time.Time
+	// pushFailureRecorded is the Event reason the current run of failed pushes was recorded with;
+	// empty while pushes succeed. A retry that fails for the same reason records nothing new.
+	pushFailureRecorded string
 
 	// deferredHeals holds heal resyncs (periodic re-anchors, removed-type sweeps) parked while a
 	// commit window is open, so a heal never force-finalizes (steals) that window — including a
@@ -619,6 +745,10 @@ type branchWorkerEventLoop struct {
 	// attachTimer fires at the earliest pending finalize deadline, so an attached
 	// window is finalized at the end of its grace even with no further events.
 	attachTimer *time.Timer
+	// retainedSince is when the item that started the loop's current run of retained work (an
+	// open window or unpushed writes) arrived on the queue; zero while nothing is retained. It
+	// feeds the oldest-pending gauge. Loop-goroutine only.
+	retainedSince time.Time
 }
 
 func newBranchWorkerEventLoop(w *BranchWorker, commitWindow time.Duration) *branchWorkerEventLoop {
@@ -632,12 +762,16 @@ func (l *branchWorkerEventLoop) run() {
 	for {
 		commitC, pushC, attachC := l.timerChannels()
 		select {
-		case <-l.w.ctx.Done():
+		case <-l.w.stopCh:
 			l.handleShutdown()
 			l.syncQueueDepthMetric()
 			return
 		case item := <-l.w.eventQueue:
+			arrived := l.w.queued.received()
 			l.handleQueueItem(item)
+			if l.retainedSince.IsZero() && l.retainsWork() {
+				l.retainedSince = arrived
+			}
 			// Decrement only after the item is fully handled; the post-handling
 			// open-window/pending-writes state is captured by syncQueueDepthMetric
 			// below, so there is no window where depth drops to 0 prematurely.
@@ -648,7 +782,7 @@ func (l *branchWorkerEventLoop) run() {
 			l.maybeSchedulePush()
 		case <-pushC:
 			l.pushTimer = nil
-			l.pushPending()
+			l.retryPush()
 		case <-attachC:
 			l.attachTimer = nil
 			// The work (attach waiting requests, finalize due ones) is done by
@@ -671,8 +805,18 @@ func (l *branchWorkerEventLoop) run() {
 // gauge converges to 0 once every accepted item has been handled (inflightItems
 // == 0) and nothing is retained (no open window, no pending writes).
 func (l *branchWorkerEventLoop) syncQueueDepthMetric() {
-	l.w.hasUnpushedWork.Store(l.openWindow != nil || len(l.pendingWrites) > 0)
+	retains := l.retainsWork()
+	l.w.hasUnpushedWork.Store(retains)
 	l.w.recordQueueDepth()
+	if !retains {
+		l.retainedSince = time.Time{}
+	}
+	l.w.recordOldestPending(l.retainedSince)
+}
+
+// retainsWork reports whether the loop holds a live open window or committed-but-unpushed writes.
+func (l *branchWorkerEventLoop) retainsWork() bool {
+	return l.openWindow != nil || len(l.pendingWrites) > 0
 }
 
 func (l *branchWorkerEventLoop) timerChannels() (<-chan time.Time, <-chan time.Time, <-chan time.Time) {
@@ -804,7 +948,9 @@ func (l *branchWorkerEventLoop) handleAtomicRequest(request *WriteRequest) {
 		// dropped in both cases.
 		name, namespace := atomicRefusalTarget(request)
 		if !l.w.reportPathRefusal(err, name, namespace) {
-			l.w.Log.Error(err, "Atomic commit failed; dropping request", "events", len(request.Events))
+			l.w.Log.Error(err, "Atomic commit failed; dropping request",
+				append([]interface{}{"events", len(request.Events)}, failedEventLogFields(err)...)...)
+			l.w.recordApplyFailedEvent(err, pendingTargetKey{Name: name, Namespace: namespace}, len(request.Events))
 		}
 		return
 	}
@@ -814,20 +960,53 @@ func (l *branchWorkerEventLoop) handleAtomicRequest(request *WriteRequest) {
 	l.maybeSchedulePush()
 }
 
+// handleShutdown drains the worker within drainTimeout: it handles the items already queued,
+// finalizes the open window, and pushes every pending commit. When the deadline passes, cancelling
+// the worker context aborts the git operation in flight and whatever is left is dropped; the next
+// leader's startup resync writes it from live state instead.
 func (l *branchWorkerEventLoop) handleShutdown() {
-	l.w.Log.Info("Handling shutdown, finalizing open window and pushing pending commits")
+	l.w.Log.Info("Draining branch worker: handling queued work and pushing pending commits",
+		"queued", len(l.w.eventQueue), "drainTimeout", l.w.drainTimeout.String())
+	if l.w.drainTimeout > 0 {
+		deadline := time.AfterFunc(l.w.drainTimeout, l.w.cancelFunc)
+		defer deadline.Stop()
+	} else {
+		l.w.cancelFunc()
+	}
+
+	l.handleQueuedItems()
 	l.finalizeOpenWindowWithReason(windowFinalizeReasonShutdown)
 	// The window is closed: drain any parked heal so its drift commits (and pushes below) on a
 	// clean shutdown rather than leaking its caller's reply channel.
 	l.applyDeferredHeals()
-	if len(l.pendingWrites) > 0 {
+	if len(l.pendingWrites) > 0 && l.w.ctx.Err() == nil {
 		// Shutdown bypasses the cooldown — pending work needs to land before
 		// the worker exits, even if a push was just sent.
 		l.pushPending()
 	}
+	if l.w.ctx.Err() != nil {
+		l.w.Log.Info("Drain timeout reached; unpushed work is left to the next leader's resync",
+			"queued", len(l.w.eventQueue), "pendingWrites", len(l.pendingWrites))
+	}
 	l.drainUnhandledQueueItems()
 }
 
+// handleQueuedItems handles every item still buffered on eventQueue, in order, until the queue is
+// empty or the drain deadline has cancelled the worker context. New items cannot arrive: beginStop
+// stops queued before the loop drains, and a send checks that under the same lock it sends with.
+func (l *branchWorkerEventLoop) handleQueuedItems() {
+	for l.w.ctx.Err() == nil {
+		select {
+		case item := <-l.w.eventQueue:
+			l.w.queued.received()
+			l.handleQueueItem(item)
+			l.w.inflightItems.Add(-1)
+		default:
+			return
+		}
+	}
+}
+
 // drainUnhandledQueueItems clears items still buffered on eventQueue that the
 // exiting loop will never handle. Each was counted into inflightItems at enqueue
 // and is decremented only by the loop after handling, so without this drain the
@@ -841,6 +1020,7 @@ func (l *branchWorkerEventLoop) drainUnhandledQueueItems() {
 	for {
 		select {
 		case <-l.w.eventQueue:
+			l.w.queued.received()
 			l.w.inflightItems.Add(-1)
 		default:
 			return
@@ -944,11 +1124,14 @@ func (l *branchWorkerEventLoop) finalizeOpenWindowWithMessage(reason windowFinal
 		// window is dropped either way — the events are already lost to the failed flush,
 		// and the next resync re-derives them.
 		if !l.w.reportPathRefusal(err, targetName, targetNamespace) {
-			l.w.Log.Error(err, "Commit failed; dropping open window",
+			l.w.Log.Error(err, "Commit failed; dropping open window", append([]interface{}{
 				"reason", string(reason),
 				"windowAuthor", windowAuthor,
 				"windowTarget", windowTarget,
-				"events", len(events))
+				"events", len(events),
+			}, failedEventLogFields(err)...)...)
+			l.w.recordApplyFailedEvent(err,
+				pendingTargetKey{Name: targetName, Namespace: targetNamespace}, len(events))
 		}
 		l.dropOpenWindow(pendingCR, fmt.Errorf("commit failed: %w", err))
 		return false
@@ -1001,6 +1184,9 @@ func (l *branchWorkerEventLoop) maybeSchedulePush() {
 	if len(l.pendingWrites) == 0 {
 		return
 	}
+	if l.pushFailures > 0 && l.pushTimer != nil {
+		return
+	}
 	if l.lastPushAt.IsZero() {
 		l.pushPending()
 		return
@@ -1018,22 +1204,49 @@ func (l *branchWorkerEventLoop) maybeSchedulePush() {
 // pushPending publishes any retained pending writes that already exist as local
 // commits. On success, pendingWrites is cleared and lastPushAt advances. On
 // failure (transient or after exhausting replay retries), pendingWrites stays
-// in place and a future commit/timer will retry.
+// in place and pushTimer is armed with a backoff, so the retry does not wait for
+// the next commit — on an idle cluster there may not be one.
 func (l *branchWorkerEventLoop) pushPending() {
 	if len(l.pendingWrites) == 0 {
 		l.stopPushTimer()
 		return
 	}
 
-	if err := l.w.pushPendingCommits(l.pendingWrites); err != nil {
+	err := l.w.pushPendingCommits(l.pendingWrites)
+	l.w.pushFailing = err != nil
+	l.w.rememberPushOutcome(l.pendingWrites, err)
+	l.w.settle()
+	if err != nil {
+		l.pushFailures++
+		retryIn := pushRetryDelay(l.pushFailures)
+		l.pushParked = pushFailurePermanent(err)
+		if l.pushParked {
+			retryIn = parkedPushRetryDelay(l.pushFailures)
+			l.pushParkedSpec = l.w.pushSpecFingerprint()
+			l.pushParkedUntil = time.Now().Add(retryIn)
+		}
 		l.w.Log.Error(err, "Push failed; pending writes retained for retry",
-			"pendingWrites", len(l.pendingWrites))
+			"pendingWrites", len(l.pendingWrites),
+			"consecutiveFailures", l.pushFailures,
+			"permanent", l.pushParked,
+			"retryIn", retryIn.String())
+		if reason := pushFailureReason(err); reason != l.pushFailureRecorded {
+			l.w.recordPushFailedEvents(l.pendingWrites, err)
+			l.pushFailureRecorded = reason
+		}
 		// Leave pendingWrites in place; do NOT advance lastPushAt — the
 		// design specifies lastPushAt only advances on a successful push. A
 		// CommitRequest riding a retained write stays unresolved while we retry.
 		l.stopPushTimer()
+		if l.pushParked {
+			retryIn = min(retryIn, pushParkedRecheck)
+		}
+		l.pushTimer = time.NewTimer(retryIn)
 		return
 	}
+	l.pushFailures = 0
+	l.pushParked = false
+	l.pushFailureRecorded = ""
 
 	// The writes are now on the remote: resolve every CommitRequest riding one with
 	// the pushed commit's own SHA (§6.5) — "Committed" means "on the remote".
@@ -1045,6 +1258,17 @@ func (l *branchWorkerEventLoop) pushPending() {
 	l.stopPushTimer()
 }
 
+// retryPush is pushTimer firing: the cooldown or a transient backoff ran out, so the pending writes
+// are pushed. A push parked on a permanent failure is only retried once the GitProvider or its
+// credentials changed, or once its backoff ran out; until then the timer is re-armed to check again.
+func (l *branchWorkerEventLoop) retryPush() {
+	if l.pushParked && time.Now().Before(l.pushParkedUntil) && l.w.pushSpecFingerprint() == l.pushParkedSpec {
+		l.pushTimer = time.NewTimer(min(pushParkedRecheck, time.Until(l.pushParkedUntil)))
+		return
+	}
+	l.pushPending()
+}
+
 // resolvePushedCommitRequests resolves Committed every CommitRequest carried by a
 // just-pushed write, using that write's own commit SHA (per-write, not branch HEAD,
 // since a batched push may stack a later commit on top). A write with no commit (a
@@ -1063,6 +1287,37 @@ func (l *branchWorkerEventLoop) resolvePushedCommitRequests() {
 	}
 }
 
+// pushRetryDelay is the backoff before retrying after the given number of consecutive push
+// failures: pushRetryBaseDelay doubled per failure, capped at pushRetryMaxDelay, then stretched
+// by up to pushRetryJitter so workers that failed together do not retry together.
+func pushRetryDelay(failures int) time.Duration {
+	return backoffDelay(pushRetryBaseDelay, pushRetryMaxDelay, failures)
+}
+
+// parkedPushRetryDelay is pushRetryDelay for a push that failed permanently: it starts where the
+// transient backoff stops and doubles up to pushParkedMaxDelay.
+func parkedPushRetryDelay(failures int) time.Duration {
+	return backoffDelay(pushRetryMaxDelay, pushParkedMaxDelay, failures)
+}
+
+func backoffDelay(base, maxDelay time.Duration, failures int) time.Duration {
+	delay := base
+	for i := 1; i < failures && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	return wait.Jitter(min(delay, maxDelay), pushRetryJitter)
+}
+
+// pushFailurePermanent reports whether a failed push will fail the same way until someone changes
+// something: the remote refused the branch update, or it refused the credentials. Retrying those
+// on the transient backoff only repeats the refusal every few seconds.
+func pushFailurePermanent(err error) bool {
+	var rejected *PushRejectedError
+	return errors.As(err, &rejected) ||
+		errors.Is(err, transport.ErrAuthenticationRequired) ||
+		errors.Is(err, transport.ErrAuthorizationFailed)
+}
+
 func (l *branchWorkerEventLoop) stopPushTimer() {
 	if l.pushTimer == nil {
 		return
@@ -1113,7 +1368,19 @@ func (w *BranchWorker) commitPendingWrites(pendingWrites []PendingWrite, hasPend
 	}
 
 	repoPath := w.repoPathForRemote(provider.Spec.URL)
+	lock := lockRepoPath(repoPath)
+	defer lock.Unlock()
+	defer w.settle()
+	// Another worker's unpushed commits in this clone are built on, not reset away: see
+	// repo_lock.go.
+	sharedRootBranch, sharedRootHash, shared := lock.sharedPushCycle(w)
+	if !hasPendingCommits && shared {
+		w.pushCycleRootBranch = sharedRootBranch
+		w.pushCycleRootHash = sharedRootHash
+		hasPendingCommits = true
+	}
 	if !hasPendingCommits {
+		w.setState(workerStateFetching)
 		// Resolve credentials only on the first commit of a push cycle — the one branch
 		// that touches the remote (PrepareBranch fetches the tip). Later commits in the
 		// same cycle build on the local repo and never use auth, so re-reading the
@@ -1123,13 +1390,14 @@ func (w *BranchWorker) commitPendingWrites(pendingWrites []PendingWrite, hasPend
 		if err != nil {
 			return fmt.Errorf("resolve auth: %w", err)
 		}
-		pullReport, err := PrepareBranch(w.ctx, provider.Spec.URL, repoPath, w.Branch, auth)
+		pullReport, err := w.prepareBranch(w.ctx, provider, repoPath, auth)
 		if err != nil {
 			return fmt.Errorf("prepare repository: %w", err)
 		}
 		w.updateBranchMetadataFromPullReport(pullReport)
 	}
 
+	w.setState(workerStateCommitting)
 	repo, err := gogit.PlainOpen(repoPath)
 	if err != nil {
 		return fmt.Errorf("open repository: %w", err)
@@ -1152,6 +1420,7 @@ func (w *BranchWorker) commitPendingWrites(pendingWrites []PendingWrite, hasPend
 	if commitsCreated == 0 {
 		return nil
 	}
+	lock.markUnpushed(w, w.pushCycleRootBranch, w.pushCycleRootHash)
 
 	w.recordPendingWritesMetrics(pendingWrites, commitsCreated)
 	w.firsts.commit.Do(func() {
@@ -1185,6 +1454,16 @@ func (w *BranchWorker) pushPendingCommits(pendingWrites []PendingWrite) error {
 	}
 
 	repoPath := w.repoPathForRemote(provider.Spec.URL)
+	// The lock covers the primary push and its replays, which move the clone, and the mirror
+	// pushes, which read its objects: a re-clone must not remove them mid-push. It is released
+	// before the pull requests, which only talk to the provider's API.
+	lock := lockRepoPath(repoPath)
+	locked := true
+	defer func() {
+		if locked {
+			lock.Unlock()
+		}
+	}()
 	repo, err := gogit.PlainOpen(repoPath)
 	if err != nil {
 		return fmt.Errorf("open repository: %w", err)
@@ -1195,15 +1474,33 @@ func (w *BranchWorker) pushPendingCommits(pendingWrites []PendingWrite) error {
 	var lastErr error
 
 	for range maxRetries {
+		w.setState(workerStatePushing)
 		rootBranch := w.pushCycleRootBranch
 		if rootBranch == "" {
 			rootBranch = plumbing.NewBranchReferenceName(w.Branch)
 		}
 
-		err := pushAtomicFn(w.ctx, repo, rootHash, rootBranch, auth)
+		err := withGitTimeout(w.ctx, provider.Spec.EffectivePushTimeout(), gitOperationPush,
+			func(ctx context.Context) error {
+				return pushAtomicFn(ctx, repo, rootHash, rootBranch, auth)
+			})
 		if err == nil {
 			w.pushCycleRootBranch = ""
 			w.pushCycleRootHash = plumbing.ZeroHash
+			lock.markPushed()
+			w.recordPushedStats(pendingWrites)
+			w.recordEventToCommitLatency(pendingWrites)
+			w.logPushedCommits(provider.Spec.URL, pendingWrites)
+			w.recordPushedEvents(pendingWrites)
+			pushed, tipErr := repo.Reference(plumbing.NewBranchReferenceName(w.Branch), true)
+			if tipErr != nil {
+				w.Log.Error(tipErr, "Cannot resolve the pushed branch tip; skipping mirror pushes")
+			} else {
+				w.pushMirrors(provider, repo, pushed.Hash(), pendingWrites)
+			}
+			lock.Unlock()
+			locked = false
+			w.queuePullRequests(provider, pendingWrites)
 			w.firsts.push.Do(func() {
 				w.Log.Info("First push to remote completed",
 					"branch", w.Branch,
@@ -1214,7 +1511,12 @@ func (w *BranchWorker) pushPendingCommits(pendingWrites []PendingWrite) error {
 		}
 		lastErr = err
 
-		remoteHash, fetchErr := fetchRemoteBranchHashFn(w.ctx, repo, rootBranch, auth)
+		var remoteHash plumbing.Hash
+		fetchErr := withGitTimeout(w.ctx, provider.Spec.EffectiveConnectionTimeout(), gitOperationFetch,
+			func(ctx context.Context) (fetchErr error) {
+				remoteHash, fetchErr = fetchRemoteBranchHashFn(ctx, repo, rootBranch, auth, w.fetchDepth)
+				return fetchErr
+			})
 		if fetchErr != nil {
 			return err
 		}
@@ -1222,7 +1524,14 @@ func (w *BranchWorker) pushPendingCommits(pendingWrites []PendingWrite) error {
 			return err
 		}
 
-		pullReport, syncErr := syncToRemoteFn(w.ctx, repo, plumbing.NewBranchReferenceName(w.Branch), auth)
+		w.setState(workerStateConflicting)
+		var pullReport *PullReport
+		syncErr := withGitTimeout(w.ctx, provider.Spec.EffectiveConnectionTimeout(), gitOperationFetch,
+			func(ctx context.Context) (syncErr error) {
+				pullReport, syncErr = syncToRemoteFn(ctx, repo, plumbing.NewBranchReferenceName(w.Branch), auth,
+					w.fetchDepth)
+				return syncErr
+			})
 		if syncErr != nil {
 			return fmt.Errorf("sync remote during replay: %w", syncErr)
 		}
@@ -1348,8 +1657,9 @@ func fetchRemoteBranchHash(
 	repo *gogit.Repository,
 	branch plumbing.ReferenceName,
 	auth transport.AuthMethod,
+	fetchDepth int,
 ) (plumbing.Hash, error) {
-	if _, err := SmartFetch(ctx, repo, branch, auth); err != nil {
+	if _, err := SmartFetch(ctx, repo, branch, auth, fetchDepth); err != nil {
 		return plumbing.ZeroHash, err
 	}
 
@@ -1436,6 +1746,94 @@ func (w *BranchWorker) getGitProvider(ctx context.Context) (*configv1alpha3.GitP
 	return &provider, nil
 }
 
+// pushSpecFingerprint identifies the GitProvider spec and every credential a push runs with (the
+// secretRef and clientCertSecretRef Secrets, and the spec.oidc token), so a push parked on a
+// permanent failure can tell when an operator changed or rotated any of them. It is empty when
+// the GitProvider cannot be read.
+func (w *BranchWorker) pushSpecFingerprint() string {
+	provider, err := w.getGitProvider(w.ctx)
+	if err != nil {
+		return ""
+	}
+	fingerprint := fmt.Sprintf("%s/%d", provider.UID, provider.Generation)
+	for _, ref := range []*configv1alpha3.LocalSecretReference{
+		provider.Spec.SecretRef, provider.Spec.ClientCertSecretRef,
+	} {
+		fingerprint += "/" + w.secretResourceVersion(provider.Namespace, ref)
+	}
+	if provider.Spec.OIDC != nil {
+		if issuedAt, ok := oidcTokens.issuedAt(provider); ok {
+			fingerprint += "/" + strconv.FormatInt(issuedAt.UnixNano(), 10)
+		}
+	}
+	return fingerprint
+}
+
+// secretResourceVersion is the resourceVersion of the Secret ref names in namespace, or empty when
+// ref is unset or the Secret cannot be read.
+func (w *BranchWorker) secretResourceVersion(namespace string, ref *configv1alpha3.LocalSecretReference) string {
+	if ref == nil || ref.Name == "" {
+		return ""
+	}
+	var secret corev1.Secret
+	if err := w.Client.Get(w.ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, &secret); err != nil {
+		return ""
+	}
+	return secret.ResourceVersion
+}
+
+// prepareBranch runs PrepareBranch bounded by the GitProvider's spec.connectionTimeout, so an
+// unreachable remote fails this attempt instead of stalling the worker. The first fetch into an
+// empty local clone downloads the whole branch and is bounded by spec.cloneTimeout instead. The
+// caller holds the lock of repoPath.
+func (w *BranchWorker) prepareBranch(
+	ctx context.Context,
+	provider *configv1alpha3.GitProvider,
+	repoPath string,
+	auth transport.AuthMethod,
+) (*PullReport, error) {
+	var report *PullReport
+	timeout := provider.Spec.EffectiveConnectionTimeout()
+	if !hasLocalClone(repoPath) {
+		timeout = provider.Spec.EffectiveCloneTimeout()
+	}
+	err := withGitTimeout(ctx, timeout, gitOperationFetch,
+		func(ctx context.Context) (err error) {
+			report, err = prepareBranchLocked(ctx, provider.Spec.URL, repoPath, w.Branch, auth, w.fetchDepth)
+			return err
+		})
+	return report, err
+}
+
+// Operation labels of gitopsreverser_git_timeout_total.
+const (
+	gitOperationPush  = "push"
+	gitOperationFetch = "fetch"
+)
+
+// withGitTimeout runs a remote git operation bounded by timeout. When the deadline cuts it short
+// the error says so, whatever go-git surfaced from the aborted transfer, and the timeout is
+// counted in gitopsreverser_git_timeout_total.
+func withGitTimeout(
+	ctx context.Context,
+	timeout time.Duration,
+	operation string,
+	run func(context.Context) error,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	err := run(ctx)
+	if err == nil || !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return err
+	}
+	if telemetry.GitTimeoutsTotal != nil {
+		telemetry.GitTimeoutsTotal.Add(context.WithoutCancel(ctx), 1, metric.WithAttributes(
+			attribute.String("operation", operation),
+		))
+	}
+	return fmt.Errorf("git %s timed out after %s: %w", operation, timeout, err)
+}
+
 // getCommitWindow returns the configured commit-window duration. The string is
 // parsed at runtime via time.ParseDuration; an unset value, a parse error, or
 // a negative duration falls back to a defensible default. Per design: parse
@@ -1465,6 +1863,120 @@ func (w *BranchWorker) GetBranchMetadata() (bool, string, time.Time) {
 	return w.branchExists, w.lastCommitSHA, w.lastFetchTime
 }
 
+// PushedTargetStats is what successful pushes published for one GitTarget.
+type PushedTargetStats struct {
+	// Events is the number of resource events pushed.
+	Events int
+	// CommitSHA is the most recent pushed commit that carried one of the target's writes.
+	CommitSHA string
+}
+
+// recordEventToCommitLatency observes one gitopsreverser_event_to_commit_seconds sample for a
+// successful push: the time since the oldest live event it carried was received. A push that
+// carried only snapshot or resync writes has no receive time and records nothing.
+func (w *BranchWorker) recordEventToCommitLatency(pendingWrites []PendingWrite) {
+	if telemetry.EventToCommitSeconds == nil {
+		return
+	}
+	var oldest time.Time
+	for _, write := range pendingWrites {
+		for _, event := range write.Events {
+			if !event.ReceivedAt.IsZero() && (oldest.IsZero() || event.ReceivedAt.Before(oldest)) {
+				oldest = event.ReceivedAt
+			}
+		}
+	}
+	if oldest.IsZero() {
+		return
+	}
+	telemetry.EventToCommitSeconds.Record(w.ctx, time.Since(oldest).Seconds(), metric.WithAttributes(
+		attribute.String("provider_namespace", w.GitProviderNamespace),
+		attribute.String("provider_name", w.GitProviderRef),
+		attribute.String("branch", w.Branch),
+	))
+}
+
+// recordPushedStats credits each just-pushed write's events and commit to its GitTarget.
+func (w *BranchWorker) recordPushedStats(pendingWrites []PendingWrite) {
+	w.metaMu.Lock()
+	defer w.metaMu.Unlock()
+	if w.pushedStats == nil {
+		w.pushedStats = make(map[pendingTargetKey]PushedTargetStats)
+	}
+	for _, write := range pendingWrites {
+		target := write.Target()
+		if target.Name == "" {
+			continue
+		}
+		key := pendingTargetKey{Name: target.Name, Namespace: target.Namespace}
+		stats := w.pushedStats[key]
+		stats.Events += len(write.Events)
+		if !write.CommitSHA.IsZero() {
+			stats.CommitSHA = write.CommitSHA.String()
+		}
+		w.pushedStats[key] = stats
+	}
+}
+
+// logPushedCommits writes the pushed writes to the commit audit log. It runs after the push, not
+// after each local commit: a push that loses a race rebuilds its commits on the new remote tip,
+// and only the SHAs that reached the remote are worth recording. A failed audit write is logged
+// and never fails the push that already happened.
+func (w *BranchWorker) logPushedCommits(repoURL string, pendingWrites []PendingWrite) {
+	if w.commitAudit == nil {
+		return
+	}
+	now := time.Now().UTC()
+	for _, write := range pendingWrites {
+		for _, entry := range commitAuditEntries(write, repoURL, w.Branch, now) {
+			if err := w.commitAudit.LogCommit(w.ctx, entry); err != nil {
+				w.Log.Error(err, "Failed to write commit audit entry", "commit", entry.CommitSHA)
+			}
+		}
+	}
+}
+
+// TakePushedStats returns what was pushed for one GitTarget since the previous call, and resets it.
+func (w *BranchWorker) TakePushedStats(name, namespace string) PushedTargetStats {
+	w.metaMu.Lock()
+	defer w.metaMu.Unlock()
+	key := pendingTargetKey{Name: name, Namespace: namespace}
+	stats := w.pushedStats[key]
+	delete(w.pushedStats, key)
+	return stats
+}
+
+// RestorePushedStats hands back stats a TakePushedStats caller could not record, merging them
+// under anything pushed since: the events add up, and a newer commit SHA wins.
+func (w *BranchWorker) RestorePushedStats(name, namespace string, stats PushedTargetStats) {
+	w.metaMu.Lock()
+	defer w.metaMu.Unlock()
+	if w.pushedStats == nil {
+		w.pushedStats = make(map[pendingTargetKey]PushedTargetStats)
+	}
+	key := pendingTargetKey{Name: name, Namespace: namespace}
+	current := w.pushedStats[key]
+	current.Events += stats.Events
+	if current.CommitSHA == "" {
+		current.CommitSHA = stats.CommitSHA
+	}
+	w.pushedStats[key] = current
+}
+
+// forgetTarget drops everything the worker remembers for one GitTarget: its pushed stats, push and
+// mirror outcomes, pull request, and compiled userMapping patterns. A GitTarget recreated with the
+// same name starts without them.
+func (w *BranchWorker) forgetTarget(name, namespace string) {
+	w.metaMu.Lock()
+	defer w.metaMu.Unlock()
+	key := pendingTargetKey{Name: name, Namespace: namespace}
+	delete(w.pushedStats, key)
+	delete(w.pushOutcomes, key)
+	delete(w.mirrorOutcomes, key)
+	delete(w.pullRequests, key)
+	w.authorPatterns.forget(key)
+}
+
 // SyncAndGetMetadata fetches latest metadata from remote Git repository.
 // Uses caching to avoid redundant fetches within 30 seconds (optimization for
 // multiple GitTargets sharing the same branch).
@@ -1517,9 +2029,11 @@ func (w *BranchWorker) syncWithRemote(ctx context.Context) (*PullReport, error)
 	}
 
 	repoPath := w.repoPathForRemote(provider.Spec.URL)
+	lock := lockRepoPath(repoPath)
+	defer lock.Unlock()
 
 	// PrepareBranch handles both initial and update cases
-	report, err := PrepareBranch(ctx, provider.Spec.URL, repoPath, w.Branch, auth)
+	report, err := w.prepareBranch(ctx, provider, repoPath, auth)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sync with remote: %w", err)
 	}
@@ -1553,8 +2067,11 @@ func (w *BranchWorker) ensureRepositoryInitialized(ctx context.Context) error {
 		return fmt.Errorf("failed to get auth: %w", err)
 	}
 
+	lock := lockRepoPath(repoPath)
+	defer lock.Unlock()
+
 	// Use new PrepareBranch abstraction
-	pullReport, err := PrepareBranch(ctx, provider.Spec.URL, repoPath, w.Branch, auth)
+	pullReport, err := w.prepareBranch(ctx, provider, repoPath, auth)
 	if err != nil {
 		return fmt.Errorf("failed to prepare repository: %w", err)
 	}
@@ -1593,16 +2110,14 @@ func (w *BranchWorker) updateBranchMetadataFromPullReport(report *PullReport) {
 }
 
 func buildBootstrapOptions(encryptionConfig *ResolvedEncryptionConfig) pathBootstrapOptions {
-	if encryptionConfig == nil || len(encryptionConfig.AgeRecipients) == 0 {
+	if !encryptionConfig.HasKeys() {
 		return pathBootstrapOptions{Enabled: true}
 	}
 
 	return pathBootstrapOptions{
 		Enabled:           true,
 		IncludeSOPSConfig: true,
-		TemplateData: bootstrapTemplateData{
-			AgeRecipients: encryptionConfig.AgeRecipients,
-		},
+		TemplateData:      newBootstrapTemplateData(encryptionConfig),
 	}
 }
 
@@ -3,15 +3,23 @@
 package git
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	configv1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
 )
@@ -152,3 +160,55 @@ func TestNewBranchWorker_DefaultsBufferCap(t *testing.T) {
 }
 
 func ptrString(s string) *string { return &s }
+
+func TestPushRetryDelay_DoublesUpToTheCap(t *testing.T) {
+	within := func(t *testing.T, got, base time.Duration) {
+		t.Helper()
+		assert.GreaterOrEqual(t, got, base)
+		assert.LessOrEqual(t, got, base+time.Duration(float64(base)*pushRetryJitter))
+	}
+	within(t, pushRetryDelay(1), 100*time.Millisecond)
+	within(t, pushRetryDelay(2), 200*time.Millisecond)
+	within(t, pushRetryDelay(4), 800*time.Millisecond)
+	within(t, pushRetryDelay(30), pushRetryMaxDelay)
+
+	within(t, parkedPushRetryDelay(1), pushRetryMaxDelay)
+	within(t, parkedPushRetryDelay(3), 4*pushRetryMaxDelay)
+	within(t, parkedPushRetryDelay(30), pushParkedMaxDelay)
+}
+
+func TestPushFailurePermanent(t *testing.T) {
+	assert.True(t, pushFailurePermanent(&PushRejectedError{Reason: "pre-receive hook declined"}))
+	assert.True(t, pushFailurePermanent(fmt.Errorf("push: %w: bad token", transport.ErrAuthenticationRequired)))
+	assert.True(t, pushFailurePermanent(fmt.Errorf("push: %w: read only", transport.ErrAuthorizationFailed)))
+	assert.False(t, pushFailurePermanent(errors.New("connection reset by peer")))
+	assert.False(t, pushFailurePermanent(transport.ErrRepositoryNotFound))
+}
+
+// TestStart_PutsTheNamedLoggerInTheWorkerContext covers the log.FromContext call sites in this
+// package: they must log under the worker's logger name, not the unnamed root logger, or
+// --log-level-override=worker-manager never reaches them.
+func TestStart_PutsTheNamedLoggerInTheWorkerContext(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, configv1alpha3.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	var mu sync.Mutex
+	var prefix string
+	named := funcr.New(func(p, args string) {
+		if strings.Contains(args, "from a package call site") {
+			mu.Lock()
+			prefix = p
+			mu.Unlock()
+		}
+	}, funcr.Options{}).WithName("worker-manager").WithName("branch-worker")
+	w := NewBranchWorker(c, named, "p", "ns", "main", nil, 0)
+
+	require.NoError(t, w.Start(context.Background()))
+	defer w.Stop()
+	log.FromContext(w.ctx).Info("from a package call site")
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, "worker-manager/branch-worker", prefix)
+}
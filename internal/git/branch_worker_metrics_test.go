@@ -4,6 +4,8 @@ package git
 
 import (
 	"context"
+	"errors"
+	"sync"
 	"testing"
 	"time"
 
@@ -11,6 +13,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 
 	"github.com/ConfigButler/gitops-reverser/internal/telemetry"
 	itypes "github.com/ConfigButler/gitops-reverser/internal/types"
@@ -18,14 +21,18 @@ import (
 
 const branchWorkerQueueDepthMetric = "gitopsreverser_branch_worker_queue_depth"
 const commitsTotalMetric = "gitopsreverser_commits_total"
+const gitTimeoutTotalMetric = "gitopsreverser_git_timeout_total"
+const eventToCommitSecondsMetric = "gitopsreverser_event_to_commit_seconds"
 
 func newMetricsTestWorker() *BranchWorker {
+	ctx, cancel := context.WithCancel(context.Background())
 	return &BranchWorker{
 		GitProviderRef:       "test-provider",
 		GitProviderNamespace: "test-ns",
 		Branch:               "main",
 		Log:                  logr.Discard(),
-		ctx:                  context.Background(),
+		ctx:                  ctx,
+		cancelFunc:           cancel,
 		contentWriter:        newContentWriter(itypes.SensitiveResourcePolicy{}),
 		eventQueue:           make(chan WorkItem, branchWorkerQueueSize),
 		branchBufferMaxBytes: DefaultBranchBufferMaxBytes,
@@ -242,3 +249,198 @@ func TestHandleShutdown_DrainsBufferedAttach(t *testing.T) {
 	assert.Equal(t, int64(0), w.inflightItems.Load(),
 		"a buffered attach must be drained from the inflight count on shutdown")
 }
+
+func TestWithGitTimeout_CountsOnlyDeadlineAbortedOperations(t *testing.T) {
+	reader, err := telemetry.InitTestExporter()
+	require.NoError(t, err)
+	ctx := context.Background()
+	hang := func(ctx context.Context) error {
+		<-ctx.Done()
+		return errors.New("read: use of closed network connection")
+	}
+
+	err = withGitTimeout(ctx, 10*time.Millisecond, gitOperationPush, hang)
+	require.ErrorContains(t, err, "git push timed out after 10ms")
+	require.ErrorContains(t, err, "use of closed network connection", "the transport error stays visible")
+
+	rejected := errors.New("remote rejected")
+	err = withGitTimeout(ctx, time.Minute, gitOperationPush, func(context.Context) error { return rejected })
+	assert.Equal(t, rejected, err, "failures within the deadline pass through unchanged")
+	require.NoError(t, withGitTimeout(ctx, time.Minute, gitOperationFetch, func(context.Context) error { return nil }))
+
+	pushes, ok := telemetry.CollectInt64Sum(reader, gitTimeoutTotalMetric, map[string]string{"operation": "push"})
+	require.True(t, ok)
+	assert.Equal(t, int64(1), pushes)
+	_, ok = telemetry.CollectInt64Sum(reader, gitTimeoutTotalMetric, map[string]string{"operation": "fetch"})
+	assert.False(t, ok, "a fetch that finished in time is not a timeout")
+}
+
+// The state gauge reads 1 for exactly the worker's current state: a transition zeroes the state it
+// left, a failed push settles as errored rather than idle, and a stopped worker zeroes every state.
+func TestBranchWorkerState_GaugeFollowsTransitions(t *testing.T) {
+	reader, err := telemetry.InitTestExporter()
+	require.NoError(t, err)
+
+	stateOf := func(state workerState) int64 {
+		labels := queueDepthLabels()
+		labels["state"] = string(state)
+		value, ok := telemetry.CollectInt64Sum(reader, "gitopsreverser_branch_worker_state", labels)
+		require.True(t, ok, "expected a branch_worker_state sample for %s", state)
+		return value
+	}
+
+	w := newMetricsTestWorker()
+	w.setState(workerStatePushing)
+	assert.Equal(t, int64(1), stateOf(workerStatePushing))
+	assert.Equal(t, int64(0), stateOf(workerStateIdle))
+
+	w.pushFailing = true
+	w.settle()
+	assert.Equal(t, int64(1), stateOf(workerStateErrored))
+	assert.Equal(t, int64(0), stateOf(workerStatePushing))
+
+	w.pushFailing = false
+	w.settle()
+	assert.Equal(t, int64(1), stateOf(workerStateIdle))
+	assert.Equal(t, int64(0), stateOf(workerStateErrored))
+
+	w.recordState("")
+	for _, state := range workerStates {
+		assert.Equal(t, int64(0), stateOf(state), "a stopped worker reports no state")
+	}
+}
+
+// StateReport keeps the latest transitions newest first, bounded at maxStateHistory, and only
+// entering or leaving errored asks the GitTarget controller to re-project them.
+func TestBranchWorkerState_ReportsTransitionsAndNotifiesOnErrored(t *testing.T) {
+	w := newMetricsTestWorker()
+	stateEvents := make(chan event.GenericEvent, 4)
+	w.stateEvents = stateEvents
+
+	state, history := w.StateReport()
+	assert.Empty(t, state)
+	assert.Empty(t, history)
+
+	w.setState(workerStateIdle)
+	w.setState(workerStateCommitting)
+	w.setState(workerStatePushing)
+	assert.Empty(t, stateEvents, "busy transitions wait for the next reconcile")
+
+	w.pushFailing = true
+	w.settle()
+	require.Len(t, stateEvents, 1, "entering errored notifies the controller")
+	assert.Equal(t, "test-provider", (<-stateEvents).Object.GetName())
+
+	w.pushFailing = false
+	w.settle()
+	require.Len(t, stateEvents, 1, "leaving errored notifies the controller")
+	<-stateEvents
+
+	state, history = w.StateReport()
+	assert.Equal(t, "Idle", state)
+	require.Len(t, history, 5)
+	assert.Equal(t, WorkerStateTransition{From: "Errored", To: "Idle", Reason: "PushRecovered", At: history[0].At},
+		history[0])
+	assert.Equal(t, WorkerStateTransition{From: "Pushing", To: "Errored", Reason: "PushFailed", At: history[1].At},
+		history[1])
+	assert.Equal(t, WorkerStateTransition{To: "Idle", Reason: "WorkerStarted", At: history[4].At}, history[4])
+
+	for range maxStateHistory {
+		w.setState(workerStateCommitting)
+		w.setState(workerStateIdle)
+	}
+	_, history = w.StateReport()
+	assert.Len(t, history, maxStateHistory)
+}
+
+// A push records exactly one event_to_commit_seconds sample, measured from the oldest live event
+// it carried; writes without a receive time (snapshots, resyncs) are not measured.
+func TestRecordEventToCommitLatency_ObservesOncePerPush(t *testing.T) {
+	reader, err := telemetry.InitTestExporter()
+	require.NoError(t, err)
+
+	w := newMetricsTestWorker()
+	w.recordEventToCommitLatency([]PendingWrite{{Kind: PendingWriteResync, Events: []Event{{Operation: "CREATE"}}}})
+	_, ok := telemetry.CollectHistogramCount(reader, eventToCommitSecondsMetric, queueDepthLabels())
+	assert.False(t, ok, "a push without live events records no latency")
+
+	now := time.Now()
+	w.recordEventToCommitLatency([]PendingWrite{
+		{Kind: PendingWriteCommit, Events: []Event{{ReceivedAt: now.Add(-2 * time.Second)}, {}}},
+		{Kind: PendingWriteCommit, Events: []Event{{ReceivedAt: now.Add(-time.Second)}}},
+	})
+	count, ok := telemetry.CollectHistogramCount(reader, eventToCommitSecondsMetric, queueDepthLabels())
+	require.True(t, ok, "expected an event_to_commit_seconds sample labelled by the worker identity")
+	assert.Equal(t, uint64(1), count, "one sample per pushed batch, not one per event")
+	sum, ok := telemetry.CollectHistogramSum(reader, eventToCommitSecondsMetric, queueDepthLabels())
+	require.True(t, ok)
+	assert.GreaterOrEqual(t, sum, 2.0, "the batch is timed from its oldest event")
+}
+
+const branchWorkerOldestPendingMetric = "gitopsreverser_branch_worker_oldest_pending_seconds"
+
+// Enqueuing N events the loop has not drained reports depth N, and the oldest-pending gauge ages
+// from the first of them.
+func TestEnqueue_UndrainedEventsReportDepthAndOldestAge(t *testing.T) {
+	reader, err := telemetry.InitTestExporter()
+	require.NoError(t, err)
+
+	w := newMetricsTestWorker()
+	w.recordOldestPending(time.Time{})
+	age, ok := telemetry.CollectInt64Sum(reader, branchWorkerOldestPendingMetric, queueDepthLabels())
+	require.True(t, ok)
+	assert.Zero(t, age, "a drained worker has nothing pending")
+
+	const n = 3
+	for range n {
+		require.True(t, w.Enqueue(Event{Operation: "CREATE"}))
+	}
+	// Backdate the first arrival instead of sleeping.
+	w.queued.times[0] = time.Now().Add(-90 * time.Second)
+
+	w.recordQueueDepth()
+	w.recordOldestPending(time.Time{})
+
+	depth, ok := telemetry.CollectInt64Sum(reader, branchWorkerQueueDepthMetric, queueDepthLabels())
+	require.True(t, ok)
+	assert.Equal(t, int64(n), depth)
+	age, ok = telemetry.CollectInt64Sum(reader, branchWorkerOldestPendingMetric, queueDepthLabels())
+	require.True(t, ok)
+	assert.GreaterOrEqual(t, age, int64(90))
+
+	// Once the loop takes the old item, the next one sets the age.
+	assert.False(t, w.queued.received().IsZero())
+	w.recordOldestPending(time.Time{})
+	age, _ = telemetry.CollectInt64Sum(reader, branchWorkerOldestPendingMetric, queueDepthLabels())
+	assert.Less(t, age, int64(90))
+
+	// Retained unpushed work older than anything queued is what the gauge reports.
+	w.recordOldestPending(time.Now().Add(-5 * time.Minute))
+	age, _ = telemetry.CollectInt64Sum(reader, branchWorkerOldestPendingMetric, queueDepthLabels())
+	assert.GreaterOrEqual(t, age, int64(300))
+}
+
+// TestBeginStop_RacingEnqueuesNeverStrandAnItem races enqueuers against beginStop. Every item the
+// queue accepted must still be on it for the loop's final drain to count, and every item after
+// stop must be refused, so inflightItems always matches what the queue holds.
+func TestBeginStop_RacingEnqueuesNeverStrandAnItem(t *testing.T) {
+	w := newMetricsTestWorker()
+	w.stopCh = make(chan struct{})
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 64 {
+				w.Enqueue(Event{Operation: "CREATE"})
+			}
+		}()
+	}
+	w.beginStop()
+	wg.Wait()
+
+	assert.False(t, w.Enqueue(Event{Operation: "CREATE"}), "a stopping worker refuses new events")
+	assert.Equal(t, int64(len(w.eventQueue)), w.inflightItems.Load())
+	assert.Len(t, w.queued.times, len(w.eventQueue))
+}
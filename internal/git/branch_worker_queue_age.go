@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/ConfigButler/gitops-reverser/internal/telemetry"
+)
+
+// queueArrivals remembers when each item still on a worker's eventQueue was accepted, oldest
+// first. A channel cannot be peeked, so without it the age of the next item to be handled would be
+// unknowable until the loop received it. Sends are serialized under mu, which keeps the recorded
+// order identical to the channel's FIFO order.
+//
+// stopped is also guarded by mu, so a send either lands before stop returns or is refused. A
+// separate draining check ahead of the send would let an item slip onto the queue after the
+// exiting loop's final drain, where nothing ever handles it.
+type queueArrivals struct {
+	mu      sync.Mutex
+	times   []time.Time
+	stopped bool
+}
+
+// send offers item to queue without blocking and records its arrival when the queue accepts it.
+// It returns ErrWorkerStopping once stop has been called and ErrFinalizeQueueFull when the queue
+// has no room.
+func (q *queueArrivals) send(queue chan<- WorkItem, item WorkItem) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.stopped {
+		return ErrWorkerStopping
+	}
+	select {
+	case queue <- item:
+		q.times = append(q.times, time.Now())
+		return nil
+	default:
+		return ErrFinalizeQueueFull
+	}
+}
+
+// stop refuses every later send. Sends already accepted stay on the queue for the loop to drain.
+func (q *queueArrivals) stop() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.stopped = true
+}
+
+// received forgets the oldest arrival, now that the loop has taken its item, and returns it.
+func (q *queueArrivals) received() time.Time {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.times) == 0 {
+		return time.Time{}
+	}
+	oldest := q.times[0]
+	q.times = q.times[1:]
+	return oldest
+}
+
+// oldest is the arrival of the item the loop will handle next, or zero when the queue is empty.
+func (q *queueArrivals) oldest() time.Time {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.times) == 0 {
+		return time.Time{}
+	}
+	return q.times[0]
+}
+
+// recordOldestPending publishes how long the worker's oldest pending work has waited, in whole
+// seconds: the older of the next queued item's arrival and retainedSince, when the loop started
+// holding the unpushed work it still holds. It reads 0 once the worker has drained. Called only
+// from the loop goroutine, alongside recordQueueDepth.
+func (w *BranchWorker) recordOldestPending(retainedSince time.Time) {
+	if telemetry.BranchWorkerOldestPendingSeconds == nil {
+		return
+	}
+	oldest := w.queued.oldest()
+	if oldest.IsZero() || (!retainedSince.IsZero() && retainedSince.Before(oldest)) {
+		oldest = retainedSince
+	}
+	var age int64
+	if !oldest.IsZero() {
+		age = int64(time.Since(oldest) / time.Second)
+	}
+	ctx := w.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	telemetry.BranchWorkerOldestPendingSeconds.Record(ctx, age, metric.WithAttributes(
+		attribute.String("provider_namespace", w.GitProviderNamespace),
+		attribute.String("provider_name", w.GitProviderRef),
+		attribute.String("branch", w.Branch),
+	))
+}
@@ -23,7 +23,9 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	configv1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
@@ -520,13 +522,15 @@ func TestBranchWorker_TransientPushFailure_RetriesSameLocalCommits(t *testing.T)
 	})
 
 	syncCalled := false
+	var pushDeadline time.Time
 	pushAtomicFn = func(
-		_ context.Context,
+		ctx context.Context,
 		_ *git.Repository,
 		_ plumbing.Hash,
 		_ plumbing.ReferenceName,
 		_ transport.AuthMethod,
 	) error {
+		pushDeadline, _ = ctx.Deadline()
 		return pushErr
 	}
 	fetchRemoteBranchHashFn = func(
@@ -534,6 +538,7 @@ func TestBranchWorker_TransientPushFailure_RetriesSameLocalCommits(t *testing.T)
 		_ *git.Repository,
 		_ plumbing.ReferenceName,
 		_ transport.AuthMethod,
+		_ int,
 	) (plumbing.Hash, error) {
 		return rootHashBefore, nil
 	}
@@ -542,6 +547,7 @@ func TestBranchWorker_TransientPushFailure_RetriesSameLocalCommits(t *testing.T)
 		_ *git.Repository,
 		_ plumbing.ReferenceName,
 		_ transport.AuthMethod,
+		_ int,
 	) (*PullReport, error) {
 		syncCalled = true
 		return &PullReport{}, nil
@@ -551,6 +557,8 @@ func TestBranchWorker_TransientPushFailure_RetriesSameLocalCommits(t *testing.T)
 	require.ErrorIs(t, err, pushErr)
 	assert.Equal(t, pushErr, err, "transient failures should preserve the original push error for retry handling")
 	assert.False(t, syncCalled, "unchanged remote tip must not trigger replay work")
+	assert.WithinDuration(t, time.Now().Add(configv1alpha3.DefaultPushTimeout), pushDeadline, 5*time.Second,
+		"each push is bounded by the GitProvider's spec.pushTimeout")
 	assert.Equal(t, rootHashBefore, worker.pushCycleRootHash)
 	assert.Equal(t, rootBranchBefore, worker.pushCycleRootBranch)
 
@@ -613,6 +621,7 @@ func TestBranchWorker_PushFollowedByFetchFailure_TreatsAsTransient(t *testing.T)
 		_ *git.Repository,
 		_ plumbing.ReferenceName,
 		_ transport.AuthMethod,
+		_ int,
 	) (plumbing.Hash, error) {
 		return plumbing.ZeroHash, fetchErr
 	}
@@ -621,6 +630,7 @@ func TestBranchWorker_PushFollowedByFetchFailure_TreatsAsTransient(t *testing.T)
 		_ *git.Repository,
 		_ plumbing.ReferenceName,
 		_ transport.AuthMethod,
+		_ int,
 	) (*PullReport, error) {
 		syncCalled = true
 		return &PullReport{}, nil
@@ -1053,6 +1063,199 @@ func TestEventLoop_AtomicPushFailure_DoesNotAdvanceCooldownOrLosePendingWrite(t
 
 	assert.True(t, loop.lastPushAt.IsZero(), "failed atomic push must not advance cooldown state")
 	assert.Len(t, loop.pendingWrites, 1, "failed atomic push must retain pending work for retry")
+	assert.Equal(t, 1, loop.pushFailures)
+	require.NotNil(t, loop.pushTimer, "a failed push schedules its own retry rather than waiting for a commit")
+
+	retry := loop.pushTimer
+	loop.maybeSchedulePush()
+	assert.Same(t, retry, loop.pushTimer, "a commit during backoff waits for the retry instead of pushing")
+	loop.stopTimers()
+}
+
+// TestEventLoop_PushRecordsGitTargetEvents verifies a push records CommitPushed on the GitTarget
+// it carried, and a run of failed pushes records CommitFailed once.
+func TestEventLoop_PushRecordsGitTargetEvents(t *testing.T) {
+	worker, _, remoteURL := setupCommitPushSplitWorker(t)
+	target := &configv1alpha3.GitTarget{}
+	target.Name = "team-a"
+	target.Namespace = "default"
+	require.NoError(t, worker.Client.Create(worker.ctx, target))
+	recorder := events.NewFakeRecorder(4)
+	worker.recorder = recorder
+
+	newPending := func(name string) PendingWrite {
+		pendingWrite, err := worker.buildAtomicPendingWrite(worker.ctx, &WriteRequest{
+			Events:             []Event{configMapEvent(name, "reconciler", "team-a")},
+			CommitMode:         CommitModeAtomic,
+			GitTargetName:      "team-a",
+			GitTargetNamespace: "default",
+		})
+		require.NoError(t, err)
+		writes := []PendingWrite{*pendingWrite}
+		require.NoError(t, worker.commitPendingWrites(writes, false))
+		return writes[0]
+	}
+
+	loop := newBranchWorkerEventLoop(worker, time.Second)
+	pushed := newPending("pushed")
+	loop.pendingWrites = []PendingWrite{pushed}
+	loop.pushPending()
+	require.Empty(t, loop.pendingWrites)
+	require.Len(t, recorder.Events, 1)
+	assert.Equal(t,
+		"Normal CommitPushed Pushed commit "+pushed.CommitSHA.String()+" with 1 events to main",
+		<-recorder.Events)
+
+	loop.pendingWrites = []PendingWrite{newPending("failed")}
+	require.NoError(t, os.RemoveAll(strings.TrimPrefix(remoteURL, "file://")))
+	loop.pushPending()
+	require.Len(t, recorder.Events, 1)
+	assert.True(t, strings.HasPrefix(<-recorder.Events, "Warning CommitFailed Failed to push: "))
+
+	loop.stopPushTimer()
+	loop.pushPending()
+	assert.Equal(t, 2, loop.pushFailures)
+	assert.Empty(t, recorder.Events, "a retry that fails the same way records no second Event")
+	loop.stopTimers()
+}
+
+// TestEventLoop_RejectedPushRecordsPushRejected verifies a push the remote's pre-receive hook
+// declines is recorded as PushRejected rather than as a generic CommitFailed, and not as
+// BranchProtected: a declining hook alone does not say the branch is protected.
+func TestEventLoop_RejectedPushRecordsPushRejected(t *testing.T) {
+	worker, _, remoteURL := setupCommitPushSplitWorker(t)
+	target := &configv1alpha3.GitTarget{}
+	target.Name = "team-a"
+	target.Namespace = "default"
+	require.NoError(t, worker.Client.Create(worker.ctx, target))
+	recorder := events.NewFakeRecorder(4)
+	worker.recorder = recorder
+
+	hook := filepath.Join(strings.TrimPrefix(remoteURL, "file://"), "hooks", "pre-receive")
+	require.NoError(t, os.MkdirAll(filepath.Dir(hook), 0o750))
+	require.NoError(t, os.WriteFile(hook, []byte("#!/bin/sh\nexit 1\n"), 0o700))
+
+	pendingWrite, err := worker.buildAtomicPendingWrite(worker.ctx, &WriteRequest{
+		Events:             []Event{configMapEvent("rejected", "reconciler", "team-a")},
+		CommitMode:         CommitModeAtomic,
+		GitTargetName:      "team-a",
+		GitTargetNamespace: "default",
+	})
+	require.NoError(t, err)
+	writes := []PendingWrite{*pendingWrite}
+	require.NoError(t, worker.commitPendingWrites(writes, false))
+
+	loop := newBranchWorkerEventLoop(worker, time.Second)
+	loop.pendingWrites = writes
+	loop.pushPending()
+	assert.Len(t, loop.pendingWrites, 1, "a rejected push keeps its writes queued")
+	require.Len(t, recorder.Events, 1)
+	assert.True(t, strings.HasPrefix(<-recorder.Events,
+		"Warning PushRejected Remote rejected the push: "))
+	loop.stopTimers()
+}
+
+// TestEventLoop_PermanentPushFailureParksUntilTheCredentialsChange verifies a push the remote
+// refused is not retried on the transient backoff: the retry timer only checks the GitProvider
+// and its credentials Secret, and pushes again once the Secret changed.
+func TestEventLoop_PermanentPushFailureParksUntilTheCredentialsChange(t *testing.T) {
+	worker, _, _ := setupCommitPushSplitWorker(t)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "git-creds", Namespace: "default"},
+		Data:       map[string][]byte{"username": []byte("bot"), "password": []byte("old")},
+	}
+	require.NoError(t, worker.Client.Create(worker.ctx, secret))
+	provider := &configv1alpha3.GitProvider{}
+	require.NoError(t, worker.Client.Get(worker.ctx, k8stypes.NamespacedName{Name: "test-repo", Namespace: "default"},
+		provider))
+	provider.Spec.SecretRef = &configv1alpha3.LocalSecretReference{Name: "git-creds"}
+	require.NoError(t, worker.Client.Update(worker.ctx, provider))
+
+	pendingWrite, err := worker.buildGroupedPendingWrite(worker.ctx,
+		[]Event{configMapEvent("parked", "alice", "team-a")})
+	require.NoError(t, err)
+	require.NoError(t, worker.commitPendingWrites([]PendingWrite{*pendingWrite}, false))
+
+	originalPush := pushAtomicFn
+	originalFetch := fetchRemoteBranchHashFn
+	t.Cleanup(func() {
+		pushAtomicFn = originalPush
+		fetchRemoteBranchHashFn = originalFetch
+	})
+	pushes := 0
+	pushAtomicFn = func(
+		_ context.Context, _ *git.Repository, _ plumbing.Hash, _ plumbing.ReferenceName, _ transport.AuthMethod,
+	) error {
+		pushes++
+		return &PushRejectedError{Ref: plumbing.NewBranchReferenceName("main"), Reason: "pre-receive hook declined"}
+	}
+	fetchRemoteBranchHashFn = func(
+		_ context.Context, _ *git.Repository, _ plumbing.ReferenceName, _ transport.AuthMethod, _ int,
+	) (plumbing.Hash, error) {
+		return worker.pushCycleRootHash, nil
+	}
+
+	loop := newBranchWorkerEventLoop(worker, time.Second)
+	defer loop.stopTimers()
+	loop.pendingWrites = []PendingWrite{*pendingWrite}
+	loop.pushPending()
+	require.Equal(t, 1, pushes)
+	assert.True(t, loop.pushParked, "a rejected push is parked")
+	assert.WithinDuration(t, time.Now().Add(pushRetryMaxDelay), loop.pushParkedUntil, 2*time.Second,
+		"a parked push backs off from where the transient backoff stops")
+	require.NotNil(t, loop.pushTimer)
+
+	loop.stopPushTimer()
+	loop.retryPush()
+	assert.Equal(t, 1, pushes, "nothing changed, so the parked push is not retried")
+	require.NotNil(t, loop.pushTimer, "the timer is re-armed to check again")
+
+	secret.Data["password"] = []byte("rotated")
+	require.NoError(t, worker.Client.Update(worker.ctx, secret))
+	loop.stopPushTimer()
+	loop.retryPush()
+	assert.Equal(t, 2, pushes, "rotated credentials retry the parked push")
+}
+
+// Every credential a push runs with is part of the fingerprint, so rotating any of them retries a
+// parked push, not only a change to the secretRef Secret.
+func TestPushSpecFingerprint_CoversEveryCredential(t *testing.T) {
+	worker, _, _ := setupCommitPushSplitWorker(t)
+	cert := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "client-cert", Namespace: "default"},
+		Data:       map[string][]byte{"tls.crt": []byte("old"), "tls.key": []byte("old")},
+	}
+	require.NoError(t, worker.Client.Create(worker.ctx, cert))
+	provider := &configv1alpha3.GitProvider{}
+	require.NoError(t, worker.Client.Get(worker.ctx, k8stypes.NamespacedName{Name: "test-repo", Namespace: "default"},
+		provider))
+	provider.Spec.ClientCertSecretRef = &configv1alpha3.LocalSecretReference{Name: "client-cert"}
+	provider.Spec.OIDC = &configv1alpha3.OIDCAuthSpec{ServiceAccountName: "pusher", Audience: "git.example.com"}
+	require.NoError(t, worker.Client.Update(worker.ctx, provider))
+
+	before := worker.pushSpecFingerprint()
+	cert.Data["tls.crt"] = []byte("rotated")
+	require.NoError(t, worker.Client.Update(worker.ctx, cert))
+	afterCert := worker.pushSpecFingerprint()
+	assert.NotEqual(t, before, afterCert, "a rotated client certificate changes the fingerprint")
+
+	key := oidcTokenKeyFor(provider)
+	mintedAt := func(issuedAt time.Time) {
+		oidcTokens.mu.Lock()
+		defer oidcTokens.mu.Unlock()
+		oidcTokens.tokens[key] = oidcToken{token: "t", issuedAt: issuedAt, expiresAt: issuedAt.Add(time.Hour)}
+	}
+	t.Cleanup(func() {
+		oidcTokens.mu.Lock()
+		defer oidcTokens.mu.Unlock()
+		delete(oidcTokens.tokens, key)
+	})
+	mintedAt(time.Now())
+	minted := worker.pushSpecFingerprint()
+	assert.NotEqual(t, afterCert, minted)
+	assert.Equal(t, minted, worker.pushSpecFingerprint(), "an unchanged token keeps the fingerprint")
+	mintedAt(time.Now().Add(time.Minute))
+	assert.NotEqual(t, minted, worker.pushSpecFingerprint(), "a re-minted OIDC token changes the fingerprint")
 }
 
 // TestResync_WorkerAppliesMarkAndSweepAndCommits drives a resync through the worker
@@ -1164,3 +1367,43 @@ func TestResync_WorkerEmptyDesiredSweepsManagedResource(t *testing.T) {
 
 	loop.stopTimers()
 }
+
+// TestStop_DrainsQueuedEventsWithinDrainTimeout covers a leader rotation: events enqueued just
+// before the parent context ends are still committed and pushed within the drain timeout, and a
+// worker that has begun stopping refuses new events instead of silently losing them.
+func TestStop_DrainsQueuedEventsWithinDrainTimeout(t *testing.T) {
+	worker, serverRepo, _ := setupCommitPushSplitWorker(t)
+	worker.drainTimeout = 30 * time.Second
+
+	initialRef, err := serverRepo.Reference(plumbing.NewBranchReferenceName("main"), true)
+	require.NoError(t, err)
+
+	parentCtx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, worker.Start(parentCtx))
+	// The default commit window (5s) holds both events open well past the cancel below, so only
+	// the shutdown drain can land them.
+	require.True(t, worker.Enqueue(configMapEvent("first", "alice", "team-a")))
+	require.True(t, worker.Enqueue(configMapEvent("second", "alice", "team-a")))
+	cancel()
+	worker.Stop()
+
+	assert.False(t, worker.Enqueue(configMapEvent("third", "alice", "team-a")),
+		"a stopped worker must refuse new events so the caller keeps its watch cursor")
+
+	afterRef, err := serverRepo.Reference(plumbing.NewBranchReferenceName("main"), true)
+	require.NoError(t, err)
+	require.NotEqual(t, initialRef.Hash(), afterRef.Hash(), "the drain must push the queued events")
+
+	commit, err := serverRepo.CommitObject(afterRef.Hash())
+	require.NoError(t, err)
+	files, err := commit.Files()
+	require.NoError(t, err)
+	var names []string
+	require.NoError(t, files.ForEach(func(f *object.File) error {
+		names = append(names, f.Name)
+		return nil
+	}))
+	assert.Contains(t, strings.Join(names, "\n"), "first")
+	assert.Contains(t, strings.Join(names, "\n"), "second")
+	assert.Equal(t, int64(0), worker.inflightItems.Load())
+}
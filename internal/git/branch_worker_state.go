@@ -0,0 +1,176 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	configv1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
+	"github.com/ConfigButler/gitops-reverser/internal/telemetry"
+)
+
+// maxStateHistory is how many state transitions a worker keeps for GitTarget status.stateHistory.
+const maxStateHistory = 10
+
+// workerState is what a branch worker is doing right now, published as
+// gitopsreverser_branch_worker_state. It tells "stuck pushing to an unreachable remote" apart from
+// "committing over and over because events keep coming", which the queue depth alone cannot.
+type workerState string
+
+const (
+	// workerStateIdle is a worker waiting for work with nothing failing.
+	workerStateIdle workerState = "idle"
+	// workerStateFetching is a worker bringing its clone up to the remote tip before committing.
+	workerStateFetching workerState = "fetching"
+	// workerStateCommitting is a worker writing and committing pending writes locally.
+	workerStateCommitting workerState = "committing"
+	// workerStatePushing is a worker publishing local commits to the remote.
+	workerStatePushing workerState = "pushing"
+	// workerStateConflicting is a worker whose push lost a race, rebuilding its writes on the new tip.
+	workerStateConflicting workerState = "conflicting"
+	// workerStateErrored is an idle worker whose last push failed and is waiting to retry it.
+	workerStateErrored workerState = "errored"
+)
+
+// workerStates lists every state, so a transition can zero the series of the states it left.
+var workerStates = []workerState{
+	workerStateIdle,
+	workerStateFetching,
+	workerStateCommitting,
+	workerStatePushing,
+	workerStateConflicting,
+	workerStateErrored,
+}
+
+// StatusValue is the state as GitTarget status.workerState spells it: "Idle" for idle.
+func (s workerState) StatusValue() string {
+	if s == "" {
+		return ""
+	}
+	return strings.ToUpper(string(s[:1])) + string(s[1:])
+}
+
+// WorkerStateTransition is one branch worker state change, as GitTarget status reports it.
+type WorkerStateTransition struct {
+	From, To, Reason string
+	At               time.Time
+}
+
+// stateTransitionReason says why the worker moved from one state to another.
+func stateTransitionReason(from, to workerState) string {
+	switch to {
+	case workerStateFetching:
+		return "FetchingRemoteTip"
+	case workerStateCommitting:
+		return "CommittingWrites"
+	case workerStatePushing:
+		return "PushingCommits"
+	case workerStateConflicting:
+		return "PushLostRace"
+	case workerStateErrored:
+		return "PushFailed"
+	}
+	switch from {
+	case "":
+		return "WorkerStarted"
+	case workerStateErrored:
+		return "PushRecovered"
+	}
+	return "WorkDone"
+}
+
+// setState moves the worker to state, publishes it and records the transition for StateReport.
+// Only the event loop goroutine calls it. Entering or leaving errored also notifies the GitTarget
+// controller (stateEvents), so a failing push reaches status without waiting for the periodic
+// reconcile; the busier transitions between the other states are picked up by the next one.
+func (w *BranchWorker) setState(state workerState) {
+	if w.state == state {
+		return
+	}
+	from := w.state
+	w.state = state
+	w.recordState(state)
+
+	w.metaMu.Lock()
+	w.stateHistory = append([]WorkerStateTransition{{
+		From:   from.StatusValue(),
+		To:     state.StatusValue(),
+		Reason: stateTransitionReason(from, state),
+		At:     time.Now(),
+	}}, w.stateHistory...)
+	if len(w.stateHistory) > maxStateHistory {
+		w.stateHistory = w.stateHistory[:maxStateHistory]
+	}
+	w.metaMu.Unlock()
+
+	if from == workerStateErrored || state == workerStateErrored {
+		w.notifyStateChange()
+	}
+}
+
+// notifyStateChange asks the GitTarget controller to reconcile the targets of the worker's
+// GitProvider. The send never blocks: a full buffer means a reconcile is already pending.
+func (w *BranchWorker) notifyStateChange() {
+	if w.stateEvents == nil {
+		return
+	}
+	evt := event.GenericEvent{Object: &configv1alpha3.GitProvider{
+		ObjectMeta: metav1.ObjectMeta{Name: w.GitProviderRef, Namespace: w.GitProviderNamespace},
+	}}
+	select {
+	case w.stateEvents <- evt:
+	default:
+	}
+}
+
+// StateReport returns the worker's current state and its latest transitions, newest first, as
+// GitTarget status reports them. The state is empty until the worker has started.
+func (w *BranchWorker) StateReport() (string, []WorkerStateTransition) {
+	w.metaMu.RLock()
+	defer w.metaMu.RUnlock()
+	if len(w.stateHistory) == 0 {
+		return "", nil
+	}
+	return w.stateHistory[0].To, append([]WorkerStateTransition(nil), w.stateHistory...)
+}
+
+// settle returns the worker to its resting state once a commit or push is done: errored while a
+// failed push waits for its retry, idle otherwise.
+func (w *BranchWorker) settle() {
+	if w.pushFailing {
+		w.setState(workerStateErrored)
+		return
+	}
+	w.setState(workerStateIdle)
+}
+
+// recordState sets the gauge to 1 for current and 0 for every other state. An empty current
+// zeroes them all, which is how a stopped worker clears its series.
+func (w *BranchWorker) recordState(current workerState) {
+	if telemetry.BranchWorkerState == nil {
+		return
+	}
+	ctx := w.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	for _, state := range workerStates {
+		var value int64
+		if state == current {
+			value = 1
+		}
+		telemetry.BranchWorkerState.Record(ctx, value, metric.WithAttributes(
+			attribute.String("provider_namespace", w.GitProviderNamespace),
+			attribute.String("provider_name", w.GitProviderRef),
+			attribute.String("branch", w.Branch),
+			attribute.String("state", string(state)),
+		))
+	}
+}
@@ -609,7 +609,7 @@ func TestBranchWorker_CommitAndPushRequest_NewBranchStartsFromLatestMain(t *test
 
 	// Pre-create a stale local checkout while remote main is still at commit A.
 	staleRepoPath := worker.repoPathForRemote(remoteURL)
-	staleReport, err := PrepareBranch(ctx, remoteURL, staleRepoPath, worker.Branch, nil)
+	staleReport, err := PrepareBranch(ctx, remoteURL, staleRepoPath, worker.Branch, nil, DefaultFetchDepth)
 	require.NoError(t, err)
 	require.Equal(t, hashA.String(), staleReport.HEAD.Sha)
 
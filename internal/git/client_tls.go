@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	nethttp "net/http"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/ConfigButler/gitops-reverser/api/v1alpha3"
+)
+
+// ClientTLSAuth is an HTTPS auth method that also presents a client certificate (mutual TLS) and
+// optionally trusts an extra CA bundle.
+//
+// go-git takes TLS material per operation (ListOptions, FetchOptions, the push Endpoint) rather
+// than on the auth method, while this package threads a single transport.AuthMethod through every
+// remote operation. Carrying the material on the auth method keeps those signatures unchanged;
+// each operation copies it back out with clientTLS.
+type ClientTLSAuth struct {
+	// Auth is the HTTP auth sent with each request, or nil when the certificate is the only credential.
+	Auth http.AuthMethod
+	// ClientCert and ClientKey are the PEM-encoded client certificate and key.
+	ClientCert []byte
+	ClientKey  []byte
+	// CABundle is an optional PEM CA bundle trusted in addition to the system roots.
+	CABundle []byte
+}
+
+// Name implements transport.AuthMethod.
+func (a *ClientTLSAuth) Name() string {
+	return "http-client-tls"
+}
+
+// String implements transport.AuthMethod without exposing key material.
+func (a *ClientTLSAuth) String() string {
+	if a.Auth == nil {
+		return a.Name()
+	}
+	return fmt.Sprintf("%s+%s", a.Name(), a.Auth.String())
+}
+
+// SetAuth implements http.AuthMethod by delegating to the wrapped HTTP auth, if any.
+func (a *ClientTLSAuth) SetAuth(r *nethttp.Request) {
+	if a.Auth != nil {
+		a.Auth.SetAuth(r)
+	}
+}
+
+// clientTLS returns the TLS material an auth method carries; all nil for any other auth method.
+func clientTLS(auth transport.AuthMethod) (cert, key, caBundle []byte) {
+	tlsAuth, ok := auth.(*ClientTLSAuth)
+	if !ok {
+		return nil, nil, nil
+	}
+	return tlsAuth.ClientCert, tlsAuth.ClientKey, tlsAuth.CABundle
+}
+
+// WithClientCertificate reads the GitProvider's clientCertSecretRef and wraps auth so it presents
+// that certificate. A GitProvider without the ref returns auth unchanged. The key pair and CA
+// bundle are parsed here so a malformed Secret fails the credentials read, not a later handshake.
+func WithClientCertificate(
+	ctx context.Context,
+	k8sClient client.Client,
+	provider *v1alpha3.GitProvider,
+	auth transport.AuthMethod,
+) (transport.AuthMethod, error) {
+	ref := provider.Spec.ClientCertSecretRef
+	if ref == nil || ref.Name == "" {
+		return auth, nil
+	}
+
+	var httpAuth http.AuthMethod
+	if auth != nil {
+		var ok bool
+		if httpAuth, ok = auth.(http.AuthMethod); !ok {
+			return nil, fmt.Errorf("clientCertSecretRef requires HTTP credentials, got %s", auth.Name())
+		}
+	}
+
+	secretName := types.NamespacedName{Name: ref.Name, Namespace: provider.Namespace}
+	var secret corev1.Secret
+	if err := k8sClient.Get(ctx, secretName, &secret); err != nil {
+		return nil, fmt.Errorf("failed to get client certificate secret %s: %w", secretName, err)
+	}
+
+	certPEM, keyPEM := secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey]
+	if len(certPEM) == 0 || len(keyPEM) == 0 {
+		return nil, fmt.Errorf("client certificate secret %s must contain %s and %s",
+			secretName, corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+	}
+	if _, err := tls.X509KeyPair(certPEM, keyPEM); err != nil {
+		return nil, fmt.Errorf("client certificate secret %s holds an invalid key pair: %w", secretName, err)
+	}
+	caBundle := secret.Data[corev1.ServiceAccountRootCAKey]
+	if len(caBundle) > 0 && !x509.NewCertPool().AppendCertsFromPEM(caBundle) {
+		return nil, fmt.Errorf("client certificate secret %s: %s holds no PEM certificate",
+			secretName, corev1.ServiceAccountRootCAKey)
+	}
+
+	return &ClientTLSAuth{Auth: httpAuth, ClientCert: certPEM, ClientKey: keyPEM, CABundle: caBundle}, nil
+}
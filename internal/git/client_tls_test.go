@@ -0,0 +1,191 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	configv1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
+)
+
+// mustClientCertPEM generates a self-signed client certificate and returns it, its key, and the
+// parsed certificate (for the server's trust pool), the cert and key PEM-encoded.
+func mustClientCertPEM(t *testing.T) ([]byte, []byte, *x509.Certificate) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(3),
+		Subject:               pkix.Name{CommonName: "gitops-reverser-mtls-test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+	parsed, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}),
+		parsed
+}
+
+func clientCertProvider(url string) *configv1alpha3.GitProvider {
+	return &configv1alpha3.GitProvider{
+		ObjectMeta: metav1.ObjectMeta{Name: "provider", Namespace: "default"},
+		Spec: configv1alpha3.GitProviderSpec{
+			URL:                 url,
+			ClientCertSecretRef: &configv1alpha3.LocalSecretReference{Name: "client-cert"},
+		},
+	}
+}
+
+func clientCertSecret(data map[string][]byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "client-cert", Namespace: "default"},
+		Type:       corev1.SecretTypeTLS,
+		Data:       data,
+	}
+}
+
+func TestWithClientCertificate(t *testing.T) {
+	certPEM, keyPEM, _ := mustClientCertPEM(t)
+	basic := &githttp.BasicAuth{Username: "git", Password: "secret"}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+
+	t.Run("no ref leaves auth unchanged", func(t *testing.T) {
+		provider := clientCertProvider("https://git.example.com/repo.git")
+		provider.Spec.ClientCertSecretRef = nil
+		auth, err := WithClientCertificate(context.Background(), fake.NewClientBuilder().WithScheme(scheme).Build(),
+			provider, basic)
+		require.NoError(t, err)
+		assert.Same(t, basic, auth)
+	})
+
+	t.Run("wraps HTTP auth with the key pair and CA bundle", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(clientCertSecret(map[string][]byte{
+			corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM, corev1.ServiceAccountRootCAKey: certPEM,
+		})).Build()
+		auth, err := WithClientCertificate(context.Background(), c, clientCertProvider("https://x/r.git"), basic)
+		require.NoError(t, err)
+
+		tlsAuth, ok := auth.(*ClientTLSAuth)
+		require.True(t, ok)
+		assert.Same(t, basic, tlsAuth.Auth)
+		cert, key, ca := clientTLS(auth)
+		assert.Equal(t, certPEM, cert)
+		assert.Equal(t, keyPEM, key)
+		assert.Equal(t, certPEM, ca)
+		assert.NotContains(t, auth.String(), "secret", "String must not expose credentials")
+	})
+
+	t.Run("certificate alone is a credential", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(clientCertSecret(map[string][]byte{
+			corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM,
+		})).Build()
+		auth, err := WithClientCertificate(context.Background(), c, clientCertProvider("https://x/r.git"), nil)
+		require.NoError(t, err)
+		require.IsType(t, &ClientTLSAuth{}, auth)
+		assert.Nil(t, auth.(*ClientTLSAuth).Auth)
+	})
+
+	errorCases := map[string]struct {
+		data    map[string][]byte
+		auth    transport.AuthMethod
+		wantErr string
+	}{
+		"missing key": {
+			data:    map[string][]byte{corev1.TLSCertKey: certPEM},
+			wantErr: "must contain tls.crt and tls.key",
+		},
+		"mismatched pair": {
+			data:    map[string][]byte{corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: []byte("not a key")},
+			wantErr: "invalid key pair",
+		},
+		"unparseable CA bundle": {
+			data: map[string][]byte{
+				corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM, corev1.ServiceAccountRootCAKey: []byte("x"),
+			},
+			wantErr: "ca.crt holds no PEM certificate",
+		},
+		"non-HTTP auth": {
+			data:    map[string][]byte{corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM},
+			auth:    sshLikeAuth{},
+			wantErr: "requires HTTP credentials",
+		},
+	}
+	for name, tc := range errorCases {
+		t.Run(name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(clientCertSecret(tc.data)).Build()
+			_, err := WithClientCertificate(context.Background(), c, clientCertProvider("https://x/r.git"), tc.auth)
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
+
+// sshLikeAuth is a transport.AuthMethod that is not an HTTP auth method, standing in for SSH.
+type sshLikeAuth struct{}
+
+func (sshLikeAuth) Name() string   { return "ssh-public-keys" }
+func (sshLikeAuth) String() string { return "ssh-public-keys" }
+
+// The certificate must actually reach the TLS handshake: a server that requires a client
+// certificate rejects the connection without one and serves the request with one.
+func TestCheckRepo_PresentsClientCertificate(t *testing.T) {
+	certPEM, keyPEM, clientCert := mustClientCertPEM(t)
+
+	var sawClientCert atomic.Bool
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sawClientCert.Store(len(r.TLS.PeerCertificates) > 0)
+		http.NotFound(w, r)
+	}))
+	pool := x509.NewCertPool()
+	pool.AddCert(clientCert)
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: pool, MinVersion: tls.VersionTLS12}
+	server.StartTLS()
+	defer server.Close()
+	serverCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	ctx := context.Background()
+	withoutCert := &ClientTLSAuth{CABundle: serverCA}
+	_, err := CheckRepo(ctx, server.URL+"/repo.git", withoutCert)
+	require.Error(t, err)
+	assert.False(t, sawClientCert.Load(), "the handshake must fail before any request is served")
+
+	withCert := &ClientTLSAuth{ClientCert: certPEM, ClientKey: keyPEM, CABundle: serverCA}
+	_, err = CheckRepo(ctx, server.URL+"/repo.git", withCert)
+	require.ErrorIs(t, err, transport.ErrRepositoryNotFound)
+	assert.True(t, sawClientCert.Load())
+}
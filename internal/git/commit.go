@@ -31,6 +31,7 @@ func renderEventCommitMessage(event Event, config CommitConfig) (string, error)
 			Name:       event.Identifier.Name,
 			APIVersion: buildAPIVersion(event.Identifier.Group, event.Identifier.Version),
 			Username:   event.UserInfo.Username,
+			Groups:     event.UserInfo.Groups,
 			GitTarget:  event.GitTargetName,
 		},
 	)
@@ -129,7 +130,7 @@ func ValidateCommitConfig(config CommitConfig) error {
 			Namespace: "default",
 			Name:      "example",
 		},
-		UserInfo:      UserInfo{Username: "template-validator"},
+		UserInfo:      UserInfo{Username: "template-validator", Groups: []string{"system:authenticated"}},
 		GitTargetName: "example-target",
 	}
 
@@ -203,10 +204,15 @@ func commitOptionsFor(
 		}
 	}
 
+	name, email := authorName(author), authorEmail(author)
+	mapped, ok := pendingWrite.Target().UserMapping.authorFor(author.Username)
+	if ok && pendingWrite.AttributionOutcome() != AttributionUnresolved {
+		name, email = mapped.Name, mapped.Email
+	}
 	return &git.CommitOptions{
 		Author: &object.Signature{
-			Name:  authorName(author),
-			Email: authorEmail(author),
+			Name:  name,
+			Email: email,
 			When:  when,
 		},
 		Committer: committer,
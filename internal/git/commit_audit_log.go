@@ -0,0 +1,191 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// CommitAuditEntry is one line of the commit audit log: one resource change that reached the
+// remote, or one resync commit, which carries no single resource.
+type CommitAuditEntry struct {
+	Timestamp          time.Time `json:"timestamp"`
+	RepoURL            string    `json:"repoURL"`
+	Branch             string    `json:"branch"`
+	CommitSHA          string    `json:"commitSHA"`
+	Operation          string    `json:"operation"`
+	ResourceIdentifier string    `json:"resourceIdentifier,omitempty"`
+	Username           string    `json:"username,omitempty"`
+	BaseFolder         string    `json:"baseFolder"`
+}
+
+// CommitAuditLogger records commits once they are pushed. It is called from the branch worker's
+// goroutine, so an implementation must be safe for concurrent use across workers.
+type CommitAuditLogger interface {
+	LogCommit(ctx context.Context, entry CommitAuditEntry) error
+}
+
+// jsonLinesCommitAuditLogger writes each entry as one JSON line.
+type jsonLinesCommitAuditLogger struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+// NewJSONLinesCommitAuditLogger returns a CommitAuditLogger that writes each entry as one JSON
+// line to out: os.Stdout for a log collector, or a RotatingFile.
+func NewJSONLinesCommitAuditLogger(out io.Writer) CommitAuditLogger {
+	return &jsonLinesCommitAuditLogger{out: out}
+}
+
+func (l *jsonLinesCommitAuditLogger) LogCommit(_ context.Context, entry CommitAuditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encode commit audit entry: %w", err)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, err = l.out.Write(append(line, '\n'))
+	return err
+}
+
+// commitAuditEntries expands a pushed write into its audit entries: one per event, or a single
+// entry for a resync, whose desired snapshot is not a list of changes.
+func commitAuditEntries(write PendingWrite, repoURL, branch string, when time.Time) []CommitAuditEntry {
+	if write.CommitSHA.IsZero() {
+		return nil
+	}
+	base := CommitAuditEntry{
+		Timestamp:  when,
+		RepoURL:    repoURL,
+		Branch:     branch,
+		CommitSHA:  write.CommitSHA.String(),
+		BaseFolder: write.Target().Path,
+	}
+	if write.Kind == PendingWriteResync {
+		base.Operation = "RESYNC"
+		return []CommitAuditEntry{base}
+	}
+
+	entries := make([]CommitAuditEntry, 0, len(write.Events))
+	for _, event := range write.Events {
+		entry := base
+		entry.Operation = event.Operation
+		entry.ResourceIdentifier = event.Identifier.Key()
+		entry.Username = event.UserInfo.Username
+		if event.Path != "" {
+			entry.BaseFolder = event.Path
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// RotatingFile is an append-only file that is rotated once a write would take it past maxBytes:
+// path becomes path.1, path.1 becomes path.2, and so on, keeping at most maxBackups old files.
+type RotatingFile struct {
+	mu         sync.Mutex
+	path       string
+	maxBytes   int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+// NewRotatingFile opens (or creates) path for appending, creating its directory if needed.
+func NewRotatingFile(path string, maxBytes int64, maxBackups int) (*RotatingFile, error) {
+	if maxBytes <= 0 {
+		return nil, errors.New("rotating file max size must be > 0")
+	}
+	if maxBackups < 0 {
+		return nil, errors.New("rotating file backup count must be >= 0")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, fmt.Errorf("create directory for %s: %w", path, err)
+	}
+	f := &RotatingFile{path: path, maxBytes: maxBytes, maxBackups: maxBackups}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *RotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", f.path, err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("stat %s: %w", f.path, err)
+	}
+	f.file, f.size = file, info.Size()
+	return nil
+}
+
+// Write appends p, rotating first when p would take a non-empty file past maxBytes. A single
+// write larger than maxBytes still lands whole, in a file of its own.
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.size > 0 && f.size+int64(len(p)) > f.maxBytes {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// rotate closes the current file, shifts it and its backups along, and opens a fresh one. The file
+// is reopened even when the shift fails, so later writes keep landing (in the unrotated file) and
+// retry the rotation, rather than going to a closed file for the rest of the process.
+func (f *RotatingFile) rotate() error {
+	err := f.file.Close()
+	if err != nil {
+		err = fmt.Errorf("close %s: %w", f.path, err)
+	} else {
+		err = f.shiftBackups()
+	}
+	if openErr := f.open(); openErr != nil {
+		return errors.Join(err, openErr)
+	}
+	return err
+}
+
+// shiftBackups moves the closed file out of the way: path becomes path.1 and every backup moves
+// up one, dropping the oldest. With no backups the file is removed.
+func (f *RotatingFile) shiftBackups() error {
+	if f.maxBackups == 0 {
+		if err := os.Remove(f.path); err != nil {
+			return fmt.Errorf("remove %s: %w", f.path, err)
+		}
+		return nil
+	}
+	for i := f.maxBackups - 1; i >= 1; i-- {
+		older := fmt.Sprintf("%s.%d", f.path, i)
+		if err := os.Rename(older, fmt.Sprintf("%s.%d", f.path, i+1)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("rotate %s: %w", older, err)
+		}
+	}
+	if err := os.Rename(f.path, f.path+".1"); err != nil {
+		return fmt.Errorf("rotate %s: %w", f.path, err)
+	}
+	return nil
+}
+
+// Close closes the current file.
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Close()
+}
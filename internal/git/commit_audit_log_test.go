@@ -0,0 +1,145 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ConfigButler/gitops-reverser/internal/types"
+)
+
+func TestCommitAuditEntries_OnePerEventAndOnePerResync(t *testing.T) {
+	when := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	sha := plumbing.NewHash("4f1c5a0e6d7b8c9d0e1f2a3b4c5d6e7f8a9b0c1d")
+	event := Event{
+		Identifier: types.NewResourceIdentifier("apps", "v1", "deployments", "team-a", "web"),
+		Operation:  "UPDATE",
+		UserInfo:   UserInfo{Username: "alice"},
+		Path:       "clusters/prod",
+	}
+
+	entries := commitAuditEntries(
+		PendingWrite{Kind: PendingWriteCommit, Events: []Event{event, event}, CommitSHA: sha},
+		"https://git.example.com/org/repo.git", "main", when)
+	require.Len(t, entries, 2)
+	assert.Equal(t, CommitAuditEntry{
+		Timestamp:          when,
+		RepoURL:            "https://git.example.com/org/repo.git",
+		Branch:             "main",
+		CommitSHA:          sha.String(),
+		Operation:          "UPDATE",
+		ResourceIdentifier: "apps/v1/deployments/team-a/web",
+		Username:           "alice",
+		BaseFolder:         "clusters/prod",
+	}, entries[0])
+
+	resync := PendingWrite{
+		Kind:               PendingWriteResync,
+		CommitSHA:          sha,
+		GitTargetName:      "target",
+		GitTargetNamespace: "default",
+		Targets: map[pendingTargetKey]ResolvedTargetMetadata{
+			{Name: "target", Namespace: "default"}: {Name: "target", Namespace: "default", Path: "clusters/prod"},
+		},
+	}
+	entries = commitAuditEntries(resync, "repo", "main", when)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "RESYNC", entries[0].Operation)
+	assert.Equal(t, "clusters/prod", entries[0].BaseFolder)
+	assert.Empty(t, entries[0].ResourceIdentifier)
+
+	resync.CommitSHA = plumbing.ZeroHash
+	assert.Empty(t, commitAuditEntries(resync, "repo", "main", when), "a write that made no commit is not logged")
+}
+
+func TestJSONLinesCommitAuditLogger_WritesOneLinePerEntry(t *testing.T) {
+	var out bytes.Buffer
+	logger := NewJSONLinesCommitAuditLogger(&out)
+
+	require.NoError(t, logger.LogCommit(context.Background(), CommitAuditEntry{CommitSHA: "a", Operation: "CREATE"}))
+	require.NoError(t, logger.LogCommit(context.Background(), CommitAuditEntry{CommitSHA: "b", Operation: "DELETE"}))
+
+	lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(lines[1], &entry))
+	assert.Equal(t, "b", entry["commitSHA"])
+	assert.Equal(t, "DELETE", entry["operation"])
+	assert.NotContains(t, entry, "username", "empty optional fields are omitted")
+}
+
+func TestRotatingFile_RotatesAndKeepsMaxBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit", "commits.jsonl")
+	file, err := NewRotatingFile(path, 10, 2)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = file.Close() })
+
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		_, err := file.Write([]byte(line))
+		require.NoError(t, err)
+	}
+
+	read := func(name string) string {
+		data, err := os.ReadFile(name)
+		require.NoError(t, err)
+		return string(data)
+	}
+	assert.Equal(t, "fourth\n", read(path))
+	assert.Equal(t, "third\n", read(path+".1"))
+	assert.Equal(t, "second\n", read(path+".2"))
+	assert.NoFileExists(t, path+".3", "only maxBackups rotated files are kept")
+}
+
+// A rotation that cannot move the file aside fails that write, but leaves the file open: the next
+// write retries the rotation instead of failing on a closed file.
+func TestRotatingFile_FailedRotationKeepsTheFileOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "commits.jsonl")
+	file, err := NewRotatingFile(path, 10, 1)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = file.Close() })
+
+	_, err = file.Write([]byte("first\n"))
+	require.NoError(t, err)
+	blocker := filepath.Join(path+".1", "blocker")
+	require.NoError(t, os.MkdirAll(blocker, 0o750))
+	_, err = file.Write([]byte("second\n"))
+	require.ErrorContains(t, err, "rotate "+path)
+
+	require.NoError(t, os.RemoveAll(path+".1"))
+	_, err = file.Write([]byte("third\n"))
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "third\n", string(data))
+	data, err = os.ReadFile(path + ".1")
+	require.NoError(t, err)
+	assert.Equal(t, "first\n", string(data))
+}
+
+func TestRotatingFile_ZeroBackupsTruncates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "commits.jsonl")
+	file, err := NewRotatingFile(path, 10, 0)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = file.Close() })
+
+	_, err = file.Write([]byte("first\n"))
+	require.NoError(t, err)
+	_, err = file.Write([]byte("second\n"))
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "second\n", string(data))
+	assert.NoFileExists(t, path+".1")
+}
@@ -11,6 +11,10 @@ import (
 // the worker's event queue is saturated.
 var ErrFinalizeQueueFull = errors.New("branch worker event queue full; item dropped")
 
+// ErrWorkerStopping is reported when a work item is refused because the worker is draining for
+// shutdown.
+var ErrWorkerStopping = errors.New("branch worker is stopping; item refused")
+
 // FinalizeOutcome is the terminal result of resolving a CommitRequest.
 type FinalizeOutcome string
 
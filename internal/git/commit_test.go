@@ -231,6 +231,30 @@ func TestRenderGroupCommitMessage_CustomTemplate(t *testing.T) {
 	assert.Equal(t, "grouped(platform): alice changed 1 resource(s)", message)
 }
 
+func TestRenderCommitMessages_ExposeAuthorGroups(t *testing.T) {
+	event := Event{
+		Operation:     "UPDATE",
+		Identifier:    types.ResourceIdentifier{Version: "v1", Resource: "configmaps", Namespace: "prod", Name: "app"},
+		UserInfo:      UserInfo{Username: "system:serviceaccount:ci:deployer", Groups: []string{"ci", "deployers"}},
+		GitTargetName: "platform",
+	}
+	config := ResolveCommitConfig(&v1alpha3.CommitSpec{
+		Message: &v1alpha3.CommitMessageSpec{
+			EventTemplate: "{{.Name}}{{range .Groups}} {{.}}{{end}}",
+			GroupTemplate: "{{.Count}}{{range .Groups}} {{.}}{{end}}",
+		},
+	})
+	require.NoError(t, ValidateCommitConfig(config))
+
+	message, err := renderEventCommitMessage(event, config)
+	require.NoError(t, err)
+	assert.Equal(t, "app ci deployers", message)
+
+	message, err = renderGroupCommitMessage(PendingWrite{Kind: PendingWriteCommit, Events: []Event{event}}, config)
+	require.NoError(t, err)
+	assert.Equal(t, "1 ci deployers", message)
+}
+
 func TestCommitOptionsFor_EmptyAuthorFallsThroughToCommitter(t *testing.T) {
 	config := ResolveCommitConfig(nil)
 	pendingWrite := PendingWrite{
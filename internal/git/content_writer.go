@@ -9,6 +9,11 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/ConfigButler/gitops-reverser/internal/sanitize"
 	"github.com/ConfigButler/gitops-reverser/internal/telemetry"
@@ -61,12 +66,17 @@ func (w *contentWriter) setEncryptor(encryptor Encryptor, scope string) {
 	w.encryptionScope = scope
 }
 
-// buildContentForWrite renders event content to stable ordered YAML and applies
-// sensitive-resource encryption when configured.
+// buildContentForWrite renders event content to stable ordered YAML, in the style the
+// event's GitTarget declares, and applies sensitive-resource encryption when configured. A
+// document the size check already rendered (event.rendered) is reused.
 func (w *contentWriter) buildContentForWrite(ctx context.Context, event Event) ([]byte, error) {
-	content, err := sanitize.MarshalToOrderedYAML(event.Object)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal object to YAML: %w", err)
+	content := event.rendered
+	if content == nil {
+		var err error
+		content, err = sanitize.MarshalToOrderedYAMLWithOptions(event.Object, event.YAMLOutput)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal object to YAML: %w", err)
+		}
 	}
 
 	if !w.isSensitiveIdentifier(event.Identifier) {
@@ -120,7 +130,9 @@ func (w *contentWriter) encryptSensitiveContent(ctx context.Context, event Event
 	if telemetry.SecretEncryptionAttemptsTotal != nil {
 		telemetry.SecretEncryptionAttemptsTotal.Add(ctx, 1)
 	}
+	started := time.Now()
 	encrypted, err := encryptor.Encrypt(ctx, plain, ResourceMeta(meta))
+	recordEncryptionDuration(ctx, meta.Identifier, time.Since(started), err)
 	if err != nil {
 		if telemetry.SecretEncryptionFailuresTotal != nil {
 			telemetry.SecretEncryptionFailuresTotal.Add(ctx, 1)
@@ -139,6 +151,27 @@ func (w *contentWriter) encryptSensitiveContent(ctx context.Context, event Event
 	return encrypted, nil
 }
 
+// slowEncryptionThreshold is the encryption time past which the writer logs a warning. Encryption
+// runs on the branch worker's write path, so a slow encryptor holds up every write on the branch.
+const slowEncryptionThreshold = time.Second
+
+// recordEncryptionDuration publishes one encryption call's duration and warns when it was slow.
+func recordEncryptionDuration(ctx context.Context, id types.ResourceIdentifier, took time.Duration, err error) {
+	if took > slowEncryptionThreshold {
+		log.FromContext(ctx).Info("Slow secret encryption; it holds up every write on the branch",
+			"resource", id.String(), "duration", took.String())
+	}
+	if telemetry.SecretEncryptionDurationSeconds == nil {
+		return
+	}
+	outcome := "success"
+	if err != nil {
+		outcome = "failure"
+	}
+	telemetry.SecretEncryptionDurationSeconds.Record(ctx, took.Seconds(),
+		metric.WithAttributes(attribute.String("outcome", outcome)))
+}
+
 func (w *contentWriter) cachedEncryptedContent(
 	ctx context.Context,
 	identityKey, cacheKey string,
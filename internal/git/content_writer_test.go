@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
+	"github.com/ConfigButler/gitops-reverser/internal/telemetry"
 	"github.com/ConfigButler/gitops-reverser/internal/types"
 )
 
@@ -323,3 +324,39 @@ func tenantSecretEvent(version string) Event {
 		},
 	}
 }
+
+func TestBuildContentForWrite_SecretEncryptionRecordsDuration(t *testing.T) {
+	reader, err := telemetry.InitTestExporter()
+	require.NoError(t, err)
+
+	writer := newContentWriter(types.SensitiveResourcePolicy{})
+	writer.setEncryptor(&stubEncryptor{result: []byte("encrypted: true\n")}, "test-scope")
+	_, err = writer.buildContentForWrite(context.Background(), secretWriteEvent("first"))
+	require.NoError(t, err)
+
+	writer.setEncryptor(&stubEncryptor{err: errors.New("boom")}, "test-scope")
+	_, err = writer.buildContentForWrite(context.Background(), secretWriteEvent("second"))
+	require.Error(t, err)
+
+	const name = "gitopsreverser_secret_encryption_duration_seconds"
+	count, ok := telemetry.CollectHistogramCount(reader, name, map[string]string{"outcome": "success"})
+	require.True(t, ok)
+	assert.Equal(t, uint64(1), count)
+	count, ok = telemetry.CollectHistogramCount(reader, name, map[string]string{"outcome": "failure"})
+	require.True(t, ok)
+	assert.Equal(t, uint64(1), count)
+}
+
+func secretWriteEvent(name string) Event {
+	return Event{
+		Identifier: types.ResourceIdentifier{Version: "v1", Resource: "secrets", Namespace: "default", Name: name},
+		Object: &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Secret",
+				"metadata":   map[string]interface{}{"name": name, "namespace": "default"},
+				"data":       map[string]interface{}{"password": "cGxhaW4="},
+			},
+		},
+	}
+}
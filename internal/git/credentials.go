@@ -37,13 +37,32 @@ type SSHHostKeyConfig struct {
 }
 
 // getAuthFromSecret fetches the credentials Secret named by the GitProvider and resolves it into
-// a go-git auth method. A GitProvider with no secretRef authenticates anonymously (public repos).
+// a go-git auth method, adding the clientCertSecretRef certificate when one is set. A GitProvider
+// with spec.oidc uses a ServiceAccount token instead of a Secret. A GitProvider with none of these
+// authenticates anonymously (public repos).
 func getAuthFromSecret(
 	ctx context.Context,
 	k8sClient client.Client,
 	provider *v1alpha3.GitProvider,
 	hostKeys SSHHostKeyConfig,
 ) (transport.AuthMethod, error) {
+	auth, err := getSecretRefAuth(ctx, k8sClient, provider, hostKeys)
+	if err != nil {
+		return nil, err
+	}
+	return WithClientCertificate(ctx, k8sClient, provider, auth)
+}
+
+func getSecretRefAuth(
+	ctx context.Context,
+	k8sClient client.Client,
+	provider *v1alpha3.GitProvider,
+	hostKeys SSHHostKeyConfig,
+) (transport.AuthMethod, error) {
+	if provider.Spec.OIDC != nil {
+		auth, _, err := OIDCTokenAuth(ctx, k8sClient, provider)
+		return auth, err
+	}
 	if provider.Spec.SecretRef == nil || provider.Spec.SecretRef.Name == "" {
 		return nil, nil //nolint:nilnil // Returning nil auth for public repos is semantically correct
 	}
@@ -8,12 +8,15 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"net/url"
+	"regexp"
 	"sort"
 	"strings"
 
 	"filippo.io/age"
 	corev1 "k8s.io/api/core/v1"
 	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/ConfigButler/gitops-reverser/api/v1alpha3"
@@ -43,6 +46,17 @@ const (
 	ageSecretKeySuffix = ".agekey"
 )
 
+// vaultTransitKeyPath is the URI path of a Vault transit key as sops addresses it.
+var vaultTransitKeyPath = regexp.MustCompile(`^/v1/.+/keys/[^/]+$`)
+
+// manifestStructureKeys are the keys of a Secret manifest's own structure. sops matches an
+// encrypted key name against every path segment, so an encryptedKeys entry with one of these
+// names would encrypt identity or framing instead of a payload value.
+var manifestStructureKeys = map[string]struct{}{
+	"apiVersion": {}, "kind": {}, "metadata": {}, "name": {}, "namespace": {}, "labels": {},
+	"annotations": {}, "data": {}, "stringData": {}, "type": {}, "immutable": {}, "sops": {},
+}
+
 // ResolvedEncryptionConfig contains runtime encryption settings resolved from GitTarget spec.
 //
 // It carries public age recipients only. The write path encrypts, it never decrypts, so no
@@ -51,6 +65,17 @@ const (
 type ResolvedEncryptionConfig struct {
 	Provider      string
 	AgeRecipients []string
+	// AWSKMSKeyARNs and VaultTransitURIs name keys sops reaches with the controller Pod's own
+	// credentials; like the age recipients they are public identifiers, not key material.
+	AWSKMSKeyARNs    []string
+	VaultTransitURIs []string
+	// EncryptedKeys, when set, limits encryption to these data and stringData keys.
+	EncryptedKeys []string
+}
+
+// HasKeys reports whether at least one SOPS key of any kind was resolved.
+func (c *ResolvedEncryptionConfig) HasKeys() bool {
+	return c != nil && (len(c.AgeRecipients) > 0 || len(c.AWSKMSKeyARNs) > 0 || len(c.VaultTransitURIs) > 0)
 }
 
 // ResolveTargetEncryption resolves and validates GitTarget encryption configuration.
@@ -68,12 +93,42 @@ func ResolveTargetEncryption(
 	if err != nil {
 		return nil, err
 	}
-	ageSpec := encryptionSpec.Age
-	if ageSpec == nil || !ageSpec.Enabled {
-		return nil, nil //nolint:nilnil // nil means encryption disabled for current provider implementation
+	ageEnabled := encryptionSpec.Age != nil && encryptionSpec.Age.Enabled
+	if !ageEnabled && encryptionSpec.AWSKMS == nil && encryptionSpec.Vault == nil {
+		return nil, nil //nolint:nilnil // nil means encryption disabled: no SOPS key backend is configured
+	}
+
+	resolved := &ResolvedEncryptionConfig{Provider: providerName}
+	if ageEnabled {
+		if resolved.AgeRecipients, err = resolveAgeRecipients(ctx, k8sClient, target, encryptionSpec); err != nil {
+			return nil, err
+		}
+	}
+	if encryptionSpec.AWSKMS != nil {
+		if resolved.AWSKMSKeyARNs, err = normalizeAWSKMSKeyARNs(encryptionSpec.AWSKMS.KeyARNs); err != nil {
+			return nil, err
+		}
+	}
+	if encryptionSpec.Vault != nil {
+		if resolved.VaultTransitURIs, err = normalizeVaultTransitURIs(encryptionSpec.Vault.TransitURIs); err != nil {
+			return nil, err
+		}
+	}
+	if resolved.EncryptedKeys, err = normalizeEncryptedKeys(encryptionSpec.EncryptedKeys); err != nil {
+		return nil, err
 	}
+	return resolved, nil
+}
 
-	publicRecipients, err := normalizePublicAgeRecipients(ageSpec.Recipients.PublicKeys)
+// resolveAgeRecipients resolves the public age recipients of an age-enabled encryption spec,
+// from age.recipients.publicKeys and the secretRef's *.agekey entries.
+func resolveAgeRecipients(
+	ctx context.Context,
+	k8sClient client.Client,
+	target *v1alpha3.GitTarget,
+	encryptionSpec *v1alpha3.EncryptionSpec,
+) ([]string, error) {
+	publicRecipients, err := normalizePublicAgeRecipients(encryptionSpec.Age.Recipients.PublicKeys)
 	if err != nil {
 		return nil, err
 	}
@@ -88,11 +143,73 @@ func ResolveTargetEncryption(
 			"encryption.age.enabled=true requires at least one resolved recipient from age.recipients.publicKeys or secret *.agekey entries",
 		)
 	}
+	return resolvedRecipients, nil
+}
+
+// normalizeAWSKMSKeyARNs validates awsKms.keyARNs as KMS key or alias ARNs. It checks shape
+// only: whether the key exists and the Pod may use it is only known when sops calls KMS.
+func normalizeAWSKMSKeyARNs(arns []string) ([]string, error) {
+	for i, arn := range arns {
+		parts := strings.SplitN(strings.TrimSpace(arn), ":", 6)
+		if len(parts) != 6 || parts[0] != "arn" || parts[2] != "kms" || parts[3] == "" || parts[4] == "" ||
+			!(strings.HasPrefix(parts[5], "key/") || strings.HasPrefix(parts[5], "alias/")) {
+			return nil, fmt.Errorf(
+				"invalid AWS KMS key ARN in awsKms.keyARNs[%d] %q: want arn:<partition>:kms:<region>:<account>:key/<id>",
+				i, arn)
+		}
+	}
+	resolved := dedupeAndSortRecipients(arns)
+	if len(resolved) == 0 {
+		return nil, errors.New("encryption.awsKms requires at least one key ARN in awsKms.keyARNs")
+	}
+	return resolved, nil
+}
+
+// normalizeVaultTransitURIs validates vault.transitURIs in the form sops parses:
+// http(s)://<address>/v1/<transit-mount>/keys/<key-name>.
+func normalizeVaultTransitURIs(uris []string) ([]string, error) {
+	for i, raw := range uris {
+		parsed, err := url.Parse(strings.TrimSpace(raw))
+		if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" ||
+			!vaultTransitKeyPath.MatchString(parsed.Path) {
+			return nil, fmt.Errorf(
+				"invalid Vault transit URI in vault.transitURIs[%d] %q: want https://<address>/v1/<mount>/keys/<name>",
+				i, raw)
+		}
+	}
+	resolved := dedupeAndSortRecipients(uris)
+	if len(resolved) == 0 {
+		return nil, errors.New("encryption.vault requires at least one key URI in vault.transitURIs")
+	}
+	return resolved, nil
+}
+
+func normalizeEncryptedKeys(keys []string) ([]string, error) {
+	for i, raw := range keys {
+		key := strings.TrimSpace(raw)
+		if errs := validation.IsConfigMapKey(key); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid key in encryptedKeys[%d] %q: %s", i, raw, strings.Join(errs, "; "))
+		}
+		if _, reserved := manifestStructureKeys[key]; reserved {
+			return nil, fmt.Errorf(
+				"invalid key in encryptedKeys[%d] %q: it names a manifest field, which sops would encrypt too",
+				i, raw)
+		}
+	}
+	return dedupeAndSortRecipients(keys), nil
+}
 
-	return &ResolvedEncryptionConfig{
-		Provider:      providerName,
-		AgeRecipients: resolvedRecipients,
-	}, nil
+// sopsEncryptedRegex builds the sops --encrypted-regex that matches exactly the given keys, or
+// "" to keep the .sops.yaml rule, which encrypts all of data and stringData.
+func sopsEncryptedRegex(keys []string) string {
+	if len(keys) == 0 {
+		return ""
+	}
+	quoted := make([]string, len(keys))
+	for i, key := range keys {
+		quoted[i] = regexp.QuoteMeta(key)
+	}
+	return "^(" + strings.Join(quoted, "|") + ")$"
 }
 
 func resolveEncryptionProvider(encryptionSpec *v1alpha3.EncryptionSpec) (string, error) {
@@ -182,7 +299,9 @@ func configureSecretEncryptionWriter(
 		// .sops.yaml at bootstrap, and the write path only encrypts, so it needs neither a
 		// private-key file (SOPS_AGE_KEY_FILE) nor blanket Secret data in the process env.
 		scope := secretEncryptionCacheScope(workDir, cfg)
-		writer.setEncryptor(NewSOPSEncryptorWithEnv(defaultSOPSBinaryPath, "", workDir, nil), scope)
+		encryptor := NewSOPSEncryptorWithEnv(defaultSOPSBinaryPath, "", workDir, nil)
+		encryptor.encryptedRegex = sopsEncryptedRegex(cfg.EncryptedKeys)
+		writer.setEncryptor(encryptor, scope)
 		return nil
 	default:
 		return fmt.Errorf("unsupported encryption provider %q", cfg.Provider)
@@ -204,6 +323,20 @@ func secretEncryptionCacheScope(workDir string, cfg *ResolvedEncryptionConfig) s
 		hasher.Write([]byte(strings.TrimSpace(recipient)))
 		hasher.Write([]byte{0})
 	}
+	// Each backend's keys are prefixed with its name, so moving a key between backends, or a
+	// change that only adds a KMS or Vault key, still yields a new scope.
+	for _, arn := range cfg.AWSKMSKeyARNs {
+		hasher.Write([]byte("kms:" + strings.TrimSpace(arn)))
+		hasher.Write([]byte{0})
+	}
+	for _, uri := range cfg.VaultTransitURIs {
+		hasher.Write([]byte("vault:" + strings.TrimSpace(uri)))
+		hasher.Write([]byte{0})
+	}
+	for _, key := range cfg.EncryptedKeys {
+		hasher.Write([]byte("encrypted-key:" + key))
+		hasher.Write([]byte{0})
+	}
 
 	sum := hasher.Sum(nil)
 	return hex.EncodeToString(sum[:16])
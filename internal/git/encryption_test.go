@@ -326,3 +326,122 @@ func TestDeriveAgeRecipientFromSecretEntry(t *testing.T) {
 		assert.Contains(t, err.Error(), "must contain exactly one AGE-SECRET-KEY identity")
 	})
 }
+
+func TestResolveTargetEncryption_KMSAndVaultKeys(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1alpha3.AddToScheme(scheme))
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	target := func(spec v1alpha3.EncryptionSpec) *v1alpha3.GitTarget {
+		return &v1alpha3.GitTarget{
+			ObjectMeta: metav1.ObjectMeta{Name: "target", Namespace: "default"},
+			Spec:       v1alpha3.GitTargetSpec{Encryption: &spec},
+		}
+	}
+	const (
+		keyARN   = "arn:aws:kms:eu-west-1:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+		aliasARN = "arn:aws-us-gov:kms:us-gov-west-1:111122223333:alias/gitops"
+		transit  = "https://vault.example.com:8200/v1/sops/keys/gitops"
+	)
+
+	resolved, err := ResolveTargetEncryption(context.Background(), k8sClient, target(v1alpha3.EncryptionSpec{
+		Provider: EncryptionProviderSOPS,
+		AWSKMS:   &v1alpha3.AWSKMSEncryptionSpec{KeyARNs: []string{" " + keyARN, aliasARN, keyARN}},
+		Vault:    &v1alpha3.VaultEncryptionSpec{TransitURIs: []string{transit}},
+	}))
+	require.NoError(t, err)
+	require.NotNil(t, resolved, "KMS and Vault keys enable encryption without age")
+	assert.True(t, resolved.HasKeys())
+	assert.Empty(t, resolved.AgeRecipients)
+	assert.Equal(t, []string{aliasARN, keyARN}, resolved.AWSKMSKeyARNs, "trimmed, deduplicated and sorted")
+	assert.Equal(t, []string{transit}, resolved.VaultTransitURIs)
+
+	for name, spec := range map[string]v1alpha3.EncryptionSpec{
+		"not a KMS ARN":   {AWSKMS: &v1alpha3.AWSKMSEncryptionSpec{KeyARNs: []string{"arn:aws:s3:::bucket"}}},
+		"no key resource": {AWSKMS: &v1alpha3.AWSKMSEncryptionSpec{KeyARNs: []string{"arn:aws:kms:eu-west-1:1:thing"}}},
+		"empty ARN list":  {AWSKMS: &v1alpha3.AWSKMSEncryptionSpec{}},
+		"not a transit path": {Vault: &v1alpha3.VaultEncryptionSpec{
+			TransitURIs: []string{"https://vault.example.com/v1/secret/data/gitops"},
+		}},
+		"no scheme":        {Vault: &v1alpha3.VaultEncryptionSpec{TransitURIs: []string{"vault.example.com/v1/sops/keys/k"}}},
+		"empty Vault list": {Vault: &v1alpha3.VaultEncryptionSpec{}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			spec.Provider = EncryptionProviderSOPS
+			_, err := ResolveTargetEncryption(context.Background(), k8sClient, target(spec))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestSecretEncryptionCacheScope_CoversEveryKeyBackend(t *testing.T) {
+	base := &ResolvedEncryptionConfig{Provider: EncryptionProviderSOPS, AgeRecipients: []string{"age1a"}}
+	withKMS := &ResolvedEncryptionConfig{
+		Provider: EncryptionProviderSOPS, AgeRecipients: []string{"age1a"},
+		AWSKMSKeyARNs: []string{"arn:aws:kms:eu-west-1:1:key/k"},
+	}
+	withVault := &ResolvedEncryptionConfig{
+		Provider: EncryptionProviderSOPS, AgeRecipients: []string{"age1a"},
+		VaultTransitURIs: []string{"https://vault/v1/sops/keys/k"},
+	}
+
+	scopes := map[string]struct{}{}
+	for _, cfg := range []*ResolvedEncryptionConfig{base, withKMS, withVault} {
+		scopes[secretEncryptionCacheScope("/repo", cfg)] = struct{}{}
+	}
+	assert.Len(t, scopes, 3, "adding a KMS or Vault key invalidates cached ciphertext")
+}
+
+func TestResolveTargetEncryption_EncryptedKeys(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1alpha3.AddToScheme(scheme))
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	resolve := func(keys ...string) (*ResolvedEncryptionConfig, error) {
+		return ResolveTargetEncryption(context.Background(), k8sClient, &v1alpha3.GitTarget{
+			ObjectMeta: metav1.ObjectMeta{Name: "target", Namespace: "default"},
+			Spec: v1alpha3.GitTargetSpec{Encryption: &v1alpha3.EncryptionSpec{
+				Provider:      EncryptionProviderSOPS,
+				AWSKMS:        &v1alpha3.AWSKMSEncryptionSpec{KeyARNs: []string{"arn:aws:kms:eu-west-1:1:key/k"}},
+				EncryptedKeys: keys,
+			}},
+		})
+	}
+
+	resolved, err := resolve("password", " tls.key", "password")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"password", "tls.key"}, resolved.EncryptedKeys)
+
+	resolved, err = resolve()
+	require.NoError(t, err)
+	assert.Empty(t, resolved.EncryptedKeys, "no keys keeps whole-payload encryption")
+
+	for _, key := range []string{"name", "labels", "sops", "has space", "a/b"} {
+		_, err := resolve(key)
+		require.Error(t, err, key)
+		assert.Contains(t, err.Error(), "encryptedKeys[0]")
+	}
+}
+
+func TestSOPSEncryptedRegex(t *testing.T) {
+	assert.Empty(t, sopsEncryptedRegex(nil))
+	assert.Equal(t, `^(password|tls\.key)$`, sopsEncryptedRegex([]string{"password", "tls.key"}))
+}
+
+func TestConfigureSecretEncryptionWriter_EncryptedKeys(t *testing.T) {
+	writer := &contentWriter{}
+	cfg := &ResolvedEncryptionConfig{
+		Provider:      EncryptionProviderSOPS,
+		AgeRecipients: []string{"age1a"},
+		EncryptedKeys: []string{"password"},
+	}
+	require.NoError(t, configureSecretEncryptionWriter(writer, "/repo", cfg))
+
+	encryptor, ok := writer.encryptor.(*SOPSEncryptor)
+	require.True(t, ok)
+	assert.Equal(t, "^(password)$", encryptor.encryptedRegex)
+
+	whole := &ResolvedEncryptionConfig{Provider: EncryptionProviderSOPS, AgeRecipients: []string{"age1a"}}
+	assert.NotEqual(t, secretEncryptionCacheScope("/repo", whole), writer.encryptionScope,
+		"changing which keys are encrypted must not reuse cached ciphertext")
+}
@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/ConfigButler/gitops-reverser/internal/manifestanalyzer"
+	"github.com/ConfigButler/gitops-reverser/internal/telemetry"
+	"github.com/ConfigButler/gitops-reverser/internal/types"
+)
+
+// ReasonApplyFailed is the Event reason recorded on a GitTarget when one of its events failed
+// to apply and the commit carrying it was dropped.
+const ReasonApplyFailed = "ApplyFailed"
+
+// eventApplyError names the event whose apply failed a flush. A commit is atomic — one failed
+// event drops the whole window — so this is the only place the failing resource is still known;
+// without it the log line says what broke but not for which object.
+type eventApplyError struct {
+	identifier types.ResourceIdentifier
+	operation  string
+	target     pendingTargetKey
+	err        error
+}
+
+func newEventApplyError(ctx context.Context, event Event, err error) error {
+	id := event.Identifier
+	if telemetry.CommitFailuresTotal != nil {
+		telemetry.CommitFailuresTotal.Add(ctx, 1, metric.WithAttributes(
+			attribute.String("gvr", manifestanalyzer.PlacementTypeKey(id.Group, id.Version, id.Resource)),
+			attribute.String("operation", event.Operation),
+		))
+	}
+	return &eventApplyError{
+		identifier: id,
+		operation:  event.Operation,
+		target:     pendingTargetKey{Name: event.GitTargetName, Namespace: event.GitTargetNamespace},
+		err:        err,
+	}
+}
+
+func (e *eventApplyError) Error() string {
+	return e.operation + " " + e.identifier.String() + ": " + e.err.Error()
+}
+
+func (e *eventApplyError) Unwrap() error { return e.err }
+
+// failedEventLogFields returns the structured fields naming the failed event in err, or nil
+// when err did not come from an event apply.
+func failedEventLogFields(err error) []interface{} {
+	var failed *eventApplyError
+	if !errors.As(err, &failed) {
+		return nil
+	}
+	return []interface{}{"failedResource", failed.identifier.String(), "failedOperation", failed.operation}
+}
+
+// recordApplyFailedEvent records a Warning ApplyFailed Event naming the event in err that failed
+// a commit. The Event goes on the failed event's own GitTarget, or on fallback when the event
+// does not name one; an err that did not come from an event apply records nothing, since it
+// names no resource a reader could act on.
+func (w *BranchWorker) recordApplyFailedEvent(err error, fallback pendingTargetKey, dropped int) {
+	var failed *eventApplyError
+	if w.recorder == nil || !errors.As(err, &failed) {
+		return
+	}
+	key := failed.target
+	if key.Name == "" || key.Namespace == "" {
+		key = fallback
+	}
+	if key.Name == "" || key.Namespace == "" {
+		return
+	}
+	w.recordTargetEvent(key, corev1.EventTypeWarning, ReasonApplyFailed, "Commit",
+		"Dropped %d events: %s %s failed: %v", dropped, failed.operation, failed.identifier.String(), failed.err)
+}
@@ -12,12 +12,15 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"syscall"
+	"time"
 
 	billyutil "github.com/go-git/go-billy/v5/util"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/format/index"
+	"github.com/go-git/go-git/v5/plumbing/storer"
 	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
 	"github.com/go-logr/logr"
@@ -72,8 +75,12 @@ func CheckRepo(ctx context.Context, repoURL string, auth transport.AuthMethod) (
 		URLs: []string{repoURL},
 	})
 
-	refs, err := remote.List(&git.ListOptions{
-		Auth: auth,
+	clientCert, clientKey, caBundle := clientTLS(auth)
+	refs, err := remote.ListContext(ctx, &git.ListOptions{
+		Auth:       auth,
+		ClientCert: clientCert,
+		ClientKey:  clientKey,
+		CABundle:   caBundle,
 	})
 	if err != nil {
 		// Check if this is an empty repository error
@@ -118,11 +125,32 @@ func CheckRepo(ctx context.Context, repoURL string, auth transport.AuthMethod) (
 }
 
 // PrepareBranch clones repository immediately when GitDestination is created, optimized for single branch usage. It tries to fetch the useful branch: either target or default.
+// fetchDepth is the number of commits fetched per branch (DefaultFetchDepth for the tip only);
+// 0 fetches the full history.
+//
+// It holds the lock of repoPath (lockRepoPath) throughout, so it never runs against a clone
+// another operation is using.
 func PrepareBranch(
 	ctx context.Context,
 	repoURL, repoPath, targetBranchName string,
 	auth transport.AuthMethod,
+	fetchDepth int,
 ) (*PullReport, error) {
+	lock := lockRepoPath(repoPath)
+	defer lock.Unlock()
+	return prepareBranchLocked(ctx, repoURL, repoPath, targetBranchName, auth, fetchDepth)
+}
+
+// prepareBranchLocked is PrepareBranch for a caller that already holds the lock of repoPath.
+func prepareBranchLocked(
+	ctx context.Context,
+	repoURL, repoPath, targetBranchName string,
+	auth transport.AuthMethod,
+	fetchDepth int,
+) (*PullReport, error) {
+	if fetchDepth < 0 {
+		return nil, fmt.Errorf("fetch depth must be >= 0, got %d", fetchDepth)
+	}
 	logger := log.FromContext(ctx)
 	logger.Info("Preparing branch for operations", "url", repoURL, "path", repoPath, "branch", targetBranchName)
 
@@ -134,8 +162,22 @@ func PrepareBranch(
 	var repo *git.Repository
 	var err error
 
+	if err := repairRepository(repoPath, logger); err != nil {
+		return nil, err
+	}
+
 	// Check if repository already exists
-	existingRepo := tryOpenExistingRepo(repoPath, logger)
+	existingRepo, err := tryOpenExistingRepo(ctx, repoPath, logger)
+	if err != nil {
+		return nil, err
+	}
+	if existingRepo != nil && fetchDepth == 0 && isShallowClone(existingRepo) {
+		// go-git never deepens a shallow clone: a depth-0 fetch wants nothing the clone already has,
+		// and it drops the remote's unshallow lines. Only a fresh clone gets the full history.
+		logger.Info("Existing repository is shallow but the fetch depth asks for full history, will clone fresh",
+			"path", repoPath)
+		existingRepo = nil
+	}
 	if existingRepo != nil {
 		logger.Info("Reusing existing repository", "path", repoPath)
 		repo = existingRepo
@@ -153,7 +195,7 @@ func PrepareBranch(
 	}
 
 	targetBranch := plumbing.NewBranchReferenceName(targetBranchName)
-	pullReport, err := syncToRemote(ctx, repo, targetBranch, auth)
+	pullReport, err := syncToRemote(ctx, repo, targetBranch, auth, fetchDepth)
 	if err != nil {
 		return nil, err
 	}
@@ -311,49 +353,159 @@ func IsValidTargetPath(p string) bool {
 	return sanitizePath(trimmed) != ""
 }
 
-// tryOpenExistingRepo attempts to open and validate an existing repository.
-func tryOpenExistingRepo(path string, logger logr.Logger) *git.Repository {
+// staleRepositoryStateFiles are the in-progress markers an interrupted operation can leave in
+// .git. The worker never merges or cherry-picks, so any it finds are left over from a crash.
+var staleRepositoryStateFiles = []string{"MERGE_HEAD", "CHERRY_PICK_HEAD"}
+
+// repairRepository clears what a worker killed mid-commit can leave behind in an existing
+// clone: lock files, in-progress markers, and an index that no longer decodes. The index is the
+// one that matters — syncToRemote's hard reset reads it first, so a torn index would fail every
+// later PrepareBranch. Dropping it is safe because that reset rebuilds it from the commit.
+// A repository that does not open at all is left to tryOpenExistingRepo, which reclones it.
+//
+// The caller must hold the lock of repoPath (lockRepoPath). A .git/*.lock file is only stale
+// because the lock rules out a live git operation on the clone; without it this would delete the
+// lock of an operation still running and let two writers into the same index.
+func repairRepository(repoPath string, logger logr.Logger) error {
+	if !repoPathLocked(repoPath) {
+		return fmt.Errorf("repairing %s without holding its clone path lock", repoPath)
+	}
+	gitDir := filepath.Join(repoPath, ".git")
+	if _, err := os.Stat(gitDir); err != nil {
+		return nil
+	}
+
+	stale, err := filepath.Glob(filepath.Join(gitDir, "*.lock"))
+	if err != nil {
+		return fmt.Errorf("failed to list lock files: %w", err)
+	}
+	for _, name := range staleRepositoryStateFiles {
+		stale = append(stale, filepath.Join(gitDir, name))
+	}
+	for _, path := range stale {
+		if err := os.Remove(path); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return fmt.Errorf("failed to remove stale repository file: %w", err)
+		}
+		logger.Info("Removed stale repository file", "path", path)
+	}
+
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return nil
+	}
+	if _, err := repo.Storer.Index(); err != nil {
+		logger.Info("Discarding unreadable index", "path", repoPath, "error", err)
+		if err := os.Remove(filepath.Join(gitDir, "index")); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove unreadable index: %w", err)
+		}
+	}
+	return nil
+}
+
+const (
+	// openRepoAttempts and openRepoRetryDelay bound how long an existing clone that fails to open
+	// with a transient I/O error is retried: the delay doubles per attempt.
+	openRepoAttempts   = 3
+	openRepoRetryDelay = 100 * time.Millisecond
+)
+
+// plainOpenFn is a narrow test seam for opening an existing clone.
+//
+//nolint:gochecknoglobals
+var plainOpenFn = git.PlainOpen
+
+// transientRepoErrnos are the I/O errors a clone that is fine can still return to an open, most
+// often on a network filesystem (an NFS-backed PVC): busy, stale, timed out, or interrupted.
+//
+//nolint:gochecknoglobals
+var transientRepoErrnos = []syscall.Errno{
+	syscall.EAGAIN, syscall.EBUSY, syscall.EINTR, syscall.EIO, syscall.ENOLCK, syscall.ESTALE, syscall.ETIMEDOUT,
+}
+
+// tryOpenExistingRepo attempts to open and validate an existing repository. It returns nil and
+// no error when there is no clone, or when the clone is confirmed corrupt (it does not open, or
+// its HEAD does not resolve), so the caller clones fresh. A transient I/O error is retried, and
+// one that persists is returned: a clone that is only unreachable for now is never discarded. A
+// cancelled ctx ends the retry wait with ctx's error.
+func tryOpenExistingRepo(ctx context.Context, path string, logger logr.Logger) (*git.Repository, error) {
 	// Check if .git directory exists
 	gitDir := filepath.Join(path, ".git")
 	if _, err := os.Stat(gitDir); os.IsNotExist(err) {
-		return nil
+		return nil, nil //nolint:nilnil // no clone yet: clone fresh
 	}
 
-	// Try to open the repository
-	repo, err := git.PlainOpen(path)
+	delay := openRepoRetryDelay
+	for attempt := 1; ; attempt++ {
+		repo, err := openExistingRepo(path)
+		if err == nil {
+			return repo, nil
+		}
+		if !isTransientRepoError(err) {
+			logger.Info("Existing repository is invalid, will clone fresh", "path", path, "error", err)
+			return nil, nil //nolint:nilnil // no usable repository, and nothing to report: clone fresh
+		}
+		if attempt == openRepoAttempts {
+			return nil, fmt.Errorf("failed to open existing repository after %d attempts: %w", attempt, err)
+		}
+		logger.Info("Existing repository failed to open, retrying",
+			"path", path, "attempt", attempt, "retryIn", delay, "error", err)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("open existing repository: %w", ctx.Err())
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+}
+
+// isShallowClone reports whether repo records shallow boundaries, that is whether an earlier fetch
+// was limited to a depth.
+func isShallowClone(repo *git.Repository) bool {
+	shallows, err := repo.Storer.Shallow()
+	return err == nil && len(shallows) > 0
+}
+
+// openExistingRepo opens the clone at path and checks that its HEAD resolves: a symbolic HEAD
+// may name a branch that does not exist yet (an unborn branch), but not one that fails to read.
+func openExistingRepo(path string) (*git.Repository, error) {
+	repo, err := plainOpenFn(path)
 	if err != nil {
-		logger.Info(
-			"Failed to open existing repository, will clone fresh",
-			"path",
-			path,
-			"error",
-			err,
-		)
-		return nil
+		return nil, err
 	}
 
 	headRef, err := repo.Storer.Reference(plumbing.HEAD)
 	if err != nil {
-		logger.Info("Existing repository is invalid, will clone fresh", "path", path, "error", err)
-		return nil
+		return nil, fmt.Errorf("failed to read HEAD: %w", err)
 	}
 
 	if headRef.Type() == plumbing.SymbolicReference {
-		if _, refErr := repo.Reference(headRef.Target(), false); refErr == nil ||
-			errors.Is(refErr, plumbing.ErrReferenceNotFound) {
-			return repo
+		if _, refErr := repo.Reference(headRef.Target(), false); refErr != nil &&
+			!errors.Is(refErr, plumbing.ErrReferenceNotFound) {
+			return nil, fmt.Errorf("invalid HEAD target %s: %w", headRef.Target(), refErr)
 		}
-		logger.Info(
-			"Existing repository has invalid HEAD target, will clone fresh",
-			"path",
-			path,
-			"target",
-			headRef.Target(),
-		)
-		return nil
 	}
 
-	return repo
+	return repo, nil
+}
+
+// isTransientRepoError reports whether an error opening a clone is a transient I/O error rather
+// than a sign of corruption. Anything else — a missing or unreadable object store, a HEAD that
+// does not parse — is taken as corruption.
+func isTransientRepoError(err error) bool {
+	if errors.Is(err, os.ErrDeadlineExceeded) {
+		return true
+	}
+	for _, errno := range transientRepoErrnos {
+		if errors.Is(err, errno) {
+			return true
+		}
+	}
+	return false
 }
 
 func createPullReport(targetBranch string, before, after plumbing.Hash, remoteExists, unborn bool) *PullReport {
@@ -383,13 +535,14 @@ func syncToRemote(
 	repo *git.Repository,
 	branch plumbing.ReferenceName,
 	auth transport.AuthMethod,
+	fetchDepth int,
 ) (*PullReport, error) {
 	_, currentHash, err := GetCurrentBranch(repo)
 	if err != nil {
 		return nil, fmt.Errorf("unexpected fail to read HEAD: %w", err)
 	}
 
-	availableBranch, err := SmartFetch(ctx, repo, branch, auth)
+	availableBranch, err := SmartFetch(ctx, repo, branch, auth, fetchDepth)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch: %w", err)
 	}
@@ -631,8 +784,18 @@ func canonicalizeManifestForComparison(content []byte) ([]byte, error) {
 		return nil, fmt.Errorf("unmarshal manifest: %w", err)
 	}
 
+	// managedFields and status are kept: only a GitTarget with spec.preserveManagedFields writes
+	// the one, and only a WatchRule with includeStatus the other, and there a change to them alone
+	// is a change to the document. Labels and annotations are kept for the same reason: a
+	// bookkeeping key still in a document was kept by spec.sanitizePerGVR on purpose.
 	obj := &unstructured.Unstructured{Object: raw}
-	return sanitize.MarshalToOrderedYAML(sanitize.Sanitize(obj))
+	opts := sanitize.Options{
+		PreserveManagedFields: true,
+		IncludeStatus:         true,
+		KeepAnnotations:       sanitize.KeepAllKeys,
+		KeepLabels:            sanitize.KeepAllKeys,
+	}
+	return sanitize.MarshalToOrderedYAML(sanitize.SanitizeWithOptions(obj, opts))
 }
 
 func generateFilePath(id types.ResourceIdentifier, sensitiveResources types.SensitiveResourcePolicy) string {
@@ -646,7 +809,31 @@ func generateFilePath(id types.ResourceIdentifier, sensitiveResources types.Sens
 	return defaultPath + ".sops.yaml"
 }
 
-// initializeCleanRepository removes corrupted repos and initializes a fresh one.
+// hasLocalClone reports whether repoPath already holds a clone with at least one reference, so the
+// next fetch is incremental rather than a download of the whole branch.
+func hasLocalClone(repoPath string) bool {
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return false
+	}
+	refs, err := repo.References()
+	if err != nil {
+		return false
+	}
+	defer refs.Close()
+	found := false
+	_ = refs.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() == plumbing.HashReference {
+			found = true
+			return storer.ErrStop
+		}
+		return nil
+	})
+	return found
+}
+
+// initializeCleanRepository removes a clone tryOpenExistingRepo confirmed corrupt and
+// initializes a fresh one.
 func initializeCleanRepository(repoPath string, logger logr.Logger) (*git.Repository, error) {
 	// If directory exists but repo is invalid, remove it
 	gitDir := filepath.Join(repoPath, ".git")
@@ -8,6 +8,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
@@ -21,6 +23,41 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
+// PushRejectedError is a push the remote received and then refused for the ref itself: branch
+// protection, a non-fast-forward rule, or a server-side hook. A lost race never gets this far,
+// validatePushState reports it before anything is sent, so a rejection will not clear by retrying.
+type PushRejectedError struct {
+	// Ref is the branch the push tried to update.
+	Ref plumbing.ReferenceName
+	// Reason is the remote's status for the ref, e.g. "protected branch hook declined".
+	Reason string
+	// HookOutput is what the remote printed while refusing the push, such as a pre-receive hook's
+	// "commits must be signed". It is empty when the remote sent nothing.
+	HookOutput string
+}
+
+func (e *PushRejectedError) Error() string {
+	if e.HookOutput != "" {
+		return fmt.Sprintf("remote rejected push to %s: %s: %s", e.Ref.Short(), e.Reason, e.HookOutput)
+	}
+	return fmt.Sprintf("remote rejected push to %s: %s", e.Ref.Short(), e.Reason)
+}
+
+// Protected reports whether the remote's reason names branch protection: GitHub's
+// "protected branch hook declined" or its GH006 error. A bare "pre-receive hook declined" or a
+// denied non-fast-forward is any hook or receive.denyNonFastForwards refusing this push, not
+// evidence of protection, so it stays a plain rejection. Either way the worker does not retry it
+// on the transient backoff.
+func (e *PushRejectedError) Protected() bool {
+	reason := strings.ToLower(e.Reason)
+	for _, marker := range []string{"protected branch", "gh006"} {
+		if strings.Contains(reason, marker) {
+			return true
+		}
+	}
+	return false
+}
+
 // getPushSession creates and returns a receive-pack session for pushing.
 func getPushSession(
 	_ context.Context,
@@ -38,6 +75,7 @@ func getPushSession(
 	if err != nil {
 		return nil, fmt.Errorf("failed to create endpoint: %w", err)
 	}
+	endpoint.ClientCert, endpoint.ClientKey, endpoint.CaBundle = clientTLS(auth)
 
 	// Get the transport client
 	transportClient, err := client.NewClient(endpoint)
@@ -54,10 +92,10 @@ func getPushSession(
 	return session, nil
 }
 
-// validatePushState checks if the push can proceed based on remote state.
+// validatePushState checks if the push can proceed based on remote state, as refs advertises it.
 func validatePushState(
 	ctx context.Context,
-	session transport.ReceivePackSession,
+	refs *packp.AdvRefs,
 	repo *git.Repository,
 	rootHash plumbing.Hash,
 	rootBranch plumbing.ReferenceName,
@@ -71,12 +109,6 @@ func validatePushState(
 
 	branchName := branch.Short()
 
-	// Phase 1: Get advertised references (remote state)
-	refs, err := session.AdvertisedReferences()
-	if err != nil {
-		return plumbing.ZeroHash, plumbing.ZeroHash, fmt.Errorf("failed to get advertised references: %w", err)
-	}
-
 	// Determine the "old" hash for the push command and validate state
 	var oldHash = plumbing.ZeroHash
 	remoteHash, found := refs.References[string(branch)]
@@ -105,10 +137,12 @@ func validatePushState(
 	return oldHash, localHash, nil
 }
 
-// performPush executes the packfile creation and push operation.
+// performPush executes the packfile creation and push operation. refs is what the session already
+// advertised; its capabilities decide how the remote's messages are requested.
 func performPush(
 	ctx context.Context,
 	session transport.ReceivePackSession,
+	refs *packp.AdvRefs,
 	repo *git.Repository,
 	rootHash, localHash, oldHash plumbing.Hash,
 	branch plumbing.ReferenceName,
@@ -153,6 +187,7 @@ func performPush(
 		return fmt.Errorf("failed to set capability: %w", err)
 	}
 	req.Packfile = packfileData
+	hookOutput := requestRemoteMessages(refs, req)
 
 	// Use oldHash (either remoteHash or ZeroHash) as the expected "old" value
 	// This tells Git what we expect the current state to be
@@ -166,6 +201,11 @@ func performPush(
 	// Send request via session
 	logger.Info("Sending packfile via ReceivePack", "objects", len(objectsToSend))
 	rs, err := session.ReceivePack(ctx, req)
+	if rejected := rejectedPush(rs); rejected != nil {
+		rejected.HookOutput = remoteMessages(hookOutput.String())
+		logger.Error(rejected, "Push rejected by server", "protected", rejected.Protected())
+		return rejected
+	}
 	if err != nil {
 		logger.Error(err, "ReceivePack failed")
 		return fmt.Errorf("failed to receive pack: %w", err)
@@ -211,9 +251,16 @@ func PushAtomic(
 	if err != nil {
 		return err
 	}
-	defer session.Close()
+	defer closePushSession(ctx, session)
 
-	oldHash, localHash, err := validatePushState(ctx, session, repo, rootHash, rootBranch)
+	// Phase 1: Get advertised references (remote state). Over HTTP every call is another request,
+	// so the push reuses them rather than asking again.
+	refs, err := session.AdvertisedReferencesContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get advertised references: %w", err)
+	}
+
+	oldHash, localHash, err := validatePushState(ctx, refs, repo, rootHash, rootBranch)
 	if err != nil {
 		return err
 	}
@@ -227,7 +274,79 @@ func PushAtomic(
 		return fmt.Errorf("failed to get current branch: %w", err)
 	}
 
-	return performPush(ctx, session, repo, rootHash, localHash, oldHash, branch, logger)
+	return performPush(ctx, session, refs, repo, rootHash, localHash, oldHash, branch, logger)
+}
+
+// rejectedPush returns the first ref the remote refused in its report, or nil when the report is
+// missing, the pack itself failed to unpack, or every ref was accepted. go-git returns such a
+// report together with a plain error, which would otherwise hide the remote's reason.
+func rejectedPush(rs *packp.ReportStatus) *PushRejectedError {
+	if rs == nil || rs.UnpackStatus != "ok" {
+		return nil
+	}
+	for _, status := range rs.CommandStatuses {
+		if status.Error() != nil {
+			return &PushRejectedError{Ref: status.ReferenceName, Reason: status.Status}
+		}
+	}
+	return nil
+}
+
+// maxRemoteMessagesLen bounds the remote's messages kept on a PushRejectedError, which ends up in
+// a GitTarget condition message.
+const maxRemoteMessagesLen = 1024
+
+// requestRemoteMessages asks the remote to send its messages on the sideband, as `git push` does,
+// when refs advertises one: that is where a refusing hook's output arrives. The returned buffer
+// collects them.
+func requestRemoteMessages(refs *packp.AdvRefs, req *packp.ReferenceUpdateRequest) *bytes.Buffer {
+	messages := &bytes.Buffer{}
+	switch {
+	case refs.Capabilities.Supports(capability.Sideband64k):
+		_ = req.Capabilities.Set(capability.Sideband64k)
+	case refs.Capabilities.Supports(capability.Sideband):
+		_ = req.Capabilities.Set(capability.Sideband)
+	default:
+		return messages
+	}
+	req.Progress = messages
+	return messages
+}
+
+// remoteMessages turns the remote's sideband output into one line: a line rewritten in place with
+// carriage returns (a progress meter) keeps only its last state, the lines are trimmed and joined,
+// and the result is capped at maxRemoteMessagesLen bytes, cut on a rune boundary so the condition
+// message it ends up in stays valid UTF-8.
+func remoteMessages(raw string) string {
+	var lines []string
+	for _, line := range strings.Split(raw, "\n") {
+		if i := strings.LastIndex(line, "\r"); i >= 0 {
+			line = line[i+1:]
+		}
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	out := strings.Join(lines, "; ")
+	if len(out) > maxRemoteMessagesLen {
+		cut := maxRemoteMessagesLen
+		for cut > 0 && !utf8.RuneStart(out[cut]) {
+			cut--
+		}
+		out = out[:cut] + "..."
+	}
+	return out
+}
+
+// closePushSession closes the receive-pack session. Once ctx is done the remote may have stopped
+// reading, and the flush packet Close sends over SSH could block on it, so the close then runs in
+// the background rather than holding the caller past its deadline.
+func closePushSession(ctx context.Context, session transport.ReceivePackSession) {
+	if ctx.Err() != nil {
+		go func() { _ = session.Close() }()
+		return
+	}
+	_ = session.Close()
 }
 
 // createPackfile creates a packfile containing the specified objects using go-git's encoder.
@@ -4,12 +4,19 @@ package git
 
 import (
 	"context"
+	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
+	"unicode/utf8"
 
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 )
 
 // TestAtomicPush_PushOnEmpty tries to push a new commit on an totally empty repo.
@@ -143,3 +150,138 @@ func TestAtomicPush_DetectsUpdatedRemote(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "remote received unknown updates")
 }
+
+// TestAtomicPush_ReportsRejectedNonFastForward pushes a rewritten history to a bare remote that
+// denies non-fast-forward updates, the plain-git stand-in for a protected branch.
+func TestAtomicPush_ReportsRejectedNonFastForward(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	serverPath := filepath.Join(tempDir, "server")
+	remoteURL := "file://" + serverPath
+
+	createBareRepo(t, serverPath)
+	simulateClientCommitOnDisk(t, remoteURL, "main", "README.md", "This is an initialized remote repo")
+	remoteTip := simulateClientCommitOnDisk(t, remoteURL, "main", "README.md", "Second commit")
+	out, err := exec.Command("git", "-C", serverPath, "config", "receive.denyNonFastForwards", "true").
+		CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	// A fresh history whose root is not the remote tip: the push names the tip as its old value,
+	// so it reaches the remote, which refuses it as a non-fast-forward.
+	emptyPath := filepath.Join(tempDir, "empty")
+	createBareRepo(t, emptyPath)
+	localPath := filepath.Join(tempDir, "local")
+	localRepo, worktree := initLocalRepo(t, localPath, "file://"+emptyPath, "main")
+	commitFileChange(t, worktree, localPath, "README.md", "Rewritten history")
+	require.NoError(t, localRepo.DeleteRemote("origin"))
+	_, err = localRepo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{remoteURL}})
+	require.NoError(t, err)
+
+	err = PushAtomic(ctx, localRepo, remoteTip, plumbing.NewBranchReferenceName("main"), nil)
+
+	var rejected *PushRejectedError
+	require.ErrorAs(t, err, &rejected)
+	assert.Equal(t, plumbing.NewBranchReferenceName("main"), rejected.Ref)
+	assert.Contains(t, rejected.Reason, "non-fast-forward")
+	assert.False(t, rejected.Protected(), "a denied non-fast-forward is not branch protection")
+}
+
+// TestAtomicPush_ReportsRejectingHookOutput pushes to a bare remote whose pre-receive hook refuses
+// every push: the rejection carries what the hook printed, not only the remote's status.
+func TestAtomicPush_ReportsRejectingHookOutput(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	serverPath := filepath.Join(tempDir, "server")
+	remoteURL := "file://" + serverPath
+
+	createBareRepo(t, serverPath)
+	remoteTip := simulateClientCommitOnDisk(t, remoteURL, "main", "README.md", "This is an initialized remote repo")
+	hook := filepath.Join(serverPath, "hooks", "pre-receive")
+	require.NoError(t, os.MkdirAll(filepath.Dir(hook), 0o750))
+	require.NoError(t, os.WriteFile(hook,
+		[]byte("#!/bin/sh\necho 'rejected: commits must be signed' >&2\nexit 1\n"), 0o700)) //nolint:gosec // a hook
+
+	localPath := filepath.Join(tempDir, "local")
+	localRepo, err := git.PlainClone(localPath, false, &git.CloneOptions{URL: remoteURL})
+	require.NoError(t, err)
+	worktree, err := localRepo.Worktree()
+	require.NoError(t, err)
+	commitFileChange(t, worktree, localPath, "README.md", "Unsigned change")
+
+	err = PushAtomic(ctx, localRepo, remoteTip, plumbing.NewBranchReferenceName("main"), nil)
+
+	var rejected *PushRejectedError
+	require.ErrorAs(t, err, &rejected)
+	assert.Contains(t, rejected.Reason, "pre-receive hook declined")
+	assert.Equal(t, "rejected: commits must be signed", rejected.HookOutput)
+	assert.Contains(t, err.Error(), "rejected: commits must be signed")
+}
+
+// A worker remembers, per GitTarget, how the latest push carrying its writes ended, asks the
+// GitTarget controller to re-project an outcome that changed, and forgets it with the GitTarget.
+func TestRememberPushOutcome(t *testing.T) {
+	stateEvents := make(chan event.GenericEvent, 4)
+	w := &BranchWorker{GitProviderRef: "test-provider", stateEvents: stateEvents}
+	writes := []PendingWrite{{Kind: PendingWriteAtomic, GitTargetName: "live", GitTargetNamespace: "default"}}
+
+	_, ok := w.LastPushFor("live", "default")
+	assert.False(t, ok, "nothing is reported before a push")
+
+	w.rememberPushOutcome(writes, &PushRejectedError{
+		Ref: "refs/heads/main", Reason: "pre-receive hook declined", HookOutput: "commits must be signed",
+	})
+	outcome, ok := w.LastPushFor("live", "default")
+	require.True(t, ok)
+	assert.True(t, outcome.Failed())
+	assert.Equal(t, ReasonPushRejected, outcome.Reason, "a declining pre-receive hook is a plain rejection")
+	assert.Contains(t, outcome.LastPushError, "commits must be signed")
+	require.Len(t, stateEvents, 1, "a failed push requeues the GitTarget")
+	<-stateEvents
+
+	w.rememberPushOutcome(writes, &PushRejectedError{
+		Ref: "refs/heads/main", Reason: "pre-receive hook declined", HookOutput: "commits must be signed",
+	})
+	assert.Empty(t, stateEvents, "the same failure again changes nothing to report")
+
+	w.rememberPushOutcome(writes, nil)
+	outcome, ok = w.LastPushFor("live", "default")
+	require.True(t, ok)
+	assert.False(t, outcome.Failed())
+	assert.Empty(t, outcome.LastPushError)
+	assert.Len(t, stateEvents, 1, "the recovery requeues the GitTarget")
+
+	w.forgetTarget("live", "default")
+	_, ok = w.LastPushFor("live", "default")
+	assert.False(t, ok, "a deleted GitTarget's outcome is forgotten")
+}
+
+func TestRemoteMessages(t *testing.T) {
+	assert.Equal(t, "Resolving deltas: 100% (2/2), done.; rejected: commits must be signed",
+		remoteMessages("Resolving deltas:  50% (1/2)\rResolving deltas: 100% (2/2), done.\n"+
+			"rejected: commits must be signed  \n\n"))
+	assert.Empty(t, remoteMessages(""))
+	assert.Len(t, remoteMessages(strings.Repeat("x", 2*maxRemoteMessagesLen)), maxRemoteMessagesLen+len("..."))
+
+	capped := remoteMessages("x" + strings.Repeat("é", maxRemoteMessagesLen))
+	assert.True(t, utf8.ValidString(capped), "the cap never splits a rune")
+	assert.Len(t, capped, maxRemoteMessagesLen-1+len("..."))
+}
+
+func TestPushRejectedError_Protected(t *testing.T) {
+	tests := []struct {
+		reason string
+		want   bool
+	}{
+		{reason: "protected branch hook declined", want: true},
+		{reason: "pre-receive hook declined", want: false},
+		{reason: "denying non-fast-forward refs/heads/main (you should pull first)", want: false},
+		{reason: "GH006: Protected branch update failed for refs/heads/main.", want: true},
+		{reason: "failed to update ref", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.reason, func(t *testing.T) {
+			err := &PushRejectedError{Ref: plumbing.NewBranchReferenceName("main"), Reason: tt.reason}
+			assert.Equal(t, tt.want, err.Protected())
+		})
+	}
+}
@@ -6,9 +6,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
 
@@ -16,6 +18,7 @@ import (
 	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -141,7 +144,7 @@ func TestCheckRepo_PublicConnectivity(t *testing.T) {
 	assert.Positive(t, repoInfo.RemoteBranchCount)
 
 	localPath := filepath.Join(tempDir, "local")
-	pullReport, err := PrepareBranch(context.Background(), remoteURL, localPath, "cool-test", nil)
+	pullReport, err := PrepareBranch(context.Background(), remoteURL, localPath, "cool-test", nil, DefaultFetchDepth)
 	require.NoError(t, err)
 
 	require.False(t, pullReport.ExistsOnRemote)
@@ -184,7 +187,7 @@ func TestCheckRepo_PublicConnectivityEmpty(t *testing.T) {
 	assert.Equal(t, 0, repoInfo.RemoteBranchCount)
 
 	localPath := filepath.Join(tempDir, "local")
-	pullReport, err := PrepareBranch(context.Background(), remoteURL, localPath, "cool-test", nil)
+	pullReport, err := PrepareBranch(context.Background(), remoteURL, localPath, "cool-test", nil, DefaultFetchDepth)
 	require.NoError(t, err)
 	require.NotNil(t, pullReport)
 	assert.False(t, pullReport.ExistsOnRemote)
@@ -257,7 +260,8 @@ func TestPrepareBranch_CheckoutNew(t *testing.T) {
 
 	// Test PrepareBranch
 	localPath := filepath.Join(tempDir, "local")
-	pullReport, err := PrepareBranch(context.Background(), "file://"+remotePath, localPath, "some-branch", nil)
+	pullReport, err := PrepareBranch(
+		context.Background(), "file://"+remotePath, localPath, "some-branch", nil, DefaultFetchDepth)
 	require.NoError(t, err)
 
 	// Verify repository was cloned
@@ -273,6 +277,50 @@ func TestPrepareBranch_CheckoutNew(t *testing.T) {
 	require.Equal(t, 1, countDepth(t, rLocal, hashCreated))
 }
 
+func TestPrepareBranch_FetchDepth(t *testing.T) {
+	tempDir := t.TempDir()
+	remotePath := filepath.Join(tempDir, "remote")
+	createBareRepo(t, remotePath)
+	simulateClientCommitOnDisk(t, "file://"+remotePath, "main", "one.txt", "1")
+	simulateClientCommitOnDisk(t, "file://"+remotePath, "main", "two.txt", "2")
+	tip := simulateClientCommitOnDisk(t, "file://"+remotePath, "main", "three.txt", "3")
+
+	tests := []struct {
+		name  string
+		depth int
+		want  int
+	}{
+		{name: "tip only", depth: DefaultFetchDepth, want: 1},
+		{name: "partial history", depth: 2, want: 2},
+		{name: "full history", depth: 0, want: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			localPath := filepath.Join(t.TempDir(), "local")
+			_, err := PrepareBranch(context.Background(), "file://"+remotePath, localPath, "main", nil, tt.depth)
+			require.NoError(t, err)
+
+			rLocal, err := git.PlainOpen(localPath)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, countDepth(t, rLocal, tip))
+		})
+	}
+
+	_, err := PrepareBranch(context.Background(), "file://"+remotePath, filepath.Join(tempDir, "bad"), "main", nil, -1)
+	require.Error(t, err, "a negative depth is refused")
+
+	// Switching to full history on a clone that was fetched shallow re-clones it.
+	localPath := filepath.Join(tempDir, "deepened")
+	_, err = PrepareBranch(context.Background(), "file://"+remotePath, localPath, "main", nil, DefaultFetchDepth)
+	require.NoError(t, err)
+	_, err = PrepareBranch(context.Background(), "file://"+remotePath, localPath, "main", nil, 0)
+	require.NoError(t, err)
+	rLocal, err := git.PlainOpen(localPath)
+	require.NoError(t, err)
+	assert.Equal(t, 3, countDepth(t, rLocal, tip))
+	assert.False(t, isShallowClone(rLocal))
+}
+
 // countDepth will count the number of commits if you follow the parent commit. This should be one if we 'properly' get our repo.
 func countDepth(t *testing.T, r *git.Repository, start plumbing.Hash) int {
 	//  2. Get the commit iterator for this branch
@@ -307,7 +355,8 @@ func TestPrepareBranch_CheckoutDefault(t *testing.T) {
 
 	// Test PrepareBranch
 	localPath := filepath.Join(tempDir, "local")
-	pullReport, err := PrepareBranch(context.Background(), "file://"+remotePath, localPath, "mymain", nil)
+	pullReport, err := PrepareBranch(
+		context.Background(), "file://"+remotePath, localPath, "mymain", nil, DefaultFetchDepth)
 	require.NoError(t, err)
 	require.True(t, pullReport.ExistsOnRemote)
 	require.Equal(t, "mymain", pullReport.HEAD.ShortName)
@@ -322,6 +371,200 @@ func TestPrepareBranch_CheckoutDefault(t *testing.T) {
 	assert.Equal(t, "mymain", branchName)
 }
 
+func TestPrepareBranch_RepairsInterruptedCommit(t *testing.T) {
+	tempDir := t.TempDir()
+	remotePath := filepath.Join(tempDir, "remote")
+	createBareRepo(t, remotePath)
+	hash := simulateClientCommitOnDisk(t, "file://"+remotePath, "main", "hello.txt", "hello")
+
+	localPath := filepath.Join(tempDir, "local")
+	_, err := PrepareBranch(context.Background(), "file://"+remotePath, localPath, "main", nil, DefaultFetchDepth)
+	require.NoError(t, err)
+
+	// What a worker killed mid-commit can leave behind: a torn index, its lock, and a marker.
+	gitDir := filepath.Join(localPath, ".git")
+	require.NoError(t, os.WriteFile(filepath.Join(gitDir, "index"), []byte("DIRCtorn"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(gitDir, "index.lock"), nil, 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(gitDir, "MERGE_HEAD"), []byte(hash.String()+"\n"), 0o600))
+
+	pullReport, err := PrepareBranch(
+		context.Background(), "file://"+remotePath, localPath, "main", nil, DefaultFetchDepth)
+	require.NoError(t, err)
+	assert.Equal(t, hash.String(), pullReport.HEAD.Sha)
+	assert.NoFileExists(t, filepath.Join(gitDir, "index.lock"))
+	assert.NoFileExists(t, filepath.Join(gitDir, "MERGE_HEAD"))
+
+	repo, err := git.PlainOpen(localPath)
+	require.NoError(t, err)
+	_, err = repo.Storer.Index()
+	require.NoError(t, err, "the hard reset rebuilds the discarded index")
+}
+
+// repairRepository removes git's lock files, which is only safe while the clone path lock rules
+// out a live operation; called without it, it refuses and leaves them alone.
+func TestRepairRepository_RefusesWithoutTheClonePathLock(t *testing.T) {
+	localPath := filepath.Join(t.TempDir(), "local")
+	_, err := git.PlainInit(localPath, false)
+	require.NoError(t, err)
+	indexLock := filepath.Join(localPath, ".git", "index.lock")
+	require.NoError(t, os.WriteFile(indexLock, nil, 0o600))
+
+	require.Error(t, repairRepository(localPath, logr.Discard()))
+	assert.FileExists(t, indexLock, "a lock file is not stale unless the clone path lock is held")
+
+	lock := lockRepoPath(localPath)
+	require.NoError(t, repairRepository(localPath, logr.Discard()))
+	lock.Unlock()
+	assert.NoFileExists(t, indexLock)
+}
+
+// A clone path's lock entry is removed once nobody holds it and no worker has unpushed commits in
+// the clone; unpushed commits keep it until they are pushed or their worker is forgotten.
+func TestLockRepoPath_RegistryForgetsIdlePaths(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "clone")
+	registered := func() bool {
+		repoPathLocks.Lock()
+		defer repoPathLocks.Unlock()
+		_, ok := repoPathLocks.byPath[filepath.Clean(path)]
+		return ok
+	}
+
+	lock := lockRepoPath(path)
+	assert.True(t, registered())
+	lock.Unlock()
+	assert.False(t, registered(), "an idle path is forgotten on its last unlock")
+
+	worker := &BranchWorker{}
+	lock = lockRepoPath(path)
+	lock.markUnpushed(worker, plumbing.NewBranchReferenceName("main"), plumbing.ZeroHash)
+	lock.Unlock()
+	assert.True(t, registered(), "unpushed commits keep the entry")
+
+	forgetUnpushedCommits(worker)
+	assert.False(t, registered(), "forgetting the worker releases the entry")
+}
+
+// A holder's unlock must not drop the entry from under a waiter that goes on to mark its own
+// commits unpushed: the next worker on the path would otherwise reset them away.
+func TestLockRepoPath_RegistryKeepsAWaitersUnpushedCommits(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "clone")
+	refs := func() int {
+		repoPathLocks.Lock()
+		defer repoPathLocks.Unlock()
+		lock, ok := repoPathLocks.byPath[filepath.Clean(path)]
+		if !ok {
+			return 0
+		}
+		return lock.refs
+	}
+
+	first := lockRepoPath(path)
+	worker := &BranchWorker{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		second := lockRepoPath(path)
+		second.markUnpushed(worker, plumbing.NewBranchReferenceName("main"), plumbing.ZeroHash)
+		second.Unlock()
+	}()
+	require.Eventually(t, func() bool { return refs() == 2 }, time.Second, time.Millisecond,
+		"the second holder waits on the lock")
+
+	first.Unlock()
+	<-done
+	assert.Equal(t, 0, refs(), "both holders released their references")
+
+	lock := lockRepoPath(path)
+	_, _, shared := lock.sharedPushCycle(&BranchWorker{})
+	lock.Unlock()
+	assert.True(t, shared, "the waiter's unpushed commits outlive the first holder's unlock")
+
+	forgetUnpushedCommits(worker)
+}
+
+// Only a clone that already holds a fetched reference counts as one: the first fetch into an
+// empty or initialized-but-unfetched directory is bounded by spec.cloneTimeout.
+func TestHasLocalClone(t *testing.T) {
+	tempDir := t.TempDir()
+	remotePath := filepath.Join(tempDir, "remote")
+	createBareRepo(t, remotePath)
+	simulateClientCommitOnDisk(t, "file://"+remotePath, "main", "hello.txt", "hello")
+
+	localPath := filepath.Join(tempDir, "local")
+	assert.False(t, hasLocalClone(localPath), "no directory")
+	_, err := git.PlainInit(localPath, false)
+	require.NoError(t, err)
+	assert.False(t, hasLocalClone(localPath), "an initialized repository without refs")
+
+	_, err = PrepareBranch(context.Background(), "file://"+remotePath, localPath, "main", nil, DefaultFetchDepth)
+	require.NoError(t, err)
+	assert.True(t, hasLocalClone(localPath))
+}
+
+// A clone that fails to open with a transient I/O error (an NFS hiccup) is retried and, when the
+// error persists, left in place: PrepareBranch fails this attempt instead of deleting the clone.
+func TestPrepareBranch_TransientOpenErrorKeepsClone(t *testing.T) {
+	tempDir := t.TempDir()
+	remotePath := filepath.Join(tempDir, "remote")
+	createBareRepo(t, remotePath)
+	simulateClientCommitOnDisk(t, "file://"+remotePath, "main", "hello.txt", "hello")
+
+	localPath := filepath.Join(tempDir, "local")
+	_, err := PrepareBranch(context.Background(), "file://"+remotePath, localPath, "main", nil, DefaultFetchDepth)
+	require.NoError(t, err)
+	marker := filepath.Join(localPath, "hello.txt")
+	require.FileExists(t, marker)
+
+	attempts := 0
+	original := plainOpenFn
+	t.Cleanup(func() { plainOpenFn = original })
+	plainOpenFn = func(path string) (*git.Repository, error) {
+		attempts++
+		return nil, &fs.PathError{Op: "open", Path: filepath.Join(path, ".git", "HEAD"), Err: syscall.EIO}
+	}
+
+	_, err = PrepareBranch(context.Background(), "file://"+remotePath, localPath, "main", nil, DefaultFetchDepth)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, syscall.EIO)
+	assert.Equal(t, openRepoAttempts, attempts)
+	assert.FileExists(t, marker, "a transient open failure must not remove the clone")
+
+	// One failure, then the filesystem recovers: the clone is reused.
+	attempts = 0
+	plainOpenFn = func(path string) (*git.Repository, error) {
+		attempts++
+		if attempts == 1 {
+			return nil, &fs.PathError{Op: "open", Path: path, Err: syscall.ESTALE}
+		}
+		return git.PlainOpen(path)
+	}
+	_, err = PrepareBranch(context.Background(), "file://"+remotePath, localPath, "main", nil, DefaultFetchDepth)
+	require.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+	assert.FileExists(t, marker)
+
+	// A cancelled context ends the retry wait instead of sleeping it out.
+	attempts = 0
+	plainOpenFn = func(path string) (*git.Repository, error) {
+		attempts++
+		return nil, &fs.PathError{Op: "open", Path: path, Err: syscall.EIO}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = PrepareBranch(ctx, "file://"+remotePath, localPath, "main", nil, DefaultFetchDepth)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, attempts, "no attempt follows the cancellation")
+	assert.FileExists(t, marker)
+}
+
+func TestIsTransientRepoError(t *testing.T) {
+	assert.True(t, isTransientRepoError(&fs.PathError{Op: "read", Path: "HEAD", Err: syscall.ESTALE}))
+	assert.True(t, isTransientRepoError(fmt.Errorf("wrapped: %w", syscall.EBUSY)))
+	assert.False(t, isTransientRepoError(git.ErrRepositoryNotExists), "a missing object store is corruption")
+	assert.False(t, isTransientRepoError(plumbing.ErrReferenceNotFound))
+	assert.False(t, isTransientRepoError(&fs.PathError{Op: "open", Path: "HEAD", Err: syscall.ENOENT}))
+}
+
 func TestBranchWorker_FirstCommitOnEmptyRepo(t *testing.T) {
 	tempDir := t.TempDir()
 	serverPath := filepath.Join(tempDir, "server")
@@ -554,6 +797,65 @@ func TestBranchWorker_ConcurrentOperations(t *testing.T) {
 	assert.Equal(t, 1+numWorkers, commits, "Repository should contain all commits from concurrent operations")
 }
 
+// Two workers that resolve to the same local clone (same provider, branch, and remote) write
+// concurrently: the clone's lock serializes their git operations, so the worktree stays intact,
+// and each builds on the other's unpushed commit instead of resetting it away, so both land.
+func TestBranchWorker_ConcurrentWritesToSharedClone(t *testing.T) {
+	tempDir := t.TempDir()
+	remotePath := filepath.Join(tempDir, "remote.git")
+	createBareRepo(t, remotePath)
+	simulateClientCommitOnDisk(t, "file://"+remotePath, "main", "README.md", "init")
+
+	const numWorkers = 2
+	workers := make([]*BranchWorker, numWorkers)
+	for i := range workers {
+		worker, err := newTestBranchWorker("file://"+remotePath, "shared-repo", "main")
+		require.NoError(t, err)
+		workers[i] = worker
+	}
+	require.Equal(t, workers[0].repoPathForRemote("file://"+remotePath),
+		workers[1].repoPathForRemote("file://"+remotePath), "both workers use one clone")
+	t.Cleanup(func() { _ = os.RemoveAll(workers[0].repoPathForRemote("file://" + remotePath)) })
+
+	results := make(chan error, numWorkers)
+	for i, worker := range workers {
+		go func() {
+			event := createTestEvent(t, fmt.Sprintf("pod-shared-%d", i))
+			pendingWrite, err := worker.buildGroupedPendingWrite(worker.ctx, []Event{event})
+			if err != nil {
+				results <- err
+				return
+			}
+			if err := worker.commitPendingWrites([]PendingWrite{*pendingWrite}, false); err != nil {
+				results <- err
+				return
+			}
+			results <- worker.pushPendingCommits([]PendingWrite{*pendingWrite})
+		}()
+	}
+	for range numWorkers {
+		require.NoError(t, <-results)
+	}
+
+	clone, err := git.PlainOpen(workers[0].repoPathForRemote("file://" + remotePath))
+	require.NoError(t, err)
+	_, err = clone.Storer.Index()
+	require.NoError(t, err, "the shared worktree's index is intact")
+
+	remote, err := git.PlainOpen(remotePath)
+	require.NoError(t, err)
+	head, err := remote.Reference(plumbing.NewBranchReferenceName("main"), true)
+	require.NoError(t, err)
+	commit, err := remote.CommitObject(head.Hash())
+	require.NoError(t, err)
+	tree, err := commit.Tree()
+	require.NoError(t, err)
+	for i := range numWorkers {
+		_, err := tree.File(fmt.Sprintf("default/pods/pod-shared-%d.yaml", i))
+		assert.NoErrorf(t, err, "worker %d's commit landed on the remote", i)
+	}
+}
+
 func TestPullBranch_BranchLifecycleDetection(t *testing.T) {
 	tempDir := t.TempDir()
 	remotePath := filepath.Join(tempDir, "server")
@@ -566,7 +868,8 @@ func TestPullBranch_BranchLifecycleDetection(t *testing.T) {
 	hash := simulateClientCommitOnDisk(t, "file://"+remotePath, "main", "my-file.txt", "This is cool!")
 
 	// Clone to local
-	pullReport, err := PrepareBranch(context.Background(), "file://"+remotePath, localPath, "feature", nil)
+	pullReport, err := PrepareBranch(
+		context.Background(), "file://"+remotePath, localPath, "feature", nil, DefaultFetchDepth)
 	require.NoError(t, err)
 	require.True(t, pullReport.IncomingChanges)
 	require.False(t, pullReport.ExistsOnRemote)
@@ -582,7 +885,8 @@ func TestPullBranch_BranchLifecycleDetection(t *testing.T) {
 	assert.Equal(t, hash, head.Hash())
 
 	// We should be able to run this check on a timer
-	pullReport, err = PrepareBranch(context.Background(), "file://"+remotePath, localPath, "feature", nil)
+	pullReport, err = PrepareBranch(
+		context.Background(), "file://"+remotePath, localPath, "feature", nil, DefaultFetchDepth)
 	require.NoError(t, err)
 	assert.False(t, pullReport.IncomingChanges)
 	assert.False(t, pullReport.ExistsOnRemote)
@@ -613,7 +917,8 @@ func TestPullBranch_LocalEditScenario(t *testing.T) {
 
 	// Clone to local and create a feature branch
 	localPath := filepath.Join(tempDir, "local")
-	pullReport, err := PrepareBranch(context.Background(), "file://"+remotePath, localPath, "main", nil)
+	pullReport, err := PrepareBranch(
+		context.Background(), "file://"+remotePath, localPath, "main", nil, DefaultFetchDepth)
 	require.NoError(t, err)
 	require.True(t, pullReport.IncomingChanges)
 
@@ -631,7 +936,8 @@ func TestPullBranch_LocalEditScenario(t *testing.T) {
 	require.NoError(t, err)
 
 	// Test PrepareBranch when branch the local repo contains weird stuff
-	pullReport, err = PrepareBranch(context.Background(), "file://"+remotePath, localPath, "main", nil)
+	pullReport, err = PrepareBranch(
+		context.Background(), "file://"+remotePath, localPath, "main", nil, DefaultFetchDepth)
 	require.NoError(t, err)
 	assert.True(t, pullReport.ExistsOnRemote)
 	assert.Equal(t, "main", pullReport.HEAD.ShortName)
@@ -656,7 +962,7 @@ func TestPullBranch_MergeToDefaultScenario(t *testing.T) {
 	// Simulate client creating initial commit on myuniquedefault
 	simulateClientCommitOnDisk(t, remoteURL, defaultBranchname, "README.md", "Some file")
 
-	pullReport, err := PrepareBranch(context.Background(), remoteURL, localPath, "feature", nil)
+	pullReport, err := PrepareBranch(context.Background(), remoteURL, localPath, "feature", nil, DefaultFetchDepth)
 	require.NoError(t, err)
 	assert.False(t, pullReport.ExistsOnRemote)
 	assert.True(
@@ -664,7 +970,7 @@ func TestPullBranch_MergeToDefaultScenario(t *testing.T) {
 		pullReport.IncomingChanges,
 	) // This is the first time we start on main: so that is certainly new content
 
-	pullReport, err = PrepareBranch(context.Background(), remoteURL, localPath, "feature", nil)
+	pullReport, err = PrepareBranch(context.Background(), remoteURL, localPath, "feature", nil, DefaultFetchDepth)
 	require.NoError(t, err)
 	assert.False(t, pullReport.ExistsOnRemote)
 	assert.False(t, pullReport.IncomingChanges)
@@ -678,14 +984,14 @@ func TestPullBranch_MergeToDefaultScenario(t *testing.T) {
 	require.NoError(t, worker.commitPendingWrites([]PendingWrite{*pendingWrite}, false))
 	require.NoError(t, worker.pushPendingCommits([]PendingWrite{*pendingWrite}))
 
-	pullReport, err = PrepareBranch(context.Background(), remoteURL, localPath, "feature", nil)
+	pullReport, err = PrepareBranch(context.Background(), remoteURL, localPath, "feature", nil, DefaultFetchDepth)
 	require.NoError(t, err)
 	assert.True(t, pullReport.ExistsOnRemote)
 	assert.True(t, pullReport.IncomingChanges)
 
 	mergedHash := simulateSimpleMerge(t, remoteURL, "feature", defaultBranchname)
 
-	pullReport, err = PrepareBranch(context.Background(), remoteURL, localPath, "feature", nil)
+	pullReport, err = PrepareBranch(context.Background(), remoteURL, localPath, "feature", nil, DefaultFetchDepth)
 	require.NoError(t, err)
 	assert.False(t, pullReport.ExistsOnRemote)
 	assert.True(
@@ -703,7 +1009,7 @@ func TestPullBranch_MergeToDefaultScenario(t *testing.T) {
 	require.NoError(t, worker.commitPendingWrites([]PendingWrite{*pendingWrite}, false))
 	require.NoError(t, worker.pushPendingCommits([]PendingWrite{*pendingWrite}))
 
-	pullReport, err = PrepareBranch(context.Background(), remoteURL, localPath, "feature", nil)
+	pullReport, err = PrepareBranch(context.Background(), remoteURL, localPath, "feature", nil, DefaultFetchDepth)
 	require.NoError(t, err)
 	assert.True(t, pullReport.ExistsOnRemote)
 	assert.True(t, pullReport.IncomingChanges)
@@ -747,7 +1053,7 @@ func TestPullBranch_DanglingHead(t *testing.T) {
 	// 5. Run PrepareBranch targeting 'feature'
 	// Expectation: SmartFetch should detect HEAD is broken, log a warning,
 	// but successfully fetch 'feature' because it exists.
-	pullReport, err := PrepareBranch(context.Background(), remoteURL, localPath, "feature", nil)
+	pullReport, err := PrepareBranch(context.Background(), remoteURL, localPath, "feature", nil, DefaultFetchDepth)
 	require.NoError(t, err, "Tool crashed on dangling HEAD")
 
 	assert.True(t, pullReport.ExistsOnRemote)
@@ -766,7 +1072,7 @@ func TestPullBranch_DanglingHead(t *testing.T) {
 
 	// 7. Verify Persistence
 	// Ensure we can sync again without issues
-	pullReport, err = PrepareBranch(context.Background(), remoteURL, localPath, "feature", nil)
+	pullReport, err = PrepareBranch(context.Background(), remoteURL, localPath, "feature", nil, DefaultFetchDepth)
 	require.NoError(t, err)
 	assert.True(t, pullReport.ExistsOnRemote)
 	assert.True(t, pullReport.IncomingChanges, "local checkout should pull the worker-pushed commit")
@@ -804,7 +1110,7 @@ func TestPullBranch_DanglingHead_NewOrphan(t *testing.T) {
 
 	// PrepareBranch logic:
 	// SmartFetch sees HEAD is broken. It sees target is missing and leaves HEAD unborn.
-	pullReport, err := PrepareBranch(context.Background(), remoteURL, localPath, targetBranch, nil)
+	pullReport, err := PrepareBranch(context.Background(), remoteURL, localPath, targetBranch, nil, DefaultFetchDepth)
 	require.NoError(t, err)
 
 	// Verify Report
@@ -850,7 +1156,7 @@ func TestPullBranch_UnexpectedMergeScenario(t *testing.T) {
 	setHeadToMain(serverRepo)
 	simulateClientCommitOnDisk(t, remoteURL, "main", "README.md", "Some file")
 
-	pullReport, err := PrepareBranch(context.Background(), remoteURL, localPath, "feature", nil)
+	pullReport, err := PrepareBranch(context.Background(), remoteURL, localPath, "feature", nil, DefaultFetchDepth)
 	require.NoError(t, err)
 	assert.False(t, pullReport.ExistsOnRemote)
 	assert.True(
@@ -858,7 +1164,7 @@ func TestPullBranch_UnexpectedMergeScenario(t *testing.T) {
 		pullReport.IncomingChanges,
 	) // This is the first time we start on main: so that is certainly new content
 
-	pullReport, err = PrepareBranch(context.Background(), remoteURL, localPath, "feature", nil)
+	pullReport, err = PrepareBranch(context.Background(), remoteURL, localPath, "feature", nil, DefaultFetchDepth)
 	require.NoError(t, err)
 	assert.False(t, pullReport.ExistsOnRemote)
 	assert.False(t, pullReport.IncomingChanges)
@@ -881,7 +1187,7 @@ func TestPullBranch_UnexpectedMergeScenario(t *testing.T) {
 	require.NoError(t, worker.commitPendingWrites([]PendingWrite{*pendingWrite}, false))
 	require.NoError(t, worker.pushPendingCommits([]PendingWrite{*pendingWrite}))
 
-	pullReport, err = PrepareBranch(context.Background(), remoteURL, localPath, "feature", nil)
+	pullReport, err = PrepareBranch(context.Background(), remoteURL, localPath, "feature", nil, DefaultFetchDepth)
 	require.NoError(t, err)
 	assert.True(t, pullReport.ExistsOnRemote)
 	assert.True(t, pullReport.IncomingChanges)
@@ -933,7 +1239,7 @@ spec:
 	require.NoError(t, err)
 	require.NotEqual(t, plumbing.ZeroHash, createdHash)
 
-	_, err = PrepareBranch(context.Background(), remoteURL, localPath, "main", nil)
+	_, err = PrepareBranch(context.Background(), remoteURL, localPath, "main", nil, DefaultFetchDepth)
 	require.NoError(t, err)
 
 	event := Event{
@@ -999,14 +1305,14 @@ func TestPullBranch_WhipedRepo(t *testing.T) {
 	// Simulate client creating initial commit
 	simulateClientCommitOnDisk(t, remoteURL, "main", "README.md", "Some file")
 
-	pullReport, err := PrepareBranch(context.Background(), remoteURL, localPath, "feature", nil)
+	pullReport, err := PrepareBranch(context.Background(), remoteURL, localPath, "feature", nil, DefaultFetchDepth)
 	require.NoError(t, err)
 
 	// This is the first time we start on main: so we do expect IncomingChanges
 	assert.False(t, pullReport.ExistsOnRemote)
 	assert.True(t, pullReport.IncomingChanges)
 
-	pullReport, err = PrepareBranch(context.Background(), remoteURL, localPath, "feature", nil)
+	pullReport, err = PrepareBranch(context.Background(), remoteURL, localPath, "feature", nil, DefaultFetchDepth)
 	require.NoError(t, err)
 	assert.False(t, pullReport.ExistsOnRemote)
 	assert.False(t, pullReport.IncomingChanges)
@@ -1020,13 +1326,13 @@ func TestPullBranch_WhipedRepo(t *testing.T) {
 	require.NoError(t, worker.commitPendingWrites([]PendingWrite{*pendingWrite}, false))
 	require.NoError(t, worker.pushPendingCommits([]PendingWrite{*pendingWrite}))
 
-	pullReport, err = PrepareBranch(context.Background(), remoteURL, localPath, "feature", nil)
+	pullReport, err = PrepareBranch(context.Background(), remoteURL, localPath, "feature", nil, DefaultFetchDepth)
 	require.NoError(t, err)
 	assert.True(t, pullReport.ExistsOnRemote)
 	assert.True(t, pullReport.IncomingChanges)
 
 	// Now execute the same with the empty remote: since no base is available, PrepareBranch keeps target branch unborn.
-	pullReport, err = PrepareBranch(context.Background(), remoteURLEmpty, localPath, "feature", nil)
+	pullReport, err = PrepareBranch(context.Background(), remoteURLEmpty, localPath, "feature", nil, DefaultFetchDepth)
 	require.NoError(t, err)
 	assert.False(t, pullReport.ExistsOnRemote)
 	assert.True(t, pullReport.IncomingChanges)
@@ -1050,6 +1356,26 @@ func TestPullBranch_WhipedRepo(t *testing.T) {
 	assert.True(t, os.IsNotExist(err))
 }
 
+// TestCreatePullReport_WipedBranchIsIncoming pins what TestPullBranch_WhipedRepo relies on: a branch
+// that had content locally and is unborn on the remote reports incoming changes, because losing the
+// content is a change. Only zero-to-zero is no change.
+func TestCreatePullReport_WipedBranchIsIncoming(t *testing.T) {
+	had := plumbing.NewHash("1111111111111111111111111111111111111111")
+
+	wiped := createPullReport("main", had, plumbing.ZeroHash, false, true)
+	assert.True(t, wiped.IncomingChanges)
+	assert.Empty(t, wiped.HEAD.Sha)
+	assert.True(t, wiped.HEAD.Unborn)
+
+	empty := createPullReport("main", plumbing.ZeroHash, plumbing.ZeroHash, false, true)
+	assert.False(t, empty.IncomingChanges)
+	assert.Empty(t, empty.HEAD.Sha)
+
+	same := createPullReport("main", had, had, true, false)
+	assert.False(t, same.IncomingChanges)
+	assert.Equal(t, had.String(), same.HEAD.Sha)
+}
+
 // Benchmark for prepareBranch shallow clone performance.
 func BenchmarkPrepareBranch_ShallowClone(b *testing.B) {
 	// 1. SETUP (Do this once, outside the timer)
@@ -1089,7 +1415,7 @@ func BenchmarkPrepareBranch_ShallowClone(b *testing.B) {
 		clonePath := filepath.Join(tempDir, fmt.Sprintf("worker-%d", i))
 
 		// This is what we are measuring
-		_, err := PrepareBranch(context.Background(), remoteURL, clonePath, "main", nil)
+		_, err := PrepareBranch(context.Background(), remoteURL, clonePath, "main", nil, DefaultFetchDepth)
 
 		if err != nil {
 			b.Fatalf("PrepareBranch failed: %v", err)
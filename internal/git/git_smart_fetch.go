@@ -21,12 +21,18 @@ import (
 // - "refs/heads/feature", nil: Target found on remote, fetched, ready to checkout.
 // - "refs/heads/main", nil:    Target missing on remote, fell back to default branch.
 // - "", nil:                   No valid branches found (empty repo).
+//
+// depth limits the fetched history to that many commits per branch; 0 fetches all of it.
 func SmartFetch(
 	ctx context.Context,
 	repo *git.Repository,
 	target plumbing.ReferenceName, // e.g. "refs/heads/feature" or "HEAD"
 	auth transport.AuthMethod,
+	depth int,
 ) (plumbing.ReferenceName, error) {
+	if depth < 0 {
+		return "", fmt.Errorf("fetch depth must be >= 0, got %d", depth)
+	}
 	remoteName := "origin"
 	remote, err := repo.Remote(remoteName)
 	if err != nil {
@@ -34,7 +40,7 @@ func SmartFetch(
 	}
 
 	// 1. Audit: List refs
-	refs, err := listRemoteRefs(remote, auth)
+	refs, err := listRemoteRefs(ctx, remote, auth)
 	if err != nil {
 		return "", err
 	}
@@ -61,13 +67,17 @@ func SmartFetch(
 
 	// 4. Execute: Fetch
 	if len(refSpecs) > 0 {
-		err = repo.Fetch(&git.FetchOptions{
+		clientCert, clientKey, caBundle := clientTLS(auth)
+		err = repo.FetchContext(ctx, &git.FetchOptions{
 			RemoteName: remoteName,
 			Auth:       auth,
 			RefSpecs:   refSpecs,
-			Depth:      1,
+			Depth:      depth,
 			Force:      true,
 			Prune:      true,
+			ClientCert: clientCert,
+			ClientKey:  clientKey,
+			CABundle:   caBundle,
 		})
 		if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
 			return "", fmt.Errorf("smart fetch failed: %w", err)
@@ -80,8 +90,13 @@ func SmartFetch(
 	return result, nil
 }
 
-func listRemoteRefs(remote *git.Remote, auth transport.AuthMethod) ([]*plumbing.Reference, error) {
-	refs, err := remote.List(&git.ListOptions{Auth: auth})
+func listRemoteRefs(
+	ctx context.Context, remote *git.Remote, auth transport.AuthMethod,
+) ([]*plumbing.Reference, error) {
+	clientCert, clientKey, caBundle := clientTLS(auth)
+	refs, err := remote.ListContext(ctx, &git.ListOptions{
+		Auth: auth, ClientCert: clientCert, ClientKey: clientKey, CABundle: caBundle,
+	})
 	if errors.Is(err, transport.ErrEmptyRemoteRepository) {
 		return nil, nil // Valid state, not an error
 	}
@@ -15,6 +15,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	v1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
+	"github.com/ConfigButler/gitops-reverser/internal/sanitize"
 	"github.com/ConfigButler/gitops-reverser/internal/types"
 	"github.com/ConfigButler/gitops-reverser/internal/typeset"
 )
@@ -194,3 +195,27 @@ func TestPlanFlush_IdenticalUpdateIsNoOp(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, string(seed), string(got), "a no-op must not rewrite the file")
 }
+
+// A new document is rendered in the style its GitTarget declares, and a later update edits that
+// flow-style document in place rather than re-rendering it in block style.
+func TestPlanFlush_FlowStyleNewDocumentIsEditedInPlace(t *testing.T) {
+	writer := newContentWriter(types.SensitiveResourcePolicy{})
+	worktree := newWorktreeForTest(t)
+	flow := sanitize.MarshalOptions{Style: sanitize.StyleFlow}
+
+	created := inplaceCMEvent("blue")
+	created.Operation = "CREATE"
+	created.YAMLOutput = flow
+	require.True(t, applyEventsViaPlanFlush(t, writer, worktree, created))
+
+	full := filepath.Join(worktree.Filesystem.Root(), writer.filePathForIdentifier(created.Identifier))
+	got, err := os.ReadFile(full)
+	require.NoError(t, err)
+	assert.Contains(t, string(got), "data: {color: blue}")
+
+	updated := inplaceCMEvent("green")
+	require.True(t, applyEventsViaPlanFlush(t, writer, worktree, updated))
+	got, err = os.ReadFile(full)
+	require.NoError(t, err)
+	assert.Contains(t, string(got), "data: {color: green}", "the existing flow style is kept")
+}
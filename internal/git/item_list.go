@@ -0,0 +1,336 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"bytes"
+	"context"
+	"path"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	sigsyaml "sigs.k8s.io/yaml"
+
+	"github.com/ConfigButler/gitops-reverser/internal/git/manifestedit"
+	"github.com/ConfigButler/gitops-reverser/internal/manifestanalyzer"
+	"github.com/ConfigButler/gitops-reverser/internal/sanitize"
+	"github.com/ConfigButler/gitops-reverser/internal/telemetry"
+	"github.com/ConfigButler/gitops-reverser/internal/types"
+)
+
+// An item List is the one `apiVersion: v1, kind: List` document a WatchRule's aggregateByKind
+// keeps per type and namespace, at types.ResourceIdentifier.ToListGitPath. The manifest model
+// does not index its items (manifestedit reports the document as ReasonItemList), so the writer
+// finds and edits them here: an object with no document of its own is looked up in its List
+// before it is placed, and placed in it when the event asks for aggregation. Items are sorted
+// by name and rendered like a new document, so the file is rebuilt from its items on every
+// change; the file is removed with its last item.
+
+// itemListHeader opens every item List the writer renders.
+const itemListHeader = "apiVersion: v1\nkind: List\nitems:\n"
+
+// itemListPaths are the paths, relative to the render anchor, an item List for id may live at:
+// the YAML and the JSON spelling, the one the event's output format writes first.
+func (wb *writeBatch) itemListPaths(event Event) []string {
+	exts := []string{".yaml", jsonFileExtension}
+	if newFileExtension(event.OutputFormat) == jsonFileExtension {
+		exts[0], exts[1] = exts[1], exts[0]
+	}
+	out := make([]string, 0, len(exts))
+	for _, ext := range exts {
+		out = append(out, path.Join(wb.writeSubdir, event.Identifier.ToListGitPath(ext)))
+	}
+	return out
+}
+
+// existingItemList returns the first item List for the event's type and namespace that exists
+// in this batch, decoded. A file at a List path that is not an item List is not one: ok is false
+// and the resource is placed as a document of its own.
+func (wb *writeBatch) existingItemList(event Event) (string, []unstructured.Unstructured, bool) {
+	for _, rel := range wb.itemListPaths(event) {
+		if _, tracked := wb.buffers[rel]; !tracked && wb.contentByPath[rel] == nil {
+			continue
+		}
+		buf := wb.buffer(rel)
+		if buf.current == nil {
+			continue
+		}
+		items, ok := decodeItemList(buf.current)
+		return rel, items, ok
+	}
+	return "", nil, false
+}
+
+// upsertListItem writes a resource that has no document of its own into its item List: in
+// place when the List already holds it, appended when the event asks for aggregation. handled
+// is false when neither applies (or the type is sensitive, which is never aggregated), and the
+// caller places the resource as a document of its own.
+func (wb *writeBatch) upsertListItem(ctx context.Context, event Event) (upsertOutcome, bool, error) {
+	if event.Object == nil || wb.writer.isSensitiveIdentifier(event.Identifier) {
+		return upsertNoChange, false, nil
+	}
+	rel, items, ok := wb.existingItemList(event)
+	if rel != "" && !ok {
+		return upsertNoChange, false, nil
+	}
+	idx := itemIndex(items, event.Object.GetName())
+	if idx < 0 && !event.AggregateByKind {
+		return upsertNoChange, false, nil
+	}
+	if rel == "" {
+		rel = wb.itemListPaths(event)[0]
+	}
+
+	outcome := upsertCreated
+	if idx >= 0 {
+		if itemsEqual(&items[idx], event.Object) {
+			return upsertNoChange, true, nil
+		}
+		items[idx] = *event.Object.DeepCopy()
+		outcome = upsertUpdated
+	} else {
+		items = append(items, *event.Object.DeepCopy())
+	}
+	content, err := renderItemList(items, event.YAMLOutput)
+	if err != nil {
+		return upsertNoChange, true, err
+	}
+	wb.buffer(rel).current = content
+	wb.intendListWrite(rel, intentFor(event.Object, rel, false))
+	log.FromContext(ctx).V(1).Info("Wrote item List entry",
+		"resource", event.Identifier.String(), "file", rel)
+	return outcome, true, nil
+}
+
+// removeListItem removes a deleted resource from its item List, removing the file with its
+// last item. It reports whether the List held the resource.
+func (wb *writeBatch) removeListItem(ctx context.Context, event Event) bool {
+	rel, items, ok := wb.existingItemList(event)
+	if !ok {
+		return false
+	}
+	idx := itemIndex(items, event.Identifier.Name)
+	if idx < 0 {
+		return false
+	}
+	removed := items[idx]
+	items = append(items[:idx], items[idx+1:]...)
+	buf := wb.buffer(rel)
+	if len(items) == 0 {
+		buf.current = nil
+		wb.dropKustomizationResource(ctx, event, rel)
+	} else {
+		content, err := renderItemList(items, event.YAMLOutput)
+		if err != nil {
+			log.FromContext(ctx).Info("Skipping item List delete: the remaining items do not render",
+				"resource", event.Identifier.String(), "file", rel, "error", err.Error())
+			return false
+		}
+		buf.current = content
+	}
+	wb.intendListWrite(rel, manifestanalyzer.WriteIntent{
+		SourcePath: rel, Kind: removed.GetKind(), Name: removed.GetName(), Removed: true,
+	})
+	return true
+}
+
+// holdsListItem reports whether the event's resource is an item of its type's item List.
+func (wb *writeBatch) holdsListItem(event Event) bool {
+	_, items, ok := wb.existingItemList(event)
+	return ok && itemIndex(items, event.Identifier.Name) >= 0
+}
+
+// recordItemListFieldPatchSkip counts a field patch not applied to an item List entry.
+func recordItemListFieldPatchSkip(ctx context.Context, event Event) {
+	if telemetry.ItemListFieldPatchSkipsTotal == nil {
+		return
+	}
+	telemetry.ItemListFieldPatchSkipsTotal.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("group", event.Identifier.Group),
+		attribute.String("version", event.Identifier.Version),
+		attribute.String("resource", event.Identifier.Resource),
+	))
+}
+
+// intendListWrite declares an item List write to the render oracle when a kustomization lists
+// the file, as any other edit inside a render root is.
+func (wb *writeBatch) intendListWrite(rel string, in manifestanalyzer.WriteIntent) {
+	if len(wb.kustomizationsListing(rel)) > 0 {
+		wb.putToKustomize = true
+	}
+	wb.intend(in)
+}
+
+// itemListSweepScopes are the scopes whose item Lists a resync sweeps: the per-type resync's own
+// scope, or, for a whole-GitTarget resync, one cluster-wide scope per type the desired set
+// aggregates. A type with no desired object left names no version to build its identities from,
+// so its Lists are swept by the next per-type resync instead.
+func itemListSweepScopes(desired []manifestanalyzer.DesiredResource, scope *ResyncScope) []*ResyncScope {
+	if scope != nil {
+		return []*ResyncScope{scope}
+	}
+	seen := map[schema.GroupResource]bool{}
+	var out []*ResyncScope
+	for _, dr := range desired {
+		gr := schema.GroupResource{Group: dr.Resource.Group, Resource: dr.Resource.Resource}
+		if !dr.AggregateByKind || seen[gr] {
+			continue
+		}
+		seen[gr] = true
+		out = append(out, &ResyncScope{GVR: schema.GroupVersionResource{
+			Group: dr.Resource.Group, Version: dr.Resource.Version, Resource: dr.Resource.Resource,
+		}})
+	}
+	return out
+}
+
+// sweepItemLists removes, from the item Lists in a resync scope, every item its desired set no
+// longer holds, as the mark-and-sweep removes a document of its own. The caller runs it only when
+// spec.prune.mode sweeps orphans. It returns the number of items removed.
+func (wb *writeBatch) sweepItemLists(
+	ctx context.Context,
+	desired []manifestanalyzer.DesiredResource,
+	scope *ResyncScope,
+	target ResolvedTargetMetadata,
+) int {
+	wanted := map[string]bool{}
+	for _, dr := range desired {
+		if scope.Matches(dr.Resource) {
+			wanted[dr.Resource.Namespace+"/"+dr.Resource.Name] = true
+		}
+	}
+	removed := 0
+	for _, rel := range wb.itemListsInScope(scope) {
+		items, ok := decodeItemList(wb.buffer(rel).current)
+		if !ok {
+			continue
+		}
+		namespace := itemListNamespace(wb.writeSubdir, rel)
+		for _, item := range items {
+			if wanted[namespace+"/"+item.GetName()] {
+				continue
+			}
+			event := Event{
+				Identifier: types.NewResourceIdentifier(
+					scope.GVR.Group, scope.GVR.Version, scope.GVR.Resource, namespace, item.GetName()),
+				Operation:    "DELETE",
+				YAMLOutput:   target.YAMLOutput,
+				OutputFormat: target.OutputFormat,
+			}
+			if wb.removeListItem(ctx, event) {
+				removed++
+				recordResyncSweepDelete(ctx, event.Identifier)
+			}
+		}
+	}
+	return removed
+}
+
+// itemListsInScope lists the existing item List paths a per-type resync scope covers: the one
+// List of a namespaced scope, or every namespace's List of a cluster-wide one.
+func (wb *writeBatch) itemListsInScope(scope *ResyncScope) []string {
+	probe := types.NewResourceIdentifier(scope.GVR.Group, scope.GVR.Version, scope.GVR.Resource, scope.Namespace, "x")
+	var out []string
+	for _, ext := range []string{".yaml", jsonFileExtension} {
+		want := path.Join(wb.writeSubdir, probe.ToListGitPath(ext))
+		if scope.Namespace != "" {
+			if wb.contentByPath[want] != nil {
+				out = append(out, want)
+			}
+			continue
+		}
+		// A cluster-wide scope: the same path under any namespace (or _cluster) segment.
+		suffix := strings.TrimPrefix(want, path.Join(wb.writeSubdir, "_cluster"))
+		for rel := range wb.contentByPath {
+			head, ok := strings.CutSuffix(rel, suffix)
+			if ok && path.Dir(head) == path.Clean(wb.writeSubdir) && head != path.Clean(wb.writeSubdir) {
+				out = append(out, rel)
+			}
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// itemListNamespace is the namespace an item List path names: its first segment under the write
+// scope, or "" for the _cluster segment of a cluster-scoped type.
+func itemListNamespace(writeSubdir, rel string) string {
+	segment, _, _ := strings.Cut(relUnder(writeSubdir, rel), "/")
+	if segment == "_cluster" {
+		return ""
+	}
+	return segment
+}
+
+// decodeItemList decodes an item List's items. ok is false for anything else, including a List
+// that shares its file with another document.
+func decodeItemList(content []byte) ([]unstructured.Unstructured, bool) {
+	if manifestedit.DocumentCount(content) != 1 {
+		return nil, false
+	}
+	raw, err := sigsyaml.YAMLToJSON(content)
+	if err != nil {
+		return nil, false
+	}
+	list := &unstructured.UnstructuredList{}
+	if err := list.UnmarshalJSON(raw); err != nil {
+		return nil, false
+	}
+	if list.GetAPIVersion() != "v1" || list.GetKind() != "List" {
+		return nil, false
+	}
+	return list.Items, true
+}
+
+// renderItemList renders items, sorted by name, as an item List: each item is the same ordered
+// YAML a document of its own would be, indented as a sequence entry.
+func renderItemList(items []unstructured.Unstructured, opts sanitize.MarshalOptions) ([]byte, error) {
+	sort.SliceStable(items, func(i, j int) bool { return items[i].GetName() < items[j].GetName() })
+	var buf bytes.Buffer
+	buf.WriteString(itemListHeader)
+	for i := range items {
+		doc, err := sanitize.MarshalToOrderedYAMLWithOptions(&items[i], opts)
+		if err != nil {
+			return nil, err
+		}
+		for n, line := range strings.SplitAfter(strings.TrimSuffix(string(doc), "\n"), "\n") {
+			if n == 0 {
+				buf.WriteString("- ")
+			} else {
+				buf.WriteString("  ")
+			}
+			buf.WriteString(line)
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// itemIndex is the position of the item named name, or -1.
+func itemIndex(items []unstructured.Unstructured, name string) int {
+	for i := range items {
+		if items[i].GetName() == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// itemsEqual reports whether an item already holds the desired object, compared the way a
+// document of its own is, so a List in either file format short-circuits an unchanged update.
+func itemsEqual(existing, desired *unstructured.Unstructured) bool {
+	a, err := sanitize.MarshalToOrderedYAML(existing)
+	if err != nil {
+		return false
+	}
+	b, err := sanitize.MarshalToOrderedYAML(desired)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(a, b) || manifestsAreSemanticallyEqual(a, b)
+}
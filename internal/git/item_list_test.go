@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	v1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
+	"github.com/ConfigButler/gitops-reverser/internal/git/manifestedit"
+	"github.com/ConfigButler/gitops-reverser/internal/manifestanalyzer"
+	"github.com/ConfigButler/gitops-reverser/internal/types"
+)
+
+// aggregatedCMEvent is cmEvent for a WatchRule with aggregateByKind.
+func aggregatedCMEvent(op, name, color string) Event {
+	event := cmEvent(op, name, color)
+	event.AggregateByKind = true
+	return event
+}
+
+// Two new ConfigMaps under aggregateByKind land as the name-sorted items of one List document
+// at {namespace}/configmaps.yaml, and no file of their own is written.
+func TestItemList_AddsItemsToOneListFile(t *testing.T) {
+	writer := newContentWriter(types.SensitiveResourcePolicy{})
+	worktree := newWorktreeForTest(t)
+	root := worktree.Filesystem.Root()
+
+	require.True(t, applyEventsViaPlanFlush(t, writer, worktree, aggregatedCMEvent("CREATE", "beta", "blue")))
+	require.True(t, applyEventsViaPlanFlush(t, writer, worktree, aggregatedCMEvent("CREATE", "alpha", "green")))
+
+	got, err := os.ReadFile(filepath.Join(root, "default", "configmaps.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, `apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: alpha
+    namespace: default
+  data:
+    color: green
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: beta
+    namespace: default
+  data:
+    color: blue
+`, string(got))
+	_, statErr := os.Stat(filepath.Join(root, "default", "configmaps"))
+	assert.True(t, os.IsNotExist(statErr), "no file of its own is written for an aggregated object")
+}
+
+// An update edits the object's item in place and leaves its siblings alone; an update that
+// matches the item is a no-op. The item is found even without aggregateByKind on the event,
+// since an object already in Git is edited where it is.
+func TestItemList_UpdatesItemInPlace(t *testing.T) {
+	writer := newContentWriter(types.SensitiveResourcePolicy{})
+	worktree := newWorktreeForTest(t)
+	full := filepath.Join(worktree.Filesystem.Root(), "default", "configmaps.yaml")
+
+	require.True(t, applyEventsViaPlanFlush(t, writer, worktree,
+		aggregatedCMEvent("CREATE", "alpha", "green"), aggregatedCMEvent("CREATE", "beta", "blue")))
+
+	assert.False(t, applyEventsViaPlanFlush(t, writer, worktree, aggregatedCMEvent("UPDATE", "alpha", "green")),
+		"an item that already holds the desired content is left alone")
+
+	require.True(t, applyEventsViaPlanFlush(t, writer, worktree, cmEvent("UPDATE", "alpha", "red")))
+	got, err := os.ReadFile(full)
+	require.NoError(t, err)
+	assert.Contains(t, string(got), "    name: alpha\n    namespace: default\n  data:\n    color: red\n")
+	assert.Contains(t, string(got), "    name: beta\n    namespace: default\n  data:\n    color: blue\n")
+	_, statErr := os.Stat(filepath.Join(worktree.Filesystem.Root(), "default", "configmaps", "alpha.yaml"))
+	assert.True(t, os.IsNotExist(statErr), "the update does not spawn a file of its own")
+}
+
+// A delete removes the object's item; removing the last item deletes the file.
+func TestItemList_RemovingLastItemDeletesFile(t *testing.T) {
+	writer := newContentWriter(types.SensitiveResourcePolicy{})
+	worktree := newWorktreeForTest(t)
+	full := filepath.Join(worktree.Filesystem.Root(), "default", "configmaps.yaml")
+
+	require.True(t, applyEventsViaPlanFlush(t, writer, worktree,
+		aggregatedCMEvent("CREATE", "alpha", "green"), aggregatedCMEvent("CREATE", "beta", "blue")))
+
+	require.True(t, applyEventsViaPlanFlush(t, writer, worktree, deleteEventFor("alpha")))
+	got, err := os.ReadFile(full)
+	require.NoError(t, err)
+	assert.NotContains(t, string(got), "alpha")
+	assert.Contains(t, string(got), "name: beta")
+
+	require.True(t, applyEventsViaPlanFlush(t, writer, worktree, deleteEventFor("beta")))
+	_, statErr := os.Stat(full)
+	assert.True(t, os.IsNotExist(statErr), "the file is deleted with its last item")
+}
+
+// A per-type resync under prune mode Always sweeps the items its snapshot no longer holds.
+func TestItemList_ScopedResyncSweepsStaleItems(t *testing.T) {
+	writer := newContentWriter(types.SensitiveResourcePolicy{})
+	worktree := newWorktreeForTest(t)
+	require.True(t, applyEventsViaPlanFlush(t, writer, worktree,
+		aggregatedCMEvent("CREATE", "alpha", "green"), aggregatedCMEvent("CREATE", "beta", "blue")))
+
+	w := &BranchWorker{contentWriter: writer, mapper: configMapMapper()}
+	scope := &ResyncScope{GVR: schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}}
+	stats, changed, err := w.applyResyncToWorktree(context.Background(), worktree, "",
+		ResolvedTargetMetadata{PruneMode: v1alpha3.PruneAlways}, nil, scope)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, 2, stats.Deleted)
+	_, statErr := os.Stat(filepath.Join(worktree.Filesystem.Root(), "default", "configmaps.yaml"))
+	assert.True(t, os.IsNotExist(statErr), "sweeping every item deletes the file")
+}
+
+// A whole-GitTarget resync under prune mode Always sweeps the stale items of every type its
+// desired set aggregates, keeping the items it still holds.
+func TestItemList_FullResyncSweepsStaleItems(t *testing.T) {
+	writer := newContentWriter(types.SensitiveResourcePolicy{})
+	worktree := newWorktreeForTest(t)
+	require.True(t, applyEventsViaPlanFlush(t, writer, worktree,
+		aggregatedCMEvent("CREATE", "alpha", "green"), aggregatedCMEvent("CREATE", "beta", "blue")))
+
+	keep := desiredCM("alpha", "green")
+	keep.AggregateByKind = true
+	w := &BranchWorker{contentWriter: writer, mapper: configMapMapper()}
+	stats, changed, err := w.applyResyncToWorktree(context.Background(), worktree, "",
+		ResolvedTargetMetadata{PruneMode: v1alpha3.PruneAlways}, []manifestanalyzer.DesiredResource{keep}, nil)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, 1, stats.Deleted)
+	got, err := os.ReadFile(filepath.Join(worktree.Filesystem.Root(), "default", "configmaps.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(got), "name: alpha")
+	assert.NotContains(t, string(got), "name: beta")
+}
+
+// A field patch whose parent is an item List entry is skipped, not dropped as missing: the
+// List is left untouched.
+func TestItemList_FieldPatchSkipsListItem(t *testing.T) {
+	writer := newContentWriter(types.SensitiveResourcePolicy{})
+	worktree := newWorktreeForTest(t)
+	require.True(t, applyEventsViaPlanFlush(t, writer, worktree, aggregatedCMEvent("CREATE", "alpha", "green")))
+
+	patch := Event{
+		FieldPatch: &FieldPatch{
+			Assignments: []manifestedit.FieldAssignment{{Path: []string{"data", "color"}, Value: "red"}},
+			Source:      "configmaps/status",
+		},
+		Identifier: types.NewResourceIdentifier("", "v1", "configmaps", "default", "alpha"),
+		Operation:  "UPDATE",
+	}
+	scan, err := scanWorktreeSubtree(worktree.Filesystem.Root(), "")
+	require.NoError(t, err)
+	batch := newWriteBatch(context.Background(), writer, configMapMapper(), scan, nil, "")
+	require.True(t, batch.holdsListItem(patch), "the parent is found as an item of its List")
+
+	w := &BranchWorker{contentWriter: writer, mapper: configMapMapper()}
+	changed, err := w.flushEventsToWorktree(context.Background(), worktree, "", []Event{patch}, nil, v1alpha3.PruneOnEvent)
+	require.NoError(t, err)
+	assert.False(t, changed, "the List item is not patched")
+}
@@ -160,7 +160,7 @@ func TestPlanFlush_DeleteInsideARenderRootIsVerified(t *testing.T) {
 	root := worktree.Filesystem.Root()
 	seedDeleteWorktree(t, worktree, deleteKustomizationYAML)
 
-	scan, err := scanWorktreeSubtree(root)
+	scan, err := scanWorktreeSubtree(root, "")
 	require.NoError(t, err)
 	batch := newWriteBatch(context.Background(), writer, configMapMapper(), scan, nil, "")
 	batch.applyDelete(context.Background(), deleteConfigMapEvent("delete-me"))
@@ -57,6 +57,10 @@ func indexOneFile(f FileContent) ([]DocumentRecord, []Diagnostic) {
 
 		id, ok := identityFromNode(root)
 		if !ok {
+			if isItemList(root) {
+				diags = append(diags, diagR(DiagInfo, ReasonItemList, loc, "a List of items, edited as a List"))
+				continue
+			}
 			diags = append(diags, diagR(DiagInfo, ReasonNotKRM, loc, "not a Kubernetes manifest, ignored"))
 			continue
 		}
@@ -163,6 +167,17 @@ func identityFromNode(root *yaml.Node) (Identity, bool) {
 	return id, ok
 }
 
+// isItemList reports whether a document is a v1 `kind: List` with an items sequence, the shape
+// a WatchRule's aggregateByKind writes.
+func isItemList(root *yaml.Node) bool {
+	if root == nil || root.Kind != yaml.MappingNode {
+		return false
+	}
+	items := nodeMapGet(root, "items")
+	return scalarOf(nodeMapGet(root, "apiVersion")) == "v1" && scalarOf(nodeMapGet(root, "kind")) == "List" &&
+		items != nil && items.Kind == yaml.SequenceNode
+}
+
 // hasDisallowed reports the first disallowed construct (anchor, alias, merge
 // key, duplicate key, unusual tag) found in a node tree, walking without
 // materializing aliases so an alias bomb cannot blow up here.
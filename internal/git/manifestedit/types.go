@@ -63,6 +63,10 @@ const (
 	// ReasonDuplicateIdentity marks a document whose manifest identity duplicates
 	// an earlier occurrence.
 	ReasonDuplicateIdentity DiagReason = "duplicate-identity"
+	// ReasonItemList marks a `kind: List` document: a WatchRule's aggregateByKind keeps the
+	// objects of one type and namespace as its items. The items are not indexed as documents;
+	// the writer edits them in the List.
+	ReasonItemList DiagReason = "item-list"
 )
 
 // Diagnostic explains an inventory or edit decision.
@@ -0,0 +1,198 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	corev1 "k8s.io/api/core/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	configv1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
+	"github.com/ConfigButler/gitops-reverser/internal/telemetry"
+)
+
+// ReasonMirrorPushFailed is the Event reason recorded on a GitTarget when a push carrying its
+// writes reached the primary remote but not one of the GitProvider's spec.mirrors.
+const ReasonMirrorPushFailed = "MirrorPushFailed"
+
+// mirrorRemoteName names the in-memory remote a mirror push goes through. It is never written to
+// the clone's config.
+const mirrorRemoteName = "mirror"
+
+// PushMirror updates branch on the remote at mirrorURL to commit, the tip the primary push published.
+// It names the commit rather than the local branch, so an operation that moves the branch after the
+// primary push, and before the mirror push, is not sent to the mirror first. Without force the
+// update must fast-forward the mirror's branch, so a mirror holding commits the primary does not is
+// refused rather than overwritten; with force the mirror follows the primary whatever it holds. A
+// mirror that is already current is not an error. The objects sent stop at what the mirror
+// advertises, so a mirror that shares no history with a shallow clone cannot be brought up to date
+// from it.
+//
+// The push goes through an in-memory remote with no fetch refspecs, so it never updates the
+// clone's refs/remotes/origin tracking refs: those follow the primary remote only.
+func PushMirror(
+	ctx context.Context,
+	repo *git.Repository,
+	mirrorURL string,
+	branch plumbing.ReferenceName,
+	commit plumbing.Hash,
+	auth transport.AuthMethod,
+	force bool,
+) error {
+	refSpec := commit.String() + ":" + branch.String()
+	if force {
+		refSpec = "+" + refSpec
+	}
+	clientCert, clientKey, caBundle := clientTLS(auth)
+	remote := git.NewRemote(repo.Storer, &config.RemoteConfig{Name: mirrorRemoteName, URLs: []string{mirrorURL}})
+	err := remote.PushContext(ctx, &git.PushOptions{
+		RemoteName: mirrorRemoteName,
+		RefSpecs:   []config.RefSpec{config.RefSpec(refSpec)},
+		Auth:       auth,
+		ClientCert: clientCert,
+		ClientKey:  clientKey,
+		CABundle:   caBundle,
+	})
+	if errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return nil
+	}
+	return err
+}
+
+// MirrorOutcome is how the latest successful push carrying a GitTarget's writes reached the
+// GitProvider's spec.mirrors.
+type MirrorOutcome struct {
+	// Mirrors is how many mirrors the push was repeated to; 0 when the GitProvider lists none.
+	Mirrors int
+	// Failures describes each mirror the push did not reach, as "<mirror>: <error>".
+	Failures []string
+}
+
+// pushMirrors pushes commit, the tip the primary push published, to each of the provider's
+// spec.mirrors once the primary push has succeeded. It runs under the clone's lock, which keeps a
+// re-clone from removing the objects it sends; each push is bounded by the mirror's
+// spec.pushTimeout. A mirror failure never fails the write: it is logged, counted in
+// gitopsreverser_mirror_push_failures_total, recorded as a MirrorPushFailed Event on the GitTargets
+// the push carried, and remembered for their MirrorsPushed condition (LastMirrorPushFor). The next
+// push retries every mirror.
+func (w *BranchWorker) pushMirrors(
+	provider *configv1alpha3.GitProvider,
+	repo *git.Repository,
+	commit plumbing.Hash,
+	pendingWrites []PendingWrite,
+) {
+	outcome := MirrorOutcome{Mirrors: len(provider.Spec.Mirrors)}
+	for _, ref := range provider.Spec.Mirrors {
+		err := w.pushMirror(repo, commit, ref)
+		if err == nil {
+			w.Log.V(1).Info("Mirror push completed", "mirror", ref.Name)
+			continue
+		}
+		w.Log.Error(err, "Mirror push failed; the primary push stands", "mirror", ref.Name)
+		w.recordMirrorPushFailure(ref.Name, pendingWrites, err)
+		outcome.Failures = append(outcome.Failures, fmt.Sprintf("%s: %v", ref.Name, err))
+	}
+	w.rememberMirrorOutcome(pendingWrites, outcome)
+}
+
+// pushMirror pushes the branch to the mirror GitProvider ref names in the worker's namespace, using
+// that provider's url, credentials, and spec.pushTimeout. A branch the mirror's allowedBranches do
+// not admit is refused before anything is sent, as it would be for a GitTarget.
+func (w *BranchWorker) pushMirror(
+	repo *git.Repository,
+	commit plumbing.Hash,
+	ref configv1alpha3.GitProviderMirror,
+) error {
+	if ref.Name == w.GitProviderRef {
+		return errors.New("a GitProvider cannot mirror itself")
+	}
+	mirror := &configv1alpha3.GitProvider{}
+	nn := k8stypes.NamespacedName{Name: ref.Name, Namespace: w.GitProviderNamespace}
+	if err := w.Client.Get(w.ctx, nn, mirror); err != nil {
+		return fmt.Errorf("get mirror GitProvider: %w", err)
+	}
+	if !branchAllowed(mirror.Spec.AllowedBranches, w.Branch) {
+		return fmt.Errorf("branch %q does not match any pattern in the mirror's allowedBranches %v",
+			w.Branch, mirror.Spec.AllowedBranches)
+	}
+	auth, err := getAuthFromSecret(w.ctx, w.Client, mirror, w.sshHostKeys)
+	if err != nil {
+		return fmt.Errorf("resolve mirror auth: %w", err)
+	}
+	return withGitTimeout(w.ctx, mirror.Spec.EffectivePushTimeout(), gitOperationPush,
+		func(ctx context.Context) error {
+			return PushMirror(ctx, repo, mirror.Spec.URL, plumbing.NewBranchReferenceName(w.Branch), commit, auth,
+				ref.Force)
+		})
+}
+
+// branchAllowed reports whether branch matches one of the allowedBranches glob patterns.
+func branchAllowed(allowedBranches []string, branch string) bool {
+	for _, pattern := range allowedBranches {
+		if match, err := filepath.Match(pattern, branch); err == nil && match {
+			return true
+		}
+	}
+	return false
+}
+
+// rememberMirrorOutcome records how a successful push reached the mirrors for each GitTarget it
+// carried, and asks the GitTarget controller to re-project an outcome that changed.
+func (w *BranchWorker) rememberMirrorOutcome(pendingWrites []PendingWrite, outcome MirrorOutcome) {
+	changed := false
+	w.metaMu.Lock()
+	if w.mirrorOutcomes == nil {
+		w.mirrorOutcomes = make(map[pendingTargetKey]MirrorOutcome)
+	}
+	for _, pushed := range pushedTargetEvents(pendingWrites) {
+		previous, ok := w.mirrorOutcomes[pushed.key]
+		if !ok || previous.Mirrors != outcome.Mirrors || !slices.Equal(previous.Failures, outcome.Failures) {
+			changed = true
+		}
+		w.mirrorOutcomes[pushed.key] = outcome
+	}
+	w.metaMu.Unlock()
+	if changed {
+		w.notifyStateChange()
+	}
+}
+
+// LastMirrorPushFor returns how the latest successful push carrying one GitTarget's writes reached
+// the mirrors. ok is false until such a push has carried them.
+func (w *BranchWorker) LastMirrorPushFor(name, namespace string) (MirrorOutcome, bool) {
+	w.metaMu.RLock()
+	defer w.metaMu.RUnlock()
+	outcome, ok := w.mirrorOutcomes[pendingTargetKey{Name: name, Namespace: namespace}]
+	return outcome, ok
+}
+
+// recordMirrorPushFailure counts a failed mirror push and records a Warning MirrorPushFailed Event
+// on each GitTarget the push carried.
+func (w *BranchWorker) recordMirrorPushFailure(mirrorName string, pendingWrites []PendingWrite, err error) {
+	if telemetry.MirrorPushFailuresTotal != nil {
+		telemetry.MirrorPushFailuresTotal.Add(context.WithoutCancel(w.ctx), 1, metric.WithAttributes(
+			attribute.String("provider_namespace", w.GitProviderNamespace),
+			attribute.String("provider_name", w.GitProviderRef),
+			attribute.String("branch", w.Branch),
+			attribute.String("mirror", mirrorName),
+		))
+	}
+	if w.recorder == nil {
+		return
+	}
+	for _, pushed := range pushedTargetEvents(pendingWrites) {
+		w.recordTargetEvent(pushed.key, corev1.EventTypeWarning, ReasonMirrorPushFailed, "Push",
+			"Pushed to the primary remote but not to mirror %s: %v", mirrorName, err)
+	}
+}
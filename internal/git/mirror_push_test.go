@@ -0,0 +1,218 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	configv1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
+	"github.com/ConfigButler/gitops-reverser/internal/telemetry"
+)
+
+const mirrorPushFailuresMetric = "gitopsreverser_mirror_push_failures_total"
+
+// seedMirror creates a bare mirror of the primary at remotePath, the way a DR mirror starts out.
+// A declining mirror gets a pre-receive hook that refuses every push.
+func seedMirror(t *testing.T, remotePath, mirrorPath string, declining bool) *git.Repository {
+	t.Helper()
+	out, err := exec.Command("git", "clone", "--bare", "--quiet", remotePath, mirrorPath).CombinedOutput()
+	require.NoError(t, err, string(out))
+	if declining {
+		hook := filepath.Join(mirrorPath, "hooks", "pre-receive")
+		require.NoError(t, os.MkdirAll(filepath.Dir(hook), 0o750))
+		require.NoError(t, os.WriteFile(hook, []byte("#!/bin/sh\nexit 1\n"), 0o700))
+	}
+	repo, err := git.PlainOpen(mirrorPath)
+	require.NoError(t, err)
+	return repo
+}
+
+func branchTip(t *testing.T, repo *git.Repository) plumbing.Hash {
+	t.Helper()
+	ref, err := repo.Reference(plumbing.NewBranchReferenceName("main"), true)
+	require.NoError(t, err)
+	return ref.Hash()
+}
+
+// TestPushPending_PushesToMirrorsBestEffort verifies a successful push is repeated to every mirror,
+// and that a mirror refusing it leaves the primary write in place while surfacing the failure.
+func TestPushPending_PushesToMirrorsBestEffort(t *testing.T) {
+	reader, err := telemetry.InitTestExporter()
+	require.NoError(t, err)
+
+	worker, serverRepo, remoteURL := setupCommitPushSplitWorker(t)
+	remotePath := strings.TrimPrefix(remoteURL, "file://")
+	tempDir := t.TempDir()
+	healthy := seedMirror(t, remotePath, filepath.Join(tempDir, "healthy.git"), false)
+	declining := seedMirror(t, remotePath, filepath.Join(tempDir, "declining.git"), true)
+	decliningBefore := branchTip(t, declining)
+
+	createMirrorProvider(t, worker, "healthy", filepath.Join(tempDir, "healthy.git"), "main")
+	createMirrorProvider(t, worker, "declining", filepath.Join(tempDir, "declining.git"), "main")
+	setMirrors(t, worker, configv1alpha3.GitProviderMirror{
+		GitProviderReference: configv1alpha3.GitProviderReference{Name: "healthy"},
+	}, configv1alpha3.GitProviderMirror{
+		GitProviderReference: configv1alpha3.GitProviderReference{Name: "declining"},
+	})
+
+	recorder := events.NewFakeRecorder(4)
+	worker.recorder = recorder
+	writes, loop := pushOneMirroredWrite(t, worker)
+
+	require.Empty(t, loop.pendingWrites, "a mirror failure does not fail the primary write")
+	pushed := branchTip(t, serverRepo)
+	assert.Equal(t, writes[0].CommitSHA, pushed)
+	assert.Equal(t, pushed, branchTip(t, healthy), "the healthy mirror follows the primary")
+	assert.Equal(t, decliningBefore, branchTip(t, declining), "the declining mirror is left as it was")
+
+	require.Len(t, recorder.Events, 2)
+	assert.True(t, strings.HasPrefix(<-recorder.Events, "Normal CommitPushed "))
+	assert.True(t, strings.HasPrefix(<-recorder.Events,
+		"Warning MirrorPushFailed Pushed to the primary remote but not to mirror declining: "))
+
+	labels := map[string]string{"provider_namespace": "default", "provider_name": "test-repo", "branch": "main"}
+	labels["mirror"] = "declining"
+	failures, ok := telemetry.CollectInt64Sum(reader, mirrorPushFailuresMetric, labels)
+	require.True(t, ok)
+	assert.Equal(t, int64(1), failures)
+	labels["mirror"] = "healthy"
+	_, ok = telemetry.CollectInt64Sum(reader, mirrorPushFailuresMetric, labels)
+	assert.False(t, ok, "a mirror that took the push records no failure")
+
+	outcome, ok := worker.LastMirrorPushFor("team-a", "default")
+	require.True(t, ok)
+	assert.Equal(t, 2, outcome.Mirrors)
+	require.Len(t, outcome.Failures, 1)
+	assert.True(t, strings.HasPrefix(outcome.Failures[0], "declining: "))
+}
+
+// TestPushMirror_LeavesTheOriginTrackingRefAlone verifies a mirror push never touches the clone's
+// refs/remotes/origin refs: other operations on the clone read them as what was last fetched from
+// the primary, so a push to a mirror must not move them.
+func TestPushMirror_LeavesTheOriginTrackingRefAlone(t *testing.T) {
+	worker, _, remoteURL := setupCommitPushSplitWorker(t)
+	mirrorPath := filepath.Join(t.TempDir(), "mirror.git")
+	mirror := seedMirror(t, strings.TrimPrefix(remoteURL, "file://"), mirrorPath, false)
+	writes, _ := pushOneMirroredWrite(t, worker)
+	pushed := writes[0].CommitSHA
+
+	clone, err := git.PlainOpen(worker.repoPathForRemote(remoteURL))
+	require.NoError(t, err)
+	trackingRef := plumbing.NewRemoteReferenceName("origin", "main")
+	before, err := clone.Reference(trackingRef, true)
+	require.NoError(t, err)
+	require.NotEqual(t, pushed, before.Hash(), "the test needs a tracking ref behind the mirrored tip")
+
+	require.NoError(t, PushMirror(t.Context(), clone, mirrorPath, plumbing.NewBranchReferenceName("main"),
+		pushed, nil, false))
+	assert.Equal(t, pushed, branchTip(t, mirror))
+
+	after, err := clone.Reference(trackingRef, true)
+	require.NoError(t, err)
+	assert.Equal(t, before.Hash(), after.Hash(), "the mirror push left the origin tracking ref alone")
+	_, err = clone.Remote(mirrorRemoteName)
+	assert.ErrorIs(t, err, git.ErrRemoteNotFound, "the mirror remote is never saved")
+}
+
+// TestPushPending_MirrorsHonorAllowedBranchesAndForce verifies a mirror only receives branches its
+// allowedBranches admit, and that a mirror whose branch has diverged is overwritten only when the
+// mirror entry asks for force.
+func TestPushPending_MirrorsHonorAllowedBranchesAndForce(t *testing.T) {
+	worker, serverRepo, remoteURL := setupCommitPushSplitWorker(t)
+	remotePath := strings.TrimPrefix(remoteURL, "file://")
+	tempDir := t.TempDir()
+	elsewhere := seedMirror(t, remotePath, filepath.Join(tempDir, "elsewhere.git"), false)
+	diverged := seedMirror(t, remotePath, filepath.Join(tempDir, "diverged.git"), false)
+	forced := seedMirror(t, remotePath, filepath.Join(tempDir, "forced.git"), false)
+	elsewhereBefore := branchTip(t, elsewhere)
+	divergedBefore := simulateClientCommitOnDisk(t, "file://"+filepath.Join(tempDir, "diverged.git"),
+		"main", "mirror-only.txt", "written on the mirror")
+	simulateClientCommitOnDisk(t, "file://"+filepath.Join(tempDir, "forced.git"),
+		"main", "mirror-only.txt", "written on the mirror")
+
+	createMirrorProvider(t, worker, "elsewhere", filepath.Join(tempDir, "elsewhere.git"), "release-*")
+	createMirrorProvider(t, worker, "diverged", filepath.Join(tempDir, "diverged.git"), "main")
+	createMirrorProvider(t, worker, "forced", filepath.Join(tempDir, "forced.git"), "main")
+	setMirrors(t, worker, configv1alpha3.GitProviderMirror{
+		GitProviderReference: configv1alpha3.GitProviderReference{Name: "elsewhere"},
+	}, configv1alpha3.GitProviderMirror{
+		GitProviderReference: configv1alpha3.GitProviderReference{Name: "diverged"},
+	}, configv1alpha3.GitProviderMirror{
+		GitProviderReference: configv1alpha3.GitProviderReference{Name: "forced"}, Force: true,
+	})
+
+	_, loop := pushOneMirroredWrite(t, worker)
+	require.Empty(t, loop.pendingWrites, "mirror failures do not fail the primary write")
+
+	pushed := branchTip(t, serverRepo)
+	assert.Equal(t, elsewhereBefore, branchTip(t, elsewhere), "a branch the mirror does not allow is not pushed")
+	assert.Equal(t, divergedBefore, branchTip(t, diverged), "a diverged mirror is not overwritten without force")
+	assert.Equal(t, pushed, branchTip(t, forced), "force overwrites a diverged mirror")
+
+	outcome, ok := worker.LastMirrorPushFor("team-a", "default")
+	require.True(t, ok)
+	assert.Equal(t, 3, outcome.Mirrors)
+	require.Len(t, outcome.Failures, 2)
+	assert.Contains(t, outcome.Failures[0], "elsewhere: branch \"main\" does not match")
+	assert.True(t, strings.HasPrefix(outcome.Failures[1], "diverged: "))
+}
+
+// createMirrorProvider creates a GitProvider for the bare mirror at path that allows the branches
+// matching allowedBranch.
+func createMirrorProvider(t *testing.T, worker *BranchWorker, name, path, allowedBranch string) {
+	t.Helper()
+	mirror := &configv1alpha3.GitProvider{
+		Spec: configv1alpha3.GitProviderSpec{URL: "file://" + path, AllowedBranches: []string{allowedBranch}},
+	}
+	mirror.Name = name
+	mirror.Namespace = "default"
+	require.NoError(t, worker.Client.Create(worker.ctx, mirror))
+}
+
+// setMirrors sets the worker's GitProvider's spec.mirrors.
+func setMirrors(t *testing.T, worker *BranchWorker, mirrors ...configv1alpha3.GitProviderMirror) {
+	t.Helper()
+	provider := &configv1alpha3.GitProvider{}
+	providerKey := client.ObjectKey{Name: "test-repo", Namespace: "default"}
+	require.NoError(t, worker.Client.Get(worker.ctx, providerKey, provider))
+	provider.Spec.Mirrors = mirrors
+	require.NoError(t, worker.Client.Update(worker.ctx, provider))
+}
+
+// pushOneMirroredWrite commits and pushes one write for the GitTarget team-a, returning the write
+// and the loop that pushed it.
+func pushOneMirroredWrite(t *testing.T, worker *BranchWorker) ([]PendingWrite, *branchWorkerEventLoop) {
+	t.Helper()
+	target := &configv1alpha3.GitTarget{}
+	target.Name = "team-a"
+	target.Namespace = "default"
+	require.NoError(t, worker.Client.Create(worker.ctx, target))
+
+	pendingWrite, err := worker.buildAtomicPendingWrite(worker.ctx, &WriteRequest{
+		Events:             []Event{configMapEvent("mirrored", "reconciler", "team-a")},
+		CommitMode:         CommitModeAtomic,
+		GitTargetName:      "team-a",
+		GitTargetNamespace: "default",
+	})
+	require.NoError(t, err)
+	writes := []PendingWrite{*pendingWrite}
+	require.NoError(t, worker.commitPendingWrites(writes, false))
+
+	loop := newBranchWorkerEventLoop(worker, time.Second)
+	loop.pendingWrites = writes
+	loop.pushPending()
+	loop.stopTimers()
+	return writes, loop
+}
@@ -0,0 +1,217 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/ConfigButler/gitops-reverser/api/v1alpha3"
+)
+
+// oidcRefreshFraction is the share of a token's lifetime left at which it is renewed.
+const oidcRefreshFraction = 0.1
+
+// defaultOIDCTokenExpirySeconds mirrors the CRD default for a spec stored without one.
+const defaultOIDCTokenExpirySeconds int64 = 3600
+
+type oidcTokenKey struct {
+	namespace      string
+	serviceAccount string
+	audience       string
+	expirySeconds  int64
+}
+
+type oidcToken struct {
+	token     string
+	issuedAt  time.Time
+	expiresAt time.Time
+}
+
+// renewAt is the moment the token has oidcRefreshFraction of its lifetime left.
+func (t oidcToken) renewAt() time.Time {
+	lifetime := t.expiresAt.Sub(t.issuedAt)
+	return t.expiresAt.Add(-time.Duration(float64(lifetime) * oidcRefreshFraction))
+}
+
+// ErrOIDCAudienceNotAllowed is returned when a GitProvider's spec.oidc.audience is not in the
+// controller's --oidc-allowed-audiences.
+var ErrOIDCAudienceNotAllowed = errors.New("audience is not in the controller's --oidc-allowed-audiences")
+
+// oidcMint is one in-flight TokenRequest; callers that want the same key wait on done.
+type oidcMint struct {
+	done  chan struct{}
+	token oidcToken
+	err   error
+}
+
+// oidcTokenCache holds one ServiceAccount token per (namespace, ServiceAccount, audience, expiry),
+// so the GitProvider controller and every branch worker on the provider share one token rather
+// than each minting its own on every remote operation.
+type oidcTokenCache struct {
+	mu       sync.Mutex
+	now      func() time.Time
+	tokens   map[oidcTokenKey]oidcToken
+	inflight map[oidcTokenKey]*oidcMint
+	// allowedAudiences is the admin-set allowlist spec.oidc.audience must be in. Empty refuses
+	// every spec.oidc.
+	allowedAudiences map[string]struct{}
+}
+
+func newOIDCTokenCache(now func() time.Time) *oidcTokenCache {
+	return &oidcTokenCache{
+		now:      now,
+		tokens:   make(map[oidcTokenKey]oidcToken),
+		inflight: make(map[oidcTokenKey]*oidcMint),
+	}
+}
+
+//nolint:gochecknoglobals // shared by the GitProvider controller and the branch workers; see oidcTokenCache
+var oidcTokens = newOIDCTokenCache(time.Now)
+
+// SetOIDCAllowedAudiences sets the audiences a GitProvider's spec.oidc may request (the controller's
+// --oidc-allowed-audiences). The TokenRequest is made with the controller's own credentials, so
+// without this allowlist anyone able to create a GitProvider could have the controller mint a
+// token for whatever audience they name. Empty, the default, refuses every spec.oidc.
+func SetOIDCAllowedAudiences(audiences []string) {
+	oidcTokens.setAllowedAudiences(audiences)
+}
+
+func (c *oidcTokenCache) setAllowedAudiences(audiences []string) {
+	allowed := make(map[string]struct{}, len(audiences))
+	for _, audience := range audiences {
+		allowed[audience] = struct{}{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.allowedAudiences = allowed
+}
+
+// token returns a cached token for the GitProvider's spec.oidc, minting a new one through the
+// TokenRequest API when there is none or the cached one is due for renewal. The token is always
+// for a ServiceAccount in the GitProvider's own namespace, and only for an allowed audience.
+// Concurrent callers for the same key wait for one mint instead of racing several; the lock
+// itself is not held across the request, so other keys are never stalled behind a slow API call.
+func (c *oidcTokenCache) token(
+	ctx context.Context,
+	k8sClient client.Client,
+	provider *v1alpha3.GitProvider,
+) (oidcToken, error) {
+	key := oidcTokenKeyFor(provider)
+
+	c.mu.Lock()
+	if _, ok := c.allowedAudiences[key.audience]; !ok {
+		c.mu.Unlock()
+		return oidcToken{}, fmt.Errorf("%w: %q", ErrOIDCAudienceNotAllowed, key.audience)
+	}
+	if cached, ok := c.tokens[key]; ok && c.now().Before(cached.renewAt()) {
+		c.mu.Unlock()
+		return cached, nil
+	}
+	if call, ok := c.inflight[key]; ok {
+		c.mu.Unlock()
+		select {
+		case <-call.done:
+			return call.token, call.err
+		case <-ctx.Done():
+			return oidcToken{}, ctx.Err()
+		}
+	}
+	call := &oidcMint{done: make(chan struct{})}
+	c.inflight[key] = call
+	c.mu.Unlock()
+
+	call.token, call.err = c.mint(ctx, k8sClient, key)
+
+	c.mu.Lock()
+	delete(c.inflight, key)
+	if call.err == nil {
+		c.tokens[key] = call.token
+	}
+	c.mu.Unlock()
+	close(call.done)
+	return call.token, call.err
+}
+
+// issuedAt reports when the cached token for the GitProvider's spec.oidc was minted, without
+// minting one. ok is false when nothing is cached.
+func (c *oidcTokenCache) issuedAt(provider *v1alpha3.GitProvider) (time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cached, ok := c.tokens[oidcTokenKeyFor(provider)]
+	return cached.issuedAt, ok
+}
+
+// oidcTokenKeyFor is the cache key of the GitProvider's spec.oidc, which must be set.
+func oidcTokenKeyFor(provider *v1alpha3.GitProvider) oidcTokenKey {
+	spec := provider.Spec.OIDC
+	key := oidcTokenKey{
+		namespace:      provider.Namespace,
+		serviceAccount: spec.ServiceAccountName,
+		audience:       spec.Audience,
+		expirySeconds:  spec.TokenExpirySeconds,
+	}
+	if key.expirySeconds == 0 {
+		key.expirySeconds = defaultOIDCTokenExpirySeconds
+	}
+	return key
+}
+
+// mint requests a fresh token through the TokenRequest API. Called without c.mu held.
+func (c *oidcTokenCache) mint(ctx context.Context, k8sClient client.Client, key oidcTokenKey) (oidcToken, error) {
+	issuedAt := c.now()
+	sa := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: key.serviceAccount, Namespace: key.namespace},
+	}
+	request := &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			Audiences:         []string{key.audience},
+			ExpirationSeconds: &key.expirySeconds,
+		},
+	}
+	if err := k8sClient.SubResource("token").Create(ctx, sa, request); err != nil {
+		return oidcToken{}, fmt.Errorf("failed to request a token for ServiceAccount %s/%s: %w",
+			key.namespace, key.serviceAccount, err)
+	}
+	if request.Status.Token == "" {
+		return oidcToken{}, fmt.Errorf("token request for ServiceAccount %s/%s returned no token",
+			key.namespace, key.serviceAccount)
+	}
+
+	return oidcToken{
+		token:     request.Status.Token,
+		issuedAt:  issuedAt,
+		expiresAt: request.Status.ExpirationTimestamp.Time,
+	}, nil
+}
+
+// OIDCTokenAuth resolves the GitProvider's spec.oidc into a Bearer token auth method and reports
+// when that token expires. The token comes from the shared cache and is only re-minted when less
+// than a tenth of its lifetime remains.
+func OIDCTokenAuth(
+	ctx context.Context,
+	k8sClient client.Client,
+	provider *v1alpha3.GitProvider,
+) (transport.AuthMethod, time.Time, error) {
+	if provider.Spec.OIDC == nil {
+		return nil, time.Time{}, errors.New("spec.oidc is not configured")
+	}
+	minted, err := oidcTokens.token(ctx, k8sClient, provider)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	auth, err := GetHTTPTokenAuthMethod(minted.token)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return auth, minted.expiresAt, nil
+}
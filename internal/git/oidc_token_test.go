@@ -0,0 +1,205 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	configv1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
+)
+
+func oidcProvider() *configv1alpha3.GitProvider {
+	return &configv1alpha3.GitProvider{
+		ObjectMeta: metav1.ObjectMeta{Name: "provider", Namespace: "default"},
+		Spec: configv1alpha3.GitProviderSpec{
+			URL: "https://git.example.com/org/repo.git",
+			OIDC: &configv1alpha3.OIDCAuthSpec{
+				ServiceAccountName: "git-writer",
+				Audience:           "git.example.com",
+				TokenExpirySeconds: 1000,
+			},
+		},
+	}
+}
+
+// tokenRequestClient issues a numbered token valid for the requested lifetime from now(), and
+// records each request it serves.
+func tokenRequestClient(
+	t *testing.T,
+	now func() time.Time,
+	requests *[]authenticationv1.TokenRequestSpec,
+) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "git-writer", Namespace: "default"}}
+
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(sa).WithInterceptorFuncs(interceptor.Funcs{
+		SubResourceCreate: func(
+			_ context.Context, _ client.Client, subResource string, obj, sub client.Object,
+			_ ...client.SubResourceCreateOption,
+		) error {
+			require.Equal(t, "token", subResource)
+			require.Equal(t, "git-writer", obj.GetName())
+			request := sub.(*authenticationv1.TokenRequest)
+			*requests = append(*requests, request.Spec)
+			lifetime := time.Duration(*request.Spec.ExpirationSeconds) * time.Second
+			request.Status.Token = fmt.Sprintf("token-%d", len(*requests))
+			request.Status.ExpirationTimestamp = metav1.NewTime(now().Add(lifetime))
+			return nil
+		},
+	}).Build()
+}
+
+func TestOIDCTokenCache_RenewsInTheLastTenthOfTheLifetime(t *testing.T) {
+	clock := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := func() time.Time { return clock }
+	var requests []authenticationv1.TokenRequestSpec
+	c := tokenRequestClient(t, now, &requests)
+	cache := newOIDCTokenCache(now)
+	cache.setAllowedAudiences([]string{"git.example.com"})
+	ctx := context.Background()
+
+	first, err := cache.token(ctx, c, oidcProvider())
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", first.token)
+	assert.Equal(t, clock.Add(1000*time.Second), first.expiresAt)
+	require.Len(t, requests, 1)
+	assert.Equal(t, []string{"git.example.com"}, requests[0].Audiences)
+	assert.Equal(t, int64(1000), *requests[0].ExpirationSeconds)
+
+	clock = clock.Add(899 * time.Second)
+	cached, err := cache.token(ctx, c, oidcProvider())
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", cached.token, "more than a tenth of the lifetime left: reuse")
+
+	clock = clock.Add(2 * time.Second)
+	renewed, err := cache.token(ctx, c, oidcProvider())
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", renewed.token, "less than a tenth left: renew")
+	assert.Len(t, requests, 2)
+}
+
+func TestOIDCTokenCache_KeysOnAudience(t *testing.T) {
+	now := time.Now
+	var requests []authenticationv1.TokenRequestSpec
+	c := tokenRequestClient(t, now, &requests)
+	cache := newOIDCTokenCache(now)
+	cache.setAllowedAudiences([]string{"git.example.com", "other.example.com"})
+
+	provider := oidcProvider()
+	_, err := cache.token(context.Background(), c, provider)
+	require.NoError(t, err)
+	provider.Spec.OIDC.Audience = "other.example.com"
+	other, err := cache.token(context.Background(), c, provider)
+	require.NoError(t, err)
+
+	assert.Equal(t, "token-2", other.token, "a token bound to one audience is never reused for another")
+}
+
+// allowOIDCAudiences sets the process-wide allowlist for one test.
+func allowOIDCAudiences(t *testing.T, audiences ...string) {
+	t.Helper()
+	SetOIDCAllowedAudiences(audiences)
+	t.Cleanup(func() { SetOIDCAllowedAudiences(nil) })
+}
+
+func TestOIDCTokenCache_RefusesAudiencesOutsideTheAllowlist(t *testing.T) {
+	var requests []authenticationv1.TokenRequestSpec
+	c := tokenRequestClient(t, time.Now, &requests)
+	cache := newOIDCTokenCache(time.Now)
+
+	_, err := cache.token(context.Background(), c, oidcProvider())
+	require.ErrorIs(t, err, ErrOIDCAudienceNotAllowed, "no allowlist refuses every spec.oidc")
+
+	cache.setAllowedAudiences([]string{"other.example.com"})
+	_, err = cache.token(context.Background(), c, oidcProvider())
+	require.ErrorIs(t, err, ErrOIDCAudienceNotAllowed)
+	assert.ErrorContains(t, err, `"git.example.com"`)
+	assert.Empty(t, requests, "a refused audience never reaches the TokenRequest API")
+}
+
+func TestOIDCTokenCache_DoesNotHoldTheLockAcrossTheRequest(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	stalled := make(chan struct{})
+	release := make(chan struct{})
+	c := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+		SubResourceCreate: func(
+			_ context.Context, _ client.Client, _ string, obj, sub client.Object,
+			_ ...client.SubResourceCreateOption,
+		) error {
+			if obj.GetNamespace() == "slow" {
+				close(stalled)
+				<-release
+			}
+			request := sub.(*authenticationv1.TokenRequest)
+			request.Status.Token = "token-" + obj.GetNamespace()
+			request.Status.ExpirationTimestamp = metav1.NewTime(time.Now().Add(time.Hour))
+			return nil
+		},
+	}).Build()
+	cache := newOIDCTokenCache(time.Now)
+	cache.setAllowedAudiences([]string{"git.example.com"})
+
+	slow := oidcProvider()
+	slow.Namespace = "slow"
+	slowDone := make(chan error, 1)
+	go func() {
+		_, err := cache.token(context.Background(), c, slow)
+		slowDone <- err
+	}()
+	<-stalled
+
+	fast, err := cache.token(context.Background(), c, oidcProvider())
+	require.NoError(t, err, "another key is served while the first request is still in flight")
+	assert.Equal(t, "token-default", fast.token)
+
+	close(release)
+	require.NoError(t, <-slowDone)
+}
+
+func TestOIDCTokenAuth_ReturnsBearerToken(t *testing.T) {
+	allowOIDCAudiences(t, "git.example.com")
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	provider := oidcProvider()
+	provider.Namespace = "oidc-bearer"
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "git-writer", Namespace: "oidc-bearer"},
+	}).Build()
+
+	auth, expiresAt, err := OIDCTokenAuth(context.Background(), c, provider)
+	require.NoError(t, err)
+	assert.Equal(t, &githttp.TokenAuth{Token: "fake-token"}, auth)
+	assert.False(t, expiresAt.IsZero())
+
+	_, _, err = OIDCTokenAuth(context.Background(), c, &configv1alpha3.GitProvider{})
+	require.ErrorContains(t, err, "spec.oidc is not configured")
+}
+
+func TestOIDCTokenAuth_MissingServiceAccount(t *testing.T) {
+	allowOIDCAudiences(t, "git.example.com")
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	provider := oidcProvider()
+	provider.Namespace = "oidc-missing"
+
+	_, _, err := OIDCTokenAuth(context.Background(), fake.NewClientBuilder().WithScheme(scheme).Build(), provider)
+	require.ErrorContains(t, err, "failed to request a token for ServiceAccount oidc-missing/git-writer")
+}
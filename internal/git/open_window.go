@@ -106,7 +106,11 @@ func windowPathKey(e Event, writer eventContentWriter) string {
 func buildGroupedCommitMessageData(author, gitTarget string, events []Event) GroupedCommitMessageData {
 	operations := make(map[string]int, groupedCommitOperationKinds)
 	resources := make([]ResourceRef, 0, len(events))
+	var groups []string
 	for _, e := range events {
+		if groups == nil {
+			groups = e.UserInfo.Groups
+		}
 		operations[e.Operation]++
 		resources = append(resources, ResourceRef{
 			Group:     e.Identifier.Group,
@@ -118,6 +122,7 @@ func buildGroupedCommitMessageData(author, gitTarget string, events []Event) Gro
 	}
 	return GroupedCommitMessageData{
 		Author:     author,
+		Groups:     groups,
 		GitTarget:  gitTarget,
 		Count:      len(events),
 		Operations: operations,
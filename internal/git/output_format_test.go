@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
+	"github.com/ConfigButler/gitops-reverser/internal/manifestanalyzer"
+	"github.com/ConfigButler/gitops-reverser/internal/types"
+)
+
+// jsonCMEvent is cmEvent for a GitTarget with spec.outputFormat JSON.
+func jsonCMEvent(op, name, color string) Event {
+	event := cmEvent(op, name, color)
+	event.OutputFormat = v1alpha3.OutputFormatJSON
+	return event
+}
+
+// A GitTarget with spec.outputFormat JSON creates a new resource as pretty-printed,
+// key-sorted JSON at the canonical path with a .json extension; an identical update is a
+// no-op, a changed one is rewritten as JSON in place, and a delete removes the file.
+func TestPlanFlush_JSONOutputCreateUpdateDelete(t *testing.T) {
+	writer := newContentWriter(types.SensitiveResourcePolicy{})
+	worktree := newWorktreeForTest(t)
+	full := filepath.Join(worktree.Filesystem.Root(), "default", "configmaps", "fresh.json")
+
+	require.True(t, applyEventsViaPlanFlush(t, writer, worktree, jsonCMEvent("CREATE", "fresh", "green")))
+	got, err := os.ReadFile(full)
+	require.NoError(t, err)
+	assert.Equal(t, `{
+  "apiVersion": "v1",
+  "data": {
+    "color": "green"
+  },
+  "kind": "ConfigMap",
+  "metadata": {
+    "name": "fresh",
+    "namespace": "default"
+  }
+}
+`, string(got))
+	_, statErr := os.Stat(filepath.Join(worktree.Filesystem.Root(), "default", "configmaps", "fresh.yaml"))
+	assert.True(t, os.IsNotExist(statErr), "no YAML copy is written beside the JSON file")
+
+	assert.False(t, applyEventsViaPlanFlush(t, writer, worktree, jsonCMEvent("UPDATE", "fresh", "green")),
+		"a JSON file that already holds the desired content is left alone")
+
+	require.True(t, applyEventsViaPlanFlush(t, writer, worktree, jsonCMEvent("UPDATE", "fresh", "blue")))
+	got, err = os.ReadFile(full)
+	require.NoError(t, err)
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(got, &decoded), "an updated JSON file stays JSON:\n%s", got)
+	assert.Equal(t, map[string]interface{}{"color": "blue"}, decoded["data"])
+	assert.Contains(t, string(got), "\n  \"data\": {\n    \"color\": \"blue\"\n  },\n", "and stays pretty-printed")
+
+	require.True(t, applyEventsViaPlanFlush(t, writer, worktree, jsonCMEvent("DELETE", "fresh", "blue")))
+	_, statErr = os.Stat(full)
+	assert.True(t, os.IsNotExist(statErr), "the delete removes the JSON file")
+}
+
+// The format decides where new documents go, not what an existing document is: under a
+// JSON-format target a document already in a .json file stays JSON, and one already in a .yaml
+// file stays YAML.
+func TestPlanFlush_ExistingDocumentKeepsItsFileFormat(t *testing.T) {
+	writer := newContentWriter(types.SensitiveResourcePolicy{})
+	worktree := newWorktreeForTest(t)
+	root := worktree.Filesystem.Root()
+
+	jsonFull := seedPlacedManifest(t, worktree, "apps/a.json",
+		`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"a","namespace":"default"},"data":{"color":"red"}}`)
+	yamlFull := seedPlacedManifest(t, worktree, "apps/b.yaml",
+		"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: b\n  namespace: default\ndata:\n  color: red\n")
+
+	require.True(t, applyEventsViaPlanFlush(t, writer, worktree,
+		jsonCMEvent("UPDATE", "a", "green"), jsonCMEvent("UPDATE", "b", "green")))
+
+	got, err := os.ReadFile(jsonFull)
+	require.NoError(t, err)
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(got, &decoded), "apps/a.json stays JSON:\n%s", got)
+	assert.Equal(t, map[string]interface{}{"color": "green"}, decoded["data"])
+
+	got, err = os.ReadFile(yamlFull)
+	require.NoError(t, err)
+	assert.Contains(t, string(got), "color: green", "apps/b.yaml stays YAML")
+
+	for _, rel := range []string{"default/configmaps/a.json", "default/configmaps/b.json"} {
+		_, statErr := os.Stat(filepath.Join(root, rel))
+		assert.Truef(t, os.IsNotExist(statErr), "no duplicate is created at %s", rel)
+	}
+}
+
+// Only a JSON-format target models .json files. Under the YAML default a .json manifest is
+// foreign content like any other unknown file: the flush refuses the folder rather than adopt,
+// patch, or sweep it.
+func TestPlanFlush_YAMLTargetRefusesJSONManifest(t *testing.T) {
+	writer := newContentWriter(types.SensitiveResourcePolicy{})
+	worktree := newWorktreeForTest(t)
+	seedPlacedManifest(t, worktree, "apps/a.json",
+		`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"a","namespace":"default"},"data":{"color":"red"}}`)
+
+	w := &BranchWorker{contentWriter: writer}
+	_, err := w.flushEventsToWorktree(context.Background(), worktree, "",
+		[]Event{cmEvent("UPDATE", "a", "green")}, nil, v1alpha3.PruneOnEvent)
+
+	var refused *manifestanalyzer.AcceptanceRefusedError
+	require.ErrorAs(t, err, &refused, "a YAML target must refuse a folder holding a .json file")
+	assert.Contains(t, refused.Error(), "apps/a.json")
+}
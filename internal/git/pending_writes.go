@@ -10,6 +10,7 @@ import (
 
 	v1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
 	"github.com/ConfigButler/gitops-reverser/internal/manifestanalyzer"
+	"github.com/ConfigButler/gitops-reverser/internal/sanitize"
 )
 
 func (w *BranchWorker) buildGroupedPendingWrite(ctx context.Context, events []Event) (*PendingWrite, error) {
@@ -88,6 +89,9 @@ func (w *BranchWorker) buildAtomicPendingWrite(ctx context.Context, request *Wri
 			resolvedEvents[i].GitTargetName = targetMetadata.Name
 			resolvedEvents[i].GitTargetNamespace = targetMetadata.Namespace
 			resolvedEvents[i].BootstrapOptions = targetMetadata.BootstrapOptions
+			resolvedEvents[i].YAMLOutput = targetMetadata.YAMLOutput
+			resolvedEvents[i].OutputFormat = targetMetadata.OutputFormat
+			resolvedEvents[i].MaxResourceBytes = targetMetadata.MaxResourceBytes
 		}
 	}
 
@@ -141,6 +145,9 @@ func (w *BranchWorker) resolveEventsForPendingWrite(
 		event.GitTargetName = targetMetadata.Name
 		event.GitTargetNamespace = targetMetadata.Namespace
 		event.BootstrapOptions = targetMetadata.BootstrapOptions
+		event.YAMLOutput = targetMetadata.YAMLOutput
+		event.OutputFormat = targetMetadata.OutputFormat
+		event.MaxResourceBytes = targetMetadata.MaxResourceBytes
 	}
 
 	return resolvedEvents, targets, nil
@@ -161,6 +168,14 @@ func (w *BranchWorker) resolveTargetMetadata(
 		return ResolvedTargetMetadata{}, fmt.Errorf("failed to resolve target encryption configuration: %w", err)
 	}
 
+	// A broken mapping Secret must not hold the target's writes: its users keep the author
+	// attribution derives, exactly as unmapped users do, and the error is logged for every commit
+	// until fixed. A pattern that does not compile already fails the GitTarget's Validated gate.
+	userMapping, err := resolveUserMapping(ctx, w.Client, target, &w.authorPatterns)
+	if err != nil {
+		w.Log.Error(err, "Ignoring GitTarget userMapping", "gitTarget", targetNamespace+"/"+targetName)
+	}
+
 	return ResolvedTargetMetadata{
 		Name:             target.Name,
 		Namespace:        target.Namespace,
@@ -170,9 +185,27 @@ func (w *BranchWorker) resolveTargetMetadata(
 		Placement:        resolvePlacementPolicy(target.Spec.Placement),
 		PruneMode:        target.EffectivePruneMode(),
 		SourceCluster:    target.SourceCluster(),
+		YAMLOutput:       resolveYAMLOutput(target.Spec.YAML),
+		OutputFormat:     target.Spec.OutputFormat,
+		MaxResourceBytes: target.Spec.MaxResourceBytes,
+		UserMapping:      userMapping,
 	}, nil
 }
 
+// resolveYAMLOutput converts the GitTarget's spec.yaml into the renderer's options. An
+// omitted spec, or an omitted style and key order, is block style in canonical order — the
+// renderer's zero value.
+func resolveYAMLOutput(spec *v1alpha3.YAMLOutputSpec) sanitize.MarshalOptions {
+	if spec == nil {
+		return sanitize.MarshalOptions{}
+	}
+	return sanitize.MarshalOptions{
+		Style:               sanitize.Style(spec.Style),
+		AutoSwitchThreshold: int(spec.AutoSwitchThreshold),
+		KeyOrder:            sanitize.KeyOrder(spec.KeyOrder),
+	}
+}
+
 // pruneModeForBase finds the effective prune mode for the GitTarget that owns base among
 // targets, matching exactly as placementPolicyForBase does (see its comment for why both
 // sides must be sanitized, and why at most one target can match).
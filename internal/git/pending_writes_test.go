@@ -7,6 +7,7 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -281,3 +282,37 @@ func TestResolvePlacementPolicy_ConvertsFieldForField(t *testing.T) {
 	assert.Equal(t, spec.ByType, got.ByType)
 	assert.Equal(t, spec.Default, got.Default)
 }
+
+func TestTakePushedStats_AccumulatesPerTargetAndResets(t *testing.T) {
+	w := &BranchWorker{}
+	first := plumbing.NewHash("1111111111111111111111111111111111111111")
+	second := plumbing.NewHash("2222222222222222222222222222222222222222")
+
+	w.recordPushedStats([]PendingWrite{
+		{Kind: PendingWriteAtomic, GitTargetName: "a", GitTargetNamespace: "ns", Events: make([]Event, 2), CommitSHA: first},
+		{Kind: PendingWriteAtomic, GitTargetName: "b", GitTargetNamespace: "ns", Events: make([]Event, 1), CommitSHA: first},
+		{Kind: PendingWriteAtomic, GitTargetName: "a", GitTargetNamespace: "ns", Events: make([]Event, 3), CommitSHA: second},
+	})
+
+	assert.Equal(t, PushedTargetStats{Events: 5, CommitSHA: second.String()}, w.TakePushedStats("a", "ns"))
+	assert.Equal(t, PushedTargetStats{}, w.TakePushedStats("a", "ns"), "taking resets the target's stats")
+	assert.Equal(t, PushedTargetStats{Events: 1, CommitSHA: first.String()}, w.TakePushedStats("b", "ns"))
+}
+
+// Stats handed back after a failed history write merge under what was pushed since.
+func TestRestorePushedStats_MergesUnderNewerPushes(t *testing.T) {
+	w := &BranchWorker{}
+	first := plumbing.NewHash("1111111111111111111111111111111111111111")
+	second := plumbing.NewHash("2222222222222222222222222222222222222222")
+
+	w.recordPushedStats([]PendingWrite{
+		{Kind: PendingWriteAtomic, GitTargetName: "a", GitTargetNamespace: "ns", Events: make([]Event, 2), CommitSHA: first},
+	})
+	taken := w.TakePushedStats("a", "ns")
+	w.recordPushedStats([]PendingWrite{
+		{Kind: PendingWriteAtomic, GitTargetName: "a", GitTargetNamespace: "ns", Events: make([]Event, 1), CommitSHA: second},
+	})
+	w.RestorePushedStats("a", "ns", taken)
+
+	assert.Equal(t, PushedTargetStats{Events: 3, CommitSHA: second.String()}, w.TakePushedStats("a", "ns"))
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/ConfigButler/gitops-reverser/internal/git/manifestedit"
 	"github.com/ConfigButler/gitops-reverser/internal/manifestanalyzer"
 	"github.com/ConfigButler/gitops-reverser/internal/manifestreport"
+	"github.com/ConfigButler/gitops-reverser/internal/sanitize"
 	"github.com/ConfigButler/gitops-reverser/internal/types"
 	"github.com/ConfigButler/gitops-reverser/internal/typeset"
 )
@@ -65,6 +66,18 @@ func clusterIDForEvents(events []Event) string {
 	return ""
 }
 
+// outputFormatForEvents returns the spec.outputFormat of the GitTarget the events in one base
+// belong to. Like the source cluster it is shared by every event in the base; the first
+// non-empty format wins, and an all-empty set is the YAML default.
+func outputFormatForEvents(events []Event) v1alpha3.OutputFormat {
+	for _, ev := range events {
+		if ev.OutputFormat != "" {
+			return ev.OutputFormat
+		}
+	}
+	return ""
+}
+
 func (w *BranchWorker) flushEventsToWorktree(
 	ctx context.Context,
 	worktree *gogit.Worktree,
@@ -74,7 +87,7 @@ func (w *BranchWorker) flushEventsToWorktree(
 	pruneMode v1alpha3.PruneMode,
 ) (bool, error) {
 	root := worktree.Filesystem.Root()
-	scoped, err := scanRenderScope(root, base)
+	scoped, err := scanRenderScope(root, base, outputFormatForEvents(events))
 	if err != nil {
 		return false, err
 	}
@@ -89,7 +102,7 @@ func (w *BranchWorker) flushEventsToWorktree(
 	}
 	for _, event := range events {
 		if err := batch.applyEvent(ctx, event); err != nil {
-			return false, err
+			return false, newEventApplyError(ctx, event, err)
 		}
 	}
 	// The flush is anchored at renderBase — spec.path, or the common ancestor of spec.path
@@ -267,6 +280,9 @@ const (
 	// count it and surface it, rather than have a not-mirrored resource vanish with
 	// no signal (placement Option B2's fail-safe skips — see createNew/writeWholeFile).
 	upsertSkippedUnsafe
+	// upsertSkippedOversized is a resource whose rendered document exceeds the GitTarget's
+	// spec.maxResourceBytes; it is logged and counted at the skip site (skipOversized).
+	upsertSkippedOversized
 )
 
 // applyEvent folds one event into the batch: a field patch sets bounded fields on an
@@ -293,15 +309,23 @@ func (wb *writeBatch) applyEvent(ctx context.Context, event Event) error {
 // a sensitive document is re-encrypted wholesale AT ITS EXISTING PATH (never patched in
 // place — that would drop the SOPS metadata and write the secret back in cleartext, and
 // never at the canonical path, which would orphan the moved copy). A resource with no
-// existing document is placed by createNew. It returns what it did to the bytes
+// existing document is edited in, or placed into, its item List when it has one or asks
+// for one (upsertListItem), and is otherwise placed by createNew. A resource larger than the
+// GitTarget's spec.maxResourceBytes is not written at all. It returns what it did to the bytes
 // (created / updated / no change).
 func (wb *writeBatch) applyUpsert(ctx context.Context, event Event) (upsertOutcome, error) {
+	if skipOversized(ctx, &event) {
+		return upsertSkippedOversized, nil
+	}
 	id, ok := manifestIdentity(event.Object)
 	if !ok {
 		return wb.createNew(ctx, event)
 	}
 	dm := wb.store.ByManifestIdentity[id]
 	if dm == nil {
+		if outcome, handled, err := wb.upsertListItem(ctx, event); handled {
+			return outcome, err
+		}
 		return wb.createNew(ctx, event)
 	}
 	filePath := wb.docLoc[dm].FilePath
@@ -355,6 +379,7 @@ func (wb *writeBatch) createNew(ctx context.Context, event Event) (upsertOutcome
 		Kind:       kind,
 		Sensitive:  sensitive,
 		WriteScope: wb.writeSubdir,
+		Extension:  newFileExtension(event.OutputFormat),
 	})
 	if err != nil {
 		log.FromContext(ctx).Info("Skipping new resource: placement could not be resolved safely",
@@ -379,6 +404,7 @@ func (wb *writeBatch) createNew(ctx context.Context, event Event) (upsertOutcome
 	if placement.NamespaceInherited && event.Object != nil {
 		event.Object = event.Object.DeepCopy()
 		event.Object.SetNamespace("")
+		event.rendered = nil // rendered from the object as it was
 	}
 
 	outcome, err := wb.placeNewDocument(ctx, event, placement, sensitive)
@@ -401,6 +427,17 @@ func (wb *writeBatch) createNew(ctx context.Context, event Event) (upsertOutcome
 	return outcome, nil
 }
 
+// jsonFileExtension is the extension of a document written as JSON.
+const jsonFileExtension = ".json"
+
+// newFileExtension is the extension of a new file for a GitTarget's spec.outputFormat.
+func newFileExtension(format v1alpha3.OutputFormat) string {
+	if format == v1alpha3.OutputFormatJSON {
+		return jsonFileExtension
+	}
+	return ".yaml"
+}
+
 // placeNewDocument writes the new document at its resolved placement: appended to an existing
 // accepted bundle, folded into a same-batch cold bundle, or as a file of its own.
 func (wb *writeBatch) placeNewDocument(
@@ -409,6 +446,20 @@ func (wb *writeBatch) placeNewDocument(
 	placement manifestanalyzer.PlacementResult,
 	sensitive bool,
 ) (upsertOutcome, error) {
+	// A JSON file holds exactly one document, so it can neither take an appended document nor
+	// become a same-batch bundle. Skip like any other unsafe placement.
+	if isJSONPath(placement.Path) && (placement.Append || wb.buffer(placement.Path).current != nil) {
+		log.FromContext(ctx).Info("Skipping new resource: a JSON file holds exactly one document",
+			"resource", event.Identifier.String(), "file", placement.Path)
+		return upsertSkippedUnsafe, nil
+	}
+	// An item List (aggregateByKind) holds its objects as items, not as documents: a document
+	// of its own appended to it, or written over it, would break the List or drop its items.
+	if _, isList := decodeItemList(wb.buffer(placement.Path).current); isList {
+		log.FromContext(ctx).Info("Skipping new resource: placement names an item List file",
+			"resource", event.Identifier.String(), "file", placement.Path)
+		return upsertSkippedUnsafe, nil
+	}
 	if placement.Append {
 		return wb.appendNewDocument(ctx, event, placement.Path)
 	}
@@ -587,6 +638,16 @@ func (wb *writeBatch) appendKustomizationResource(
 // in the same batch that shifted a multi-document file does not misdirect the edit.
 func (wb *writeBatch) applyFieldPatch(ctx context.Context, event Event) error {
 	filePath, id, ok := wb.resolveFieldPatchTarget(event)
+	if !ok && wb.holdsListItem(event) {
+		// An item List is rendered whole from its items, so there is no document to patch
+		// field by field, and a whole-item replace from the partial desired would drop every
+		// field the subresource did not mention. Skip it, visibly.
+		log.FromContext(ctx).Info("Field patch not applied: parent is an item List entry",
+			"resource", event.Identifier.String(), "source", event.FieldPatch.Source,
+			"reason", "subresource_patch_item_list")
+		recordItemListFieldPatchSkip(ctx, event)
+		return nil
+	}
 	if !ok {
 		log.FromContext(ctx).Info("Dropping field patch: parent manifest not present in Git",
 			"resource", event.Identifier.String(), "source", event.FieldPatch.Source,
@@ -1163,6 +1224,8 @@ func (wb *writeBatch) applyDelete(ctx context.Context, event Event) {
 	}
 	target, found := wb.resolveDelete(event)
 	if !found {
+		// No document of its own: the object may be an item of its type's List.
+		wb.removeListItem(ctx, event)
 		return
 	}
 	buf := wb.buffer(target.filePath)
@@ -1418,6 +1481,9 @@ func (wb *writeBatch) flush(ctx context.Context, worktree *gogit.Worktree, root,
 	// write-fan-in = 1 rule (never write a live change through into context shared by more
 	// than one render root). See
 	// docs/design/support-boundary/gittarget-granularity-and-cross-environment-edits.md §1.
+	if err := wb.formatJSONBuffers(); err != nil {
+		return false, err
+	}
 	if err := wb.ignoreShadowPrecondition(); err != nil {
 		return false, err
 	}
@@ -1452,6 +1518,31 @@ func (wb *writeBatch) flush(ctx context.Context, worktree *gogit.Worktree, root,
 	return changed, nil
 }
 
+// formatJSONBuffers renders every dirty .json buffer as pretty-printed, key-sorted JSON. The
+// writer builds and patches documents as YAML, and JSON is YAML, so a .json file is read,
+// patched in place, and written whole through the same paths as a .yaml file; only its final
+// bytes are converted here, once, before the preconditions see them. A buffer that converts
+// back to its original bytes is no longer dirty, so a no-op edit stays a no-op.
+func (wb *writeBatch) formatJSONBuffers() error {
+	for _, rel := range sortedBufferKeys(wb.buffers) {
+		buf := wb.buffers[rel]
+		if !isJSONPath(rel) || !buf.dirty() {
+			continue
+		}
+		formatted, err := sanitize.YAMLToIndentedJSON(buf.current)
+		if err != nil {
+			return fmt.Errorf("format %s as JSON: %w", rel, err)
+		}
+		buf.current = formatted
+	}
+	return nil
+}
+
+// isJSONPath reports whether a file is written as JSON rather than YAML.
+func isJSONPath(rel string) bool {
+	return strings.HasSuffix(rel, jsonFileExtension)
+}
+
 // ignoreShadowPrecondition tests every planned write in the batch — a created or edited
 // file (dirty) and a removed file (deleted) — against the active .gittargetignore matcher.
 // On a match it returns an *AcceptanceRefusedError carrying one IssueIgnoreShadowsManaged
@@ -1604,8 +1695,9 @@ func writeAndStageFile(worktree *gogit.Worktree, worktreePath, fullPath string,
 // A missing base directory (a never-written GitTarget path) yields an empty scan, not an
 // error. Unlike the analyzer scan, a mid-walk read error is fatal: the live writer must
 // never plan against a partial view of the subtree (an unreadable managed file it skipped
-// would be re-created, churning the mirror). Symlinks are never followed.
-func scanWorktreeSubtree(absBase string) (manifestanalyzer.FolderScan, error) {
+// would be re-created, churning the mirror). Symlinks are never followed. A .json manifest is
+// modeled only when format is JSON; under a YAML target it is foreign content.
+func scanWorktreeSubtree(absBase string, format v1alpha3.OutputFormat) (manifestanalyzer.FolderScan, error) {
 	ignore, ignoreIssues := loadWorktreeGitTargetIgnore(absBase)
 	scan := manifestanalyzer.FolderScan{Ignore: ignore, IgnoreIssues: ignoreIssues}
 
@@ -1621,7 +1713,7 @@ func scanWorktreeSubtree(absBase string) (manifestanalyzer.FolderScan, error) {
 			return relErr
 		}
 		rel = filepath.ToSlash(rel)
-		switch manifestanalyzer.ClassifyEntry(rel, d, ignore) {
+		switch manifestanalyzer.ClassifyEntry(rel, d, ignore, format == v1alpha3.OutputFormatJSON) {
 		case manifestanalyzer.RoleSkipDir:
 			return filepath.SkipDir
 		case manifestanalyzer.RoleManagedYAML:
@@ -1630,6 +1722,19 @@ func scanWorktreeSubtree(absBase string) (manifestanalyzer.FolderScan, error) {
 				return readErr
 			}
 			scan.YAMLFiles = append(scan.YAMLFiles, manifestedit.FileContent{Path: rel, Content: content})
+		case manifestanalyzer.RoleJSONCandidate:
+			content, readErr := os.ReadFile(p) //nolint:gosec // scanning the GitTarget worktree subtree is the feature
+			if readErr != nil {
+				return readErr
+			}
+			if manifestanalyzer.IsKRMJSON(content) {
+				scan.YAMLFiles = append(scan.YAMLFiles, manifestedit.FileContent{Path: rel, Content: content})
+				return nil
+			}
+			scan.NonYAML = append(scan.NonYAML, rel)
+			scan.Foreign = append(scan.Foreign, manifestanalyzer.ForeignEntry{
+				Path: rel, Kind: manifestanalyzer.ForeignFile,
+			})
 		case manifestanalyzer.RoleOperatorArtifact, manifestanalyzer.RoleBenignPassenger:
 			scan.NonYAML = append(scan.NonYAML, rel)
 		case manifestanalyzer.RoleForeignFile:
@@ -0,0 +1,223 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	corev1 "k8s.io/api/core/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	configv1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
+	"github.com/ConfigButler/gitops-reverser/internal/pullrequest"
+)
+
+const (
+	// ReasonPullRequestOpened is the Event reason recorded on a GitTarget when a push carrying its
+	// writes opened the pull request its spec.pullRequest asks for.
+	ReasonPullRequestOpened = "PullRequestOpened"
+	// ReasonPullRequestFailed is the Event reason recorded on a GitTarget when a push carrying its
+	// writes reached spec.branch but its pull request could not be found or opened.
+	ReasonPullRequestFailed = "PullRequestFailed"
+)
+
+// pullRequestOpenerFunc builds the client a pull request is opened through; pullrequest.New
+// outside tests.
+type pullRequestOpenerFunc func(api pullrequest.API, apiURL, repoURL, token string) (pullrequest.Opener, error)
+
+// pullRequestQueue hands the GitTargets a push carried to the goroutine that keeps their pull
+// requests open, so the GitTarget reads and API calls never run on the event loop. At most one such
+// goroutine runs per worker; targets queued while it runs are taken by it before it exits.
+type pullRequestQueue struct {
+	mu       sync.Mutex
+	running  bool
+	provider *configv1alpha3.GitProvider
+	targets  map[pendingTargetKey]struct{}
+}
+
+// queuePullRequests schedules ensurePullRequests for each GitTarget the push carried, against the
+// provider as the push saw it. It never blocks on the API.
+func (w *BranchWorker) queuePullRequests(provider *configv1alpha3.GitProvider, pendingWrites []PendingWrite) {
+	q := &w.pullRequestQueue
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.targets == nil {
+		q.targets = make(map[pendingTargetKey]struct{})
+	}
+	for _, pushed := range pushedTargetEvents(pendingWrites) {
+		q.targets[pushed.key] = struct{}{}
+	}
+	q.provider = provider
+	if q.running || len(q.targets) == 0 {
+		return
+	}
+	q.running = true
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		w.drainPullRequestQueue()
+	}()
+}
+
+// drainPullRequestQueue runs ensurePullRequests until no GitTarget is left queued.
+func (w *BranchWorker) drainPullRequestQueue() {
+	q := &w.pullRequestQueue
+	for {
+		q.mu.Lock()
+		if len(q.targets) == 0 || w.ctx.Err() != nil {
+			q.running = false
+			q.targets = nil
+			q.mu.Unlock()
+			return
+		}
+		provider, targets := q.provider, q.targets
+		q.targets = nil
+		q.mu.Unlock()
+		w.ensurePullRequests(provider, targets)
+	}
+}
+
+// pullRequestsIdle reports whether no pull request work is queued or running.
+func (w *BranchWorker) pullRequestsIdle() bool {
+	q := &w.pullRequestQueue
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return !q.running
+}
+
+// ensurePullRequests keeps a pull request open from the branch into spec.pullRequest.baseBranch
+// for each of targets that sets it. It runs after the push succeeded: a failure never fails the
+// write, it is logged and recorded as a PullRequestFailed Event, and the next push carrying the
+// target tries again. An open pull request picks up the pushed commits by itself, so a target whose
+// request is already open costs one API read.
+func (w *BranchWorker) ensurePullRequests(
+	provider *configv1alpha3.GitProvider,
+	targets map[pendingTargetKey]struct{},
+) {
+	var token string
+	var tokenErr error
+	tokenRead := false
+	for key := range targets {
+		target := &configv1alpha3.GitTarget{}
+		nn := k8stypes.NamespacedName{Name: key.Name, Namespace: key.Namespace}
+		if err := w.Client.Get(w.ctx, nn, target); err != nil {
+			continue
+		}
+		spec := target.Spec.PullRequest
+		if spec == nil {
+			w.forgetPullRequest(key)
+			continue
+		}
+		if !tokenRead {
+			token, tokenErr = w.pullRequestToken(provider)
+			tokenRead = true
+		}
+		pr, err := w.ensurePullRequest(provider, target, token, tokenErr)
+		if err != nil {
+			w.Log.Error(err, "Pull request not opened; the push stands",
+				"target", nn.String(), "baseBranch", spec.BaseBranch)
+			w.recordPullRequestEvent(target, corev1.EventTypeWarning, ReasonPullRequestFailed,
+				"Pushed to %s but could not open the pull request into %s: %v", w.Branch, spec.BaseBranch, err)
+			continue
+		}
+		w.rememberPullRequest(key, *pr)
+		if pr.Created {
+			w.Log.Info("Pull request opened", "target", nn.String(), "url", pr.URL)
+			w.recordPullRequestEvent(target, corev1.EventTypeNormal, ReasonPullRequestOpened,
+				"Opened pull request #%d from %s into %s: %s", pr.Number, w.Branch, spec.BaseBranch, pr.URL)
+		}
+	}
+}
+
+// ensurePullRequest finds or opens the pull request one GitTarget's spec.pullRequest asks for.
+func (w *BranchWorker) ensurePullRequest(
+	provider *configv1alpha3.GitProvider,
+	target *configv1alpha3.GitTarget,
+	token string,
+	tokenErr error,
+) (*pullrequest.PullRequest, error) {
+	if tokenErr != nil {
+		return nil, tokenErr
+	}
+	spec := target.Spec.PullRequest
+	if spec.BaseBranch == w.Branch {
+		return nil, errors.New("spec.pullRequest.baseBranch is spec.branch; a pull request needs two branches")
+	}
+	opener, err := w.newPullRequestOpener(pullrequest.API(spec.API), spec.APIURL, provider.Spec.URL, token)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(w.ctx, provider.Spec.EffectivePushTimeout())
+	defer cancel()
+	return opener.Ensure(ctx, pullrequest.Request{
+		Head:  w.Branch,
+		Base:  spec.BaseBranch,
+		Title: fmt.Sprintf("Cluster changes from %s", w.Branch),
+		Body: fmt.Sprintf("Opened by gitops-reverser for GitTarget %s/%s. "+
+			"Later changes are pushed to %s and appear here.", target.Namespace, target.Name, w.Branch),
+	})
+}
+
+// pullRequestToken is the API token in the GitProvider's HTTPS credentials: the basic-auth
+// password (a personal or app access token on GitHub and GitLab) or the bearer token.
+func (w *BranchWorker) pullRequestToken(provider *configv1alpha3.GitProvider) (string, error) {
+	auth, err := getAuthFromSecret(w.ctx, w.Client, provider, w.sshHostKeys)
+	if err != nil {
+		return "", fmt.Errorf("resolve GitProvider credentials: %w", err)
+	}
+	return httpToken(auth)
+}
+
+func httpToken(auth transport.AuthMethod) (string, error) {
+	if tlsAuth, ok := auth.(*ClientTLSAuth); ok {
+		return httpToken(tlsAuth.Auth)
+	}
+	switch a := auth.(type) {
+	case *http.BasicAuth:
+		return a.Password, nil
+	case *http.TokenAuth:
+		return a.Token, nil
+	default:
+		return "", errors.New("spec.pullRequest needs a GitProvider with HTTPS password or bearer token credentials")
+	}
+}
+
+func (w *BranchWorker) recordPullRequestEvent(
+	target *configv1alpha3.GitTarget,
+	eventType, reason, note string,
+	args ...interface{},
+) {
+	if w.recorder == nil {
+		return
+	}
+	w.recorder.Eventf(target, nil, eventType, reason, "PullRequest", note, args...)
+}
+
+func (w *BranchWorker) rememberPullRequest(key pendingTargetKey, pr pullrequest.PullRequest) {
+	w.metaMu.Lock()
+	defer w.metaMu.Unlock()
+	if w.pullRequests == nil {
+		w.pullRequests = make(map[pendingTargetKey]pullrequest.PullRequest)
+	}
+	w.pullRequests[key] = pr
+}
+
+func (w *BranchWorker) forgetPullRequest(key pendingTargetKey) {
+	w.metaMu.Lock()
+	defer w.metaMu.Unlock()
+	delete(w.pullRequests, key)
+}
+
+// PullRequestFor returns the pull request a push last found or opened for one GitTarget. It is
+// not reset by reading: status keeps reporting the request until spec.pullRequest is removed.
+func (w *BranchWorker) PullRequestFor(name, namespace string) (pullrequest.PullRequest, bool) {
+	w.metaMu.RLock()
+	defer w.metaMu.RUnlock()
+	pr, ok := w.pullRequests[pendingTargetKey{Name: name, Namespace: namespace}]
+	return pr, ok
+}
@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	configv1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
+	"github.com/ConfigButler/gitops-reverser/internal/pullrequest"
+)
+
+// fakePullRequests is an Opener that keeps one pull request per head and base: the first Ensure
+// opens it, later ones find it open.
+type fakePullRequests struct {
+	mu      sync.Mutex
+	created []pullrequest.Request
+}
+
+func (f *fakePullRequests) Ensure(_ context.Context, req pullrequest.Request) (*pullrequest.PullRequest, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	pr := &pullrequest.PullRequest{Number: 1, URL: "https://github.com/acme/live/pull/1"}
+	if len(f.created) == 0 {
+		f.created = append(f.created, req)
+		pr.Created = true
+	}
+	return pr, nil
+}
+
+// TestPushPending_OpensPullRequestOnce verifies a push carrying a spec.pullRequest target opens
+// the pull request from the worker's branch into the base branch, and a later push finds it open.
+func TestPushPending_OpensPullRequestOnce(t *testing.T) {
+	worker, _, _ := setupCommitPushSplitWorker(t)
+	// The test remote is a file:// URL, which has no API; the pullrequest package covers the HTTP
+	// calls, so the worker gets an in-memory opener here.
+	opener := &fakePullRequests{}
+	worker.newPullRequestOpener = func(_ pullrequest.API, _, _, token string) (pullrequest.Opener, error) {
+		assert.Equal(t, "ghp-token", token)
+		return opener, nil
+	}
+
+	secret := &corev1.Secret{Data: map[string][]byte{"bearerToken": []byte("ghp-token")}}
+	secret.Name = "git-creds"
+	secret.Namespace = "default"
+	require.NoError(t, worker.Client.Create(worker.ctx, secret))
+	provider := &configv1alpha3.GitProvider{}
+	require.NoError(t, worker.Client.Get(worker.ctx, client.ObjectKey{Name: "test-repo", Namespace: "default"}, provider))
+	provider.Spec.SecretRef = &configv1alpha3.LocalSecretReference{Name: "git-creds"}
+	require.NoError(t, worker.Client.Update(worker.ctx, provider))
+
+	target := &configv1alpha3.GitTarget{Spec: configv1alpha3.GitTargetSpec{
+		Branch:      "main",
+		PullRequest: &configv1alpha3.GitTargetPullRequestSpec{BaseBranch: "release"},
+	}}
+	target.Name = "team-a"
+	target.Namespace = "default"
+	require.NoError(t, worker.Client.Create(worker.ctx, target))
+	recorder := events.NewFakeRecorder(8)
+	worker.recorder = recorder
+
+	for _, name := range []string{"first", "second"} {
+		pendingWrite, err := worker.buildAtomicPendingWrite(worker.ctx, &WriteRequest{
+			Events:             []Event{configMapEvent(name, "reconciler", "team-a")},
+			CommitMode:         CommitModeAtomic,
+			GitTargetName:      "team-a",
+			GitTargetNamespace: "default",
+		})
+		require.NoError(t, err)
+		writes := []PendingWrite{*pendingWrite}
+		require.NoError(t, worker.commitPendingWrites(writes, false))
+		loop := newBranchWorkerEventLoop(worker, time.Second)
+		loop.pendingWrites = writes
+		loop.pushPending()
+		loop.stopTimers()
+		require.Empty(t, loop.pendingWrites)
+		require.Eventually(t, worker.pullRequestsIdle, 5*time.Second, 10*time.Millisecond,
+			"the pull request is ensured off the event loop")
+	}
+
+	require.Len(t, opener.created, 1, "the second push finds the pull request open")
+	assert.Equal(t, "main", opener.created[0].Head)
+	assert.Equal(t, "release", opener.created[0].Base)
+
+	pr, ok := worker.PullRequestFor("team-a", "default")
+	require.True(t, ok)
+	assert.Equal(t, 1, pr.Number)
+	assert.Equal(t, "https://github.com/acme/live/pull/1", pr.URL)
+
+	var reasons []string
+	for len(recorder.Events) > 0 {
+		reasons = append(reasons, strings.Fields(<-recorder.Events)[1])
+	}
+	assert.Equal(t, []string{ReasonCommitPushed, ReasonPullRequestOpened, ReasonCommitPushed}, reasons)
+}
+
+func TestHTTPToken(t *testing.T) {
+	basic := &githttp.BasicAuth{Username: "x-access-token", Password: "ghs-app-token"}
+	token, err := httpToken(&ClientTLSAuth{Auth: basic})
+	require.NoError(t, err)
+	assert.Equal(t, "ghs-app-token", token, "the password of basic auth behind a client certificate")
+
+	_, err = httpToken(nil)
+	require.Error(t, err, "anonymous and SSH credentials carry no API token")
+}
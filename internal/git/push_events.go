@@ -0,0 +1,172 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	configv1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
+)
+
+const (
+	// ReasonCommitPushed is the Event reason recorded on a GitTarget when a push carried its writes.
+	ReasonCommitPushed = "CommitPushed"
+	// ReasonCommitFailed is the Event reason recorded on a GitTarget when a push of its writes failed.
+	ReasonCommitFailed = "CommitFailed"
+	// ReasonPushRejected is the Event reason recorded on a GitTarget when the remote refused the
+	// branch update itself, which retrying does not fix.
+	ReasonPushRejected = "PushRejected"
+	// ReasonBranchProtected is ReasonPushRejected for a refusal that names branch protection.
+	ReasonBranchProtected = "BranchProtected"
+)
+
+// pushedTargetEvent is one GitTarget's share of a push: its newest commit and its event count.
+type pushedTargetEvent struct {
+	key       pendingTargetKey
+	commitSHA string
+	events    int
+}
+
+// pushedTargetEvents groups pending writes by GitTarget, in first-seen order, so one push records
+// one Event per target rather than one per commit.
+func pushedTargetEvents(pendingWrites []PendingWrite) []pushedTargetEvent {
+	var out []pushedTargetEvent
+	index := make(map[pendingTargetKey]int)
+	for _, write := range pendingWrites {
+		target := write.Target()
+		if target.Name == "" {
+			continue
+		}
+		key := pendingTargetKey{Name: target.Name, Namespace: target.Namespace}
+		i, ok := index[key]
+		if !ok {
+			i = len(out)
+			index[key] = i
+			out = append(out, pushedTargetEvent{key: key})
+		}
+		out[i].events += len(write.Events)
+		if !write.CommitSHA.IsZero() {
+			out[i].commitSHA = write.CommitSHA.String()
+		}
+	}
+	return out
+}
+
+// recordPushedEvents records a Normal CommitPushed Event on each GitTarget the push carried.
+func (w *BranchWorker) recordPushedEvents(pendingWrites []PendingWrite) {
+	if w.recorder == nil {
+		return
+	}
+	for _, pushed := range pushedTargetEvents(pendingWrites) {
+		w.recordTargetEvent(pushed.key, corev1.EventTypeNormal, ReasonCommitPushed, "Push",
+			"Pushed commit %s with %d events to %s", pushed.commitSHA, pushed.events, w.Branch)
+	}
+}
+
+// recordPushFailedEvents records a Warning CommitFailed Event on each GitTarget whose writes are
+// still waiting for the push. A push the remote refused is recorded as PushRejected, or
+// BranchProtected when the remote's reason names branch protection, so it is not mistaken for a
+// transient failure. The event loop calls it once per run of failed pushes and again only when
+// the reason changes, and the note carries no attempt count, so the retries of one outage
+// aggregate into one Event instead of flooding the GitTarget.
+func (w *BranchWorker) recordPushFailedEvents(pendingWrites []PendingWrite, err error) {
+	if w.recorder == nil {
+		return
+	}
+	reason, note := pushFailureReason(err), "Failed to push: %v; retrying"
+	switch reason {
+	case ReasonPushRejected:
+		note = "Remote rejected the push: %v"
+	case ReasonBranchProtected:
+		note = "Remote rejected the push, the branch looks protected: %v; " +
+			"allow the operator's credentials to push to it or target another branch"
+	}
+	for _, pending := range pushedTargetEvents(pendingWrites) {
+		w.recordTargetEvent(pending.key, corev1.EventTypeWarning, reason, "Push", note, err)
+	}
+}
+
+// pushFailureReason is the reason a failed push is reported with: PushRejected when the remote
+// refused the branch update, BranchProtected when its reason names branch protection, and
+// CommitFailed otherwise.
+func pushFailureReason(err error) string {
+	var rejected *PushRejectedError
+	if !errors.As(err, &rejected) {
+		return ReasonCommitFailed
+	}
+	if rejected.Protected() {
+		return ReasonBranchProtected
+	}
+	return ReasonPushRejected
+}
+
+// PushOutcome is how the latest push carrying a GitTarget's writes ended.
+type PushOutcome struct {
+	// Reason is ReasonCommitPushed for a push that succeeded, and the failure's Event reason
+	// otherwise.
+	Reason string
+	// LastPushError is the failed push's error, including what the remote's hooks printed when it
+	// rejected the push. It is empty for a push that succeeded.
+	LastPushError string
+}
+
+// Failed reports whether the push failed.
+func (o PushOutcome) Failed() bool {
+	return o.Reason != ReasonCommitPushed
+}
+
+// rememberPushOutcome records how a push ended for each GitTarget it carried; err is nil for a
+// push that succeeded. An outcome that changed for any of them asks the GitTarget controller to
+// re-project it (notifyStateChange), so a failed push reaches the Pushed condition without waiting
+// for the periodic reconcile.
+func (w *BranchWorker) rememberPushOutcome(pendingWrites []PendingWrite, err error) {
+	outcome := PushOutcome{Reason: ReasonCommitPushed}
+	if err != nil {
+		outcome = PushOutcome{Reason: pushFailureReason(err), LastPushError: err.Error()}
+	}
+	changed := false
+	w.metaMu.Lock()
+	if w.pushOutcomes == nil {
+		w.pushOutcomes = make(map[pendingTargetKey]PushOutcome)
+	}
+	for _, pushed := range pushedTargetEvents(pendingWrites) {
+		if previous, ok := w.pushOutcomes[pushed.key]; !ok || previous != outcome {
+			changed = true
+		}
+		w.pushOutcomes[pushed.key] = outcome
+	}
+	w.metaMu.Unlock()
+	if changed {
+		w.notifyStateChange()
+	}
+}
+
+// LastPushFor returns how the latest push carrying one GitTarget's writes ended. ok is false
+// until a push has carried them.
+func (w *BranchWorker) LastPushFor(name, namespace string) (PushOutcome, bool) {
+	w.metaMu.RLock()
+	defer w.metaMu.RUnlock()
+	outcome, ok := w.pushOutcomes[pendingTargetKey{Name: name, Namespace: namespace}]
+	return outcome, ok
+}
+
+// recordTargetEvent records one Event on a GitTarget. The target is read back so the Event carries
+// its UID; a target that is gone or unreadable gets no Event, since nothing could show it.
+func (w *BranchWorker) recordTargetEvent(
+	key pendingTargetKey,
+	eventType, reason, action, note string,
+	args ...interface{},
+) {
+	target := &configv1alpha3.GitTarget{}
+	nn := k8stypes.NamespacedName{Name: key.Name, Namespace: key.Namespace}
+	if err := w.Client.Get(w.ctx, nn, target); err != nil {
+		w.Log.V(1).Info("Skipping GitTarget event; target not readable",
+			"target", fmt.Sprintf("%s/%s", key.Namespace, key.Name), "reason", reason, "error", err.Error())
+		return
+	}
+	w.recorder.Eventf(target, nil, eventType, reason, action, note, args...)
+}
@@ -10,6 +10,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/ConfigButler/gitops-reverser/api/v1alpha3"
 	"github.com/ConfigButler/gitops-reverser/internal/git/manifestedit"
 	"github.com/ConfigButler/gitops-reverser/internal/manifestanalyzer"
 )
@@ -54,10 +55,11 @@ type renderScopeResult struct {
 // subtree's kustomizations read from OUTSIDE spec.path — following the resources/patches
 // graph transitively, refusing a reference that escapes the repository root — and re-keys the
 // whole set relative to their common ancestor. A subtree that reads no out-of-scope file
-// returns the plain scan unchanged.
-func scanRenderScope(root, base string) (renderScopeResult, error) {
+// returns the plain scan unchanged. format is the GitTarget's spec.outputFormat, which decides
+// whether a .json file is a manifest or foreign content.
+func scanRenderScope(root, base string, format v1alpha3.OutputFormat) (renderScopeResult, error) {
 	absBase := filepath.Join(root, filepath.FromSlash(base))
-	specScan, err := scanWorktreeSubtree(absBase)
+	specScan, err := scanWorktreeSubtree(absBase, format)
 	if err != nil {
 		return renderScopeResult{}, err
 	}
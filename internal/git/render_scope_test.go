@@ -141,7 +141,7 @@ func TestScanRenderScope_ResolvesOverlayBase(t *testing.T) {
 	root := worktree.Filesystem.Root()
 	seedOverlayWorktree(t, root)
 
-	scoped, err := scanRenderScope(root, overlayGitPath)
+	scoped, err := scanRenderScope(root, overlayGitPath, "")
 	require.NoError(t, err)
 
 	assert.Equal(t, "apps/frontend", scoped.renderBase, "renderBase is the common ancestor of the overlay and its base")
@@ -168,7 +168,7 @@ func TestScanRenderScope_SelfContainedIsIdentity(t *testing.T) {
 	root := worktree.Filesystem.Root()
 	seedOverridesWorktree(t, root) // kustomization + apps/deployment.yaml, all in-subtree
 
-	scoped, err := scanRenderScope(root, "")
+	scoped, err := scanRenderScope(root, "", "")
 	require.NoError(t, err)
 	assert.Empty(t, scoped.renderBase)
 	assert.Empty(t, scoped.writeSubdir)
@@ -247,7 +247,7 @@ func TestScanRenderScope_RefusesBaseEscapingRepoRoot(t *testing.T) {
 	require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o750))
 	require.NoError(t, os.WriteFile(full, []byte(kust), 0o600))
 
-	_, err := scanRenderScope(root, "app")
+	_, err := scanRenderScope(root, "app", "")
 	require.Error(t, err, "a base escaping the repository root must be refused")
 	assert.Contains(t, err.Error(), "escapes the repository root")
 }
@@ -325,7 +325,7 @@ func TestScanRenderScope_DualKustomizationFilesImportedBoth(t *testing.T) {
 	write("apps/frontend/base/kustomization.yml", k)
 	write("apps/frontend/base/deployment.yaml", overlayBaseDeploymentYAML)
 
-	scoped, err := scanRenderScope(root, "apps/frontend/overlays/production")
+	scoped, err := scanRenderScope(root, "apps/frontend/overlays/production", "")
 	require.NoError(t, err)
 	got := map[string]bool{}
 	for _, f := range scoped.scan.YAMLFiles {
@@ -356,7 +356,7 @@ func TestScanRenderScope_DoesNotFollowKustomizationSymlink(t *testing.T) {
 	require.NoError(t, os.MkdirAll(filepath.Join(root, "apps/frontend/base"), 0o750))
 	require.NoError(t, os.Symlink(outside, filepath.Join(root, "apps/frontend/base/kustomization.yaml")))
 
-	scoped, err := scanRenderScope(root, "apps/frontend/overlays/production")
+	scoped, err := scanRenderScope(root, "apps/frontend/overlays/production", "")
 	require.NoError(t, err)
 	for _, f := range scoped.scan.YAMLFiles {
 		assert.NotContains(t, string(f.Content), "SECRET-OUTSIDE-WORKTREE",
@@ -395,7 +395,7 @@ func TestScanRenderScope_TransitiveBaseAndForeignRekey(t *testing.T) {
 	write("apps/frontend/shared/configmap.yaml",
 		"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: shared\ndata:\n  k: v\n")
 
-	scoped, err := scanRenderScope(root, "apps/frontend/overlays/production")
+	scoped, err := scanRenderScope(root, "apps/frontend/overlays/production", "")
 	require.NoError(t, err)
 	assert.Equal(t, "apps/frontend", scoped.renderBase, "renderBase climbs to the ancestor of both bases")
 	assert.Equal(t, "overlays/production", scoped.writeSubdir)
@@ -441,7 +441,7 @@ func TestScanRenderScope_ExternalResourceFile(t *testing.T) {
 	write("apps/frontend/shared/extra.yaml",
 		"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: extra\ndata:\n  k: v\n")
 
-	scoped, err := scanRenderScope(root, "apps/frontend/overlays/production")
+	scoped, err := scanRenderScope(root, "apps/frontend/overlays/production", "")
 	require.NoError(t, err)
 	assert.Equal(t, "apps/frontend", scoped.renderBase)
 
@@ -478,7 +478,7 @@ func TestScanRenderScope_UnrelatedBaseContentNotPulled(t *testing.T) {
 	write("apps/frontend/base/experimental/cm.yaml",
 		"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: exp\ndata:\n  k: v\n")
 
-	scoped, err := scanRenderScope(root, "apps/frontend/overlays/production")
+	scoped, err := scanRenderScope(root, "apps/frontend/overlays/production", "")
 	require.NoError(t, err)
 	for _, f := range scoped.scan.YAMLFiles {
 		assert.NotContains(t, f.Path, "experimental",
@@ -504,7 +504,7 @@ func TestScanRenderScope_SkipsRemoteBase(t *testing.T) {
 		"apiVersion: kustomize.config.k8s.io/v1beta1\nkind: Kustomization\nresources:\n  - deployment.yaml\n")
 	write("apps/frontend/base/deployment.yaml", overlayBaseDeploymentYAML)
 
-	scoped, err := scanRenderScope(root, "apps/frontend/overlays/production")
+	scoped, err := scanRenderScope(root, "apps/frontend/overlays/production", "")
 	require.NoError(t, err, "a remote base is skipped, not an error")
 	assert.Equal(t, "apps/frontend", scoped.renderBase)
 	assert.Equal(t, "overlays/production", scoped.writeSubdir)
@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"path/filepath"
+	"sync"
+
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// A local clone has a single writer at a time. Every operation that touches one — PrepareBranch,
+// and a BranchWorker's commit, push, sync and bootstrap — holds the lock of the clone's path for
+// its whole duration, so two workers that share a path (a replacement worker starting while the
+// one it replaces drains, or two GitProviders that resolve to the same checkout) never run git
+// against the same worktree at once.
+//
+// The lock serializes operations; it does not make a worker's commit and its later push one
+// step. So the lock also records which workers have commits in the clone the remote has not seen
+// yet: a worker starting a push cycle then builds on them instead of resetting them away, and
+// whichever worker pushes first publishes both. The other's push finds its writes already on the
+// remote and replays to nothing.
+
+// repoPathLocks is the registry of clone path locks. An entry lives while anyone holds or waits for
+// its lock, or while a worker has unpushed commits in its clone; the last release of an idle entry
+// removes it, so the registry does not grow with every clone path the process has ever used.
+//
+//nolint:gochecknoglobals
+var repoPathLocks = struct {
+	sync.Mutex
+	byPath map[string]*repoPathLock
+}{byPath: map[string]*repoPathLock{}}
+
+// repoPathLock is the single-writer lock of one local clone path.
+type repoPathLock struct {
+	mu sync.Mutex
+
+	// key is the entry's registry key, and refs counts the holders and waiters of mu. Both are
+	// protected by the registry's mutex.
+	key  string
+	refs int
+
+	// unpushed holds the workers with commits in the clone that have not reached the remote, and
+	// rootBranch/rootHash are where the first of those commits was based. Protected by mu.
+	unpushed   map[*BranchWorker]struct{}
+	rootBranch plumbing.ReferenceName
+	rootHash   plumbing.Hash
+}
+
+// lockRepoPath acquires the lock of the clone at path, blocking while another operation holds it.
+// The caller releases it with Unlock.
+func lockRepoPath(path string) *repoPathLock {
+	key := filepath.Clean(path)
+	repoPathLocks.Lock()
+	lock, ok := repoPathLocks.byPath[key]
+	if !ok {
+		lock = &repoPathLock{key: key}
+		repoPathLocks.byPath[key] = lock
+	}
+	lock.refs++
+	repoPathLocks.Unlock()
+
+	lock.mu.Lock()
+	return lock
+}
+
+// repoPathLocked reports whether the lock of the clone at path is held. It cannot tell who holds
+// it, so it only catches a caller that holds no lock at all; that is enough to guard the
+// operations, like removing git's own lock files, that are only safe under it.
+func repoPathLocked(path string) bool {
+	repoPathLocks.Lock()
+	lock, ok := repoPathLocks.byPath[filepath.Clean(path)]
+	repoPathLocks.Unlock()
+	if !ok {
+		return false
+	}
+	if lock.mu.TryLock() {
+		lock.mu.Unlock()
+		return false
+	}
+	return true
+}
+
+// Unlock releases the lock. It drops the caller's reference to the entry while still holding mu,
+// and removes the entry from the registry when that was the last reference and the clone holds no
+// unpushed commits. Deciding both under mu matters: once mu is released a waiter can take it and
+// mark its own commits unpushed, and an entry removed after that would lose them.
+//
+// The registry's mutex is taken inside mu here, and never the other way around: lockRepoPath and
+// forgetUnpushedCommits release it before they wait for mu.
+func (l *repoPathLock) Unlock() {
+	repoPathLocks.Lock()
+	l.refs--
+	if l.refs == 0 && len(l.unpushed) == 0 && repoPathLocks.byPath[l.key] == l {
+		delete(repoPathLocks.byPath, l.key)
+	}
+	repoPathLocks.Unlock()
+	l.mu.Unlock()
+}
+
+// sharedPushCycle returns the push cycle another worker's unpushed commits in the clone belong
+// to. ok is false when no other worker has any, and the caller starts its cycle from the remote.
+func (l *repoPathLock) sharedPushCycle(w *BranchWorker) (plumbing.ReferenceName, plumbing.Hash, bool) {
+	for other := range l.unpushed {
+		if other != w {
+			return l.rootBranch, l.rootHash, true
+		}
+	}
+	return "", plumbing.ZeroHash, false
+}
+
+// markUnpushed records that w committed to the clone in the push cycle based at rootBranch and
+// rootHash. The first unpushed worker sets the shared root.
+func (l *repoPathLock) markUnpushed(w *BranchWorker, rootBranch plumbing.ReferenceName, rootHash plumbing.Hash) {
+	if len(l.unpushed) == 0 {
+		l.unpushed = map[*BranchWorker]struct{}{}
+		l.rootBranch = rootBranch
+		l.rootHash = rootHash
+	}
+	l.unpushed[w] = struct{}{}
+}
+
+// markPushed records a successful push: it published the whole local branch, so no worker has
+// unpushed commits left in the clone.
+func (l *repoPathLock) markPushed() {
+	l.unpushed = nil
+	l.rootBranch = ""
+	l.rootHash = plumbing.ZeroHash
+}
+
+// forgetUnpushedCommits drops a stopped worker from every clone's unpushed set: the writes behind
+// its commits were dropped with it, so the next push cycle starts from the remote again.
+func forgetUnpushedCommits(w *BranchWorker) {
+	repoPathLocks.Lock()
+	locks := make([]*repoPathLock, 0, len(repoPathLocks.byPath))
+	for _, lock := range repoPathLocks.byPath {
+		lock.refs++
+		locks = append(locks, lock)
+	}
+	repoPathLocks.Unlock()
+
+	for _, lock := range locks {
+		lock.mu.Lock()
+		delete(lock.unpushed, w)
+		if len(lock.unpushed) == 0 {
+			lock.markPushed()
+		}
+		lock.Unlock()
+	}
+}
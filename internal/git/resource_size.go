@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/ConfigButler/gitops-reverser/internal/sanitize"
+	"github.com/ConfigButler/gitops-reverser/internal/telemetry"
+)
+
+// skipOversized reports whether an upsert is larger than its GitTarget's spec.maxResourceBytes
+// and must not be written. The size is the resource's rendered YAML document, measured before
+// any encryption, so one limit means the same thing for every type. A skipped resource is logged
+// and counted; whatever Git already holds for it stays as it is. The rendered document is kept on
+// the event (event.rendered), so a write that goes ahead does not render it again.
+func skipOversized(ctx context.Context, event *Event) bool {
+	if event.MaxResourceBytes <= 0 || event.Object == nil {
+		return false
+	}
+	content, err := sanitize.MarshalToOrderedYAMLWithOptions(event.Object, event.YAMLOutput)
+	if err != nil {
+		// Not this guard's failure to report: the write itself renders the object again.
+		return false
+	}
+	event.rendered = content
+	size := int64(len(content))
+	if size <= event.MaxResourceBytes {
+		return false
+	}
+	log.FromContext(ctx).Info("Skipping resource: its document exceeds spec.maxResourceBytes",
+		"resource", event.Identifier.String(), "bytes", size, "maxResourceBytes", event.MaxResourceBytes)
+	recordOversizedResource(ctx, event)
+	return true
+}
+
+func recordOversizedResource(ctx context.Context, event *Event) {
+	if telemetry.OversizedResourceTotal == nil {
+		return
+	}
+	telemetry.OversizedResourceTotal.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("group", event.Identifier.Group),
+		attribute.String("version", event.Identifier.Version),
+		attribute.String("resource", event.Identifier.Resource),
+	))
+}
@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
+	"github.com/ConfigButler/gitops-reverser/internal/manifestanalyzer"
+	"github.com/ConfigButler/gitops-reverser/internal/types"
+)
+
+// limitedCMEvent is cmEvent for a GitTarget with spec.maxResourceBytes.
+func limitedCMEvent(op, name, color string, maxBytes int64) Event {
+	event := cmEvent(op, name, color)
+	event.MaxResourceBytes = maxBytes
+	return event
+}
+
+// A resource whose document exceeds spec.maxResourceBytes is not written, while one within the
+// limit is; an oversized update leaves the document Git already holds untouched.
+func TestPlanFlush_SkipsOversizedResource(t *testing.T) {
+	writer := newContentWriter(types.SensitiveResourcePolicy{})
+	worktree := newWorktreeForTest(t)
+	dir := filepath.Join(worktree.Filesystem.Root(), "default", "configmaps")
+	blob := strings.Repeat("x", 4096)
+
+	assert.False(t, applyEventsViaPlanFlush(t, writer, worktree, limitedCMEvent("CREATE", "huge", blob, 1024)),
+		"an oversized resource writes nothing")
+	_, statErr := os.Stat(filepath.Join(dir, "huge.yaml"))
+	assert.True(t, os.IsNotExist(statErr))
+
+	require.True(t, applyEventsViaPlanFlush(t, writer, worktree, limitedCMEvent("CREATE", "small", "green", 1024)))
+	assert.FileExists(t, filepath.Join(dir, "small.yaml"))
+
+	assert.False(t, applyEventsViaPlanFlush(t, writer, worktree, limitedCMEvent("UPDATE", "small", blob, 1024)),
+		"an update that grows past the limit is skipped")
+	got, err := os.ReadFile(filepath.Join(dir, "small.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(got), "color: green")
+
+	require.True(t, applyEventsViaPlanFlush(t, writer, worktree, limitedCMEvent("CREATE", "huge", blob, 0)),
+		"0 is unlimited")
+	assert.FileExists(t, filepath.Join(dir, "huge.yaml"))
+}
+
+// A resync counts the resources it skipped for their size in its stats, beside the placement skips.
+func TestApplyResync_CountsOversizedSkips(t *testing.T) {
+	writer := newContentWriter(types.SensitiveResourcePolicy{})
+	worktree := newWorktreeForTest(t)
+	w := &BranchWorker{contentWriter: writer, mapper: configMapMapper()}
+
+	target := ResolvedTargetMetadata{PruneMode: v1alpha3.PruneOnEvent, MaxResourceBytes: 1024}
+	desired := []manifestanalyzer.DesiredResource{
+		desiredCM("huge", strings.Repeat("x", 4096)),
+		desiredCM("small", "green"),
+	}
+	stats, changed, err := w.applyResyncToWorktree(context.Background(), worktree, "", target, desired, nil)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, 1, stats.Created)
+	assert.Equal(t, 1, stats.OversizedSkipped)
+	assert.Zero(t, stats.PlacementSkipped)
+}
+
+// The document the size check renders is the one written: a resource within the limit is rendered
+// once and written byte for byte as an unlimited write renders it.
+func TestSkipOversized_KeepsTheRenderedDocument(t *testing.T) {
+	event := limitedCMEvent("CREATE", "small", "green", 1024)
+	require.False(t, skipOversized(context.Background(), &event))
+	require.NotNil(t, event.rendered)
+
+	writer := newContentWriter(types.SensitiveResourcePolicy{})
+	unlimited := limitedCMEvent("CREATE", "small", "green", 0)
+	want, err := writer.buildContentForWrite(context.Background(), unlimited)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(event.rendered))
+}
@@ -144,6 +144,7 @@ func (l *branchWorkerEventLoop) applyResync(req *ResyncRequest) {
 		"deleted", stats.Deleted,
 		"skipped", stats.Skipped,
 		"placementSkipped", stats.PlacementSkipped,
+		"oversizedSkipped", stats.OversizedSkipped,
 		"pendingWrites", len(l.pendingWrites))
 	req.reply(ResyncResult{Stats: *stats})
 }
@@ -223,7 +224,7 @@ func (w *BranchWorker) executeResyncPendingWrite(
 	target := pendingWrite.Target()
 	base := sanitizePath(target.Path)
 
-	if err := w.refuseUnsafeWorktree(ctx, worktree, base, target.SourceCluster); err != nil {
+	if err := w.refuseUnsafeWorktree(ctx, worktree, base, target); err != nil {
 		return 0, err
 	}
 
@@ -278,22 +279,25 @@ func (w *BranchWorker) executeResyncPendingWrite(
 	log.FromContext(ctx).Info("git resync commit created",
 		"created", stats.Created, "updated", stats.Updated,
 		"deleted", stats.Deleted, "skipped", stats.Skipped,
-		"placementSkipped", stats.PlacementSkipped, "revision", pendingWrite.Revision)
+		"placementSkipped", stats.PlacementSkipped, "oversizedSkipped", stats.OversizedSkipped,
+		"revision", pendingWrite.Revision)
 	return 1, nil
 }
 
 func (w *BranchWorker) refuseUnsafeWorktree(
 	ctx context.Context,
 	worktree *gogit.Worktree,
-	base, clusterID string,
+	base string,
+	target ResolvedTargetMetadata,
 ) error {
 	root := worktree.Filesystem.Root()
-	scoped, err := scanRenderScope(root, base)
+	scoped, err := scanRenderScope(root, base, target.OutputFormat)
 	if err != nil {
 		return err
 	}
 	// The acceptance gate never places a resource, so no placement policy is needed here.
-	batch := newWriteBatch(ctx, w.contentWriter, w.mapperForCluster(clusterID), scoped.scan, nil, scoped.writeSubdir)
+	mapper := w.mapperForCluster(target.SourceCluster)
+	batch := newWriteBatch(ctx, w.contentWriter, mapper, scoped.scan, nil, scoped.writeSubdir)
 	return batch.refusal()
 }
 
@@ -337,7 +341,7 @@ func (w *BranchWorker) applyResyncToWorktree(
 	// individual reader has to remember.
 	target.PruneMode = target.PruneMode.OrDefault()
 	root := worktree.Filesystem.Root()
-	scoped, err := scanRenderScope(root, base)
+	scoped, err := scanRenderScope(root, base, target.OutputFormat)
 	if err != nil {
 		return ResyncStats{}, false, err
 	}
@@ -365,10 +369,18 @@ func (w *BranchWorker) applyResyncToWorktree(
 	plan := resyncPlan(batch.store, scoped.scan.YAMLFiles, desired, scope, target.PruneMode)
 	w.reportRetainedOrphans(ctx, plan, target, base, scope)
 
-	stats, err := batch.applyResyncPlan(ctx, desired, plan)
+	stats, err := batch.applyResyncPlan(ctx, desired, plan, target)
 	if err != nil {
 		return ResyncStats{}, false, err
 	}
+	// The planner does not see the items of an item List (aggregateByKind), so they are swept
+	// here, under the same prune gate: the per-type resync's own Lists, or, for a whole-GitTarget
+	// resync, those of every type the desired set aggregates.
+	if target.PruneMode.SweepsOrphans() {
+		for _, listScope := range itemListSweepScopes(desired, scope) {
+			stats.Deleted += batch.sweepItemLists(ctx, desired, listScope, target)
+		}
+	}
 	stats.PruneMode = target.PruneMode
 	// Anchored at renderBase; the write jail (writeSubdir) is enforced inside the flush.
 	changed, err := batch.flush(ctx, worktree, root, scoped.renderBase)
@@ -385,6 +397,7 @@ func (wb *writeBatch) applyResyncPlan(
 	ctx context.Context,
 	desired []manifestanalyzer.DesiredResource,
 	plan manifestanalyzer.Plan,
+	target ResolvedTargetMetadata,
 ) (ResyncStats, error) {
 	var stats ResyncStats
 	for _, dr := range desired {
@@ -396,7 +409,7 @@ func (wb *writeBatch) applyResyncPlan(
 		// Count from what the upsert actually did, not from the plan: a sensitive
 		// resource is PlanSkip in the plan but applyUpsert re-encrypts and changes it,
 		// so plan-based stats would report a real commit as skipped.
-		outcome, err := wb.applyUpsert(ctx, eventForDesired(dr))
+		outcome, err := wb.applyUpsert(ctx, eventForDesired(dr, target))
 		if err != nil {
 			return ResyncStats{}, err
 		}
@@ -411,6 +424,9 @@ func (wb *writeBatch) applyResyncPlan(
 			// of vanishing between Created and Skipped; the per-resource reason is
 			// already logged at the skip site.
 			stats.PlacementSkipped++
+		case upsertSkippedOversized:
+			// Counted for the same reason; the skip site logs the size and the cap.
+			stats.OversizedSkipped++
 		case upsertNoChange:
 		}
 	}
@@ -552,12 +568,17 @@ func (wb *writeBatch) dropDocument(filePath string, id manifestedit.Identity) bo
 // eventForDesired adapts a desired snapshot entry into the Event the content-derived
 // upsert path consumes. The operation is informational here (applyUpsert only
 // distinguishes DELETE from everything else); the object and identity carry
-// everything placement, rendering, and sensitive-resource encryption need.
-func eventForDesired(dr manifestanalyzer.DesiredResource) Event {
+// everything placement, rendering, and sensitive-resource encryption need, with the
+// GitTarget's rendering options and output format alongside.
+func eventForDesired(dr manifestanalyzer.DesiredResource, target ResolvedTargetMetadata) Event {
 	return Event{
-		Object:     dr.Object,
-		Identifier: dr.Resource,
-		Operation:  "RECONCILE",
+		Object:           dr.Object,
+		Identifier:       dr.Resource,
+		Operation:        "RECONCILE",
+		YAMLOutput:       target.YAMLOutput,
+		OutputFormat:     target.OutputFormat,
+		AggregateByKind:  dr.AggregateByKind,
+		MaxResourceBytes: target.MaxResourceBytes,
 	}
 }
 
@@ -3,8 +3,10 @@
 package git
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -19,6 +21,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/events"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	configv1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
@@ -130,6 +133,9 @@ func TestBranchWorker_SecretEncryptionFailureDoesNotWritePlaintext(t *testing.T)
 	err = worker.commitPendingWrites([]PendingWrite{*pendingWrite}, false)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "secret encryption is required")
+	assert.Equal(t,
+		[]interface{}{"failedResource", event.Identifier.String(), "failedOperation", "CREATE"},
+		failedEventLogFields(err), "the dropped commit names the event that failed it")
 
 	repoPath := worker.repoPathForRemote(remoteURL)
 	secretPath := filepath.Join(repoPath, "v1", "secrets", "default", "test-secret.yaml")
@@ -141,6 +147,50 @@ func TestBranchWorker_SecretEncryptionFailureDoesNotWritePlaintext(t *testing.T)
 	assert.Error(t, statErr, "Secret file should not be written when encryption fails")
 }
 
+// TestBranchWorker_ApplyFailureRecordsWarningEvent verifies a commit dropped because one of its
+// events failed to apply records an ApplyFailed Warning on that event's GitTarget, naming the
+// resource and operation that failed.
+func TestBranchWorker_ApplyFailureRecordsWarningEvent(t *testing.T) {
+	tempDir := t.TempDir()
+	remotePath := filepath.Join(tempDir, "remote.git")
+	remoteURL := "file://" + remotePath
+	createBareRepo(t, remotePath)
+
+	target := &configv1alpha3.GitTarget{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Namespace: "default"}}
+	worker, err := newTestBranchWorker(remoteURL, "test-repo", "master", target)
+	require.NoError(t, err)
+	recorder := events.NewFakeRecorder(4)
+	worker.recorder = recorder
+
+	event := Event{
+		Object: &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Secret",
+			"metadata":   map[string]interface{}{"name": "test-secret", "namespace": "default"},
+			"data":       map[string]interface{}{"password": "ZG8tbm90LWNvbW1pdA=="},
+		}},
+		Identifier: types.ResourceIdentifier{
+			Version: "v1", Resource: "secrets", Namespace: "default", Name: "test-secret",
+		},
+		Operation:          "CREATE",
+		GitTargetName:      "team-a",
+		GitTargetNamespace: "default",
+	}
+
+	pendingWrite, err := worker.buildGroupedPendingWrite(worker.ctx, []Event{event})
+	require.NoError(t, err)
+	err = worker.commitPendingWrites([]PendingWrite{*pendingWrite}, false)
+	require.Error(t, err)
+
+	worker.recordApplyFailedEvent(err, pendingTargetKey{}, 1)
+	require.Len(t, recorder.Events, 1)
+	assert.True(t, strings.HasPrefix(<-recorder.Events,
+		"Warning ApplyFailed Dropped 1 events: CREATE "+event.Identifier.String()+" failed: "))
+
+	worker.recordApplyFailedEvent(errors.New("push failed"), pendingTargetKey{Name: "team-a", Namespace: "default"}, 1)
+	assert.Empty(t, recorder.Events, "an error that names no failed event records nothing")
+}
+
 func TestBranchWorker_SecretWritesSOPSPath(t *testing.T) {
 	installFakeSOPSBinary(t)
 
@@ -18,6 +18,9 @@ type SOPSEncryptor struct {
 	configPath string
 	workDir    string
 	env        map[string]string
+	// encryptedRegex, when set, overrides the .sops.yaml encrypted_regex so only matching keys
+	// are encrypted and the rest of the document stays plaintext.
+	encryptedRegex string
 }
 
 // NewSOPSEncryptor creates an Encryptor that shells out to sops.
@@ -48,6 +51,9 @@ func (e *SOPSEncryptor) Encrypt(ctx context.Context, plain []byte, meta Resource
 		"--filename-override", sopsFilenameOverride(meta),
 		"/dev/stdin",
 	}
+	if e.encryptedRegex != "" {
+		args = append([]string{"--encrypted-regex", e.encryptedRegex}, args...)
+	}
 	if strings.TrimSpace(e.configPath) != "" {
 		args = append([]string{"--config", e.configPath}, args...)
 	}
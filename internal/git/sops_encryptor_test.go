@@ -5,11 +5,16 @@ package git
 import (
 	"context"
 	"os"
+	"os/exec"
+	"path"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"filippo.io/age"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
 )
 
 func TestSOPSEncryptorEncrypt(t *testing.T) {
@@ -40,3 +45,70 @@ func TestSOPSEncryptorEncryptFailure(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "sops encryption failed")
 }
+
+func TestSOPSEncryptorEncrypt_EncryptedRegexOverridesConfig(t *testing.T) {
+	dir := t.TempDir()
+	argsFile := filepath.Join(dir, "args")
+	script := filepath.Join(dir, "sops")
+	require.NoError(t, os.WriteFile(script, []byte(`#!/usr/bin/env bash
+set -euo pipefail
+printf '%s\n' "$@" > "`+argsFile+`"
+cat
+`), 0700))
+
+	encryptor := NewSOPSEncryptor(script, "")
+	_, err := encryptor.Encrypt(context.Background(), []byte("kind: Secret\n"), ResourceMeta{})
+	require.NoError(t, err)
+	args, err := os.ReadFile(argsFile)
+	require.NoError(t, err)
+	assert.NotContains(t, string(args), "--encrypted-regex", "the .sops.yaml rule applies by default")
+
+	encryptor.encryptedRegex = "^(password)$"
+	_, err = encryptor.Encrypt(context.Background(), []byte("kind: Secret\n"), ResourceMeta{})
+	require.NoError(t, err)
+	args, err = os.ReadFile(argsFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(args), "--encrypted-regex\n^(password)$\n")
+}
+
+// TestSOPSEncryptorEncrypt_OnlyListedKeysAreCiphertext runs the real sops binary against a
+// bootstrapped .sops.yaml and checks that partial encryption leaves unlisted keys readable.
+func TestSOPSEncryptorEncrypt_OnlyListedKeysAreCiphertext(t *testing.T) {
+	if _, err := exec.LookPath("sops"); err != nil {
+		t.Skip("sops not found in PATH")
+	}
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	raw, err := bootstrapTemplateFS.ReadFile(path.Join(bootstrapTemplateDir, sopsConfigFileName))
+	require.NoError(t, err)
+	sopsConfig, err := renderSOPSBootstrapTemplate(raw, bootstrapTemplateData{
+		AgeRecipients: []string{identity.Recipient().String()},
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, sopsConfigFileName), sopsConfig, 0600))
+
+	encryptor := NewSOPSEncryptorWithEnv("sops", "", dir, nil)
+	encryptor.encryptedRegex = sopsEncryptedRegex([]string{"password"})
+	plain := []byte(`apiVersion: v1
+kind: Secret
+metadata:
+  name: app
+  namespace: default
+stringData:
+  config.yaml: "listen: 8080"
+  password: hunter2
+`)
+	out, err := encryptor.Encrypt(context.Background(), plain, ResourceMeta{})
+	require.NoError(t, err)
+
+	var doc struct {
+		StringData map[string]string `json:"stringData"`
+		SOPS       map[string]any    `json:"sops"`
+	}
+	require.NoError(t, yaml.Unmarshal(out, &doc), string(out))
+	assert.True(t, strings.HasPrefix(doc.StringData["password"], "ENC["), "listed key must be ciphertext")
+	assert.Equal(t, "listen: 8080", doc.StringData["config.yaml"], "unlisted key must stay readable")
+	assert.Equal(t, "^(password)$", doc.SOPS["encrypted_regex"], "the file records its partial-encryption rule")
+}
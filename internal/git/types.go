@@ -5,6 +5,7 @@ package git
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	gogit "github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
@@ -14,6 +15,7 @@ import (
 	v1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
 	"github.com/ConfigButler/gitops-reverser/internal/git/manifestedit"
 	"github.com/ConfigButler/gitops-reverser/internal/manifestanalyzer"
+	"github.com/ConfigButler/gitops-reverser/internal/sanitize"
 	"github.com/ConfigButler/gitops-reverser/internal/types"
 )
 
@@ -183,6 +185,10 @@ type UserInfo struct {
 	// Email is the address from the OIDC "email" claim, when the audit event
 	// carries it. Empty means "fall back to ConstructSafeEmail(Username)".
 	Email string
+	// Groups are the groups the API server authenticated the user into, as the audit event
+	// recorded them. They are offered to commit message templates only; user.extra is not
+	// carried, because it holds credential and session identifiers that do not belong in Git.
+	Groups []string
 }
 
 // CommitMode defines how a write request should be committed.
@@ -262,6 +268,19 @@ type ResolvedTargetMetadata struct {
 	// documents' GVK->GVR against that cluster's registry, so a folder mirroring a remote is swept
 	// against the right cluster's mapping.
 	SourceCluster string
+	// YAMLOutput is the GitTarget's spec.yaml, resolved to the renderer's options. It applies
+	// only where a document is rendered from scratch; the zero value renders block style.
+	YAMLOutput sanitize.MarshalOptions
+	// OutputFormat is the GitTarget's spec.outputFormat: the file format of new documents. The
+	// zero value writes YAML.
+	OutputFormat v1alpha3.OutputFormat
+	// MaxResourceBytes is the GitTarget's spec.maxResourceBytes: the largest rendered document
+	// the writer writes. 0 is unlimited.
+	MaxResourceBytes int64
+	// UserMapping is the GitTarget's spec.userMapping, read fresh each time the target is
+	// resolved: Kubernetes username to the git author its commits are recorded under. Nil when
+	// the GitTarget declares none.
+	UserMapping *userMapping
 }
 
 // PendingWrite is the unit retained until a push succeeds.
@@ -421,13 +440,15 @@ type ResyncResult struct {
 // co-mingle sensitive and plaintext documents (placement Option B2). It is counted (not
 // silently swallowed) and logged per-resource so a not-mirrored resource is visible
 // in the resync summary; it is not (yet) surfaced as a dedicated GitTarget status
-// condition.
+// condition. OversizedSkipped is resources not written because their document exceeds
+// spec.maxResourceBytes; each is logged and counted at the skip site as well.
 type ResyncStats struct {
 	Created          int
 	Updated          int
 	Deleted          int
 	Skipped          int
 	PlacementSkipped int
+	OversizedSkipped int
 	// Retained is how many managed documents this resync's prune policy kept that a converged
 	// mirror would have dropped. It is the ONE count here that does not describe something the
 	// resync did: a suppressed drop produces no action, no commit, and no other stat, so without
@@ -483,6 +504,11 @@ type Event struct {
 	// writer. See docs/finished/signing-snapshot-tail-replay-failure-investigation.md §7.
 	AuditStreamID string
 
+	// ReceivedAt is when the live watch delivered this change to the controller. It is zero for
+	// snapshot, resync, and replay events, which have no single change to time. A push measures
+	// gitopsreverser_event_to_commit_seconds from the oldest ReceivedAt it carried.
+	ReceivedAt time.Time
+
 	// UserInfo contains user information for commit messages.
 	UserInfo UserInfo
 
@@ -516,6 +542,29 @@ type Event struct {
 
 	// BootstrapOptions controls path-scoped bootstrap file staging for this event.
 	BootstrapOptions pathBootstrapOptions
+
+	// YAMLOutput is the owning GitTarget's spec.yaml rendering options. It applies only where
+	// the writer renders this event's document from scratch; a document edited in place keeps
+	// the style it already has. The zero value renders block style.
+	YAMLOutput sanitize.MarshalOptions
+
+	// OutputFormat is the owning GitTarget's spec.outputFormat. JSON places a new document in
+	// a .json file; an existing document keeps the format of the file it lives in. The zero
+	// value writes YAML.
+	OutputFormat v1alpha3.OutputFormat
+
+	// AggregateByKind places the resource, when it has no document yet, as an item of the List
+	// document its type keeps per namespace, as the WatchRule that matched it asks. An item
+	// already in such a List is edited there whatever this says.
+	AggregateByKind bool
+
+	// MaxResourceBytes is the owning GitTarget's spec.maxResourceBytes. A resource whose
+	// rendered document is larger is skipped, not written. 0 is unlimited.
+	MaxResourceBytes int64
+
+	// rendered is Object's document as the size check rendered it, reused by the write so the
+	// object is not marshaled twice. Nil until the check runs.
+	rendered []byte
 }
 
 // IsFieldPatch reports whether the event carries a bounded field patch instead of
@@ -576,7 +625,9 @@ type CommitMessageData struct {
 	Name       string
 	APIVersion string
 	Username   string
-	GitTarget  string
+	// Groups are the acting user's authenticated groups; empty whenever Username is.
+	Groups    []string
+	GitTarget string
 }
 
 // ReconcileCommitMessageData is the template context for reconcile commit messages.
@@ -640,6 +691,9 @@ func (r ResourceRef) String() string {
 type GroupedCommitMessageData struct {
 	// Author is the verbatim event.UserInfo.Username for the group.
 	Author string
+	// Groups are the author's authenticated groups, as recorded on the first event that
+	// carries them.
+	Groups []string
 	// GitTarget is the single target this commit is bound to.
 	GitTarget string
 	// Count is the number of distinct resources committed.
@@ -0,0 +1,217 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/ConfigButler/gitops-reverser/api/v1alpha3"
+)
+
+// mappedAuthor is the git author a GitTarget's spec.userMapping assigns to a Kubernetes username.
+type mappedAuthor struct {
+	Name  string
+	Email string
+}
+
+// userMapping is a GitTarget's resolved spec.userMapping: the Secret's exact usernames, then the
+// patterns in declaration order.
+type userMapping struct {
+	exact    map[string]mappedAuthor
+	patterns []authorPattern
+}
+
+// authorPattern is one compiled spec.userMapping.patterns entry. name and email are templates
+// that may reference the pattern's capture groups.
+type authorPattern struct {
+	re    *regexp.Regexp
+	name  string
+	email string
+}
+
+// authorFor returns the author username maps to. An exact Secret entry wins; otherwise the first
+// pattern that matches the whole username decides. A pattern whose expanded name or email is not
+// a safe author maps nothing, so the user keeps the author attribution derives rather than
+// falling through to a later, broader pattern.
+func (m *userMapping) authorFor(username string) (mappedAuthor, bool) {
+	if m == nil {
+		return mappedAuthor{}, false
+	}
+	if author, ok := m.exact[username]; ok {
+		return author, true
+	}
+	for _, pattern := range m.patterns {
+		match := pattern.re.FindStringSubmatchIndex(username)
+		if match == nil {
+			continue
+		}
+		name := strings.TrimSpace(string(pattern.re.ExpandString(nil, pattern.name, username, match)))
+		email := strings.TrimSpace(string(pattern.re.ExpandString(nil, pattern.email, username, match)))
+		if name == "" || !isSafeSignatureField(name) || !validEmailRegex.MatchString(email) {
+			return mappedAuthor{}, false
+		}
+		return mappedAuthor{Name: name, Email: email}, true
+	}
+	return mappedAuthor{}, false
+}
+
+// resolveUserMapping reads the GitTarget's spec.userMapping: the Secret when one is named, and the
+// patterns, compiled through cache (nil compiles them afresh). It returns nil when the GitTarget
+// declares no mapping. A missing Secret, a missing key, any entry that is not a safe "Name <email>"
+// author, or a pattern that does not compile rejects the whole mapping, so a typo in one entry
+// cannot silently apply the others.
+func resolveUserMapping(
+	ctx context.Context,
+	k8sClient client.Client,
+	target *v1alpha3.GitTarget,
+	cache *authorPatternCache,
+) (*userMapping, error) {
+	spec := target.Spec.UserMapping
+	if spec == nil {
+		return nil, nil //nolint:nilnil // nil means no mapping declared
+	}
+
+	patterns, err := cache.compile(target)
+	if err != nil {
+		return nil, err
+	}
+	mapping := &userMapping{patterns: patterns}
+	if spec.SecretRef == nil {
+		return mapping, nil
+	}
+
+	secretKey := types.NamespacedName{Name: spec.SecretRef.Name, Namespace: target.Namespace}
+	var secret corev1.Secret
+	if err := k8sClient.Get(ctx, secretKey, &secret); err != nil {
+		return nil, fmt.Errorf("failed to get userMapping Secret %s: %w", secretKey, err)
+	}
+	data, ok := secret.Data[v1alpha3.UserMappingSecretKey]
+	if !ok {
+		return nil, fmt.Errorf("userMapping Secret %s has no %q key", secretKey, v1alpha3.UserMappingSecretKey)
+	}
+	mapping.exact, err = parseUserMapping(data)
+	if err != nil {
+		return nil, fmt.Errorf("userMapping Secret %s: %w", secretKey, err)
+	}
+	return mapping, nil
+}
+
+// ValidateUserMappingPatterns compiles spec.userMapping.patterns the way the writer does, so the
+// GitTarget controller can refuse a pattern that does not compile on the Validated condition.
+func ValidateUserMappingPatterns(specs []v1alpha3.UserMappingPattern) error {
+	_, err := compileAuthorPatterns(specs)
+	return err
+}
+
+// authorPatternCache holds each GitTarget's compiled spec.userMapping.patterns, so they are
+// compiled once per generation of the GitTarget rather than for every commit. The zero value is
+// ready to use.
+type authorPatternCache struct {
+	mu       sync.Mutex
+	byTarget map[pendingTargetKey]compiledAuthorPatterns
+}
+
+// compiledAuthorPatterns is one GitTarget generation's compiled patterns, or the error compiling
+// them returned.
+type compiledAuthorPatterns struct {
+	uid        types.UID
+	generation int64
+	patterns   []authorPattern
+	err        error
+}
+
+// compile returns the target's compiled patterns, compiling them when the cache holds none for
+// this generation of the target. A nil cache always compiles.
+func (c *authorPatternCache) compile(target *v1alpha3.GitTarget) ([]authorPattern, error) {
+	if c == nil {
+		return compileAuthorPatterns(target.Spec.UserMapping.Patterns)
+	}
+	key := pendingTargetKey{Name: target.Name, Namespace: target.Namespace}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cached, ok := c.byTarget[key]; ok && cached.uid == target.UID && cached.generation == target.Generation {
+		return cached.patterns, cached.err
+	}
+	patterns, err := compileAuthorPatterns(target.Spec.UserMapping.Patterns)
+	if c.byTarget == nil {
+		c.byTarget = make(map[pendingTargetKey]compiledAuthorPatterns)
+	}
+	c.byTarget[key] = compiledAuthorPatterns{
+		uid: target.UID, generation: target.Generation, patterns: patterns, err: err,
+	}
+	return patterns, err
+}
+
+// forget drops a deleted GitTarget's compiled patterns.
+func (c *authorPatternCache) forget(key pendingTargetKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.byTarget, key)
+}
+
+// compileAuthorPatterns compiles each usernamePattern anchored to the whole username, so
+// "system:serviceaccount:.+" cannot also match a username that merely contains it.
+func compileAuthorPatterns(specs []v1alpha3.UserMappingPattern) ([]authorPattern, error) {
+	patterns := make([]authorPattern, 0, len(specs))
+	for i, spec := range specs {
+		re, err := regexp.Compile("^(?:" + spec.UsernamePattern + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("userMapping pattern %d %q: %w", i, spec.UsernamePattern, err)
+		}
+		patterns = append(patterns, authorPattern{re: re, name: spec.GitName, email: spec.GitEmail})
+	}
+	return patterns, nil
+}
+
+// parseUserMapping decodes a JSON object from Kubernetes username to "Name <email>" author.
+// Entries are checked in username order so the reported error is stable.
+func parseUserMapping(data []byte) (map[string]mappedAuthor, error) {
+	var raw map[string]string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("%s is not a JSON object of strings: %w", v1alpha3.UserMappingSecretKey, err)
+	}
+
+	usernames := make([]string, 0, len(raw))
+	for username := range raw {
+		usernames = append(usernames, username)
+	}
+	sort.Strings(usernames)
+
+	mapping := make(map[string]mappedAuthor, len(raw))
+	for _, username := range usernames {
+		author, err := parseMappedAuthor(raw[username])
+		if err != nil {
+			return nil, fmt.Errorf("entry for %q: %w", username, err)
+		}
+		mapping[username] = author
+	}
+	return mapping, nil
+}
+
+// parseMappedAuthor parses a "Name <email>" author and checks both halves can be placed verbatim
+// into a commit's author header.
+func parseMappedAuthor(value string) (mappedAuthor, error) {
+	address, err := mail.ParseAddress(strings.TrimSpace(value))
+	if err != nil {
+		return mappedAuthor{}, fmt.Errorf("%q is not in \"Name <email>\" form: %w", value, err)
+	}
+	name := strings.TrimSpace(address.Name)
+	if name == "" || !isSafeSignatureField(name) {
+		return mappedAuthor{}, fmt.Errorf("%q has no usable author name", value)
+	}
+	if !validEmailRegex.MatchString(address.Address) {
+		return mappedAuthor{}, fmt.Errorf("%q has no valid email address", value)
+	}
+	return mappedAuthor{Name: name, Email: address.Address}, nil
+}
@@ -0,0 +1,173 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/ConfigButler/gitops-reverser/api/v1alpha3"
+)
+
+func TestParseUserMapping(t *testing.T) {
+	mapping, err := parseUserMapping([]byte(
+		`{"alice@company.com": "Alice Smith <alice@company.com>", "oidc:bob": "\"Jones, Bob\" <bob@company.com>"}`,
+	))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]mappedAuthor{
+		"alice@company.com": {Name: "Alice Smith", Email: "alice@company.com"},
+		"oidc:bob":          {Name: "Jones, Bob", Email: "bob@company.com"},
+	}, mapping)
+
+	for name, data := range map[string]string{
+		"not an object": `["alice"]`,
+		"bare address":  `{"alice": "alice@company.com"}`,
+		"no address":    `{"alice": "Alice Smith"}`,
+		"invalid email": `{"alice": "Alice <alice@localhost>"}`,
+		"unsafe name":   `{"alice": "=?utf-8?q?Alice=0ASmith?= <alice@company.com>"}`,
+	} {
+		_, err := parseUserMapping([]byte(data))
+		assert.Error(t, err, name)
+	}
+}
+
+func TestResolveUserMapping_ReadsTheTargetNamespaceSecret(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "git-authors", Namespace: "team-a"},
+		Data: map[string][]byte{
+			v1alpha3.UserMappingSecretKey: []byte(`{"alice": "Alice Smith <alice@company.com>"}`),
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	target := &v1alpha3.GitTarget{ObjectMeta: metav1.ObjectMeta{Name: "target", Namespace: "team-a"}}
+
+	mapping, err := resolveUserMapping(context.Background(), c, target, nil)
+	require.NoError(t, err)
+	assert.Nil(t, mapping, "no spec.userMapping, no mapping")
+
+	target.Spec.UserMapping = &v1alpha3.UserMappingSpec{
+		SecretRef: &v1alpha3.LocalSecretReference{Name: "git-authors"},
+	}
+	mapping, err = resolveUserMapping(context.Background(), c, target, nil)
+	require.NoError(t, err)
+	assert.Equal(t, mappedAuthor{Name: "Alice Smith", Email: "alice@company.com"}, mapping.exact["alice"])
+
+	target.Namespace = "team-b"
+	_, err = resolveUserMapping(context.Background(), c, target, nil)
+	require.ErrorContains(t, err, "failed to get userMapping Secret team-b/git-authors")
+}
+
+func TestCommitOptionsFor_UserMappingRewritesOnlyMappedAuthors(t *testing.T) {
+	write := func(event Event) PendingWrite {
+		pw := commitWrite(event)
+		pw.Targets = map[pendingTargetKey]ResolvedTargetMetadata{
+			{Name: "target", Namespace: "default"}: {
+				Name:      "target",
+				Namespace: "default",
+				UserMapping: &userMapping{exact: map[string]mappedAuthor{
+					"alice": {Name: "Alice Smith", Email: "alice@company.com"},
+				}},
+			},
+		}
+		return pw
+	}
+	config := ResolveCommitConfig(nil)
+
+	options := commitOptionsFor(write(attributedEvent("alice", AttributionResolved)), config, nil, time.Now())
+	assert.Equal(t, "Alice Smith", options.Author.Name)
+	assert.Equal(t, "alice@company.com", options.Author.Email)
+	assert.Equal(t, DefaultCommitterName, options.Committer.Name, "the committer is never mapped")
+
+	options = commitOptionsFor(write(attributedEvent("bob", AttributionResolved)), config, nil, time.Now())
+	assert.Equal(t, "bob", options.Author.Name, "unmapped users keep their derived author")
+
+	options = commitOptionsFor(write(attributedEvent("", AttributionUnresolved)), config, nil, time.Now())
+	assert.Equal(t, UnresolvedAuthorDisplayName, options.Author.Name)
+}
+
+func TestUserMapping_PatternsFollowExactEntries(t *testing.T) {
+	patterns, err := compileAuthorPatterns([]v1alpha3.UserMappingPattern{
+		{UsernamePattern: "system:serviceaccount:(.+):(.+)", GitName: "$2 ($1)", GitEmail: "${2}@serviceaccounts.internal"},
+		{UsernamePattern: "system:serviceaccount:.+", GitName: "unreachable", GitEmail: "x@example.com"},
+		{UsernamePattern: "ci-(.*)", GitName: "CI $1", GitEmail: "$1"},
+	})
+	require.NoError(t, err)
+	mapping := &userMapping{
+		exact: map[string]mappedAuthor{
+			"system:serviceaccount:flux-system:kustomize-controller": {Name: "Flux", Email: "flux@company.com"},
+		},
+		patterns: patterns,
+	}
+
+	author, ok := mapping.authorFor("system:serviceaccount:flux-system:kustomize-controller")
+	require.True(t, ok)
+	assert.Equal(t, mappedAuthor{Name: "Flux", Email: "flux@company.com"}, author, "an exact entry wins")
+
+	author, ok = mapping.authorFor("system:serviceaccount:apps:deployer")
+	require.True(t, ok)
+	assert.Equal(t, mappedAuthor{Name: "deployer (apps)", Email: "deployer@serviceaccounts.internal"}, author)
+
+	_, ok = mapping.authorFor("alice-system:serviceaccount:apps:deployer")
+	assert.False(t, ok, "a pattern matches the whole username only")
+
+	_, ok = mapping.authorFor("ci-runner")
+	assert.False(t, ok, "an expansion that is no valid email maps nothing")
+
+	var none *userMapping
+	_, ok = none.authorFor("alice")
+	assert.False(t, ok)
+}
+
+func TestResolveUserMapping_PatternsWithoutSecret(t *testing.T) {
+	target := &v1alpha3.GitTarget{ObjectMeta: metav1.ObjectMeta{Name: "target", Namespace: "team-a"}}
+	target.Spec.UserMapping = &v1alpha3.UserMappingSpec{Patterns: []v1alpha3.UserMappingPattern{
+		{UsernamePattern: "bot-(.+)", GitName: "Bot $1", GitEmail: "$1@bots.example.com"},
+	}}
+	c := fake.NewClientBuilder().Build()
+
+	mapping, err := resolveUserMapping(context.Background(), c, target, nil)
+	require.NoError(t, err)
+	author, ok := mapping.authorFor("bot-renovate")
+	require.True(t, ok)
+	assert.Equal(t, mappedAuthor{Name: "Bot renovate", Email: "renovate@bots.example.com"}, author)
+
+	target.Spec.UserMapping.Patterns[0].UsernamePattern = "bot-(.+"
+	_, err = resolveUserMapping(context.Background(), c, target, nil)
+	require.ErrorContains(t, err, "userMapping pattern 0")
+	require.ErrorContains(t, ValidateUserMappingPatterns(target.Spec.UserMapping.Patterns), "userMapping pattern 0")
+}
+
+// The patterns are compiled once per generation of the GitTarget, not for every commit.
+func TestAuthorPatternCache_CompilesOncePerGeneration(t *testing.T) {
+	target := &v1alpha3.GitTarget{ObjectMeta: metav1.ObjectMeta{Name: "target", Namespace: "team-a", Generation: 1}}
+	target.Spec.UserMapping = &v1alpha3.UserMappingSpec{Patterns: []v1alpha3.UserMappingPattern{
+		{UsernamePattern: "bot-(.+)", GitName: "Bot $1", GitEmail: "$1@bots.example.com"},
+	}}
+	var cache authorPatternCache
+
+	first, err := cache.compile(target)
+	require.NoError(t, err)
+	again, err := cache.compile(target)
+	require.NoError(t, err)
+	assert.Same(t, first[0].re, again[0].re, "the same generation reuses the compiled pattern")
+
+	target.Generation = 2
+	target.Spec.UserMapping.Patterns[0].UsernamePattern = "ci-(.+)"
+	edited, err := cache.compile(target)
+	require.NoError(t, err)
+	assert.True(t, edited[0].re.MatchString("ci-runner"), "a new generation recompiles")
+
+	cache.forget(pendingTargetKey{Name: "target", Namespace: "team-a"})
+	assert.Empty(t, cache.byTarget)
+}
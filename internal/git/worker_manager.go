@@ -6,20 +6,36 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
+	"k8s.io/client-go/tools/events"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 
 	configv1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
 	"github.com/ConfigButler/gitops-reverser/internal/types"
 	"github.com/ConfigButler/gitops-reverser/internal/typeset"
 )
 
+// stateEventsBuffer sizes the worker state-change channel. A full buffer means reconciles are
+// already pending, so a dropped event is harmless; the periodic requeue is the backstop.
+const stateEventsBuffer = 64
+
 // DefaultBranchBufferMaxBytes is the default cap on a worker's combined event
 // buffer + unpushed-events memory. Operators override this via
 // --branch-buffer-max-size (8Mi by default).
 const DefaultBranchBufferMaxBytes int64 = 8 * 1024 * 1024
 
+// DefaultDrainTimeout is how long a stopping branch worker may keep committing and pushing its
+// queued and retained work (--drain-timeout). It fits inside the chart's 20s termination grace
+// period with room for the rest of the shutdown.
+const DefaultDrainTimeout = 10 * time.Second
+
+// DefaultFetchDepth is how many commits of history each branch worker fetches (--fetch-depth):
+// the tip only, which is all committing and pushing need.
+const DefaultFetchDepth = 1
+
 // WorkerManager manages BranchWorkers.
 // Creates workers per (repo, branch), shared by multiple GitDestinations.
 // Implements controller-runtime's Runnable interface for lifecycle management.
@@ -29,6 +45,12 @@ type WorkerManager struct {
 
 	branchBufferMaxBytes int64
 	sensitiveResources   types.SensitiveResourcePolicy
+	// drainTimeout bounds each worker's shutdown drain. Set once at startup (SetDrainTimeout)
+	// before any worker is created.
+	drainTimeout time.Duration
+	// fetchDepth is each worker's fetch depth. Set once at startup (SetFetchDepth) before any
+	// worker is created.
+	fetchDepth int
 
 	mu      sync.RWMutex
 	workers map[BranchKey]*BranchWorker
@@ -53,6 +75,18 @@ type WorkerManager struct {
 	// CLI and in tests that do not assert on the status transition.
 	pathRefusal PathRefusalReporter
 
+	// commitAudit records every pushed commit. Set once at startup (SetCommitAuditLogger)
+	// before any worker is created; nil disables the commit audit log.
+	commitAudit CommitAuditLogger
+
+	// recorder records push outcomes as Events on GitTargets. Set once at startup
+	// (SetEventRecorder) before any worker is created; nil records no Events.
+	recorder events.EventRecorder
+
+	// stateEvents carries the workers' requests to re-project their state into GitTarget status
+	// (StateEvents). Created with the manager, so every worker is created with it.
+	stateEvents chan event.GenericEvent
+
 	// renderFidelityGate is shared by every worker and the watch manager. It is created with the
 	// manager so a target's state survives workers being recreated for the same branch.
 	renderFidelityGate *RenderFidelityGate
@@ -75,7 +109,10 @@ func NewWorkerManager(
 		Log:                  log,
 		branchBufferMaxBytes: branchBufferMaxBytes,
 		sensitiveResources:   sensitiveResources,
+		drainTimeout:         DefaultDrainTimeout,
+		fetchDepth:           DefaultFetchDepth,
 		workers:              make(map[BranchKey]*BranchWorker),
+		stateEvents:          make(chan event.GenericEvent, stateEventsBuffer),
 		renderFidelityGate:   NewRenderFidelityGate(),
 	}
 }
@@ -123,6 +160,45 @@ func (m *WorkerManager) SetPathRefusalReporter(reporter PathRefusalReporter) {
 	m.pathRefusal = reporter
 }
 
+// SetCommitAuditLogger injects the commit audit log every worker writes its pushed commits to.
+// Like SetMapper, it is called once at startup before any worker is created.
+func (m *WorkerManager) SetCommitAuditLogger(logger CommitAuditLogger) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.commitAudit = logger
+}
+
+// SetDrainTimeout sets how long each worker may keep committing and pushing queued work once
+// shutdown begins. Like SetMapper, it is called once at startup before any worker is created.
+func (m *WorkerManager) SetDrainTimeout(timeout time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.drainTimeout = timeout
+}
+
+// SetFetchDepth sets how many commits of history each worker fetches per branch; 0 fetches the
+// full history. Like SetMapper, it is called once at startup before any worker is created.
+func (m *WorkerManager) SetFetchDepth(depth int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.fetchDepth = depth
+}
+
+// SetEventRecorder injects the recorder every worker uses to record CommitPushed and CommitFailed
+// Events on GitTargets. Like SetMapper, it is called once at startup before any worker is created.
+func (m *WorkerManager) SetEventRecorder(recorder events.EventRecorder) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.recorder = recorder
+}
+
+// StateEvents returns the channel the GitTarget controller wires via source.Channel so a worker
+// entering or leaving the errored state enqueues the GitTargets of its GitProvider. Each event
+// carries the GitProvider's name and namespace.
+func (m *WorkerManager) StateEvents() <-chan event.GenericEvent {
+	return m.stateEvents
+}
+
 // RegisterTarget ensures a worker exists for the target's (provider, branch)
 // and registers the target with that worker.
 // This is called by GitTarget controller when a target becomes Ready.
@@ -182,6 +258,11 @@ func (m *WorkerManager) EnsureWorker(
 		worker.clusterMapper = m.clusterMapper
 		worker.sshHostKeys = m.sshHostKeys
 		worker.pathRefusal = m.pathRefusal
+		worker.commitAudit = m.commitAudit
+		worker.recorder = m.recorder
+		worker.stateEvents = m.stateEvents
+		worker.drainTimeout = m.drainTimeout
+		worker.fetchDepth = m.fetchDepth
 		worker.renderFidelityGate = m.renderFidelityGate
 
 		if err := worker.Start(m.ctx); err != nil {
@@ -225,6 +306,16 @@ func (m *WorkerManager) UnregisterTarget(
 	return nil
 }
 
+// ForgetGitTarget drops what every worker remembers for a deleted GitTarget. The GitTarget is gone,
+// so its provider and branch are unknown and every worker is asked.
+func (m *WorkerManager) ForgetGitTarget(name, namespace string) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, worker := range m.workers {
+		worker.forgetTarget(name, namespace)
+	}
+}
+
 // GetWorkerForTarget finds the worker for a target's (provider, branch).
 // Returns the worker and true if found, nil and false otherwise.
 // This is used by EventRouter to dispatch events to the correct worker.
@@ -308,11 +399,14 @@ func (m *WorkerManager) Start(ctx context.Context) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	// Stop all workers gracefully
+	// Stop all workers gracefully and in parallel, so every worker gets the whole drain timeout
+	// rather than the shutdown taking one timeout per worker.
+	var stopped sync.WaitGroup
 	for key, worker := range m.workers {
 		m.Log.Info("Stopping worker for shutdown", "key", key.String())
-		worker.Stop()
+		stopped.Go(worker.Stop)
 	}
+	stopped.Wait()
 
 	m.workers = make(map[BranchKey]*BranchWorker)
 	m.Log.Info("WorkerManager stopped")
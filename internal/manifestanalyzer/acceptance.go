@@ -375,6 +375,13 @@ func recordlessRefusal(store *ManifestStore, d manifestedit.Diagnostic) (Accepta
 		// Accompany a managed record (handled by duplicateRefusals / the planner skip);
 		// not a record-less gap.
 		return AcceptanceIssue{}, false
+	case manifestedit.ReasonItemList:
+		// The List a WatchRule's aggregateByKind maintains. In a file of its own it is managed
+		// content the writer edits; sharing a file with other documents it is impure.
+		if managed {
+			return impureIssue(d, "an item List"), true
+		}
+		return AcceptanceIssue{}, false
 	}
 	return AcceptanceIssue{}, false
 }
@@ -28,6 +28,7 @@ package manifestanalyzer
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io/fs"
 	"os"
@@ -89,6 +90,9 @@ const (
 	ClassNonKRM Class = "non-krm"
 	// ClassKRM is a valid Kubernetes manifest.
 	ClassKRM Class = "krm"
+	// ClassItemList is a `kind: List` document whose items a WatchRule's aggregateByKind
+	// maintains. It is accepted, and its items are not modeled as documents.
+	ClassItemList Class = "item-list"
 )
 
 // DocumentReport describes one YAML document inside a file.
@@ -200,7 +204,7 @@ func buildStoreFS(
 	lookup typeset.Lookup,
 	allowlist Allowlist,
 ) *ManifestStore {
-	return buildStore(ctx, collectFiles(fsys), lookup, allowlist)
+	return buildStore(ctx, collectFiles(fsys, false), lookup, allowlist)
 }
 
 // Analyze scans fsys and returns a Report. It is read-only and never fails: any
@@ -208,7 +212,7 @@ func buildStoreFS(
 // diagnostic rather than an error. The Report is a projection rendered from the
 // ManifestStore built by buildStore.
 func Analyze(fsys fs.FS) Report {
-	scan := collectFiles(fsys)
+	scan := collectFiles(fsys, false)
 	// Analyze is the no-cluster default: a nil mapper keeps it structure-only, so the
 	// resource index stays empty and no mapping diagnostics are emitted. It
 	// materialises every KRM document (the empty allowlist), since the legacy report
@@ -268,8 +272,9 @@ func projectReport(store *ManifestStore, yamlFiles []manifestedit.FileContent, n
 // The matcher is loaded once up front (order-independent, never relying on walk order) and
 // every entry is classified through the shared ClassifyEntry policy, so the analyzer scan
 // and the live writer's worktree scan apply the foreign-content and ignore rules
-// identically. An ignored entry — file, symlink, or whole subtree — is never read.
-func collectFiles(fsys fs.FS) FolderScan {
+// identically. An ignored entry — file, symlink, or whole subtree — is never read. A .json
+// file is modeled only when jsonManifests is set; otherwise it is foreign content.
+func collectFiles(fsys fs.FS, jsonManifests bool) FolderScan {
 	ignore, ignoreIssues := loadRootGitTargetIgnore(fsys)
 	scan := FolderScan{Ignore: ignore, IgnoreIssues: ignoreIssues}
 
@@ -284,7 +289,7 @@ func collectFiles(fsys fs.FS) FolderScan {
 		if path == "." {
 			return nil
 		}
-		switch ClassifyEntry(path, d, ignore) {
+		switch ClassifyEntry(path, d, ignore, jsonManifests) {
 		case RoleSkipDir:
 			return fs.SkipDir
 		case RoleManagedYAML:
@@ -297,6 +302,14 @@ func collectFiles(fsys fs.FS) FolderScan {
 				return nil //nolint:nilerr // an unreadable file must not abort the whole scan
 			}
 			scan.YAMLFiles = append(scan.YAMLFiles, manifestedit.FileContent{Path: path, Content: content})
+		case RoleJSONCandidate:
+			content, readErr := fs.ReadFile(fsys, path)
+			if readErr == nil && IsKRMJSON(content) {
+				scan.YAMLFiles = append(scan.YAMLFiles, manifestedit.FileContent{Path: path, Content: content})
+				return nil
+			}
+			scan.NonYAML = append(scan.NonYAML, path)
+			scan.Foreign = append(scan.Foreign, ForeignEntry{Path: path, Kind: ForeignFile})
 		case RoleOperatorArtifact, RoleBenignPassenger:
 			scan.NonYAML = append(scan.NonYAML, path)
 		case RoleForeignFile:
@@ -390,7 +403,7 @@ func gapIndices(diags []manifestedit.Diagnostic) map[int]bool {
 	gaps := map[int]bool{}
 	for _, d := range diags {
 		switch d.Reason {
-		case manifestedit.ReasonEmptyDocument, manifestedit.ReasonNotKRM,
+		case manifestedit.ReasonEmptyDocument, manifestedit.ReasonNotKRM, manifestedit.ReasonItemList,
 			manifestedit.ReasonInvalidYAML, manifestedit.ReasonMissingSopsKey:
 			gaps[d.DocumentIndex] = true
 		case manifestedit.ReasonNonEditable, manifestedit.ReasonDuplicateIdentity:
@@ -479,6 +492,8 @@ func classFromDiag(d manifestedit.Diagnostic) Class {
 		return ClassInvalidYAML
 	case manifestedit.ReasonNotKRM:
 		return ClassNonKRM
+	case manifestedit.ReasonItemList:
+		return ClassItemList
 	case manifestedit.ReasonNonEditable, manifestedit.ReasonDuplicateIdentity:
 		// These reasons always accompany a record, which wins the merge, so they are
 		// never classified here; fall through to the non-KRM default for safety.
@@ -552,8 +567,8 @@ func buildIssues(
 				issues = append(issues, AcceptanceIssue{
 					Kind: IssueInvalidYAML, Path: f.Path, DocumentIndex: d.Index, Message: invalidMsgs[ref],
 				})
-			case ClassNonYAML, ClassEmpty, ClassKRM:
-				// Not acceptance issues: ignored files, empty documents, and valid KRM.
+			case ClassNonYAML, ClassEmpty, ClassKRM, ClassItemList:
+				// Not acceptance issues: ignored files, empty documents, valid KRM, and item Lists.
 			}
 		}
 	}
@@ -574,3 +589,22 @@ func identityRef(id manifestedit.Identity) string {
 func isYAMLFile(path string) bool {
 	return strings.HasSuffix(path, ".yaml") || strings.HasSuffix(path, ".yml")
 }
+
+// isJSONFile reports whether a path is a JSON file by extension.
+func isJSONFile(path string) bool {
+	return strings.HasSuffix(path, ".json")
+}
+
+// IsKRMJSON reports whether content is a JSON object carrying a non-empty apiVersion and
+// kind: a Kubernetes manifest written as JSON, which the walkers model like a YAML
+// document. Any other JSON (a values file, a package.json) stays foreign content.
+func IsKRMJSON(content []byte) bool {
+	var head struct {
+		APIVersion string `json:"apiVersion"`
+		Kind       string `json:"kind"`
+	}
+	if err := json.Unmarshal(content, &head); err != nil {
+		return false
+	}
+	return head.APIVersion != "" && head.Kind != ""
+}
@@ -70,6 +70,13 @@ const (
 	// RoleManagedYAML is a YAML file the walker must read into the model (managed KRM, or a
 	// retained build directive / operator .sops.yaml the store's allowlist handles).
 	RoleManagedYAML
+	// RoleJSONCandidate is a .json file in a scan that models JSON manifests — the subtree of
+	// a GitTarget with spec.outputFormat JSON. It is managed KRM, read into the model like a
+	// YAML file, when its content is a JSON object with an apiVersion and a kind (IsKRMJSON),
+	// and a foreign file otherwise. The role only names the candidate: the walker reads the
+	// content to decide. Without the opt-in a .json file is never a candidate: it is foreign
+	// content like any other unknown file, so a YAML target never adopts (or sweeps) one.
+	RoleJSONCandidate
 	// RoleOperatorArtifact is an accepted non-YAML operator artifact (README.md). It is
 	// listed in the report's non-YAML inventory but is never foreign.
 	RoleOperatorArtifact
@@ -92,18 +99,20 @@ const (
 
 // ClassifyEntry decides the role of one walked entry. rel is the entry's slash-separated
 // path relative to the scanned root; d is its directory entry; ignore is the active root
-// matcher (nil when the path carries no .gittargetignore). It is a pure function — the
+// matcher (nil when the path carries no .gittargetignore); jsonManifests opts the scan in to
+// modeling .json files (RoleJSONCandidate), which only a GitTarget with spec.outputFormat JSON
+// does. It is a pure function — the
 // single source of truth for the precedence in §4.1 of the design:
 //
 //	operator artifacts + build directives  →  root .gittargetignore filter  →
-//	  managed KRM  →  benign passenger  →  foreign
+//	  managed KRM (YAML, then KRM JSON when opted in)  →  benign passenger  →  foreign
 //
 // so a user cannot use .gittargetignore to hide the operator's own files (README.md,
 // .sops.yaml) or to silence a hard-kustomize refusal (kustomization.yaml). Benign-passenger
 // hygiene files (a license, docs, .gitignore) are accepted after the ignore filter — so they
 // no longer refuse a folder yet remain user-suppressible — while every other unknown non-YAML
 // entry is refused unless an ignore pattern names it.
-func ClassifyEntry(rel string, d fs.DirEntry, ignore *IgnoreMatcher) EntryRole {
+func ClassifyEntry(rel string, d fs.DirEntry, ignore *IgnoreMatcher, jsonManifests bool) EntryRole {
 	// Symlinks are foreign wherever they appear and whatever they are named — a writer
 	// could follow one out of the subtree. The only way to keep one is to ignore it.
 	if d.Type()&fs.ModeSymlink != 0 {
@@ -140,6 +149,9 @@ func ClassifyEntry(rel string, d fs.DirEntry, ignore *IgnoreMatcher) EntryRole {
 	if isYAMLFile(rel) {
 		return RoleManagedYAML
 	}
+	if jsonManifests && isJSONFile(rel) {
+		return RoleJSONCandidate
+	}
 	if isBenignPassenger(rel) {
 		return RoleBenignPassenger
 	}
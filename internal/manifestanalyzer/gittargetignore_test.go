@@ -84,7 +84,7 @@ func TestCollectFiles_BenignPassengerAcceptedByDefault(t *testing.T) {
 	}
 	// They are still recorded in the non-YAML inventory (accepted, never managed), and the
 	// managed manifest is modeled as usual.
-	scan := collectFiles(fsys)
+	scan := collectFiles(fsys, false)
 	for _, want := range []string{
 		"LICENSE", "COPYING", "CONTRIBUTING.md", "docs/guide.markdown", ".gitignore", ".gitattributes", "sub/.gitkeep",
 	} {
@@ -106,7 +106,7 @@ func TestCollectFiles_BenignPassengerStillUserSuppressible(t *testing.T) {
 		"NOTES.md":         {Data: []byte("# notes")},
 		".gittargetignore": {Data: []byte("NOTES.md\n")},
 	}
-	scan := collectFiles(fsys)
+	scan := collectFiles(fsys, false)
 	if containsString(scan.NonYAML, "NOTES.md") {
 		t.Error("a benign passenger named in .gittargetignore must be dropped (never read)")
 	}
@@ -137,6 +137,35 @@ func TestIsBenignPassenger_AcceptedAndRefused(t *testing.T) {
 	}
 }
 
+// With JSON manifests opted in (a GitTarget with spec.outputFormat JSON), a .json file holding
+// a Kubernetes manifest is managed content, modeled like a YAML document; any other .json file
+// is still foreign. Without the opt-in every .json file is foreign.
+func TestForeignContent_KRMJSONIsManagedOnlyWhenOptedIn(t *testing.T) {
+	fsys := fstest.MapFS{
+		"app/cm.json": {Data: []byte(
+			`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm","namespace":"app"},"data":{"k":"v"}}`)},
+		"app/values.json": {Data: []byte(`{"k":"v"}`)},
+	}
+
+	store := Scan(context.Background(), fsys, nil, nil, ScanPolicy{JSONManifests: true}).Store
+	got := foreignPaths(store)
+	if len(got) != 1 || got["app/values.json"] != ForeignFile {
+		t.Errorf("foreign entries = %+v, want only app/values.json", store.Foreign)
+	}
+	if len(store.ByManifestIdentity) != 1 {
+		t.Errorf("managed documents = %d, want the one ConfigMap in app/cm.json", len(store.ByManifestIdentity))
+	}
+
+	store = BuildStore(context.Background(), fsys, nil)
+	got = foreignPaths(store)
+	if len(got) != 2 || got["app/cm.json"] != ForeignFile || got["app/values.json"] != ForeignFile {
+		t.Errorf("foreign entries = %+v, want both .json files", store.Foreign)
+	}
+	if len(store.ByManifestIdentity) != 0 {
+		t.Errorf("managed documents = %d, want none without the opt-in", len(store.ByManifestIdentity))
+	}
+}
+
 func TestForeignContent_OperatorArtifactsAccepted(t *testing.T) {
 	// README.md is an operator artifact (role 3); the root .gittargetignore is recognized
 	// positionally; a deeply nested README.md is still basename-matched as an artifact.
@@ -295,7 +324,7 @@ func TestGitTargetIgnore_ImmuneFilesNotHidden(t *testing.T) {
 		"README.md":        {Data: []byte("# readme")},
 		".gittargetignore": {Data: []byte("README.md\ndeploy.yaml\n")},
 	}
-	scan := collectFiles(fsys)
+	scan := collectFiles(fsys, false)
 
 	if !containsString(scan.NonYAML, "README.md") {
 		t.Error("README.md is an operator artifact and must survive an ignore rule")
@@ -50,6 +50,22 @@ type PlacementRequest struct {
 	// it. Empty for a self-contained subtree, where the scan root IS spec.path and every
 	// resolved path is already in scope.
 	WriteScope string
+	// Extension is the file extension of a new file: "" or ".yaml" for YAML, ".json" for
+	// a GitTarget with spec.outputFormat JSON. A sensitive resource is always ".sops.yaml".
+	Extension string
+}
+
+// fileSuffix is the suffix a new file for req ends in: ".sops.yaml" for a sensitive
+// resource, otherwise req.Extension, defaulting to ".yaml".
+func (req PlacementRequest) fileSuffix() string {
+	switch {
+	case req.Sensitive:
+		return ".sops.yaml"
+	case req.Extension != "":
+		return req.Extension
+	default:
+		return ".yaml"
+	}
 }
 
 // PlacementSource names which mechanism produced a PlacementResult's Path, for
@@ -181,10 +197,7 @@ func resolveKustomizeRoot(store *ManifestStore, req PlacementRequest) (string, b
 	if only == nil {
 		return "", false, false
 	}
-	name := req.Identifier.Name + ".yaml"
-	if req.Sensitive {
-		name = req.Identifier.Name + ".sops.yaml"
-	}
+	name := req.Identifier.Name + req.fileSuffix()
 	return cleanJoin(slashDir(only.Path), name), true, only.Namespace != ""
 }
 
@@ -300,8 +313,8 @@ func kustomizationListsResource(k *KustomizationInfo, resolvedPath string) bool
 // regardless of which mechanism produced it: non-empty, a clean relative path
 // staying under the GitTarget's spec.path (no "..", not absolute, no redundant
 // segments), no Windows-style backslash separators, a non-empty final file name,
-// and a recognized YAML suffix (".sops.yaml"/".sops.yml" satisfy this too, since
-// they end in ".yaml"/".yml"). finishPlacement runs this on every path before a
+// and a recognized YAML or JSON suffix (".sops.yaml"/".sops.yml" satisfy this too,
+// since they end in ".yaml"/".yml"). finishPlacement runs this on every path before a
 // single byte is written, so a bad declared template (Option B) can never
 // escape the folder the writer owns — sanitizePlacementSegment already defends
 // each individual variable's value, but the template's own literal text is
@@ -324,26 +337,20 @@ func ValidateResolvedPlacementPath(p string) error {
 	if base == "" || base == "." || base == "/" {
 		return fmt.Errorf("path %q has no file name", p)
 	}
-	if !strings.HasSuffix(cleaned, ".yaml") && !strings.HasSuffix(cleaned, ".yml") {
-		return fmt.Errorf("path %q must end in .yaml or .yml", p)
+	if !strings.HasSuffix(cleaned, ".yaml") && !strings.HasSuffix(cleaned, ".yml") &&
+		!strings.HasSuffix(cleaned, ".json") {
+		return fmt.Errorf("path %q must end in .yaml, .yml, or .json", p)
 	}
 	return nil
 }
 
 // canonicalPath mirrors internal/git's generateFilePath (ResourceIdentifier.ToGitPath
-// plus the .sops.yaml suffix for a sensitive resource). It is re-implemented here,
+// plus the .sops.yaml suffix for a sensitive resource, or req.Extension otherwise). It is re-implemented here,
 // not imported, because internal/git already imports manifestanalyzer and importing
 // the other way would cycle; the duplicated logic is six lines and covered by tests
 // on both sides.
 func canonicalPath(req PlacementRequest) string {
-	base := req.Identifier.ToGitPath()
-	if !req.Sensitive {
-		return base
-	}
-	if strings.HasSuffix(base, ".yaml") {
-		return strings.TrimSuffix(base, ".yaml") + ".sops.yaml"
-	}
-	return base + ".sops.yaml"
+	return req.Identifier.ToGitPathWithExtension(req.fileSuffix())
 }
 
 // --- Option B: declared type-map placement -------------------------------------
@@ -421,10 +428,7 @@ func placementVars(req PlacementRequest) map[string]string {
 	if id.Group != "" {
 		apiVersion = id.Group + "/" + id.Version
 	}
-	sensitiveSuffix := ".yaml"
-	if req.Sensitive {
-		sensitiveSuffix = ".sops.yaml"
-	}
+	sensitiveSuffix := req.fileSuffix()
 	return map[string]string{
 		"group":              id.Group,
 		"groupPath":          id.Group,
@@ -510,9 +514,9 @@ func ValidPlacementTemplateSyntax(tmpl string) error {
 // literal text (never mind any variable substitution, which sanitizePlacementSegment
 // already defends per-value) could render outside the GitTarget's spec.path or
 // with the wrong kind of file name: an explicit ".." path segment, a leading "/"
-// (absolute), a "\" separator, or a suffix that isn't ".yaml"/".yml" (a template
-// ending in the literal "{sensitiveSuffix}" placeholder is accepted without
-// rendering it, since that variable only ever expands to ".yaml" or ".sops.yaml").
+// (absolute), a "\" separator, or a suffix that isn't ".yaml"/".yml"/".json" (a
+// template ending in the literal "{sensitiveSuffix}" placeholder is accepted without
+// rendering it, since that variable only ever expands to ".yaml", ".json", or ".sops.yaml").
 // This runs at the GitTarget's Validated gate — before any repository scan, and
 // before any resource can ever trigger a write — so a bad template fails fast and
 // visibly instead of silently skipping (or, without ValidateResolvedPlacementPath's
@@ -533,9 +537,9 @@ func ValidPlacementTemplatePath(tmpl string) error {
 			return fmt.Errorf("placement template %q must not contain a \"..\" path segment", tmpl)
 		}
 	}
-	if !strings.HasSuffix(trimmed, "{sensitiveSuffix}") &&
-		!strings.HasSuffix(trimmed, ".yaml") && !strings.HasSuffix(trimmed, ".yml") {
-		return fmt.Errorf("placement template %q must end in .yaml, .yml, or {sensitiveSuffix}", tmpl)
+	if !strings.HasSuffix(trimmed, "{sensitiveSuffix}") && !strings.HasSuffix(trimmed, ".yaml") &&
+		!strings.HasSuffix(trimmed, ".yml") && !strings.HasSuffix(trimmed, ".json") {
+		return fmt.Errorf("placement template %q must end in .yaml, .yml, .json, or {sensitiveSuffix}", tmpl)
 	}
 	return nil
 }
@@ -771,10 +775,7 @@ func cohortDestination(
 
 	sort.Strings(singletonDirs)
 	winDir := singletonDirs[0]
-	name := req.Identifier.Name + ".yaml"
-	if req.Sensitive {
-		name = req.Identifier.Name + ".sops.yaml"
-	}
+	name := req.Identifier.Name + req.fileSuffix()
 	nsInherited := dirReps[winDir] != nil && dirReps[winDir].NamespaceInheritedFromContext()
 	return cleanJoin(winDir, name), cohort, nsInherited, true
 }
@@ -90,6 +90,32 @@ func TestLocateNew_EmptyRepo_Canonical(t *testing.T) {
 	}
 }
 
+// A JSON-format request keeps the canonical shape with a .json extension; a sensitive one is
+// still SOPS YAML.
+func TestLocateNew_EmptyRepo_CanonicalJSONExtension(t *testing.T) {
+	store := placementStore(t, fstest.MapFS{})
+	req := newConfigMapRequest("cache", "app")
+	req.Extension = ".json"
+
+	res, err := LocateNew(store, nil, req)
+	if err != nil {
+		t.Fatalf("LocateNew: %v", err)
+	}
+	if want := "app/configmaps/cache.json"; res.Path != want || res.Source != PlacementSourceCanonical {
+		t.Fatalf("got %+v, want canonical path %q", res, want)
+	}
+
+	secret := newSecretRequest("api-token")
+	secret.Extension = ".json"
+	res, err = LocateNew(store, nil, secret)
+	if err != nil {
+		t.Fatalf("LocateNew: %v", err)
+	}
+	if want := "app/secrets/api-token.sops.yaml"; res.Path != want {
+		t.Fatalf("got %+v, want the SOPS path %q", res, want)
+	}
+}
+
 // With render-root scoping the scan is re-rooted at renderBase, so a canonical path resolves
 // outside spec.path. WriteScope rebases it back under the write jail rather than letting it
 // escape (and be skipped) — placement stays relative to spec.path as documented.
@@ -876,6 +902,7 @@ func TestValidateResolvedPlacementPath(t *testing.T) {
 		{"clean relative yaml", "overlays/test/cache.yaml", true},
 		{"clean relative yml", "overlays/test/cache.yml", true},
 		{"sops path is a yaml path too", "secrets/app/db.sops.yaml", true},
+		{"clean relative json", "overlays/test/cache.json", true},
 		{"empty", "", false},
 		{"parent traversal", "../outside.yaml", false},
 		{"nested parent traversal", "overlays/../../outside.yaml", false},
@@ -903,6 +930,7 @@ func TestValidPlacementTemplatePath(t *testing.T) {
 	}{
 		{"clean relative", "{namespace}/{name}.yaml", true},
 		{"sensitiveSuffix placeholder", "{namespace}/secret-{name}{sensitiveSuffix}", true},
+		{"json suffix", "{namespace}/{name}.json", true},
 		{"parent traversal", "../outside.yaml", false},
 		{"nested parent traversal", "{namespace}/../../outside.yaml", false},
 		{"absolute", "/etc/{name}.yaml", false},
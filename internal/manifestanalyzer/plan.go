@@ -144,6 +144,9 @@ func (p Plan) Counts() map[PlanActionKind]int {
 type DesiredResource struct {
 	Resource types.ResourceIdentifier
 	Object   *unstructured.Unstructured
+	// AggregateByKind places the resource, when it has no document yet, as an item of its
+	// type's List document in its namespace (a WatchRule's aggregateByKind).
+	AggregateByKind bool
 }
 
 // SweepMode decides whether the Git-only mark-and-sweep may turn an unmatched managed
@@ -31,7 +31,7 @@ func Scan(
 	desired []DesiredResource,
 	policy ScanPolicy,
 ) ScanResult {
-	scan := collectFiles(fsys)
+	scan := collectFiles(fsys, policy.JSONManifests)
 	store := buildStore(ctx, scan, lookup, policy.Acceptance.Allowlist)
 	acc := Accept(store, policy.Acceptance)
 	plan := BuildPlan(store, scan.YAMLFiles, desired, policy.Plan)
@@ -69,6 +69,10 @@ type ScanPolicy struct {
 	// and therefore no prune policy to read, so a caller that wants the folder's orphans
 	// listed must set Plan.Sweep to SweepDropOrphans deliberately — see FolderScanPlanPolicy.
 	Plan Policy
+	// JSONManifests models a .json file holding a Kubernetes manifest like a YAML document, as
+	// the live writer does for a GitTarget with spec.outputFormat JSON. Off by default: a
+	// folder scan has no GitTarget, and any .json file is then foreign content.
+	JSONManifests bool
 }
 
 // FolderScanPlanPolicy is the planning policy for an offline folder scan: it DROPS orphans.
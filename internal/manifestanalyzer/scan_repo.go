@@ -181,7 +181,7 @@ func ScanRepo(ctx context.Context, root string) (RepoReport, error) {
 
 // scanRepoFS is ScanRepo over an fs.FS, so it is testable against an in-memory tree.
 func scanRepoFS(ctx context.Context, fsys fs.FS) RepoReport {
-	scan := collectFiles(fsys)
+	scan := collectFiles(fsys, false)
 	kusts := parseKustomizations(scan.YAMLFiles)
 	// Structure-only whole-repo store built with the live writer's allowlist (WriterAllowlist:
 	// kustomization files + the operator's .sops.yaml bootstrap config), so acceptance and the
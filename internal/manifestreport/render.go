@@ -24,8 +24,15 @@ import (
 // state the reverser would store. This is the "what does clean mean" policy that
 // manifestedit does not own; the integration layer supplies it, and it is exactly
 // the projection the live writer path uses (internal/sanitize).
+//
+// Every object it is given was already sanitized where it was watched, so the labels and
+// annotations it still carries are kept: a bookkeeping key a GitTarget chose to keep
+// (spec.sanitizePerGVR keepAnnotations) must not be stripped a second time here.
 func Project(obj *unstructured.Unstructured) *unstructured.Unstructured {
-	return sanitize.Sanitize(obj)
+	return sanitize.SanitizeWithOptions(obj, sanitize.Options{
+		KeepAnnotations: sanitize.KeepAllKeys,
+		KeepLabels:      sanitize.KeepAllKeys,
+	})
 }
 
 // Render is the house canonical renderer injected into manifestedit for
@@ -39,13 +39,14 @@ func dirtyConfigMap() *unstructured.Unstructured {
 // sanitize.MarshalToOrderedYAML on an already-sanitized object). If these ever
 // diverge, whole-replace/new-file output would no longer match committed content.
 func TestRender_MatchesWriterHouseFormat(t *testing.T) {
-	raw := dirtyConfigMap()
+	// What the watch boundary hands the writer: the object sanitized once.
+	watched := sanitize.Sanitize(dirtyConfigMap())
 
 	// What the writer would commit: MarshalToOrderedYAML on the sanitized object.
-	want, err := sanitize.MarshalToOrderedYAML(sanitize.Sanitize(raw))
+	want, err := sanitize.MarshalToOrderedYAML(watched)
 	require.NoError(t, err)
 
-	got, err := Render(Project(raw))
+	got, err := Render(Project(watched))
 	require.NoError(t, err)
 
 	assert.Equal(t, string(want), string(got), "the integration renderer must match the Git writer")
@@ -55,13 +56,13 @@ func TestRender_MatchesWriterHouseFormat(t *testing.T) {
 // options, must produce exactly the house format — proving new-file and
 // fallback output stay in lockstep with the writer.
 func TestRender_WholeReplaceMatchesHouseFormat(t *testing.T) {
-	raw := dirtyConfigMap()
-	want, err := sanitize.MarshalToOrderedYAML(sanitize.Sanitize(raw))
+	watched := sanitize.Sanitize(dirtyConfigMap())
+	want, err := sanitize.MarshalToOrderedYAML(watched)
 	require.NoError(t, err)
 
 	// A top-level sequence is not a KRM object, forcing manifestedit to fall back
 	// to a canonical whole-document render via the injected Render.
-	res, diags := manifestedit.PatchDocument([]byte("- a\n- b\n"), 0, Project(raw), EditOptions())
+	res, diags := manifestedit.PatchDocument([]byte("- a\n- b\n"), 0, Project(watched), EditOptions())
 	require.Equal(t, manifestedit.EditWholeReplace, res.Mode)
 	require.NotEmpty(t, diags)
 
@@ -69,3 +70,15 @@ func TestRender_WholeReplaceMatchesHouseFormat(t *testing.T) {
 	assert.Equal(t, string(want), string(res.Content),
 		"whole-replace output must be the house canonical format")
 }
+
+// Project runs on objects the watch boundary already sanitized. A bookkeeping annotation still on
+// one was kept on purpose (GitTarget spec.sanitizePerGVR keepAnnotations) and must reach Git.
+func TestProject_KeepsWhatTheWatchBoundaryKept(t *testing.T) {
+	watched := sanitize.SanitizeWithOptions(dirtyConfigMap(), sanitize.Options{
+		KeepAnnotations: []string{"kubectl.kubernetes.io/last-applied-configuration"},
+	})
+
+	got, err := Render(Project(watched))
+	require.NoError(t, err)
+	assert.Contains(t, string(got), "kubectl.kubernetes.io/last-applied-configuration")
+}
@@ -0,0 +1,196 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package pullrequest opens pull requests (GitHub) and merge requests (GitLab) through the
+// hosting provider's REST API. It is intentionally not an SDK: it covers the two calls a GitTarget
+// with spec.pullRequest needs — find the open request for a head/base pair, or create it.
+package pullrequest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const defaultHTTPTimeout = 15 * time.Second
+
+// API names a hosting provider's pull request API.
+type API string
+
+const (
+	// APIGitHub is the GitHub (and GitHub Enterprise Server) pulls API.
+	APIGitHub API = "GitHub"
+	// APIGitLab is the GitLab merge requests API.
+	APIGitLab API = "GitLab"
+)
+
+// Request describes the pull request to open or find.
+type Request struct {
+	// Head is the branch the operator pushes to.
+	Head string
+	// Base is the branch the pull request merges into.
+	Base string
+	// Title and Body are only used when a new pull request is created; an open one is left as
+	// its reviewers may have edited it.
+	Title string
+	Body  string
+}
+
+// PullRequest is the open pull request for a Request.
+type PullRequest struct {
+	// Number is the provider's per-repository number (GitHub number, GitLab iid).
+	Number int
+	// URL is the pull request's web page.
+	URL string
+	// Created is true when Ensure opened it, false when it was already open.
+	Created bool
+}
+
+// Opener finds or opens the pull request for a Request. Ensure is idempotent: a second call for
+// the same head and base returns the request the first one opened.
+type Opener interface {
+	Ensure(ctx context.Context, req Request) (*PullRequest, error)
+}
+
+// New returns the Opener for api against the repository at repoURL, the GitProvider's clone URL.
+// apiURL overrides the REST root derived from repoURL's host; token authenticates every call, so
+// an override must be https on the repository's host or on the API host derived from it.
+func New(api API, apiURL, repoURL, token string) (Opener, error) {
+	if apiURL != "" {
+		if err := checkAPIURL(api, apiURL, repoURL); err != nil {
+			return nil, err
+		}
+	}
+	return newOpener(api, apiURL, repoURL, token)
+}
+
+// checkAPIURL refuses an apiURL override the token must not be sent to: one that is not https,
+// or whose host is neither the repository's nor the one its derived API root is served from.
+func checkAPIURL(api API, apiURL, repoURL string) error {
+	parsed, err := url.Parse(apiURL)
+	if err != nil {
+		return fmt.Errorf("parse apiURL %q: %w", apiURL, err)
+	}
+	if parsed.Scheme != "https" {
+		return fmt.Errorf("apiURL %q must use https: the GitProvider's API token is sent to it", apiURL)
+	}
+	repo, err := ParseRepository(repoURL)
+	if err != nil {
+		return err
+	}
+	derived := repo.GitLabAPIURL()
+	if api == APIGitHub || api == "" {
+		derived = repo.GitHubAPIURL()
+	}
+	for _, root := range []string{repo.Scheme + "://" + repo.Host, derived} {
+		if allowed, err := url.Parse(root); err == nil && strings.EqualFold(allowed.Hostname(), parsed.Hostname()) {
+			return nil
+		}
+	}
+	return fmt.Errorf("apiURL %q is not on the GitProvider's host %s: the GitProvider's API token is sent to it",
+		apiURL, repo.Host)
+}
+
+// newOpener is New without the apiURL check.
+func newOpener(api API, apiURL, repoURL, token string) (Opener, error) {
+	if token == "" {
+		return nil, errors.New("no API token: the GitProvider credentials carry no HTTPS password or bearer token")
+	}
+	repo, err := ParseRepository(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	switch api {
+	case APIGitHub, "":
+		if apiURL == "" {
+			apiURL = repo.GitHubAPIURL()
+		}
+		return &GitHub{client: newClient(apiURL, token), Owner: repo.Owner(), Repo: repo.Name()}, nil
+	case APIGitLab:
+		if apiURL == "" {
+			apiURL = repo.GitLabAPIURL()
+		}
+		return &GitLab{client: newClient(apiURL, token), Project: repo.Path}, nil
+	default:
+		return nil, fmt.Errorf("unsupported pull request API %q", api)
+	}
+}
+
+// client issues JSON requests with a bearer token, which both GitHub and GitLab accept for
+// personal, project, and app access tokens.
+type client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+func newClient(baseURL, token string) client {
+	return client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		token:      token,
+		httpClient: &http.Client{Timeout: defaultHTTPTimeout},
+	}
+}
+
+// do issues an authenticated JSON request. If out is non-nil and the response is 2xx with a
+// body, the body is unmarshalled into out. The raw body is always returned so callers can report
+// unexpected responses.
+func (c client) do(ctx context.Context, method, path string, in, out any) (int, []byte, error) {
+	var reader io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return 0, nil, fmt.Errorf("marshal %s %s body: %w", method, path, err)
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return 0, nil, fmt.Errorf("build %s %s: %w", method, path, err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, nil, fmt.Errorf("read %s %s body: %w", method, path, err)
+	}
+
+	if out != nil && len(raw) > 0 && resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		if err := json.Unmarshal(raw, out); err != nil {
+			return resp.StatusCode, raw, fmt.Errorf("decode %s %s: %w (body=%s)",
+				method, path, err, truncateBody(string(raw)))
+		}
+	}
+	return resp.StatusCode, raw, nil
+}
+
+// truncateBody clips a response body for safe inclusion in error messages.
+func truncateBody(s string) string {
+	const limit = 512
+	if len(s) <= limit {
+		return s
+	}
+	return s[:limit] + "..."
+}
+
+// unexpectedStatus is a convenience for building a consistent error string.
+func unexpectedStatus(method, path string, code int, raw []byte) error {
+	return fmt.Errorf("%s %s: HTTP %d: %s", method, path, code, truncateBody(string(raw)))
+}
@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pullrequest
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// GitHub opens pull requests through the GitHub REST pulls API. Head and base are branches of the
+// same repository.
+type GitHub struct {
+	client
+
+	Owner string
+	Repo  string
+}
+
+type githubPull struct {
+	Number  int    `json:"number"`
+	HTMLURL string `json:"html_url"`
+}
+
+// Ensure returns the open pull request from req.Head into req.Base, creating it when none is open.
+func (g *GitHub) Ensure(ctx context.Context, req Request) (*PullRequest, error) {
+	if open, err := g.findOpen(ctx, req); err != nil || open != nil {
+		return open, err
+	}
+
+	path := g.pullsPath()
+	payload := map[string]string{
+		"title": req.Title,
+		"head":  req.Head,
+		"base":  req.Base,
+		"body":  req.Body,
+	}
+	var created githubPull
+	code, raw, err := g.do(ctx, http.MethodPost, path, payload, &created)
+	if err != nil {
+		return nil, err
+	}
+	switch code {
+	case http.StatusCreated:
+		return &PullRequest{Number: created.Number, URL: created.HTMLURL, Created: true}, nil
+	case http.StatusUnprocessableEntity:
+		// Also returned when a concurrent caller opened the same pull request first.
+		if open, findErr := g.findOpen(ctx, req); findErr != nil || open != nil {
+			return open, findErr
+		}
+		return nil, unexpectedStatus(http.MethodPost, path, code, raw)
+	default:
+		return nil, unexpectedStatus(http.MethodPost, path, code, raw)
+	}
+}
+
+func (g *GitHub) findOpen(ctx context.Context, req Request) (*PullRequest, error) {
+	query := url.Values{}
+	query.Set("state", "open")
+	query.Set("head", g.Owner+":"+req.Head)
+	query.Set("base", req.Base)
+	path := g.pullsPath() + "?" + query.Encode()
+
+	var pulls []githubPull
+	code, raw, err := g.do(ctx, http.MethodGet, path, nil, &pulls)
+	if err != nil {
+		return nil, err
+	}
+	if code != http.StatusOK {
+		return nil, unexpectedStatus(http.MethodGet, path, code, raw)
+	}
+	if len(pulls) == 0 {
+		return nil, nil //nolint:nilnil // no open pull request is not an error
+	}
+	return &PullRequest{Number: pulls[0].Number, URL: pulls[0].HTMLURL}, nil
+}
+
+func (g *GitHub) pullsPath() string {
+	return "/repos/" + url.PathEscape(g.Owner) + "/" + url.PathEscape(g.Repo) + "/pulls"
+}
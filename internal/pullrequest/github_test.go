@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pullrequest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGitHubEnsure_CreatesPullRequestWhenNoneIsOpen(t *testing.T) {
+	t.Parallel()
+
+	var created map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer s3cret" {
+			t.Errorf("Authorization = %q, want Bearer s3cret", got)
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/repos/acme/live/pulls":
+			query := r.URL.Query()
+			if query.Get("state") != "open" || query.Get("head") != "acme:reverser/live" || query.Get("base") != "main" {
+				t.Errorf("unexpected list query: %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`[]`))
+		case r.Method == http.MethodPost && r.URL.Path == "/repos/acme/live/pulls":
+			if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+				t.Errorf("decode create body: %v", err)
+			}
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"number":42,"html_url":"https://github.com/acme/live/pull/42"}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	opener, err := newOpener(APIGitHub, server.URL, "https://github.com/acme/live.git", "s3cret")
+	if err != nil {
+		t.Fatalf("newOpener() error = %v", err)
+	}
+	pr, err := opener.Ensure(context.Background(), Request{
+		Head: "reverser/live", Base: "main", Title: "Cluster changes", Body: "Opened by gitops-reverser.",
+	})
+	if err != nil {
+		t.Fatalf("Ensure() error = %v", err)
+	}
+	if pr.Number != 42 || pr.URL != "https://github.com/acme/live/pull/42" || !pr.Created {
+		t.Fatalf("Ensure() = %+v, want created #42", pr)
+	}
+	want := map[string]string{
+		"title": "Cluster changes", "head": "reverser/live", "base": "main", "body": "Opened by gitops-reverser.",
+	}
+	for key, value := range want {
+		if created[key] != value {
+			t.Fatalf("create body %s = %q, want %q", key, created[key], value)
+		}
+	}
+}
+
+func TestGitHubEnsure_ReturnsOpenPullRequest(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/repos/acme/live/pulls" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`[{"number":7,"html_url":"https://github.com/acme/live/pull/7"}]`))
+	}))
+	defer server.Close()
+
+	opener, err := newOpener(APIGitHub, server.URL, "git@github.com:acme/live.git", "s3cret")
+	if err != nil {
+		t.Fatalf("newOpener() error = %v", err)
+	}
+	pr, err := opener.Ensure(context.Background(), Request{Head: "reverser/live", Base: "main"})
+	if err != nil {
+		t.Fatalf("Ensure() error = %v", err)
+	}
+	if pr.Number != 7 || pr.Created {
+		t.Fatalf("Ensure() = %+v, want the open #7", pr)
+	}
+}
+
+func TestGitHubEnsure_ReportsRefusedCreate(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message":"Resource not accessible by integration"}`))
+	}))
+	defer server.Close()
+
+	opener, err := newOpener(APIGitHub, server.URL, "https://github.com/acme/live", "s3cret")
+	if err != nil {
+		t.Fatalf("newOpener() error = %v", err)
+	}
+	if _, err := opener.Ensure(context.Background(), Request{Head: "reverser/live", Base: "main"}); err == nil {
+		t.Fatal("Ensure() error = nil, want the HTTP 403")
+	}
+}
@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pullrequest
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// GitLab opens merge requests through the GitLab REST merge requests API. Source and target are
+// branches of the same project.
+type GitLab struct {
+	client
+
+	// Project is the project's full path, e.g. "group/subgroup/repo".
+	Project string
+}
+
+type gitlabMergeRequest struct {
+	IID    int    `json:"iid"`
+	WebURL string `json:"web_url"`
+}
+
+// Ensure returns the open merge request from req.Head into req.Base, creating it when none is open.
+func (g *GitLab) Ensure(ctx context.Context, req Request) (*PullRequest, error) {
+	if open, err := g.findOpen(ctx, req); err != nil || open != nil {
+		return open, err
+	}
+
+	path := g.mergeRequestsPath()
+	payload := map[string]string{
+		"source_branch": req.Head,
+		"target_branch": req.Base,
+		"title":         req.Title,
+		"description":   req.Body,
+	}
+	var created gitlabMergeRequest
+	code, raw, err := g.do(ctx, http.MethodPost, path, payload, &created)
+	if err != nil {
+		return nil, err
+	}
+	switch code {
+	case http.StatusCreated:
+		return &PullRequest{Number: created.IID, URL: created.WebURL, Created: true}, nil
+	case http.StatusConflict:
+		// Returned when a concurrent caller opened the same merge request first.
+		if open, findErr := g.findOpen(ctx, req); findErr != nil || open != nil {
+			return open, findErr
+		}
+		return nil, unexpectedStatus(http.MethodPost, path, code, raw)
+	default:
+		return nil, unexpectedStatus(http.MethodPost, path, code, raw)
+	}
+}
+
+func (g *GitLab) findOpen(ctx context.Context, req Request) (*PullRequest, error) {
+	query := url.Values{}
+	query.Set("state", "opened")
+	query.Set("source_branch", req.Head)
+	query.Set("target_branch", req.Base)
+	path := g.mergeRequestsPath() + "?" + query.Encode()
+
+	var requests []gitlabMergeRequest
+	code, raw, err := g.do(ctx, http.MethodGet, path, nil, &requests)
+	if err != nil {
+		return nil, err
+	}
+	if code != http.StatusOK {
+		return nil, unexpectedStatus(http.MethodGet, path, code, raw)
+	}
+	if len(requests) == 0 {
+		return nil, nil //nolint:nilnil // no open merge request is not an error
+	}
+	return &PullRequest{Number: requests[0].IID, URL: requests[0].WebURL}, nil
+}
+
+// mergeRequestsPath addresses the project by its URL-encoded full path, which GitLab accepts in
+// place of the numeric project ID.
+func (g *GitLab) mergeRequestsPath() string {
+	return "/projects/" + url.PathEscape(g.Project) + "/merge_requests"
+}
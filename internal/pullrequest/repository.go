@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pullrequest
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Repository is a hosted repository located from its clone URL.
+type Repository struct {
+	// Scheme is the scheme the API is reached over: the clone URL's for HTTP(S), https for SSH.
+	Scheme string
+	// Host is the clone URL's host, without an SSH user or port.
+	Host string
+	// Path is the repository path without a leading slash or trailing ".git", e.g. "org/repo" or
+	// "group/subgroup/repo" on GitLab.
+	Path string
+}
+
+// ParseRepository locates the repository behind an HTTPS, ssh://, or scp-style
+// (git@host:org/repo.git) clone URL.
+func ParseRepository(repoURL string) (Repository, error) {
+	raw := strings.TrimSpace(repoURL)
+	if !strings.Contains(raw, "://") {
+		// scp-style: [user@]host:path
+		hostPart, path, ok := strings.Cut(raw, ":")
+		if !ok {
+			return Repository{}, fmt.Errorf("repository URL %q is neither a URL nor user@host:path", repoURL)
+		}
+		if _, host, hasUser := strings.Cut(hostPart, "@"); hasUser {
+			hostPart = host
+		}
+		return newRepository(repoURL, "https", hostPart, path)
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return Repository{}, fmt.Errorf("parse repository URL %q: %w", repoURL, err)
+	}
+	scheme := parsed.Scheme
+	host := parsed.Host
+	switch scheme {
+	case "http", "https":
+	case "ssh":
+		scheme = "https"
+		host = parsed.Hostname()
+	default:
+		return Repository{}, fmt.Errorf("repository URL %q: scheme %q has no pull request API", repoURL, scheme)
+	}
+	return newRepository(repoURL, scheme, host, parsed.Path)
+}
+
+func newRepository(repoURL, scheme, host, path string) (Repository, error) {
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	if host == "" || !strings.Contains(path, "/") {
+		return Repository{}, fmt.Errorf("repository URL %q does not name a host and an owner/repository path", repoURL)
+	}
+	return Repository{Scheme: scheme, Host: host, Path: path}, nil
+}
+
+// Owner is the path up to the repository name: the GitHub owner, or the GitLab namespace.
+func (r Repository) Owner() string {
+	owner, _, _ := cutLast(r.Path, "/")
+	return owner
+}
+
+// Name is the repository name, the last path segment.
+func (r Repository) Name() string {
+	_, name, _ := cutLast(r.Path, "/")
+	return name
+}
+
+// GitHubAPIURL is the REST root for the repository's host: api.github.com for github.com, and
+// the /api/v3 root GitHub Enterprise Server serves on its own host.
+func (r Repository) GitHubAPIURL() string {
+	if r.Host == "github.com" {
+		return "https://api.github.com"
+	}
+	return r.Scheme + "://" + r.Host + "/api/v3"
+}
+
+// GitLabAPIURL is the /api/v4 REST root on the repository's host.
+func (r Repository) GitLabAPIURL() string {
+	return r.Scheme + "://" + r.Host + "/api/v4"
+}
+
+func cutLast(s, sep string) (string, string, bool) {
+	i := strings.LastIndex(s, sep)
+	if i < 0 {
+		return "", s, false
+	}
+	return s[:i], s[i+len(sep):], true
+}
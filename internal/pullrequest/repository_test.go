@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pullrequest
+
+import "testing"
+
+func TestParseRepository(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		url                  string
+		owner, name          string
+		githubAPI, gitlabAPI string
+	}{
+		{
+			url: "https://github.com/acme/live.git", owner: "acme", name: "live",
+			githubAPI: "https://api.github.com", gitlabAPI: "https://github.com/api/v4",
+		},
+		{
+			url: "git@github.com:acme/live.git", owner: "acme", name: "live",
+			githubAPI: "https://api.github.com", gitlabAPI: "https://github.com/api/v4",
+		},
+		{
+			url: "ssh://git@gitlab.example.com:2222/group/sub/live.git", owner: "group/sub", name: "live",
+			githubAPI: "https://gitlab.example.com/api/v3", gitlabAPI: "https://gitlab.example.com/api/v4",
+		},
+		{
+			url: "http://ghe.internal:8080/acme/live/", owner: "acme", name: "live",
+			githubAPI: "http://ghe.internal:8080/api/v3", gitlabAPI: "http://ghe.internal:8080/api/v4",
+		},
+	}
+	for _, tt := range tests {
+		repo, err := ParseRepository(tt.url)
+		if err != nil {
+			t.Fatalf("ParseRepository(%q) error = %v", tt.url, err)
+		}
+		if repo.Owner() != tt.owner || repo.Name() != tt.name {
+			t.Fatalf("ParseRepository(%q) = %s / %s, want %s / %s", tt.url, repo.Owner(), repo.Name(), tt.owner, tt.name)
+		}
+		if repo.GitHubAPIURL() != tt.githubAPI || repo.GitLabAPIURL() != tt.gitlabAPI {
+			t.Fatalf("ParseRepository(%q) API roots = %s, %s", tt.url, repo.GitHubAPIURL(), repo.GitLabAPIURL())
+		}
+	}
+
+	for _, bad := range []string{"file:///srv/git/live.git", "https://github.com/live", "not a url"} {
+		if _, err := ParseRepository(bad); err == nil {
+			t.Fatalf("ParseRepository(%q) error = nil, want an error", bad)
+		}
+	}
+}
+
+func TestNew_ChecksTheAPIURLOverride(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		api     API
+		apiURL  string
+		repoURL string
+		ok      bool
+	}{
+		{api: APIGitHub, apiURL: "https://api.github.com", repoURL: "https://github.com/acme/live.git", ok: true},
+		{api: APIGitHub, apiURL: "https://github.com/api/v3", repoURL: "git@github.com:acme/live.git", ok: true},
+		{api: APIGitLab, apiURL: "https://gitlab.example.com/api/v4", repoURL: "ssh://git@gitlab.example.com:2222/g/live",
+			ok: true},
+		{api: APIGitHub, apiURL: "http://api.github.com", repoURL: "https://github.com/acme/live.git"},
+		{api: APIGitHub, apiURL: "https://collector.example.net", repoURL: "https://github.com/acme/live.git"},
+		{api: APIGitLab, apiURL: "https://api.github.com", repoURL: "https://gitlab.example.com/g/live.git"},
+	}
+	for _, tt := range tests {
+		_, err := New(tt.api, tt.apiURL, tt.repoURL, "s3cret")
+		if (err == nil) != tt.ok {
+			t.Fatalf("New(%s, %q, %q) error = %v, want ok=%v", tt.api, tt.apiURL, tt.repoURL, err, tt.ok)
+		}
+	}
+}
@@ -88,19 +88,20 @@ const (
 // the object identity (group-resource, namespace, name, uid) off the key and into the
 // value, so the fact is self-describing.
 type AuthorFact struct {
-	GroupResource    string `json:"groupResource,omitempty"`
-	Namespace        string `json:"namespace,omitempty"`
-	Name             string `json:"name,omitempty"`
-	UID              string `json:"uid,omitempty"`
-	Author           string `json:"author"`
-	DisplayName      string `json:"displayName,omitempty"`
-	Email            string `json:"email,omitempty"`
-	Verb             string `json:"verb,omitempty"`
-	Subresource      string `json:"subresource,omitempty"`
-	AuditID          string `json:"auditID,omitempty"`
-	ResourceVersion  string `json:"resourceVersion,omitempty"`
-	StageTimestamp   string `json:"stageTimestamp,omitempty"`
-	IsServiceAccount bool   `json:"isServiceAccount,omitempty"`
+	GroupResource    string   `json:"groupResource,omitempty"`
+	Namespace        string   `json:"namespace,omitempty"`
+	Name             string   `json:"name,omitempty"`
+	UID              string   `json:"uid,omitempty"`
+	Author           string   `json:"author"`
+	DisplayName      string   `json:"displayName,omitempty"`
+	Email            string   `json:"email,omitempty"`
+	Groups           []string `json:"groups,omitempty"`
+	Verb             string   `json:"verb,omitempty"`
+	Subresource      string   `json:"subresource,omitempty"`
+	AuditID          string   `json:"auditID,omitempty"`
+	ResourceVersion  string   `json:"resourceVersion,omitempty"`
+	StageTimestamp   string   `json:"stageTimestamp,omitempty"`
+	IsServiceAccount bool     `json:"isServiceAccount,omitempty"`
 }
 
 // AuthorResolution is the structured result of an attribution lookup.
@@ -167,6 +168,7 @@ func (a *AttributionIndex) RecordFact(ctx context.Context, auditRoute string, ev
 		Author:           user.Username,
 		DisplayName:      user.DisplayName,
 		Email:            user.Email,
+		Groups:           user.Groups,
 		Verb:             event.Verb,
 		Subresource:      event.ObjectRef.Subresource,
 		AuditID:          string(event.AuditID),
@@ -260,6 +262,7 @@ func (a *AttributionIndex) RecordDeleteCollectionFacts(
 		Author:           user.Username,
 		DisplayName:      user.DisplayName,
 		Email:            user.Email,
+		Groups:           user.Groups,
 		Verb:             "deletecollection",
 		AuditID:          string(event.AuditID),
 		IsServiceAccount: strings.HasPrefix(user.Username, serviceAccountUserPrefix),
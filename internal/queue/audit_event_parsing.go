@@ -38,6 +38,7 @@ func resolveUserInfo(event auditv1.Event) git.UserInfo {
 		Username:    user.Username,
 		DisplayName: firstExtraValue(user.Extra, displayNameExtraKey),
 		Email:       firstExtraValue(user.Extra, emailExtraKey),
+		Groups:      user.Groups,
 	}
 }
 
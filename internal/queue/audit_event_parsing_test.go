@@ -46,6 +46,14 @@ func TestResolveUserInfo(t *testing.T) {
 		assert.Equal(t, "Carol Q. User", got.DisplayName)
 		assert.Equal(t, "carol@example.com", got.Email)
 	})
+
+	t.Run("GroupsFollowTheEffectiveUser", func(t *testing.T) {
+		got := resolveUserInfo(auditv1.Event{
+			User:             authnv1.UserInfo{Username: "admin", Groups: []string{"system:masters"}},
+			ImpersonatedUser: &authnv1.UserInfo{Username: "bob", Groups: []string{"team-a"}},
+		})
+		assert.Equal(t, []string{"team-a"}, got.Groups)
+	})
 }
 
 func TestFirstExtraValue(t *testing.T) {
@@ -7,9 +7,12 @@ It provides efficient lookup and matching of Kubernetes resources against active
 package rulestore
 
 import (
+	"fmt"
+	"path"
 	"strings"
 	"sync"
 
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -65,6 +68,78 @@ type CompiledResourceRule struct {
 	// unevaluatable policy stops the rule from compiling at all, because an empty set that reached
 	// here would be the input to a resync sweep.
 	SourceNamespaces []string
+
+	// ObjectSelector is the item's compiled objectSelector, matched against each object's labels.
+	// Nil means every object.
+	ObjectSelector labels.Selector
+
+	// NameFilter is the item's compiled nameIncludes/nameExcludes globs, matched against each
+	// object's name. Nil means every name.
+	NameFilter *NameFilter
+
+	// IncludeStatus is the item's includeStatus: matched objects keep their status in Git.
+	IncludeStatus bool
+
+	// AggregateByKind is the item's aggregateByKind: new matched objects are written as items of
+	// one List document per type and namespace.
+	AggregateByKind bool
+}
+
+// NameFilter is a rule item's validated name globs, in path.Match syntax. An object is selected
+// when its name matches any include (or there are none) and no exclude: an exclude always wins.
+type NameFilter struct {
+	Includes []string
+	Excludes []string
+}
+
+// CompileNameFilter validates an item's nameIncludes and nameExcludes once, so matching never meets
+// a malformed glob. It returns nil when both are empty, which means every name. A malformed glob is
+// an error rather than a silent skip: dropping an include would widen the mirror, and dropping an
+// exclude would write exactly the objects the rule asked to leave out.
+func CompileNameFilter(includes, excludes []string) (*NameFilter, error) {
+	if len(includes) == 0 && len(excludes) == 0 {
+		return nil, nil
+	}
+	for _, glob := range append(append([]string(nil), includes...), excludes...) {
+		if _, err := path.Match(glob, ""); err != nil {
+			return nil, fmt.Errorf("name glob %q: %w", glob, err)
+		}
+	}
+	return &NameFilter{
+		Includes: append([]string(nil), includes...),
+		Excludes: append([]string(nil), excludes...),
+	}, nil
+}
+
+// Matches reports whether an object with this name is selected. A nil filter selects every name.
+func (f *NameFilter) Matches(name string) bool {
+	if f == nil {
+		return true
+	}
+	if matchesAnyGlob(f.Excludes, name) {
+		return false
+	}
+	return len(f.Includes) == 0 || matchesAnyGlob(f.Includes, name)
+}
+
+// String renders the filter canonically for plan fingerprints and stream specs; nil is "". Each
+// glob is quoted: a glob may itself hold a comma or a semicolon, and ["a,b"] must not render like
+// ["a", "b"].
+func (f *NameFilter) String() string {
+	if f == nil {
+		return ""
+	}
+	return fmt.Sprintf("include=%q;exclude=%q", f.Includes, f.Excludes)
+}
+
+func matchesAnyGlob(globs []string, name string) bool {
+	for _, glob := range globs {
+		// CompileNameFilter rejected every malformed glob, so the error cannot occur here.
+		if ok, _ := path.Match(glob, name); ok {
+			return true
+		}
+	}
+	return false
 }
 
 // CompiledClusterRule represents a fully processed ClusterWatchRule, ready for quick lookups.
@@ -175,12 +250,28 @@ func (s *RuleStore) AddOrUpdateWatchRule(
 		if i < len(sourceNamespaces) {
 			namespaces = append([]string(nil), sourceNamespaces[i]...)
 		}
+		selector, err := r.CompileObjectSelector()
+		if err != nil {
+			// CompileWatchRule refuses a rule whose selector does not compile before it gets here.
+			// Skipping the item rather than dropping its selector keeps a caller that did not
+			// check from widening the mirror.
+			continue
+		}
+		nameFilter, err := CompileNameFilter(r.NameIncludes, r.NameExcludes)
+		if err != nil {
+			// Refused by CompileWatchRule the same way, and skipped here for the same reason.
+			continue
+		}
 		compiled.ResourceRules = append(compiled.ResourceRules, CompiledResourceRule{
 			Operations:       r.Operations,
 			APIGroups:        r.APIGroups,
 			APIVersions:      r.APIVersions,
 			Resources:        r.Resources,
 			SourceNamespaces: namespaces,
+			ObjectSelector:   selector,
+			NameFilter:       nameFilter,
+			IncludeStatus:    r.IncludeStatus,
+			AggregateByKind:  r.AggregateByKind,
 		})
 	}
 
@@ -283,9 +374,11 @@ func (s *RuleStore) IsReady() bool {
 
 // GetMatchingRules returns all namespaced WatchRules that match the given resource.
 // For namespaced resources, callers should provide an object carrying the event namespace
-// so namespaced WatchRules only match objects from their own namespace.
+// so namespaced WatchRules only match objects from their own namespace. The object's labels are
+// matched against each item's objectSelector and its name against the item's name globs; for a
+// DELETE, pass the last-known object so the labels it carried while it existed decide the match.
 // Parameters:
-//   - obj: The Kubernetes object to match; its namespace is used for WatchRule filtering
+//   - obj: The Kubernetes object to match; its namespace, name and labels are used for WatchRule filtering
 //   - resourcePlural: The plural form of the resource (e.g., "pods", "deployments")
 //   - operation: The operation type (CREATE, UPDATE, DELETE)
 //   - apiGroup: The API group of the resource (empty string for core API)
@@ -302,9 +395,12 @@ func (s *RuleStore) GetMatchingRules(
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	eventNamespace := ""
+	eventNamespace, objName := "", ""
+	var objLabels map[string]string
 	if obj != nil {
 		eventNamespace = obj.GetNamespace()
+		objName = obj.GetName()
+		objLabels = obj.GetLabels()
 	}
 
 	var matchingRules []CompiledRule
@@ -314,7 +410,7 @@ func (s *RuleStore) GetMatchingRules(
 			continue // WatchRule can't match cluster resources
 		}
 
-		if rule.matches(eventNamespace, resourcePlural, operation, apiGroup, apiVersion) {
+		if rule.matches(eventNamespace, objName, objLabels, resourcePlural, operation, apiGroup, apiVersion) {
 			matchingRules = append(matchingRules, rule)
 		}
 	}
@@ -389,6 +485,8 @@ func (s *RuleStore) clusterRuleMatches(
 // Matching the rule object's namespace instead would drop every event an override asked for.
 func (r *CompiledRule) matches(
 	eventNamespace string,
+	objName string,
+	objLabels map[string]string,
 	resourcePlural string,
 	operation configv1alpha3.OperationType,
 	apiGroup string,
@@ -396,7 +494,7 @@ func (r *CompiledRule) matches(
 ) bool {
 	// Check if any resource rule matches (logical OR)
 	for _, rule := range r.ResourceRules {
-		if rule.matches(eventNamespace, resourcePlural, operation, apiGroup, apiVersion) {
+		if rule.matches(eventNamespace, objName, objLabels, resourcePlural, operation, apiGroup, apiVersion) {
 			return true
 		}
 	}
@@ -407,6 +505,8 @@ func (r *CompiledRule) matches(
 // matches checks if a resource rule matches the given filters.
 func (r *CompiledResourceRule) matches(
 	eventNamespace string,
+	objName string,
+	objLabels map[string]string,
 	resourcePlural string,
 	operation configv1alpha3.OperationType,
 	apiGroup string,
@@ -418,6 +518,16 @@ func (r *CompiledResourceRule) matches(
 		return false
 	}
 
+	// Match the object's labels (no selector = match all)
+	if r.ObjectSelector != nil && !r.ObjectSelector.Matches(labels.Set(objLabels)) {
+		return false
+	}
+
+	// Match the object's name (no globs = match all; an exclude wins)
+	if !r.NameFilter.Matches(objName) {
+		return false
+	}
+
 	// Match operations (empty = match all)
 	if !r.matchesOperations(operation) {
 		return false
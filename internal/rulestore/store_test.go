@@ -1166,3 +1166,265 @@ func TestGetMatchingRules_WildcardItemMatchesEveryResolvedNamespace(t *testing.T
 			"implicitly included, got %d matches", len(got))
 	}
 }
+
+// TestGetMatchingRules_ObjectSelector verifies that an item's objectSelector is matched against
+// the object's labels, including for a wildcard API group and for a DELETE, where the caller
+// passes the last-known object.
+func TestGetMatchingRules_ObjectSelector(t *testing.T) {
+	store := NewStore()
+
+	rule := configv1alpha3.WatchRule{
+		Spec: configv1alpha3.WatchRuleSpec{
+			Rules: []configv1alpha3.ResourceRule{
+				{
+					APIGroups: []string{""},
+					Resources: []string{"configmaps"},
+					ObjectSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"gitops.io/export": "true"},
+					},
+				},
+				{
+					APIGroups: []string{"*"},
+					Resources: []string{"*"},
+					ObjectSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{{
+							Key:      "tier",
+							Operator: metav1.LabelSelectorOpIn,
+							Values:   []string{"prod"},
+						}},
+					},
+				},
+			},
+		},
+	}
+	rule.Name = "selected"
+	rule.Namespace = "apps"
+	store.AddOrUpdateWatchRule(rule, ownNamespaceScope(rule), "target", "apps", "provider", "apps", "main", "live")
+
+	object := func(objLabels map[string]string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetNamespace("apps")
+		obj.SetLabels(objLabels)
+		return obj
+	}
+
+	tests := []struct {
+		name      string
+		labels    map[string]string
+		resource  string
+		apiGroup  string
+		operation configv1alpha3.OperationType
+		want      int
+	}{
+		{
+			name:      "matchLabels selects a labeled configmap",
+			labels:    map[string]string{"gitops.io/export": "true"},
+			resource:  "configmaps",
+			operation: configv1alpha3.OperationCreate,
+			want:      1,
+		},
+		{
+			name:      "matchLabels rejects an unlabeled configmap",
+			resource:  "configmaps",
+			operation: configv1alpha3.OperationCreate,
+			want:      0,
+		},
+		{
+			name:      "matchLabels rejects a different label value",
+			labels:    map[string]string{"gitops.io/export": "false"},
+			resource:  "configmaps",
+			operation: configv1alpha3.OperationUpdate,
+			want:      0,
+		},
+		{
+			name:      "wildcard group item selects a matching object in any group",
+			labels:    map[string]string{"tier": "prod"},
+			resource:  "deployments",
+			apiGroup:  "apps",
+			operation: configv1alpha3.OperationCreate,
+			want:      1,
+		},
+		{
+			name:      "wildcard group item rejects a non-matching object",
+			labels:    map[string]string{"tier": "dev"},
+			resource:  "deployments",
+			apiGroup:  "apps",
+			operation: configv1alpha3.OperationCreate,
+			want:      0,
+		},
+		{
+			name:      "delete matches on the last-known labels",
+			labels:    map[string]string{"gitops.io/export": "true"},
+			resource:  "configmaps",
+			operation: configv1alpha3.OperationDelete,
+			want:      1,
+		},
+		{
+			name:      "delete of an object that never matched does not match",
+			labels:    map[string]string{"tier": "dev"},
+			resource:  "configmaps",
+			operation: configv1alpha3.OperationDelete,
+			want:      0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := store.GetMatchingRules(object(tt.labels), tt.resource, tt.operation, tt.apiGroup, "v1", false)
+			if len(matches) != tt.want {
+				t.Fatalf("expected %d matching rules, got %d", tt.want, len(matches))
+			}
+		})
+	}
+}
+
+// TestAddOrUpdateWatchRule_InvalidObjectSelectorItemNeverWidens verifies that an item whose
+// selector does not compile is left out rather than compiled without its selector.
+func TestAddOrUpdateWatchRule_InvalidObjectSelectorItemNeverWidens(t *testing.T) {
+	store := NewStore()
+
+	rule := configv1alpha3.WatchRule{
+		Spec: configv1alpha3.WatchRuleSpec{
+			Rules: []configv1alpha3.ResourceRule{{
+				Resources: []string{"configmaps"},
+				ObjectSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{
+						Key:      "tier",
+						Operator: metav1.LabelSelectorOpIn,
+					}},
+				},
+			}},
+		},
+	}
+	rule.Name = "invalid"
+	rule.Namespace = "apps"
+	store.AddOrUpdateWatchRule(rule, ownNamespaceScope(rule), "target", "apps", "provider", "apps", "main", "live")
+
+	obj := &unstructured.Unstructured{}
+	obj.SetNamespace("apps")
+	matches := store.GetMatchingRules(obj, "configmaps", configv1alpha3.OperationCreate, "", "v1", false)
+	if len(matches) != 0 {
+		t.Fatalf("expected an item with an invalid selector to match nothing, got %d rules", len(matches))
+	}
+}
+
+func TestNameFilter_Matches(t *testing.T) {
+	tests := []struct {
+		name     string
+		includes []string
+		excludes []string
+		objName  string
+		want     bool
+	}{
+		{name: "no globs selects every name", objName: "anything", want: true},
+		{name: "include selects a matching name", includes: []string{"app-*"}, objName: "app-web", want: true},
+		{name: "include rejects a non-matching name", includes: []string{"app-*"}, objName: "db", want: false},
+		{name: "any include is enough", includes: []string{"db", "app-?"}, objName: "app-1", want: true},
+		{name: "exclude rejects a matching name", excludes: []string{"kube-*"}, objName: "kube-root-ca.crt", want: false},
+		{name: "exclude keeps a non-matching name", excludes: []string{"kube-*"}, objName: "settings", want: true},
+		{
+			name:     "exclude wins over include",
+			includes: []string{"app-*"},
+			excludes: []string{"app-secret*"},
+			objName:  "app-secret-1",
+			want:     false,
+		},
+		{
+			name:     "include still applies beside an exclude",
+			includes: []string{"app-*"},
+			excludes: []string{"app-secret*"},
+			objName:  "app-web",
+			want:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := CompileNameFilter(tt.includes, tt.excludes)
+			if err != nil {
+				t.Fatalf("CompileNameFilter: %v", err)
+			}
+			if got := filter.Matches(tt.objName); got != tt.want {
+				t.Fatalf("Matches(%q) = %v, want %v", tt.objName, got, tt.want)
+			}
+		})
+	}
+}
+
+// Two filters that select different names must render differently, or a glob edit would not
+// change the stream spec and the stream would keep running under the old globs.
+func TestNameFilter_StringIsUnambiguous(t *testing.T) {
+	render := func(includes, excludes []string) string {
+		t.Helper()
+		filter, err := CompileNameFilter(includes, excludes)
+		if err != nil {
+			t.Fatalf("CompileNameFilter: %v", err)
+		}
+		return filter.String()
+	}
+	pairs := [][2]string{
+		{render([]string{"a,b"}, nil), render([]string{"a", "b"}, nil)},
+		{render([]string{"a;exclude=b"}, []string{"c"}), render([]string{"a"}, []string{"b;exclude=c"})},
+	}
+	for _, pair := range pairs {
+		if pair[0] == pair[1] {
+			t.Fatalf("different filters render alike: %s", pair[0])
+		}
+	}
+}
+
+func TestCompileNameFilter_RejectsMalformedGlob(t *testing.T) {
+	if _, err := CompileNameFilter([]string{"app-["}, nil); err == nil {
+		t.Fatal("expected an unterminated class to be rejected")
+	}
+	if _, err := CompileNameFilter(nil, []string{"app-["}); err == nil {
+		t.Fatal("expected an unterminated class in an exclude to be rejected")
+	}
+}
+
+func TestGetMatchingRules_NameGlobs(t *testing.T) {
+	store := NewStore()
+
+	rule := configv1alpha3.WatchRule{
+		Spec: configv1alpha3.WatchRuleSpec{
+			Rules: []configv1alpha3.ResourceRule{{
+				APIGroups:    []string{""},
+				Resources:    []string{"configmaps"},
+				NameIncludes: []string{"app-*"},
+				NameExcludes: []string{"app-secret*"},
+				ObjectSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"gitops.io/export": "true"},
+				},
+			}},
+		},
+	}
+	rule.Name = "named"
+	rule.Namespace = "apps"
+	store.AddOrUpdateWatchRule(rule, ownNamespaceScope(rule), "target", "apps", "provider", "apps", "main", "live")
+
+	exported := map[string]string{"gitops.io/export": "true"}
+	tests := []struct {
+		name   string
+		obj    string
+		labels map[string]string
+		want   int
+	}{
+		{name: "included name with matching labels", obj: "app-web", labels: exported, want: 1},
+		{name: "included name without matching labels", obj: "app-web", want: 0},
+		{name: "name outside the includes", obj: "db", labels: exported, want: 0},
+		{name: "excluded name", obj: "app-secret-1", labels: exported, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &unstructured.Unstructured{}
+			obj.SetNamespace("apps")
+			obj.SetName(tt.obj)
+			obj.SetLabels(tt.labels)
+			matches := store.GetMatchingRules(obj, "configmaps", configv1alpha3.OperationUpdate, "", "v1", false)
+			if len(matches) != tt.want {
+				t.Fatalf("expected %d matching rules, got %d", tt.want, len(matches))
+			}
+		})
+	}
+}
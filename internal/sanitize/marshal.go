@@ -4,21 +4,143 @@ package sanitize
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"sort"
 
+	yamlv3 "gopkg.in/yaml.v3"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/yaml"
 )
 
+// Style selects how MarshalToOrderedYAMLWithOptions renders the payload.
+type Style string
+
+const (
+	// StyleBlock renders the payload in indented block style. It is the default.
+	StyleBlock Style = "Block"
+	// StyleFlow renders each top-level payload value in flow style ({...} / [...]), so a
+	// resource with many or large values stays a handful of lines.
+	StyleFlow Style = "Flow"
+	// StyleAuto renders block style unless the result exceeds AutoSwitchThreshold bytes,
+	// and flow style otherwise.
+	StyleAuto Style = "Auto"
+)
+
+// KeyOrder selects the order of the payload's top-level keys, between metadata and status.
+type KeyOrder string
+
+const (
+	// KeyOrderCanonical renders spec first and sorts the other payload keys, the order kubectl
+	// and hand-written manifests use. It is the default.
+	KeyOrderCanonical KeyOrder = "Canonical"
+	// KeyOrderAlphabetical sorts every payload key, spec included.
+	KeyOrderAlphabetical KeyOrder = "Alphabetical"
+)
+
+// DefaultAutoSwitchThreshold is the StyleAuto size, in bytes, above which flow style is used.
+const DefaultAutoSwitchThreshold = 10 * 1024
+
+// MarshalOptions controls the rendering of MarshalToOrderedYAMLWithOptions. The zero value
+// renders block style in canonical key order, exactly like MarshalToOrderedYAML.
+type MarshalOptions struct {
+	// Style is the payload style; empty means StyleBlock.
+	Style Style
+	// KeyOrder is the payload key order; empty means KeyOrderCanonical.
+	KeyOrder KeyOrder
+	// AutoSwitchThreshold is the StyleAuto switch size in bytes; zero or less means
+	// DefaultAutoSwitchThreshold. Ignored by the other styles.
+	AutoSwitchThreshold int
+}
+
 // MarshalToOrderedYAML converts an unstructured object to YAML with guaranteed field order.
-// Field order: apiVersion, kind, metadata, then payload (spec, data, rules, etc.)
+// Field order: apiVersion, kind, metadata, spec, then the rest of the payload (data, rules, etc.)
+// sorted, then status.
+// Status is rendered only when present: Sanitize strips it unless Options.IncludeStatus.
+// Keys of every nested map, at any depth, are sorted: the object is rendered through
+// sigs.k8s.io/yaml, which goes via encoding/json, and that sorts map keys. So the same object
+// always renders to the same bytes, whatever order Go iterates its maps in.
 func MarshalToOrderedYAML(obj *unstructured.Unstructured) ([]byte, error) {
+	return MarshalToOrderedYAMLWithOptions(obj, MarshalOptions{})
+}
+
+// MarshalToOrderedYAMLWithOptions is MarshalToOrderedYAML with a selectable payload style.
+// The header (apiVersion, kind, metadata) is always block style, so the identity of a
+// flow-style document stays readable at the top of the file. Style only changes the
+// bytes, never the content: both styles decode to the same object.
+func MarshalToOrderedYAMLWithOptions(obj *unstructured.Unstructured, opts MarshalOptions) ([]byte, error) {
 	if obj == nil {
 		return nil, errors.New("object is nil")
 	}
 
+	var specFirst bool
+	switch opts.KeyOrder {
+	case KeyOrderCanonical, "":
+		specFirst = true
+	case KeyOrderAlphabetical:
+	default:
+		return nil, fmt.Errorf("unknown YAML key order %q", opts.KeyOrder)
+	}
+
+	switch opts.Style {
+	case StyleFlow:
+		return marshalOrdered(obj, true, specFirst)
+	case StyleAuto:
+		block, err := marshalOrdered(obj, false, specFirst)
+		if err != nil {
+			return nil, err
+		}
+		threshold := opts.AutoSwitchThreshold
+		if threshold <= 0 {
+			threshold = DefaultAutoSwitchThreshold
+		}
+		if len(block) <= threshold {
+			return block, nil
+		}
+		return marshalOrdered(obj, true, specFirst)
+	case StyleBlock, "":
+		return marshalOrdered(obj, false, specFirst)
+	default:
+		return nil, fmt.Errorf("unknown YAML style %q", opts.Style)
+	}
+}
+
+// YAMLToIndentedJSON converts one YAML document, such as MarshalToOrderedYAML's output, to
+// pretty-printed JSON: two-space indentation, keys sorted at every depth, and a trailing
+// newline. The same object always converts to the same bytes. More than one document is an
+// error, since a JSON file holds exactly one.
+func YAMLToIndentedJSON(doc []byte) ([]byte, error) {
+	decoder := yamlv3.NewDecoder(bytes.NewReader(doc))
+	documents := 0
+	for {
+		var node yamlv3.Node
+		err := decoder.Decode(&node)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse YAML: %w", err)
+		}
+		documents++
+	}
+	if documents != 1 {
+		return nil, fmt.Errorf("a JSON file holds exactly one document, got %d", documents)
+	}
+	compact, err := yaml.YAMLToJSON(doc)
+	if err != nil {
+		return nil, fmt.Errorf("convert YAML to JSON: %w", err)
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, compact, "", "  "); err != nil {
+		return nil, fmt.Errorf("indent JSON: %w", err)
+	}
+	out.WriteByte('\n')
+	return out.Bytes(), nil
+}
+
+func marshalOrdered(obj *unstructured.Unstructured, flow, specFirst bool) ([]byte, error) {
 	var buf bytes.Buffer
 
 	// Header: apiVersion, kind, metadata
@@ -28,10 +150,27 @@ func MarshalToOrderedYAML(obj *unstructured.Unstructured) ([]byte, error) {
 
 	// Payload: everything except apiVersion, kind, metadata, status
 	payload := extractPayload(obj)
-	if err := marshalPayload(&buf, payload); err != nil {
+	marshal := marshalPayload
+	if flow {
+		marshal = marshalFlowPayload
+	}
+	if spec, ok := payload["spec"]; ok && specFirst {
+		if err := marshal(&buf, map[string]interface{}{"spec": spec}); err != nil {
+			return nil, err
+		}
+		delete(payload, "spec")
+	}
+	if err := marshal(&buf, payload); err != nil {
 		return nil, err
 	}
 
+	// Status last, as kubectl renders it, so a status change is a diff at the end of the file.
+	if status, ok := obj.Object["status"]; ok && status != nil {
+		if err := marshal(&buf, map[string]interface{}{"status": status}); err != nil {
+			return nil, err
+		}
+	}
+
 	return buf.Bytes(), nil
 }
 
@@ -46,6 +185,10 @@ func marshalHeader(buf *bytes.Buffer, obj *unstructured.Unstructured) error {
 
 	var metadata PartialObjectMeta
 	metadata.FromUnstructured(obj)
+	// The object was sanitized before it got here, and any bookkeeping label or annotation it
+	// still carries was kept on purpose (GitTarget spec.sanitizePerGVR keep lists).
+	metadata.Labels = obj.GetLabels()
+	metadata.Annotations = obj.GetAnnotations()
 	metadataMap := buildMetadataMap(metadata)
 
 	if err := writeYAMLMap(buf, map[string]interface{}{"metadata": metadataMap}); err != nil {
@@ -79,6 +222,52 @@ func marshalPayload(buf *bytes.Buffer, payload map[string]interface{}) error {
 	return nil
 }
 
+// marshalFlowPayload writes the payload with its top-level keys in block style and each
+// value in flow style. The payload is round-tripped through JSON first, the same
+// normalization sigs.k8s.io/yaml applies on the block path, so both styles see identical
+// values (no typed structs or int/float distinctions the block path would erase).
+func marshalFlowPayload(buf *bytes.Buffer, payload map[string]interface{}) error {
+	if len(payload) == 0 {
+		return nil
+	}
+
+	raw, err := yaml.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+	var doc yamlv3.Node
+	if err := yamlv3.Unmarshal(raw, &doc); err != nil {
+		return fmt.Errorf("failed to re-read payload: %w", err)
+	}
+	if doc.Kind != yamlv3.DocumentNode || len(doc.Content) != 1 || doc.Content[0].Kind != yamlv3.MappingNode {
+		return errors.New("failed to re-read payload: not a mapping")
+	}
+	root := doc.Content[0]
+	for i := 1; i < len(root.Content); i += 2 {
+		setFlowStyle(root.Content[i])
+	}
+
+	enc := yamlv3.NewEncoder(buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(root); err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+	return enc.Close()
+}
+
+// setFlowStyle marks every collection under n as flow style. Scalars keep their own
+// style: a multi-line string inside a flow collection is emitted double-quoted.
+func setFlowStyle(n *yamlv3.Node) {
+	if n.Kind == yamlv3.MappingNode || n.Kind == yamlv3.SequenceNode {
+		n.Style = yamlv3.FlowStyle
+	} else if n.Style == yamlv3.LiteralStyle || n.Style == yamlv3.FoldedStyle {
+		n.Style = yamlv3.DoubleQuotedStyle
+	}
+	for _, c := range n.Content {
+		setFlowStyle(c)
+	}
+}
+
 // writeYAMLMap marshals a map to YAML and writes it to the buffer.
 func writeYAMLMap(buf *bytes.Buffer, m map[string]interface{}) error {
 	b, err := yaml.Marshal(m)
@@ -104,6 +293,9 @@ func buildMetadataMap(md PartialObjectMeta) map[string]interface{} {
 	if len(md.Annotations) > 0 {
 		out["annotations"] = md.Annotations
 	}
+	if len(md.ManagedFields) > 0 {
+		out["managedFields"] = md.ManagedFields
+	}
 	return out
 }
 
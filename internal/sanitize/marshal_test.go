@@ -240,7 +240,7 @@ func TestMarshalToOrderedYAML_StatusRemoved(t *testing.T) {
 		},
 	}
 
-	yamlBytes, err := MarshalToOrderedYAML(obj)
+	yamlBytes, err := MarshalToOrderedYAML(Sanitize(obj))
 	require.NoError(t, err)
 
 	yamlStr := string(yamlBytes)
@@ -250,6 +250,38 @@ func TestMarshalToOrderedYAML_StatusRemoved(t *testing.T) {
 	assert.NotContains(t, yamlStr, "phase:")
 }
 
+// A status Sanitize was asked to keep renders after the payload, in both styles.
+func TestMarshalToOrderedYAML_KeptStatusRendersLast(t *testing.T) {
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata":   map[string]interface{}{"name": "test-pod", "namespace": "default"},
+			"spec":       map[string]interface{}{"nodeSelector": map[string]interface{}{"zone": "a"}},
+			"status":     map[string]interface{}{"phase": "Running"},
+		},
+	}
+	kept := SanitizeWithOptions(obj, Options{IncludeStatus: true})
+
+	block, err := MarshalToOrderedYAML(kept)
+	require.NoError(t, err)
+	assert.Equal(t, `apiVersion: v1
+kind: Pod
+metadata:
+  name: test-pod
+  namespace: default
+spec:
+  nodeSelector:
+    zone: a
+status:
+  phase: Running
+`, string(block))
+
+	flow, err := MarshalToOrderedYAMLWithOptions(kept, MarshalOptions{Style: StyleFlow})
+	require.NoError(t, err)
+	assert.Contains(t, string(flow), "spec: {nodeSelector: {zone: a}}\nstatus: {phase: Running}\n")
+}
+
 func TestMarshalToOrderedYAML_RoundTrip(t *testing.T) {
 	original := &unstructured.Unstructured{
 		Object: map[string]interface{}{
@@ -339,3 +371,224 @@ func TestMarshalToOrderedYAML_CustomResource(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "example.com/v1alpha1", parsed["apiVersion"])
 }
+
+func flowTestObject() *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]interface{}{"name": "queries", "namespace": "default"},
+			"data": map[string]interface{}{
+				"report.sql": "select *\nfrom orders\n",
+				"blob":       strings.Repeat("QUJD", 64),
+			},
+		},
+	}
+}
+
+func TestMarshalToOrderedYAMLWithOptions_FlowKeepsHeaderInBlockStyle(t *testing.T) {
+	obj := flowTestObject()
+
+	got, err := MarshalToOrderedYAMLWithOptions(obj, MarshalOptions{Style: StyleFlow})
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(string(got), "\n"), "\n")
+	assert.Equal(t, []string{"apiVersion: v1", "kind: ConfigMap", "metadata:", "  name: queries", "  namespace: default"},
+		lines[:5])
+	require.Len(t, lines, 6, "the whole payload is one flow line")
+	assert.True(t, strings.HasPrefix(lines[5], "data: {"))
+
+	var decoded map[string]interface{}
+	require.NoError(t, yaml.Unmarshal(got, &decoded))
+	assert.Equal(t, obj.Object["data"], decoded["data"], "flow style must decode to the same values")
+}
+
+func TestMarshalToOrderedYAMLWithOptions_StyleSelection(t *testing.T) {
+	obj := flowTestObject()
+	block, err := MarshalToOrderedYAML(obj)
+	require.NoError(t, err)
+	flow, err := MarshalToOrderedYAMLWithOptions(obj, MarshalOptions{Style: StyleFlow})
+	require.NoError(t, err)
+	require.NotEqual(t, block, flow)
+
+	size := len(block)
+	tests := map[string]struct {
+		opts MarshalOptions
+		want []byte
+	}{
+		"zero value is block":         {opts: MarshalOptions{}, want: block},
+		"explicit block":              {opts: MarshalOptions{Style: StyleBlock}, want: block},
+		"auto below default":          {opts: MarshalOptions{Style: StyleAuto}, want: block},
+		"auto at threshold":           {opts: MarshalOptions{Style: StyleAuto, AutoSwitchThreshold: size}, want: block},
+		"auto above threshold":        {opts: MarshalOptions{Style: StyleAuto, AutoSwitchThreshold: size - 1}, want: flow},
+		"threshold ignored for block": {opts: MarshalOptions{Style: StyleBlock, AutoSwitchThreshold: 1}, want: block},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := MarshalToOrderedYAMLWithOptions(obj, tc.opts)
+			require.NoError(t, err)
+			assert.Equal(t, string(tc.want), string(got))
+		})
+	}
+
+	_, err = MarshalToOrderedYAMLWithOptions(obj, MarshalOptions{Style: "Folded"})
+	require.ErrorContains(t, err, `unknown YAML style "Folded"`)
+}
+
+func TestMarshalToOrderedYAMLWithOptions_KeyOrder(t *testing.T) {
+	deployment := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec":       map[string]interface{}{"replicas": int64(2)},
+		"metadata":   map[string]interface{}{"name": "web", "namespace": "shop"},
+		"kind":       "Deployment",
+		"apiVersion": "apps/v1",
+	}}
+	got, err := MarshalToOrderedYAML(deployment)
+	require.NoError(t, err)
+	assert.Equal(t, "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\n  namespace: shop\n"+
+		"spec:\n  replicas: 2\n", string(got))
+
+	// A custom resource with payload keys on both sides of spec in alphabetical order.
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "example.com/v1",
+		"kind":       "Widget",
+		"metadata":   map[string]interface{}{"name": "w"},
+		"webhooks":   []interface{}{"hook"},
+		"spec":       map[string]interface{}{"size": int64(1)},
+		"data":       map[string]interface{}{"key": "value"},
+	}}
+	topLevelKeys := func(out []byte) []string {
+		var keys []string
+		for _, line := range strings.Split(string(out), "\n") {
+			if line != "" && !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "-") {
+				keys = append(keys, strings.SplitN(line, ":", 2)[0])
+			}
+		}
+		return keys
+	}
+
+	tests := map[string]struct {
+		opts MarshalOptions
+		want []string
+	}{
+		"zero value is canonical": {
+			opts: MarshalOptions{},
+			want: []string{"apiVersion", "kind", "metadata", "spec", "data", "webhooks"},
+		},
+		"canonical flow": {
+			opts: MarshalOptions{Style: StyleFlow, KeyOrder: KeyOrderCanonical},
+			want: []string{"apiVersion", "kind", "metadata", "spec", "data", "webhooks"},
+		},
+		"alphabetical": {
+			opts: MarshalOptions{KeyOrder: KeyOrderAlphabetical},
+			want: []string{"apiVersion", "kind", "metadata", "data", "spec", "webhooks"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			first, err := MarshalToOrderedYAMLWithOptions(obj, tc.opts)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, topLevelKeys(first))
+			for range 5 {
+				again, err := MarshalToOrderedYAMLWithOptions(obj, tc.opts)
+				require.NoError(t, err)
+				assert.Equal(t, string(first), string(again), "the order is stable across renders")
+			}
+		})
+	}
+
+	_, err = MarshalToOrderedYAMLWithOptions(obj, MarshalOptions{KeyOrder: "Random"})
+	require.ErrorContains(t, err, `unknown YAML key order "Random"`)
+}
+
+func managedFieldsTestObject(entries ...interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":            "web",
+			"namespace":       "shop",
+			"resourceVersion": "7",
+			"managedFields":   entries,
+		},
+		"spec": map[string]interface{}{"replicas": int64(2)},
+	}}
+}
+
+func TestMarshalToOrderedYAML_PreservedManagedFieldsAreByteStable(t *testing.T) {
+	apply := map[string]interface{}{
+		"manager": "kubectl", "operation": "Apply", "apiVersion": "apps/v1",
+		"time": "2026-01-01T00:00:00Z", "fieldsType": "FieldsV1",
+		"fieldsV1": map[string]interface{}{"f:spec": map[string]interface{}{"f:replicas": map[string]interface{}{}}},
+	}
+	update := map[string]interface{}{
+		"manager": "kube-controller-manager", "operation": "Update", "apiVersion": "apps/v1",
+		"subresource": "status", "time": "2026-01-01T00:01:00Z", "fieldsType": "FieldsV1",
+		"fieldsV1": map[string]interface{}{"f:status": map[string]interface{}{}},
+	}
+	opts := Options{PreserveManagedFields: true}
+
+	first, err := MarshalToOrderedYAML(SanitizeWithOptions(managedFieldsTestObject(apply, update), opts))
+	require.NoError(t, err)
+	second, err := MarshalToOrderedYAML(SanitizeWithOptions(managedFieldsTestObject(update, apply), opts))
+	require.NoError(t, err)
+	again, err := MarshalToOrderedYAML(SanitizeWithOptions(managedFieldsTestObject(apply, update), opts))
+	require.NoError(t, err)
+
+	assert.Equal(t, string(first), string(second), "entry order read from the server does not change the bytes")
+	assert.Equal(t, string(first), string(again))
+	assert.Contains(t, string(first), "managedFields:")
+	assert.Less(t, strings.Index(string(first), "manager: kube-controller-manager"),
+		strings.Index(string(first), "manager: kubectl"), "entries are sorted by manager")
+	assert.NotContains(t, string(first), "resourceVersion", "other server fields are still stripped")
+
+	stripped, err := MarshalToOrderedYAML(Sanitize(managedFieldsTestObject(apply, update)))
+	require.NoError(t, err)
+	assert.NotContains(t, string(stripped), "managedFields", "managedFields are stripped by default")
+}
+
+// TestMarshalToOrderedYAML_NestedMapsAreByteStable pins the recursive key ordering: Go randomizes
+// map iteration on every range, so repeated renders of one object only agree if every nested map
+// is sorted.
+func TestYAMLToIndentedJSON(t *testing.T) {
+	got, err := YAMLToIndentedJSON([]byte("kind: ConfigMap\napiVersion: v1\ndata: {b: \"2\", a: \"1\"}\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"apiVersion\": \"v1\",\n  \"data\": {\n    \"a\": \"1\",\n    \"b\": \"2\"\n  },\n"+
+		"  \"kind\": \"ConfigMap\"\n}\n", string(got), "keys are sorted at every depth")
+
+	_, err = YAMLToIndentedJSON([]byte("kind: A\n---\nkind: B\n"))
+	require.Error(t, err, "a JSON file holds exactly one document")
+}
+
+func TestMarshalToOrderedYAML_NestedMapsAreByteStable(t *testing.T) {
+	data := map[string]interface{}{}
+	nested := map[string]interface{}{}
+	for i := range 40 {
+		key := string(rune('a'+i%26)) + strings.Repeat("k", i/26)
+		data[key] = "value-" + key
+		nested[key] = map[string]interface{}{"z": int64(i), "a": []interface{}{map[string]interface{}{"y": "1", "b": "2"}}}
+	}
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":        "cfg",
+			"namespace":   "default",
+			"labels":      map[string]interface{}{"z": "1", "a": "2", "m": "3"},
+			"annotations": map[string]interface{}{"z.example.com/x": "1", "a.example.com/x": "2"},
+		},
+		"data": data,
+		"spec": map[string]interface{}{"nested": nested},
+	}}
+
+	for _, style := range []Style{StyleBlock, StyleFlow} {
+		want, err := MarshalToOrderedYAMLWithOptions(obj, MarshalOptions{Style: style})
+		require.NoError(t, err)
+		assert.Less(t, strings.Index(string(want), "value-ak"), strings.Index(string(want), "value-z"),
+			"data keys are sorted, not only top-level fields")
+		for range 100 {
+			got, err := MarshalToOrderedYAMLWithOptions(obj.DeepCopy(), MarshalOptions{Style: style})
+			require.NoError(t, err)
+			require.Equal(t, string(want), string(got), "style %q", style)
+		}
+	}
+}
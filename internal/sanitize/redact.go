@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sanitize
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// RedactedValue replaces every value a RedactFields path matches.
+const RedactedValue = "***REDACTED***"
+
+// redactSegment is one step of a redact path: a field name, and whether the field is a list whose
+// every element the rest of the path applies to ("containers[*]").
+type redactSegment struct {
+	field string
+	each  bool
+}
+
+// parseRedactPath splits a dot-separated redact path. A segment ending in "[*]" walks every
+// element of the list at that field, e.g. "spec.template.spec.containers[*].env[*].value". Like
+// ParseFieldPath it accepts one leading "." and refuses a path through the object's identity.
+func parseRedactPath(path string) ([]redactSegment, error) {
+	fields, err := splitFieldPath(path)
+	if err != nil {
+		return nil, err
+	}
+	segments := make([]redactSegment, len(fields))
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		name, each := strings.CutSuffix(field, "[*]")
+		if name == "" || strings.ContainsAny(name, "[]") {
+			return nil, fmt.Errorf("redact path %q has an invalid segment %q; only a trailing [*] is allowed",
+				path, field)
+		}
+		segments[i] = redactSegment{field: name, each: each}
+		names[i] = name
+	}
+	if identityPath(names) {
+		return nil, fmt.Errorf("redact path %q would redact the object's identity", path)
+	}
+	return segments, nil
+}
+
+// RedactFields replaces the values at paths with RedactedValue, in place. A path that ends on an
+// object or list redacts every scalar inside it and keeps its keys and shape, so the document
+// still shows which entries exist. A null value is left as it is. A path that is malformed,
+// absent, or runs into a value of the wrong type redacts nothing.
+func RedactFields(obj *unstructured.Unstructured, paths []string) {
+	if obj == nil {
+		return
+	}
+	for _, path := range paths {
+		segments, err := parseRedactPath(path)
+		if err != nil {
+			continue
+		}
+		redactIn(obj.Object, segments)
+	}
+}
+
+func redactIn(m map[string]interface{}, segments []redactSegment) {
+	seg, rest := segments[0], segments[1:]
+	value, ok := m[seg.field]
+	if !ok {
+		return
+	}
+	if !seg.each {
+		if len(rest) == 0 {
+			m[seg.field] = redactValue(value)
+		} else if child, ok := value.(map[string]interface{}); ok {
+			redactIn(child, rest)
+		}
+		return
+	}
+	items, ok := value.([]interface{})
+	if !ok {
+		return
+	}
+	for i := range items {
+		if len(rest) == 0 {
+			items[i] = redactValue(items[i])
+		} else if child, ok := items[i].(map[string]interface{}); ok {
+			redactIn(child, rest)
+		}
+	}
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case map[string]interface{}:
+		for k := range v {
+			v[k] = redactValue(v[k])
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = redactValue(v[i])
+		}
+		return v
+	default:
+		return RedactedValue
+	}
+}
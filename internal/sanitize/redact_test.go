@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sanitize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func redactTestObject() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "example.com/v1",
+		"kind":       "Job",
+		"metadata":   map[string]interface{}{"name": "sync", "namespace": "ops"},
+		"spec": map[string]interface{}{
+			"token": "s3cr3t",
+			"auth":  map[string]interface{}{"user": "bot", "password": "hunter2", "retries": int64(3), "ca": nil},
+			"template": map[string]interface{}{"spec": map[string]interface{}{
+				"containers": []interface{}{
+					map[string]interface{}{
+						"name": "app",
+						"env": []interface{}{
+							map[string]interface{}{"name": "API_TOKEN", "value": "abc"},
+							map[string]interface{}{"name": "FROM_SECRET", "valueFrom": map[string]interface{}{
+								"secretKeyRef": map[string]interface{}{"name": "creds", "key": "token"},
+							}},
+						},
+					},
+					map[string]interface{}{"name": "sidecar"},
+				},
+			}},
+			"args": []interface{}{"--token", "abc"},
+		},
+	}}
+}
+
+func TestRedactFields(t *testing.T) {
+	cases := []struct {
+		name  string
+		paths []string
+		check func(t *testing.T, obj map[string]interface{})
+	}{
+		{
+			name:  "nested map leaf",
+			paths: []string{".spec.token"},
+			check: func(t *testing.T, obj map[string]interface{}) {
+				v, _, _ := unstructured.NestedString(obj, "spec", "token")
+				assert.Equal(t, RedactedValue, v)
+			},
+		},
+		{
+			name:  "object keeps its keys and null values",
+			paths: []string{"spec.auth"},
+			check: func(t *testing.T, obj map[string]interface{}) {
+				auth, _, _ := unstructured.NestedMap(obj, "spec", "auth")
+				assert.Equal(t, map[string]interface{}{
+					"user": RedactedValue, "password": RedactedValue, "retries": RedactedValue, "ca": nil,
+				}, auth)
+			},
+		},
+		{
+			name:  "array wildcards",
+			paths: []string{"spec.template.spec.containers[*].env[*].value"},
+			check: func(t *testing.T, obj map[string]interface{}) {
+				containers, _, _ := unstructured.NestedSlice(obj, "spec", "template", "spec", "containers")
+				env := containers[0].(map[string]interface{})["env"].([]interface{})
+				assert.Equal(t, map[string]interface{}{"name": "API_TOKEN", "value": RedactedValue}, env[0])
+				assert.NotContains(t, env[1], "value", "an element without the field is left alone")
+				ref, _, _ := unstructured.NestedString(env[1].(map[string]interface{}), "valueFrom", "secretKeyRef", "name")
+				assert.Equal(t, "creds", ref)
+				assert.Equal(t, map[string]interface{}{"name": "sidecar"}, containers[1],
+					"a container without env is left alone")
+			},
+		},
+		{
+			name:  "trailing wildcard redacts every list element",
+			paths: []string{"spec.args[*]"},
+			check: func(t *testing.T, obj map[string]interface{}) {
+				args, _, _ := unstructured.NestedStringSlice(obj, "spec", "args")
+				assert.Equal(t, []string{RedactedValue, RedactedValue}, args)
+			},
+		},
+		{
+			name: "missing and mistyped paths redact nothing",
+			paths: []string{
+				"spec.absent", "spec.absent.deeper", "status.token", "spec.token.deeper",
+				"spec.token[*]", "spec.auth[*].user", "spec..token", "spec.args[0]",
+			},
+			check: func(t *testing.T, obj map[string]interface{}) {
+				assert.Equal(t, redactTestObject().Object, obj)
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			obj := redactTestObject()
+			RedactFields(obj, tc.paths)
+			tc.check(t, obj.Object)
+			assert.Equal(t, "sync", obj.GetName())
+		})
+	}
+}
+
+func TestRedactFields_NilObjectIsNoOp(t *testing.T) {
+	assert.NotPanics(t, func() { RedactFields(nil, []string{"spec.token"}) })
+}
+
+func TestRules_ApplyRedactsAfterSanitize(t *testing.T) {
+	obj := Sanitize(redactTestObject())
+	rules := &Rules{RedactFields: []string{"spec.token"}}
+	require.NoError(t, rules.Validate())
+	rules.Apply(obj)
+
+	v, _, _ := unstructured.NestedString(obj.Object, "spec", "token")
+	assert.Equal(t, RedactedValue, v)
+}
+
+func TestRules_ValidateRedactFields(t *testing.T) {
+	require.NoError(t, (&Rules{RedactFields: []string{"spec.containers[*].env[*].value", "metadata.labels"}}).Validate())
+	for _, bad := range []string{"", "spec..token", "spec.items[0]", "spec.[*]", "spec.a[*]b", "kind",
+		"metadata", "metadata.name", "metadata[*]"} {
+		assert.Error(t, (&Rules{RedactFields: []string{bad}}).Validate(), bad)
+	}
+}
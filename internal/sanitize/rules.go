@@ -0,0 +1,294 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sanitize
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Rules adjusts what Sanitize keeps for one resource type. KeepAnnotations and KeepLabels are
+// handed to Sanitize as Options, so a bookkeeping key they match survives it; everything else
+// applies AFTER Sanitize (Apply). The allowlists, blocklists and StripFields remove more,
+// SortListFields reorders lists, and RedactFields masks values; none of them adds a field.
+//
+// A list entry is an exact key, or a prefix when it ends in "*" ("example.com/*"). An empty
+// allowlist allows every key; the blocklist is applied after the allowlist, and both apply to
+// kept keys too.
+type Rules struct {
+	KeepAnnotations     []string
+	KeepLabels          []string
+	AnnotationAllowlist []string
+	AnnotationBlocklist []string
+	LabelAllowlist      []string
+	LabelBlocklist      []string
+	// StripFields are JSONPath field references (".spec.replicas",
+	// "$.metadata.annotations['example.com/x']") or plain dot paths ("spec.replicas") removed from
+	// the object; see ParseFieldPath. A path to a field that is absent removes nothing.
+	StripFields []string
+	// SortListFields are dot-separated paths to lists rewritten in a stable order; see
+	// SortListFields.
+	SortListFields []string
+	// RedactFields are dot-separated paths, with "[*]" for every list element, whose values are
+	// replaced with RedactedValue; see RedactFields.
+	RedactFields []string
+}
+
+// Validate reports the first StripFields path that is malformed or would remove part of the
+// object's identity (apiVersion, kind, metadata, metadata.name, metadata.namespace), then the
+// first malformed SortListFields path, then the first RedactFields path that is malformed or
+// would redact part of the identity.
+func (r *Rules) Validate() error {
+	for _, path := range r.StripFields {
+		if _, err := ParseFieldPath(path); err != nil {
+			return err
+		}
+	}
+	for _, path := range r.SortListFields {
+		if _, err := splitFieldPath(path); err != nil {
+			return err
+		}
+	}
+	for _, path := range r.RedactFields {
+		if _, err := parseRedactPath(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ParseFieldPath splits a field path to remove. It takes the field-reference subset of
+// JSONPath, as kubectl's -o jsonpath writes it: ".spec.replicas", "$.spec.replicas" or
+// "{.spec.replicas}", with a bracket-quoted member for a key that holds a dot, as in
+// ".metadata.annotations['example.com/x']". A plain dot path without the leading "." is taken too.
+// Wildcards, indexes and filters are refused: a path names exactly one field.
+func ParseFieldPath(path string) ([]string, error) {
+	fields, err := splitJSONPath(path)
+	if err != nil {
+		return nil, err
+	}
+	if identityPath(fields) {
+		return nil, fmt.Errorf("field path %q would remove the object's identity", path)
+	}
+	return fields, nil
+}
+
+func splitJSONPath(path string) ([]string, error) {
+	expr := strings.TrimSpace(path)
+	if inner, ok := strings.CutPrefix(expr, "{"); ok {
+		if expr, ok = strings.CutSuffix(inner, "}"); !ok {
+			return nil, fmt.Errorf("field path %q has an unclosed \"{\"", path)
+		}
+	}
+	expr = strings.TrimPrefix(expr, "$")
+	if expr != "" && expr[0] != '.' && expr[0] != '[' {
+		expr = "." + expr
+	}
+	if expr == "" {
+		return nil, fmt.Errorf("field path %q is empty", path)
+	}
+
+	var fields []string
+	for expr != "" {
+		var field string
+		switch expr[0] {
+		case '.':
+			expr = expr[1:]
+			end := strings.IndexAny(expr, ".[")
+			if end < 0 {
+				end = len(expr)
+			}
+			field, expr = expr[:end], expr[end:]
+		case '[':
+			quoted := len(expr) > 1 && (expr[1] == '\'' || expr[1] == '"')
+			if !quoted {
+				return nil, fmt.Errorf("field path %q: only ['quoted'] members are supported, "+
+					"not wildcards, indexes or filters", path)
+			}
+			closing := string(expr[1]) + "]"
+			end := strings.Index(expr[2:], closing)
+			if end < 0 {
+				return nil, fmt.Errorf("field path %q has an unclosed %q", path, expr[:2])
+			}
+			field, expr = expr[2:2+end], expr[2+end+len(closing):]
+		default:
+			return nil, fmt.Errorf("field path %q: expected \".\" or \"[\" before %q", path, expr)
+		}
+		if field == "" || field == "*" {
+			return nil, fmt.Errorf("field path %q has an empty or wildcard segment", path)
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+func splitFieldPath(path string) ([]string, error) {
+	fields := strings.Split(strings.TrimPrefix(path, "."), ".")
+	for _, field := range fields {
+		if field == "" {
+			return nil, fmt.Errorf("field path %q has an empty segment", path)
+		}
+	}
+	return fields, nil
+}
+
+func identityPath(fields []string) bool {
+	switch fields[0] {
+	case "apiVersion", "kind":
+		return true
+	case "metadata":
+		return len(fields) == 1 || fields[1] == "name" || fields[1] == "namespace"
+	}
+	return false
+}
+
+// Apply applies the rules to obj, in place and in this order: it filters annotations and labels
+// through the allow and block lists, removes the StripFields paths, sorts the SortListFields lists,
+// and replaces the RedactFields values with RedactedValue. A nil Rules is a no-op. Call Validate
+// first; an invalid path is skipped here rather than failing the write.
+func (r *Rules) Apply(obj *unstructured.Unstructured) {
+	if r == nil || obj == nil {
+		return
+	}
+	if len(r.AnnotationAllowlist) > 0 || len(r.AnnotationBlocklist) > 0 {
+		obj.SetAnnotations(filterKeys(obj.GetAnnotations(), r.AnnotationAllowlist, r.AnnotationBlocklist))
+	}
+	if len(r.LabelAllowlist) > 0 || len(r.LabelBlocklist) > 0 {
+		obj.SetLabels(filterKeys(obj.GetLabels(), r.LabelAllowlist, r.LabelBlocklist))
+	}
+	for _, path := range r.StripFields {
+		fields, err := ParseFieldPath(path)
+		if err != nil {
+			continue
+		}
+		unstructured.RemoveNestedField(obj.Object, fields...)
+	}
+	SortListFields(obj, r.SortListFields)
+	RedactFields(obj, r.RedactFields)
+}
+
+// SortListFields rewrites each list at paths in a stable order, in place. When every element is
+// an object with a string "name" — the usual Kubernetes list key — the list is sorted by it;
+// otherwise by each element's JSON, which orders any value the same way every time. A path that
+// is malformed, absent, or not a list is left alone.
+func SortListFields(obj *unstructured.Unstructured, paths []string) {
+	if obj == nil {
+		return
+	}
+	for _, path := range paths {
+		fields, err := splitFieldPath(path)
+		if err != nil {
+			continue
+		}
+		list, found, err := unstructured.NestedFieldNoCopy(obj.Object, fields...)
+		items, ok := list.([]interface{})
+		if err != nil || !found || !ok || len(items) < 2 {
+			continue
+		}
+		sortList(items)
+	}
+}
+
+func sortList(items []interface{}) {
+	keys := make([]string, len(items))
+	byName := true
+	for i, item := range items {
+		if m, ok := item.(map[string]interface{}); ok {
+			if name, ok := m["name"].(string); ok {
+				keys[i] = name
+				continue
+			}
+		}
+		byName = false
+		break
+	}
+	if !byName {
+		for i, item := range items {
+			b, err := json.Marshal(item)
+			if err != nil {
+				return
+			}
+			keys[i] = string(b)
+		}
+	}
+	sort.Stable(keyedList{keys: keys, items: items})
+}
+
+// keyedList sorts a list by a precomputed key per element, moving both together.
+type keyedList struct {
+	keys  []string
+	items []interface{}
+}
+
+func (l keyedList) Len() int           { return len(l.keys) }
+func (l keyedList) Less(i, j int) bool { return l.keys[i] < l.keys[j] }
+func (l keyedList) Swap(i, j int) {
+	l.keys[i], l.keys[j] = l.keys[j], l.keys[i]
+	l.items[i], l.items[j] = l.items[j], l.items[i]
+}
+
+// StripAnnotations removes the annotations patterns match from obj, in place. A pattern is an
+// exact key, or a prefix when it ends in "*", as in Rules. It is the install-wide addition to the
+// operational annotations Sanitize always removes, so an empty list changes nothing.
+func StripAnnotations(obj *unstructured.Unstructured, patterns []string) {
+	if obj == nil || len(patterns) == 0 {
+		return
+	}
+	obj.SetAnnotations(filterKeys(obj.GetAnnotations(), nil, patterns))
+}
+
+// ParseKeyPatterns splits a comma-separated list of exact keys and "prefix*" patterns, trimming
+// each entry and dropping empty ones. A "*" anywhere but the end is refused: matching is by
+// exact key or prefix only.
+func ParseKeyPatterns(list string) ([]string, error) {
+	var patterns []string
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if strings.Contains(strings.TrimSuffix(entry, "*"), "*") {
+			return nil, fmt.Errorf("key pattern %q may only end in \"*\"", entry)
+		}
+		patterns = append(patterns, entry)
+	}
+	return patterns, nil
+}
+
+// filterKeys keeps the keys the allowlist admits and the blocklist does not. Like cleanLabels it
+// returns nil rather than an empty map, so an emptied field is dropped from the document.
+func filterKeys(in map[string]string, allow, block []string) map[string]string {
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		if len(allow) > 0 && !matchesAnyKey(k, allow) {
+			continue
+		}
+		if matchesAnyKey(k, block) {
+			continue
+		}
+		out[k] = v
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+func matchesAnyKey(key string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+			continue
+		}
+		if key == pattern {
+			return true
+		}
+	}
+	return false
+}
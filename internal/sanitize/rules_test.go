@@ -0,0 +1,212 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sanitize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func rulesTestDeployment() *unstructured.Unstructured {
+	return Sanitize(&unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":      "web",
+			"namespace": "shop",
+			"labels":    map[string]interface{}{"app": "web", "team": "payments", "example.com/tier": "gold"},
+			"annotations": map[string]interface{}{
+				"deployment.kubernetes.io/revision": "7",
+				"example.com/owner":                 "alice",
+				"example.com/build":                 "1234",
+				"note":                              "keep",
+			},
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(3),
+			"template": map[string]interface{}{"spec": map[string]interface{}{"priority": int64(5)}},
+		},
+	}})
+}
+
+func TestRules_Apply(t *testing.T) {
+	obj := rulesTestDeployment()
+	rules := &Rules{
+		AnnotationAllowlist: []string{"example.com/*", "deployment.kubernetes.io/revision"},
+		AnnotationBlocklist: []string{"example.com/build"},
+		LabelBlocklist:      []string{"example.com/*"},
+		StripFields:         []string{"spec.replicas", ".spec.template.spec.priority", "spec.absent.field"},
+	}
+	require.NoError(t, rules.Validate())
+	rules.Apply(obj)
+
+	assert.Equal(t, map[string]string{"example.com/owner": "alice"}, obj.GetAnnotations(),
+		"the allowlist never restores an annotation Sanitize stripped")
+	assert.Equal(t, map[string]string{"app": "web", "team": "payments"}, obj.GetLabels())
+	_, found, _ := unstructured.NestedFieldNoCopy(obj.Object, "spec", "replicas")
+	assert.False(t, found)
+	_, found, _ = unstructured.NestedFieldNoCopy(obj.Object, "spec", "template", "spec", "priority")
+	assert.False(t, found)
+	assert.Equal(t, "web", obj.GetName())
+}
+
+func TestRules_ApplyDropsAnEmptiedField(t *testing.T) {
+	obj := rulesTestDeployment()
+	(&Rules{AnnotationAllowlist: []string{"nothing-matches"}}).Apply(obj)
+
+	_, found, _ := unstructured.NestedFieldNoCopy(obj.Object, "metadata", "annotations")
+	assert.False(t, found)
+}
+
+func TestRules_NilIsNoOp(t *testing.T) {
+	obj := rulesTestDeployment()
+	want := obj.DeepCopy()
+	var rules *Rules
+	rules.Apply(obj)
+	assert.Equal(t, want, obj)
+}
+
+func TestSortListFields(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"name": "sidecar", "image": "b"},
+				map[string]interface{}{"name": "app", "image": "a"},
+			},
+			"tolerations": []interface{}{
+				map[string]interface{}{"key": "zone", "operator": "Exists"},
+				map[string]interface{}{"key": "gpu", "operator": "Exists"},
+			},
+			"args": []interface{}{"--b", "--a"},
+		},
+	}}
+	SortListFields(obj, []string{".spec.containers", "spec.tolerations", "spec.absent", "spec..args"})
+
+	containers, _, _ := unstructured.NestedSlice(obj.Object, "spec", "containers")
+	assert.Equal(t, "app", containers[0].(map[string]interface{})["name"], "named elements sort by name")
+	tolerations, _, _ := unstructured.NestedSlice(obj.Object, "spec", "tolerations")
+	assert.Equal(t, "gpu", tolerations[0].(map[string]interface{})["key"], "other elements sort by their JSON")
+	args, _, _ := unstructured.NestedStringSlice(obj.Object, "spec", "args")
+	assert.Equal(t, []string{"--b", "--a"}, args, "an unlisted path keeps its order")
+}
+
+func TestRules_ValidateSortListFields(t *testing.T) {
+	require.NoError(t, (&Rules{SortListFields: []string{"spec.template.spec.volumes"}}).Validate())
+	require.Error(t, (&Rules{SortListFields: []string{"spec..volumes"}}).Validate())
+}
+
+func TestParseFieldPath(t *testing.T) {
+	fields, err := ParseFieldPath(".spec.replicas")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"spec", "replicas"}, fields)
+
+	fields, err = ParseFieldPath("metadata.labels")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"metadata", "labels"}, fields)
+
+	for path, want := range map[string][]string{
+		"$.spec.replicas":                          {"spec", "replicas"},
+		"{.spec.replicas}":                         {"spec", "replicas"},
+		".metadata.annotations['example.com/x']":   {"metadata", "annotations", "example.com/x"},
+		`$.metadata.labels["app.kubernetes.io/x"]`: {"metadata", "labels", "app.kubernetes.io/x"},
+	} {
+		fields, err := ParseFieldPath(path)
+		require.NoError(t, err, path)
+		assert.Equal(t, want, fields, path)
+	}
+
+	for _, bad := range []string{"", ".", "spec..replicas", "kind", "apiVersion", "metadata", "metadata.name",
+		"metadata.namespace", ".spec.containers[0]", ".spec.containers[*]", ".spec.x['unclosed", "{.spec",
+		"$['metadata']['name']"} {
+		_, err := ParseFieldPath(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestRules_KeepSurvivesSanitizeAndStillPassesTheAllowlist(t *testing.T) {
+	raw := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name": "web",
+			"annotations": map[string]interface{}{
+				"deployment.kubernetes.io/revision":                "7",
+				"kubectl.kubernetes.io/last-applied-configuration": "{}",
+				"example.com/owner":                                "alice",
+			},
+		},
+	}}
+	rules := &Rules{
+		KeepAnnotations:     []string{"deployment.kubernetes.io/revision"},
+		AnnotationBlocklist: []string{"example.com/owner"},
+	}
+	obj := SanitizeWithOptions(raw, Options{KeepAnnotations: rules.KeepAnnotations})
+	rules.Apply(obj)
+	assert.Equal(t, map[string]string{"deployment.kubernetes.io/revision": "7"}, obj.GetAnnotations())
+
+	allowOnly := SanitizeWithOptions(raw, Options{KeepAnnotations: rules.KeepAnnotations})
+	(&Rules{AnnotationAllowlist: []string{"example.com/*"}}).Apply(allowOnly)
+	assert.Equal(t, map[string]string{"example.com/owner": "alice"}, allowOnly.GetAnnotations(),
+		"a kept key still has to pass the allowlist")
+
+	rendered, err := MarshalToOrderedYAML(obj)
+	require.NoError(t, err)
+	assert.Contains(t, string(rendered), "deployment.kubernetes.io/revision",
+		"rendering does not strip a key sanitizing kept")
+}
+
+func TestStripAnnotations_AddsToTheDefaults(t *testing.T) {
+	object := func() *unstructured.Unstructured {
+		return Sanitize(&unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name": "cfg",
+				"annotations": map[string]interface{}{
+					"kubectl.kubernetes.io/last-applied-configuration": "{}",
+					"operator.foo/generation":                          "12",
+					"operator.foo/observed":                            "12",
+					"operator.foo.io/keep":                             "yes",
+					"note":                                             "keep",
+				},
+			},
+		}})
+	}
+
+	unchanged := object()
+	StripAnnotations(unchanged, nil)
+	assert.Equal(t, map[string]string{
+		"operator.foo/generation": "12",
+		"operator.foo/observed":   "12",
+		"operator.foo.io/keep":    "yes",
+		"note":                    "keep",
+	}, unchanged.GetAnnotations(), "an empty list leaves the built-in stripping as the only stripping")
+
+	stripped := object()
+	StripAnnotations(stripped, []string{"operator.foo/*", "note"})
+	assert.Equal(t, map[string]string{"operator.foo.io/keep": "yes"}, stripped.GetAnnotations(),
+		"the list adds to the defaults, matching exact keys and prefixes")
+
+	emptied := object()
+	StripAnnotations(emptied, []string{"*"})
+	_, found, _ := unstructured.NestedFieldNoCopy(emptied.Object, "metadata", "annotations")
+	assert.False(t, found, "an emptied annotations map is dropped")
+}
+
+func TestParseKeyPatterns(t *testing.T) {
+	patterns, err := ParseKeyPatterns(" operator.foo/* ,, example.com/build ,")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"operator.foo/*", "example.com/build"}, patterns)
+
+	patterns, err = ParseKeyPatterns("")
+	require.NoError(t, err)
+	assert.Empty(t, patterns)
+
+	for _, bad := range []string{"operator.*/gen", "*.foo/*"} {
+		_, err := ParseKeyPatterns(bad)
+		assert.Error(t, err, bad)
+	}
+}
@@ -12,14 +12,49 @@ import (
 
 // serverGeneratedFields are metadata fields that should be removed during sanitization
 
+// Options selects what SanitizeWithOptions keeps beyond the desired state. The zero value is
+// Sanitize.
+type Options struct {
+	// PreserveManagedFields keeps metadata.managedFields, the server-side-apply ownership record,
+	// with its entries in SortManagedFields order so the same ownership renders the same bytes.
+	PreserveManagedFields bool
+	// IncludeStatus keeps status, the object's observed state, which is otherwise not desired
+	// state and stripped.
+	IncludeStatus bool
+	// KeepAnnotations and KeepLabels are keys, or prefixes ending in "*", kept even though they
+	// are controller bookkeeping Sanitize would otherwise strip. "*" keeps every key.
+	KeepAnnotations []string
+	KeepLabels      []string
+}
+
+// IsZero reports whether o is the zero Options, i.e. plain Sanitize.
+func (o Options) IsZero() bool {
+	return !o.PreserveManagedFields && !o.IncludeStatus && len(o.KeepAnnotations) == 0 && len(o.KeepLabels) == 0
+}
+
+// KeepAllKeys, as Options.KeepAnnotations or KeepLabels, keeps every key. It is for an object
+// already sanitized once, whose remaining keys were kept on purpose.
+var KeepAllKeys = []string{"*"} //nolint:gochecknoglobals // read-only pattern list
+
 // Sanitize removes server-side fields from a Kubernetes object,
 // leaving only the desired state.
 func Sanitize(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	return SanitizeWithOptions(obj, Options{})
+}
+
+// SanitizeWithOptions is Sanitize, keeping what opts asks for.
+func SanitizeWithOptions(obj *unstructured.Unstructured, opts Options) *unstructured.Unstructured {
 	sanitized := &unstructured.Unstructured{Object: make(map[string]interface{})}
 
-	setCoreIdentityFields(sanitized, obj)
+	setCoreIdentityFields(sanitized, obj, opts)
+	// A Secret's stringData is deliberately absent: it is write-only, folded into data by the API
+	// server, and would be plaintext in Git. data itself only reaches Git encrypted; the writer
+	// refuses a sensitive resource when no encryptor is configured.
 	preserveFields(sanitized, obj, []string{"spec", "data", "binaryData"})
 	preserveTopLevelFields(sanitized, obj)
+	if opts.IncludeStatus {
+		preserveFields(sanitized, obj, []string{"status"})
+	}
 
 	// Remove auto-generated metadata fields (adapted from Kyverno)
 	if metadata, found, _ := unstructured.NestedMap(sanitized.Object, "metadata"); found {
@@ -30,18 +65,24 @@ func Sanitize(obj *unstructured.Unstructured) *unstructured.Unstructured {
 	// Remove nested server-generated fields based on resource kind
 	removeNestedServerFields(sanitized)
 
+	if opts.PreserveManagedFields {
+		if entries := managedFields(obj); len(entries) > 0 {
+			_ = unstructured.SetNestedSlice(sanitized.Object, entries, "metadata", "managedFields")
+		}
+	}
+
 	return sanitized
 }
 
 // setCoreIdentityFields preserves the core identity fields of the object.
-func setCoreIdentityFields(sanitized, obj *unstructured.Unstructured) {
+func setCoreIdentityFields(sanitized, obj *unstructured.Unstructured, opts Options) {
 	sanitized.SetAPIVersion(obj.GetAPIVersion())
 	sanitized.SetKind(obj.GetKind())
 	sanitized.SetName(obj.GetName())
 	sanitized.SetNamespace(obj.GetNamespace())
-	sanitized.SetLabels(cleanLabels(obj.GetLabels()))
+	sanitized.SetLabels(cleanLabels(obj.GetLabels(), opts.KeepLabels))
 	// Clean annotations using the cleanAnnotations function from types.go
-	sanitized.SetAnnotations(cleanAnnotations(obj.GetAnnotations()))
+	sanitized.SetAnnotations(cleanAnnotations(obj.GetAnnotations(), opts.KeepAnnotations))
 }
 
 // preserveFields preserves specified fields from the original object.
@@ -118,6 +118,30 @@ func TestSanitize_ConfigMapWithData(t *testing.T) {
 	}, binaryData)
 }
 
+// stringData is write-only and never returned by a GET, but an object that still carries it (a
+// manifest read back, or a client-constructed object) must not put it into Git in plaintext.
+func TestSanitize_SecretStringDataIsDropped(t *testing.T) {
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Secret",
+			"metadata":   map[string]interface{}{"name": "db", "namespace": "shop"},
+			"type":       "Opaque",
+			"data":       map[string]interface{}{"password": "aHVudGVyMg=="},
+			"stringData": map[string]interface{}{"token": "plaintext-token"},
+		},
+	}
+
+	sanitized := Sanitize(obj)
+
+	_, found := sanitized.Object["stringData"]
+	assert.False(t, found, "stringData must never be preserved")
+	out, err := MarshalToOrderedYAML(sanitized)
+	require.NoError(t, err)
+	assert.NotContains(t, string(out), "plaintext-token")
+	assert.NotContains(t, string(out), "stringData")
+}
+
 func TestSanitize_ClusterScopedResource(t *testing.T) {
 	obj := &unstructured.Unstructured{
 		Object: map[string]interface{}{
@@ -697,3 +721,29 @@ func TestSanitize_CustomResource(t *testing.T) {
 	assert.NotContains(t, metadata, "uid")
 	assert.NotContains(t, metadata, "resourceVersion")
 }
+
+func TestSanitizeWithOptions_IncludeStatus(t *testing.T) {
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "cert-manager.io/v1",
+			"kind":       "Certificate",
+			"metadata": map[string]interface{}{
+				"name":      "web",
+				"namespace": "apps",
+			},
+			"spec": map[string]interface{}{"secretName": "web-tls"},
+			"status": map[string]interface{}{
+				"notAfter": "2027-01-01T00:00:00Z",
+			},
+		},
+	}
+
+	_, found, err := unstructured.NestedMap(Sanitize(obj).Object, "status")
+	require.NoError(t, err)
+	assert.False(t, found, "status is stripped by default")
+
+	status, found, err := unstructured.NestedMap(SanitizeWithOptions(obj, Options{IncludeStatus: true}).Object, "status")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, map[string]interface{}{"notAfter": "2027-01-01T00:00:00Z"}, status)
+}
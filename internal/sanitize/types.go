@@ -3,6 +3,7 @@
 package sanitize
 
 import (
+	"sort"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -15,25 +16,56 @@ type PartialObjectMeta struct {
 	Namespace   string            `json:"namespace,omitempty"   yaml:"namespace,omitempty"`
 	Labels      map[string]string `json:"labels,omitempty"      yaml:"labels,omitempty"`
 	Annotations map[string]string `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+	// ManagedFields is only ever set for an object sanitized with Options.PreserveManagedFields;
+	// Sanitize removes it otherwise.
+	ManagedFields []interface{} `json:"managedFields,omitempty" yaml:"managedFields,omitempty"`
 }
 
 // FromUnstructured extracts PartialObjectMeta from an unstructured object.
 func (p *PartialObjectMeta) FromUnstructured(obj *unstructured.Unstructured) {
 	p.Name = obj.GetName()
 	p.Namespace = obj.GetNamespace()
-	p.Labels = cleanLabels(obj.GetLabels())
-	p.Annotations = cleanAnnotations(obj.GetAnnotations())
+	p.Labels = cleanLabels(obj.GetLabels(), nil)
+	p.Annotations = cleanAnnotations(obj.GetAnnotations(), nil)
+	p.ManagedFields = managedFields(obj)
 }
 
-// cleanLabels removes operational labels that should not be persisted.
-func cleanLabels(labels map[string]string) map[string]string {
+// managedFields returns a copy of obj's metadata.managedFields in SortManagedFields order, or nil.
+func managedFields(obj *unstructured.Unstructured) []interface{} {
+	entries, found, err := unstructured.NestedSlice(obj.Object, "metadata", "managedFields")
+	if err != nil || !found || len(entries) == 0 {
+		return nil
+	}
+	SortManagedFields(entries)
+	return entries
+}
+
+// SortManagedFields orders managedFields entries by manager, operation, subresource and
+// apiVersion, in place. The API server does not promise an order, and one that moved between two
+// reads of the same ownership would otherwise show as a diff.
+func SortManagedFields(entries []interface{}) {
+	keys := make([]string, len(entries))
+	for i, entry := range entries {
+		m, _ := entry.(map[string]interface{})
+		parts := make([]string, 0, 4)
+		for _, field := range []string{"manager", "operation", "subresource", "apiVersion"} {
+			value, _ := m[field].(string)
+			parts = append(parts, value)
+		}
+		keys[i] = strings.Join(parts, "\x00")
+	}
+	sort.Stable(keyedList{keys: keys, items: entries})
+}
+
+// cleanLabels removes operational labels that should not be persisted, except those keep matches.
+func cleanLabels(labels map[string]string, keep []string) map[string]string {
 	if labels == nil {
 		return nil
 	}
 
 	cleaned := make(map[string]string)
 	for k, v := range labels {
-		if isOperationalLabel(k) {
+		if isOperationalLabel(k) && !matchesAnyKey(k, keep) {
 			continue
 		}
 		cleaned[k] = v
@@ -45,16 +77,16 @@ func cleanLabels(labels map[string]string) map[string]string {
 	return cleaned
 }
 
-// cleanAnnotations removes operational annotations.
+// cleanAnnotations removes operational annotations, except those keep matches.
 // Adapted from Kyverno's approach for cleaning system-managed annotations.
-func cleanAnnotations(annotations map[string]string) map[string]string {
+func cleanAnnotations(annotations map[string]string, keep []string) map[string]string {
 	if annotations == nil {
 		return nil
 	}
 
 	cleaned := make(map[string]string)
 	for k, v := range annotations {
-		if isOperationalAnnotation(k) {
+		if isOperationalAnnotation(k) && !matchesAnyKey(k, keep) {
 			continue
 		}
 		cleaned[k] = v
@@ -203,7 +203,7 @@ func TestCleanLabels(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := cleanLabels(tt.input)
+			result := cleanLabels(tt.input, nil)
 			assert.Equal(t, tt.expected, result)
 		})
 	}
@@ -364,7 +364,7 @@ func TestCleanAnnotations(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := cleanAnnotations(tt.input)
+			result := cleanAnnotations(tt.input, nil)
 			assert.Equal(t, tt.expected, result)
 		})
 	}
@@ -42,6 +42,9 @@ var (
 	// drop produces no plan action, no commit, and no ResyncStats entry. A non-zero value
 	// is the configured behaviour, never a fault.
 	PruneRetainedDocumentsTotal metric.Int64Counter
+	// CommitFailuresTotal counts events whose apply failed a commit, labelled by {gvr, operation}.
+	// The commit is atomic, so the whole window is dropped; this names the event that caused it.
+	CommitFailuresTotal metric.Int64Counter
 
 	// TargetReconcileCompletedTotal counts completed watch recovery passes per
 	// GitTarget: each increment marks either a streaming-snapshot resync applied on
@@ -72,6 +75,21 @@ var (
 	// TargetReconcileCompletedTotal). Load-bearing for the restart-reconcile e2e
 	// spec's drain wait; treat the name/labels as a public observability contract.
 	BranchWorkerQueueDepth metric.Int64Gauge
+	// BranchWorkerOldestPendingSeconds gauges, in whole seconds, how long a branch worker's oldest
+	// pending work has waited: the next queued item, or the work the worker has kept unpushed
+	// since it last had none. It reads 0 once the worker has drained. Labelled like
+	// BranchWorkerQueueDepth.
+	BranchWorkerOldestPendingSeconds metric.Int64Gauge
+	// EventToCommitSeconds records, once per successful push, how long the oldest live watch event
+	// it carried waited between reaching the controller and reaching the remote. Snapshot and
+	// resync writes carry no receive time and are not measured. Labelled by {provider_namespace,
+	// provider_name, branch}, the same worker keys as BranchWorkerQueueDepth.
+	EventToCommitSeconds metric.Float64Histogram
+	// BranchWorkerState gauges what each branch worker is doing: 1 for its current state and 0 for
+	// the others (idle/fetching/committing/pushing/conflicting/errored). Labelled by
+	// {provider_namespace, provider_name, branch, state}, the same worker keys as
+	// BranchWorkerQueueDepth.
+	BranchWorkerState metric.Int64Gauge
 
 	// ResyncBackgroundFailuresTotal counts rule-change resyncs whose apply failed or
 	// timed out at the worker AFTER being enqueued. Delivery is marked on enqueue (the
@@ -123,6 +141,30 @@ var (
 	// WatchedTypes gauges the number of watched types per GitTarget, labelled by
 	// gittarget_namespace and gittarget_name.
 	WatchedTypes metric.Int64Gauge
+	// ReconcileHistoryEntries gauges the length of each GitTarget's reconcile history annotation,
+	// labelled by gittarget_namespace and gittarget_name.
+	ReconcileHistoryEntries metric.Int64Gauge
+	// ThrottledEventsTotal counts live UPDATE events a GitTarget's spec.perGVRThrottle dropped
+	// before routing, labelled by {gvr} in the same "[group/]version/resource" form as the spec key.
+	ThrottledEventsTotal metric.Int64Counter
+	// ExcludedByAnnotationTotal counts live CREATE and UPDATE events routed as a DELETE because the
+	// object carries the exclude annotation (--exclude-annotation), labelled by {gvr}.
+	ExcludedByAnnotationTotal metric.Int64Counter
+	// GitTimeoutsTotal counts remote git operations a GitProvider's timeouts cut short, labelled by
+	// {operation} ("push" or "fetch").
+	GitTimeoutsTotal metric.Int64Counter
+	// MirrorPushFailuresTotal counts failed pushes to a GitProvider's spec.mirrors, labelled by
+	// {provider_namespace, provider_name, branch, mirror}, where mirror names the mirror GitProvider.
+	MirrorPushFailuresTotal metric.Int64Counter
+	// DedupCacheEvictionsTotal counts objects evicted from the live dedup cache because it was
+	// full (--dedup-cache-size). An evicted object's next UPDATE is routed rather than deduped.
+	DedupCacheEvictionsTotal metric.Int64Counter
+	// OversizedResourceTotal counts resource writes skipped because the rendered document exceeds
+	// the GitTarget's spec.maxResourceBytes, labelled by {group, version, resource}.
+	OversizedResourceTotal metric.Int64Counter
+	// ItemListFieldPatchSkipsTotal counts subresource field patches not applied because the parent
+	// is an item of an aggregateByKind List, labelled by {group, version, resource}.
+	ItemListFieldPatchSkipsTotal metric.Int64Counter
 
 	// SecretEncryptionAttemptsTotal counts total Secret encryption attempts.
 	SecretEncryptionAttemptsTotal metric.Int64Counter
@@ -130,6 +172,9 @@ var (
 	SecretEncryptionSuccessTotal metric.Int64Counter
 	// SecretEncryptionFailuresTotal counts failed Secret encryptions.
 	SecretEncryptionFailuresTotal metric.Int64Counter
+	// SecretEncryptionDurationSeconds records how long one Secret encryption call takes, cache hits
+	// excluded, labelled by bounded outcome (success/failure).
+	SecretEncryptionDurationSeconds metric.Float64Histogram
 	// SecretEncryptionCacheHitsTotal counts cache hits for encrypted Secret content.
 	SecretEncryptionCacheHitsTotal metric.Int64Counter
 	// SecretEncryptionMarkerSkipsTotal counts marker-based skips that reused cached Secret content.
@@ -207,7 +252,10 @@ func registerInstruments() error {
 	if err := registerHistograms(); err != nil {
 		return err
 	}
-	return registerGauges()
+	if err := registerGauges(); err != nil {
+		return err
+	}
+	return registerProviderReachable()
 }
 
 func registerCounters() error {
@@ -217,8 +265,16 @@ func registerCounters() error {
 		{"gitopsreverser_commits_total", &CommitsTotal},
 		{"gitopsreverser_resync_sweep_deletes_total", &ResyncSweepDeletesTotal},
 		{"gitopsreverser_prune_retained_documents_total", &PruneRetainedDocumentsTotal},
+		{"gitopsreverser_commit_failures_total", &CommitFailuresTotal},
 		{"gitopsreverser_target_reconcile_completed_total", &TargetReconcileCompletedTotal},
 		{"gitopsreverser_resync_background_failures_total", &ResyncBackgroundFailuresTotal},
+		{"gitopsreverser_throttled_events_total", &ThrottledEventsTotal},
+		{"gitopsreverser_excluded_by_annotation_total", &ExcludedByAnnotationTotal},
+		{"gitopsreverser_git_timeout_total", &GitTimeoutsTotal},
+		{"gitopsreverser_mirror_push_failures_total", &MirrorPushFailuresTotal},
+		{"gitopsreverser_dedup_cache_evictions_total", &DedupCacheEvictionsTotal},
+		{"gitopsreverser_oversized_resource_total", &OversizedResourceTotal},
+		{"gitopsreverser_item_list_field_patch_skips_total", &ItemListFieldPatchSkipsTotal},
 		{"gitopsreverser_audit_events_total", &AuditEventsTotal},
 		{"gitopsreverser_audit_eventlists_total", &AuditEventListsTotal},
 		{"gitopsreverser_audit_eventlist_events_total", &AuditEventListEventsTotal},
@@ -251,6 +307,12 @@ func registerHistograms() error {
 	// attributionWaitBuckets span zero-wait hits up through the default grace window
 	// and slower configured waits.
 	attributionWaitBuckets := []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2, 3, 5, 10}
+	// encryptionDurationBuckets span an in-process encryptor (milliseconds) up through a sops
+	// process start on a loaded node, past the one-second mark the writer warns at.
+	encryptionDurationBuckets := []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 2.5, 5}
+	// eventToCommitBuckets span a push right after a short commit window (sub-second) up through
+	// a long window, push retries against a flaky remote, and a backed-up worker (minutes).
+	eventToCommitBuckets := []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600}
 	hists := []hSpec{
 		{"gitopsreverser_audit_eventlist_duration_seconds", &AuditEventListDurationSeconds, eventListDurationBuckets},
 		{
@@ -263,6 +325,12 @@ func registerHistograms() error {
 			&APICatalogRefreshDurationSeconds,
 			catalogRefreshBuckets,
 		},
+		{"gitopsreverser_event_to_commit_seconds", &EventToCommitSeconds, eventToCommitBuckets},
+		{
+			"gitopsreverser_secret_encryption_duration_seconds",
+			&SecretEncryptionDurationSeconds,
+			encryptionDurationBuckets,
+		},
 	}
 	for _, s := range hists {
 		opts := []metric.Float64HistogramOption{}
@@ -284,7 +352,10 @@ func registerGauges() error {
 		{"gitopsreverser_api_catalog_group_versions", &APICatalogGroupVersions},
 		{"gitopsreverser_api_catalog_generation", &APICatalogGeneration},
 		{"gitopsreverser_watched_types", &WatchedTypes},
+		{"gitopsreverser_reconcile_history_entries", &ReconcileHistoryEntries},
 		{"gitopsreverser_branch_worker_queue_depth", &BranchWorkerQueueDepth},
+		{"gitopsreverser_branch_worker_state", &BranchWorkerState},
+		{"gitopsreverser_branch_worker_oldest_pending_seconds", &BranchWorkerOldestPendingSeconds},
 		{"gitopsreverser_attribution_fact_index_size", &AttributionFactIndexSize},
 	}
 	for _, s := range gauges {
@@ -107,6 +107,12 @@ func TestMetricsInitialization(t *testing.T) {
 			APICatalogRefreshDurationSeconds.Record(ctx, 0.05)
 		})
 	})
+
+	t.Run("SecretEncryptionDurationSeconds", func(t *testing.T) {
+		assert.NotPanics(t, func() {
+			SecretEncryptionDurationSeconds.Record(ctx, 0.02)
+		})
+	})
 }
 
 func TestMeterInitialization(t *testing.T) {
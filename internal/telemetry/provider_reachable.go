@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package telemetry
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// providerKey identifies one GitProvider's gitopsreverser_provider_reachable series.
+type providerKey struct {
+	namespace string
+	name      string
+}
+
+// providerReachability holds the outcome of each GitProvider's latest connectivity check: 1 when
+// the repository answered, 0 when it did not. The gitopsreverser_provider_reachable gauge observes
+// it on every collection, labelled by {provider_namespace, provider_name}, the same keys as
+// BranchWorkerQueueDepth. It is an observable gauge rather than a metric.Int64Gauge so that
+// ForgetProviderReachable can drop a deleted GitProvider's series instead of leaving its last
+// value exported for the life of the process.
+//
+//nolint:gochecknoglobals // Process-wide metric state, like the instruments in exporter.go.
+var providerReachability = struct {
+	mu     sync.Mutex
+	values map[providerKey]int64
+}{values: map[providerKey]int64{}}
+
+// RecordProviderReachable stores the outcome of a GitProvider's connectivity check.
+func RecordProviderReachable(namespace, name string, reachable bool) {
+	var value int64
+	if reachable {
+		value = 1
+	}
+	providerReachability.mu.Lock()
+	defer providerReachability.mu.Unlock()
+	providerReachability.values[providerKey{namespace: namespace, name: name}] = value
+}
+
+// ForgetProviderReachable removes a GitProvider's series, so a deleted GitProvider no longer
+// reports its last connectivity outcome.
+func ForgetProviderReachable(namespace, name string) {
+	providerReachability.mu.Lock()
+	defer providerReachability.mu.Unlock()
+	delete(providerReachability.values, providerKey{namespace: namespace, name: name})
+}
+
+// registerProviderReachable creates the gitopsreverser_provider_reachable observable gauge against
+// the current otelMeter.
+func registerProviderReachable() error {
+	_, err := otelMeter.Int64ObservableGauge(
+		"gitopsreverser_provider_reachable",
+		metric.WithInt64Callback(observeProviderReachable),
+	)
+	return err
+}
+
+func observeProviderReachable(_ context.Context, o metric.Int64Observer) error {
+	providerReachability.mu.Lock()
+	defer providerReachability.mu.Unlock()
+	for key, value := range providerReachability.values {
+		o.Observe(value, metric.WithAttributes(
+			attribute.String("provider_namespace", key.namespace),
+			attribute.String("provider_name", key.name),
+		))
+	}
+	return nil
+}
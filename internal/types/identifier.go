@@ -5,6 +5,7 @@ package types
 
 import (
 	"fmt"
+	"path"
 )
 
 // ResourceIdentifier encapsulates all information needed to uniquely identify a
@@ -56,6 +57,12 @@ func (r ResourceIdentifier) Key() string {
 // so changing this shape never moves a file that is already in Git. See
 // docs/spec/gittarget-new-file-placement-rules.md.
 func (r ResourceIdentifier) ToGitPath() string {
+	return r.ToGitPathWithExtension(".yaml")
+}
+
+// ToGitPathWithExtension is ToGitPath with the file extension ext (".yaml", ".json")
+// in place of ".yaml".
+func (r ResourceIdentifier) ToGitPathWithExtension(ext string) string {
 	scope := r.Namespace
 	if scope == "" {
 		// Cluster-scoped resource: the scope segment is "_cluster", an illegal
@@ -68,10 +75,17 @@ func (r ResourceIdentifier) ToGitPath() string {
 
 	if r.Group == "" {
 		// Core resources (no group): omit the group segment entirely.
-		return fmt.Sprintf("%s/%s/%s.yaml", scope, r.Resource, r.Name)
+		return fmt.Sprintf("%s/%s/%s%s", scope, r.Resource, r.Name, ext)
 	}
 
-	return fmt.Sprintf("%s/%s/%s/%s.yaml", scope, r.Group, r.Resource, r.Name)
+	return fmt.Sprintf("%s/%s/%s/%s%s", scope, r.Group, r.Resource, r.Name, ext)
+}
+
+// ToListGitPath is the path of the List document a WatchRule's aggregateByKind keeps for the
+// resource's type and namespace: {namespace-or-cluster}/{group}/{resource}{ext}, the directory
+// ToGitPath would place the resource in, as one file.
+func (r ResourceIdentifier) ToListGitPath(ext string) string {
+	return path.Dir(r.ToGitPathWithExtension(ext)) + ext
 }
 
 // IsClusterScoped returns true if the resource is cluster-scoped.
@@ -123,6 +123,27 @@ func TestResourceIdentifier_ToGitPath(t *testing.T) {
 	}
 }
 
+func TestResourceIdentifier_ToGitPathWithExtension(t *testing.T) {
+	namespaced := ResourceIdentifier{
+		Group: "apps", Version: "v1", Resource: "deployments", Namespace: "default", Name: "web",
+	}
+	assert.Equal(t, "default/apps/deployments/web.json", namespaced.ToGitPathWithExtension(".json"))
+
+	core := ResourceIdentifier{Version: "v1", Resource: "namespaces", Name: "team-a"}
+	assert.Equal(t, "_cluster/namespaces/team-a.json", core.ToGitPathWithExtension(".json"))
+	assert.Equal(t, core.ToGitPath(), core.ToGitPathWithExtension(".yaml"))
+}
+
+func TestResourceIdentifier_ToListGitPath(t *testing.T) {
+	namespaced := ResourceIdentifier{
+		Group: "apps", Version: "v1", Resource: "deployments", Namespace: "default", Name: "web",
+	}
+	assert.Equal(t, "default/apps/deployments.yaml", namespaced.ToListGitPath(".yaml"))
+
+	core := ResourceIdentifier{Version: "v1", Resource: "namespaces", Name: "team-a"}
+	assert.Equal(t, "_cluster/namespaces.json", core.ToListGitPath(".json"))
+}
+
 func TestResourceIdentifier_IsClusterScoped(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -207,6 +207,7 @@ func (r *attributionResolver) userInfoForResolution(
 		Username:    fact.Author,
 		DisplayName: fact.DisplayName,
 		Email:       fact.Email,
+		Groups:      fact.Groups,
 	}, git.AttributionResolved, result
 }
 
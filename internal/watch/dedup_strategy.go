@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package watch
+
+import (
+	v1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
+	"github.com/ConfigButler/gitops-reverser/internal/types"
+)
+
+// A GitTarget's spec.dedupStrategy is captured on Declare like its throttles, and read by
+// skipUnchangedLiveUpdate on every live event.
+//
+// Both caches are written whatever the strategy, so switching strategies never dedups against a
+// value the other strategy let go stale: every routed event records its resourceVersion, and the
+// ResourceVersion strategy, which never hashes, clears the stored hash instead of leaving one
+// that Git has since moved past.
+//
+// Only the live event path dedups. Snapshot, resync and bootstrap writes carry no
+// resourceVersion worth comparing and never pass through here.
+
+// rememberGitTargetDedupStrategy records the strategy a GitTarget declared.
+func (m *Manager) rememberGitTargetDedupStrategy(gitDest types.ResourceReference, strategy v1alpha3.DedupStrategy) {
+	m.gitTargetDedupStrategiesMu.Lock()
+	defer m.gitTargetDedupStrategiesMu.Unlock()
+	if m.gitTargetDedupStrategies == nil {
+		m.gitTargetDedupStrategies = map[string]v1alpha3.DedupStrategy{}
+	}
+	m.gitTargetDedupStrategies[gitDest.Key()] = strategy
+}
+
+// forgetGitTargetDedupStrategy drops a deleted GitTarget's strategy.
+func (m *Manager) forgetGitTargetDedupStrategy(gitDest types.ResourceReference) {
+	m.gitTargetDedupStrategiesMu.Lock()
+	defer m.gitTargetDedupStrategiesMu.Unlock()
+	delete(m.gitTargetDedupStrategies, gitDest.Key())
+}
+
+// dedupStrategyFor returns the GitTarget's declared strategy, ContentHash when none was declared.
+func (m *Manager) dedupStrategyFor(gitDest types.ResourceReference) v1alpha3.DedupStrategy {
+	m.gitTargetDedupStrategiesMu.Lock()
+	defer m.gitTargetDedupStrategiesMu.Unlock()
+	if strategy, ok := m.gitTargetDedupStrategies[gitDest.Key()]; ok {
+		return strategy
+	}
+	return v1alpha3.DedupContentHash
+}
+
+// liveDedupCache returns the live dedup cache, built on first use with DedupCacheSize.
+func (m *Manager) liveDedupCache() *liveDedupCache {
+	m.liveDedupOnce.Do(func() {
+		m.liveDedup = newLiveDedupCache(m.DedupCacheSize)
+	})
+	return m.liveDedup
+}
+
+// sameLiveResourceVersion reports whether rv is the resourceVersion last recorded for key. An
+// empty rv never matches: an object without one cannot be told apart from a real change.
+func (m *Manager) sameLiveResourceVersion(key, rv string) bool {
+	if rv == "" {
+		return false
+	}
+	prev, loaded := m.liveDedupCache().get(key)
+	return loaded && prev.resourceVersion == rv
+}
+
+// recordLiveResourceVersion stores rv as key's last seen resourceVersion, or clears it when the
+// object has none.
+func (m *Manager) recordLiveResourceVersion(key, rv string) {
+	m.liveDedupCache().update(key, func(entry *liveDedupEntry) {
+		entry.resourceVersion = rv
+	})
+}
@@ -245,8 +245,17 @@ func TestServiceCommitRequest_RegisteredWorkerResolvesNoOpenWindow(t *testing.T)
 	workerManager := git.NewWorkerManager(client, logr.Discard(), 0, types.SensitiveResourcePolicy{})
 
 	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-	go func() { _ = workerManager.Start(ctx) }()
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		_ = workerManager.Start(ctx)
+	}()
+	// Wait for the manager to stop its workers: a worker left running would read the global
+	// telemetry instruments while a later test reinitialises them.
+	defer func() {
+		cancel()
+		<-stopped
+	}()
 	time.Sleep(100 * time.Millisecond) // allow the manager to record its context
 
 	require.NoError(t, workerManager.EnsureWorker(ctx, "team-a-provider", "team-a", "main"))
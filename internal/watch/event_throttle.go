@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package watch
+
+import (
+	"context"
+	"math"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	v1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
+	"github.com/ConfigButler/gitops-reverser/internal/manifestanalyzer"
+	"github.com/ConfigButler/gitops-reverser/internal/telemetry"
+	"github.com/ConfigButler/gitops-reverser/internal/types"
+)
+
+// A GitTarget's spec.perGVRThrottle is captured on Declare like its prune mode, and for the same
+// reason: the watch tables are rule-derived and carry nothing of the GitTarget's own spec.
+//
+// The limiters outlive a Declare. The GitTarget controller re-declares on every steady requeue, and
+// rebuilding a bucket each time would hand a hot type a fresh burst every five minutes; a limiter
+// is only replaced when its configured rate actually changes.
+
+// rememberGitTargetThrottles records the per-type rates a GitTarget declared, keeping any existing
+// limiter whose rate is unchanged. A nil or empty map removes every throttle for the target.
+func (m *Manager) rememberGitTargetThrottles(
+	gitDest types.ResourceReference,
+	throttles map[string]v1alpha3.RateLimitSpec,
+) {
+	m.gitTargetThrottlesMu.Lock()
+	defer m.gitTargetThrottlesMu.Unlock()
+	if len(throttles) == 0 {
+		delete(m.gitTargetThrottles, gitDest.Key())
+		return
+	}
+	if m.gitTargetThrottles == nil {
+		m.gitTargetThrottles = map[string]map[string]*rate.Limiter{}
+	}
+	previous := m.gitTargetThrottles[gitDest.Key()]
+	next := make(map[string]*rate.Limiter, len(throttles))
+	for typeKey, spec := range throttles {
+		perSecond := spec.MaxEventsPerSecond.AsApproximateFloat64()
+		limit := rate.Limit(perSecond)
+		if existing := previous[typeKey]; existing != nil && existing.Limit() == limit {
+			next[typeKey] = existing
+			continue
+		}
+		next[typeKey] = rate.NewLimiter(limit, throttleBurst(perSecond))
+	}
+	m.gitTargetThrottles[gitDest.Key()] = next
+}
+
+// forgetGitTargetThrottles drops a deleted GitTarget's limiters.
+func (m *Manager) forgetGitTargetThrottles(gitDest types.ResourceReference) {
+	m.gitTargetThrottlesMu.Lock()
+	defer m.gitTargetThrottlesMu.Unlock()
+	delete(m.gitTargetThrottles, gitDest.Key())
+}
+
+// throttleLiveUpdate reports whether a live UPDATE for gvr must be dropped because the GitTarget's
+// token bucket for that type is empty, and counts the drop. A type without a configured throttle
+// is never dropped.
+func (m *Manager) throttleLiveUpdate(gitDest types.ResourceReference, gvr schema.GroupVersionResource) bool {
+	typeKey := manifestanalyzer.PlacementTypeKey(gvr.Group, gvr.Version, gvr.Resource)
+	m.gitTargetThrottlesMu.Lock()
+	limiter := m.gitTargetThrottles[gitDest.Key()][typeKey]
+	m.gitTargetThrottlesMu.Unlock()
+	if limiter == nil || limiter.Allow() {
+		return false
+	}
+	if telemetry.ThrottledEventsTotal != nil {
+		telemetry.ThrottledEventsTotal.Add(context.Background(), 1, metric.WithAttributes(
+			attribute.String("gvr", typeKey),
+		))
+	}
+	return true
+}
+
+// liveDedupSnapshot is an object's live dedup entry as it stood before an UPDATE recorded itself,
+// so a throttled UPDATE can put it back (restoreLiveDedup).
+type liveDedupSnapshot struct {
+	key   string
+	entry liveDedupEntry
+	found bool
+}
+
+// snapshotLiveDedup captures u's dedup entry in gitDest's stream.
+func (m *Manager) snapshotLiveDedup(
+	gitDest types.ResourceReference, gvr schema.GroupVersionResource, u *unstructured.Unstructured,
+) liveDedupSnapshot {
+	key := liveContentDedupKey(gitDest, gvr, u)
+	entry, found := m.liveDedupCache().get(key)
+	return liveDedupSnapshot{key: key, entry: entry, found: found}
+}
+
+// restoreLiveDedup puts back the entry a throttled UPDATE replaced. The dedup baseline must stay
+// what was last routed: a dropped UPDATE left as the baseline would make the next update with the
+// same content look unchanged, and the folder would never receive it.
+func (m *Manager) restoreLiveDedup(snapshot liveDedupSnapshot) {
+	cache := m.liveDedupCache()
+	if !snapshot.found {
+		cache.remove(snapshot.key)
+		return
+	}
+	cache.update(snapshot.key, func(entry *liveDedupEntry) { *entry = snapshot.entry })
+}
+
+// throttleBurst admits one second's worth of events at once, and never less than one: a bucket
+// smaller than a single token would refuse every event.
+func throttleBurst(perSecond float64) int {
+	return max(1, int(math.Ceil(perSecond)))
+}
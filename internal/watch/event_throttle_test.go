@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package watch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+
+	configv1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
+	"github.com/ConfigButler/gitops-reverser/internal/reconcile"
+	"github.com/ConfigButler/gitops-reverser/internal/types"
+)
+
+func TestThrottleLiveUpdate_OnlyConfiguredTypesAreThrottled(t *testing.T) {
+	m := &Manager{}
+	dest := types.NewResourceReference("gt", "ns")
+	pods := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	m.rememberGitTargetThrottles(dest, map[string]configv1alpha3.RateLimitSpec{
+		"v1/pods": {MaxEventsPerSecond: resource.MustParse("1m")},
+	})
+
+	assert.False(t, m.throttleLiveUpdate(dest, pods), "the first event spends the single burst token")
+	assert.True(t, m.throttleLiveUpdate(dest, pods), "the bucket is empty until it refills")
+	assert.False(t, m.throttleLiveUpdate(dest, dedupGVR()), "a type without an entry is never throttled")
+	assert.False(t, m.throttleLiveUpdate(types.NewResourceReference("other", "ns"), pods),
+		"another GitTarget has its own (absent) throttle")
+}
+
+// A steady re-declare must not hand a hot type a fresh burst; only a rate change rebuilds.
+func TestRememberGitTargetThrottles_KeepsLimiterWhileRateUnchanged(t *testing.T) {
+	m := &Manager{}
+	dest := types.NewResourceReference("gt", "ns")
+	pods := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	slow := map[string]configv1alpha3.RateLimitSpec{"v1/pods": {MaxEventsPerSecond: resource.MustParse("1m")}}
+
+	m.rememberGitTargetThrottles(dest, slow)
+	require.False(t, m.throttleLiveUpdate(dest, pods))
+	m.rememberGitTargetThrottles(dest, slow)
+	assert.True(t, m.throttleLiveUpdate(dest, pods), "re-declaring the same rate keeps the drained bucket")
+
+	m.rememberGitTargetThrottles(dest, map[string]configv1alpha3.RateLimitSpec{"v1/pods": {MaxEventsPerSecond: resource.MustParse("2m")}})
+	assert.False(t, m.throttleLiveUpdate(dest, pods), "a rate change starts a new bucket")
+
+	m.rememberGitTargetThrottles(dest, nil)
+	assert.False(t, m.throttleLiveUpdate(dest, pods), "removing the spec removes the throttle")
+}
+
+// A throttled UPDATE must not become the dedup baseline: the folder never received it, so the
+// next unthrottled UPDATE with the same content has to be routed, not skipped as unchanged.
+func TestRouteLiveTargetWatchEvent_ThrottledUpdateIsNotTheDedupBaseline(t *testing.T) {
+	gitDest := types.NewResourceReference("target", "default")
+	enqueuer := &recordingEnqueuer{}
+	stream := reconcile.NewGitTargetEventStream(gitDest.Name, gitDest.Namespace, enqueuer, logr.Discard())
+	manager := &Manager{EventRouter: &EventRouter{
+		Log:              logr.Discard(),
+		gitTargetStreams: map[string]*reconcile.GitTargetEventStream{gitDest.Key(): stream},
+	}}
+	manager.rememberGitTargetThrottles(gitDest, map[string]configv1alpha3.RateLimitSpec{
+		"v1/configmaps": {MaxEventsPerSecond: resource.MustParse("1m")},
+	})
+	key := targetWatchKey{GVR: configmapsGVR, Namespace: "apps"}
+	route := func(eventType watch.EventType, rv, value string) {
+		t.Helper()
+		obj := configMapObject(rv)
+		obj.Object["data"] = map[string]interface{}{"key": value}
+		_, err := manager.routeLiveTargetWatchEvent(context.Background(), logr.Discard(), gitDest, key, nil,
+			ObjectSelectorSet{}, watch.Event{Type: eventType, Object: obj})
+		require.NoError(t, err)
+	}
+
+	route(watch.Added, "10", "a")
+	route(watch.Modified, "11", "b") // spends the single burst token
+	route(watch.Modified, "12", "c") // throttled
+	require.Len(t, enqueuer.events, 2)
+
+	manager.rememberGitTargetThrottles(gitDest, nil)
+	route(watch.Modified, "13", "c")
+	route(watch.Modified, "14", "c")
+
+	require.Len(t, enqueuer.events, 3, "the dropped content is routed once, then dedups")
+	assert.Equal(t, "UPDATE", enqueuer.events[2].Operation)
+	assert.Equal(t, "c", enqueuer.events[2].Object.Object["data"].(map[string]interface{})["key"])
+}
+
+func TestThrottleBurst(t *testing.T) {
+	assert.Equal(t, 1, throttleBurst(0.25))
+	assert.Equal(t, 1, throttleBurst(1))
+	assert.Equal(t, 3, throttleBurst(2.5))
+}
@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package watch
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	v1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
+	"github.com/ConfigButler/gitops-reverser/internal/manifestanalyzer"
+	"github.com/ConfigButler/gitops-reverser/internal/telemetry"
+)
+
+// excludeAnnotationKey is the annotation that opts an object out of Git: ExcludeAnnotationKey when
+// set, else v1alpha3.ExcludeAnnotation.
+func (m *Manager) excludeAnnotationKey() string {
+	if m.ExcludeAnnotationKey != "" {
+		return m.ExcludeAnnotationKey
+	}
+	return v1alpha3.ExcludeAnnotation
+}
+
+// excludedByAnnotation reports whether the live object opts out of Git with annotation key set to
+// "true". It reads the live object, before sanitization, so the answer never depends on what the
+// sanitizer keeps.
+func excludedByAnnotation(u *unstructured.Unstructured, key string) bool {
+	return u.GetAnnotations()[key] == "true"
+}
+
+// countExcludedByAnnotation counts one live create or update the exclude annotation turned into a
+// removal. Snapshots are not counted: they would count the same excluded object again on every
+// replay.
+func countExcludedByAnnotation(gvr schema.GroupVersionResource) {
+	if telemetry.ExcludedByAnnotationTotal == nil {
+		return
+	}
+	telemetry.ExcludedByAnnotationTotal.Add(context.Background(), 1, metric.WithAttributes(
+		attribute.String("gvr", manifestanalyzer.PlacementTypeKey(gvr.Group, gvr.Version, gvr.Resource)),
+	))
+}
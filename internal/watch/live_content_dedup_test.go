@@ -6,11 +6,13 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	configv1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
 	"github.com/ConfigButler/gitops-reverser/internal/git"
+	"github.com/ConfigButler/gitops-reverser/internal/telemetry"
 	"github.com/ConfigButler/gitops-reverser/internal/types"
 )
 
@@ -22,8 +24,13 @@ func dedupGVR() schema.GroupVersionResource {
 // callSkip drives skipUnchangedLiveUpdate for one object identity, with a sanitized
 // content marker (empty for delete, where the writer leaves Object nil).
 func callSkip(m *Manager, gitDest types.ResourceReference, uid, content, op string) bool {
+	return callSkipAt(m, gitDest, uid, "", content, op)
+}
+
+// callSkipAt is callSkip for a live object at resourceVersion rv.
+func callSkipAt(m *Manager, gitDest types.ResourceReference, uid, rv, content, op string) bool {
 	u := &unstructured.Unstructured{Object: map[string]interface{}{
-		"metadata": map[string]interface{}{"uid": uid},
+		"metadata": map[string]interface{}{"uid": uid, "resourceVersion": rv},
 	}}
 	event := &git.Event{
 		Identifier: types.NewResourceIdentifier("apps", "v1", "deployments", "ns", "d"),
@@ -85,3 +92,102 @@ func TestSkipUnchangedLiveUpdate_PerGitTargetIsolation(t *testing.T) {
 	// destB has never seen this object: its CREATE still routes.
 	assert.False(t, callSkip(m, destB, "uid-1", "A", create), "a different GitTarget dedups independently")
 }
+
+func TestSkipUnchangedLiveUpdate_ResourceVersionStrategy(t *testing.T) {
+	m := &Manager{}
+	dest := types.NewResourceReference("gt", "ns")
+	m.rememberGitTargetDedupStrategy(dest, configv1alpha3.DedupResourceVersion)
+	update := string(configv1alpha3.OperationUpdate)
+
+	assert.False(t, callSkipAt(m, dest, "uid-1", "10", "A", string(configv1alpha3.OperationCreate)))
+	assert.True(t, callSkipAt(m, dest, "uid-1", "10", "A", update), "a redelivered resourceVersion is skipped")
+	assert.False(t, callSkipAt(m, dest, "uid-1", "11", "A", update),
+		"a new resourceVersion routes even when the content is unchanged")
+	assert.False(t, callSkipAt(m, dest, "uid-1", "", "A", update), "an object without a resourceVersion routes")
+	assert.False(t, callSkipAt(m, dest, "uid-1", "", "A", update))
+	entry, _ := m.liveDedupCache().get(liveContentDedupKey(dest, dedupGVR(), uidObject("uid-1")))
+	assert.Empty(t, entry.contentHash, "the ResourceVersion strategy never hashes")
+}
+
+func TestSkipUnchangedLiveUpdate_BothStrategy(t *testing.T) {
+	m := &Manager{}
+	dest := types.NewResourceReference("gt", "ns")
+	m.rememberGitTargetDedupStrategy(dest, configv1alpha3.DedupBoth)
+	update := string(configv1alpha3.OperationUpdate)
+
+	assert.False(t, callSkipAt(m, dest, "uid-1", "10", "A", string(configv1alpha3.OperationCreate)))
+	assert.True(t, callSkipAt(m, dest, "uid-1", "10", "B", update),
+		"an unchanged resourceVersion is skipped without comparing content")
+	assert.True(t, callSkipAt(m, dest, "uid-1", "11", "A", update), "a new resourceVersion with the same content")
+	assert.False(t, callSkipAt(m, dest, "uid-1", "12", "C", update), "a new resourceVersion with new content")
+
+	assert.False(t, callSkipAt(m, dest, "uid-1", "", "", string(configv1alpha3.OperationDelete)))
+	assert.False(t, callSkipAt(m, dest, "uid-1", "12", "C", update), "DELETE clears the resourceVersion too")
+}
+
+// Switching back to ContentHash must not dedup against a hash recorded before the
+// ResourceVersion strategy let Git move past it.
+func TestSkipUnchangedLiveUpdate_StrategySwitchNeverUsesStaleHash(t *testing.T) {
+	m := &Manager{}
+	dest := types.NewResourceReference("gt", "ns")
+	update := string(configv1alpha3.OperationUpdate)
+
+	assert.False(t, callSkipAt(m, dest, "uid-1", "10", "A", string(configv1alpha3.OperationCreate)))
+	m.rememberGitTargetDedupStrategy(dest, configv1alpha3.DedupResourceVersion)
+	assert.False(t, callSkipAt(m, dest, "uid-1", "11", "B", update))
+	m.rememberGitTargetDedupStrategy(dest, configv1alpha3.DedupContentHash)
+	assert.False(t, callSkipAt(m, dest, "uid-1", "12", "A", update), "content A is no longer what Git holds")
+}
+
+// TestSkipUnchangedLiveUpdate_BoundedCacheEvictsLeastRecentlySeen verifies the cache never holds
+// more than DedupCacheSize objects, that the object seen longest ago is the one evicted and
+// counted, and that an object re-seen in the meantime keeps deduping.
+func TestSkipUnchangedLiveUpdate_BoundedCacheEvictsLeastRecentlySeen(t *testing.T) {
+	reader, err := telemetry.InitTestExporter()
+	require.NoError(t, err)
+	m := &Manager{DedupCacheSize: 2}
+	dest := types.NewResourceReference("gt", "ns")
+	create := string(configv1alpha3.OperationCreate)
+	update := string(configv1alpha3.OperationUpdate)
+
+	assert.False(t, callSkip(m, dest, "uid-1", "A", create))
+	assert.False(t, callSkip(m, dest, "uid-2", "A", create))
+	assert.True(t, callSkip(m, dest, "uid-1", "A", update), "re-seeing uid-1 makes uid-2 the oldest")
+	assert.False(t, callSkip(m, dest, "uid-3", "A", create), "a third object evicts uid-2")
+	assert.Equal(t, 2, m.liveDedupCache().len())
+
+	evictions, ok := telemetry.CollectInt64Sum(reader, "gitopsreverser_dedup_cache_evictions_total", nil)
+	require.True(t, ok)
+	assert.Equal(t, int64(1), evictions)
+
+	assert.True(t, callSkip(m, dest, "uid-1", "A", update), "the re-seen object still dedups")
+	assert.False(t, callSkip(m, dest, "uid-2", "A", update), "the evicted object routes: no baseline")
+}
+
+func uidObject(uid string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{"metadata": map[string]interface{}{"uid": uid}}}
+}
+
+func TestSanitizedContentHash_StableAcrossMapIteration(t *testing.T) {
+	data := map[string]interface{}{}
+	for i := range 32 {
+		key := "key-" + string(rune('a'+i%26)) + string(rune('a'+i/26))
+		data[key] = map[string]interface{}{"z": key, "a": []interface{}{key}}
+	}
+	event := &git.Event{Object: &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "cfg", "namespace": "ns"},
+		"data":       data,
+	}}}
+
+	want, ok := sanitizedContentHash(event)
+	assert.True(t, ok)
+	for range 100 {
+		copied := &git.Event{Object: event.Object.DeepCopy()}
+		got, _ := sanitizedContentHash(copied)
+		if got != want {
+			t.Fatal("the dedup hash of one object changed between calls; nested keys must hash in a fixed order")
+		}
+	}
+}
@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package watch
+
+import (
+	"container/list"
+	"context"
+	"sync"
+
+	"github.com/ConfigButler/gitops-reverser/internal/telemetry"
+)
+
+// DefaultDedupCacheSize is the default Manager.DedupCacheSize: how many objects the live dedup
+// cache remembers across every GitTarget stream.
+const DefaultDedupCacheSize = 100000
+
+// liveDedupEntry is what the live event path remembers about one object in one GitTarget stream:
+// the resourceVersion and the sanitized-content hash of the last event routed for it. Either may
+// be empty, which never matches.
+type liveDedupEntry struct {
+	resourceVersion string
+	contentHash     string
+}
+
+// liveDedupCache is a bounded least-recently-seen cache of liveDedupEntry, keyed by
+// liveContentDedupKey. A cluster with more watched objects than the cache holds only loses dedup
+// for the objects it has not seen for longest: an evicted object's next UPDATE is routed rather
+// than dropped (fail open), as after a restart. Evictions are counted in
+// gitopsreverser_dedup_cache_evictions_total.
+type liveDedupCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // front is the most recently seen
+	items    map[string]*list.Element
+}
+
+type liveDedupItem struct {
+	key   string
+	entry liveDedupEntry
+}
+
+func newLiveDedupCache(capacity int) *liveDedupCache {
+	if capacity <= 0 {
+		capacity = DefaultDedupCacheSize
+	}
+	return &liveDedupCache{capacity: capacity, order: list.New(), items: map[string]*list.Element{}}
+}
+
+// get returns key's entry and marks it most recently seen.
+func (c *liveDedupCache) get(key string) (liveDedupEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.items[key]
+	if !ok {
+		return liveDedupEntry{}, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*liveDedupItem).entry, true
+}
+
+// update applies mutate to key's entry, creating it when absent, and marks it most recently seen.
+// Creating an entry in a full cache evicts the least recently seen one.
+func (c *liveDedupCache) update(key string, mutate func(*liveDedupEntry)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.items[key]; ok {
+		mutate(&elem.Value.(*liveDedupItem).entry)
+		c.order.MoveToFront(elem)
+		return
+	}
+	item := &liveDedupItem{key: key}
+	mutate(&item.entry)
+	c.items[key] = c.order.PushFront(item)
+	evicted := 0
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*liveDedupItem).key)
+		evicted++
+	}
+	if evicted > 0 && telemetry.DedupCacheEvictionsTotal != nil {
+		telemetry.DedupCacheEvictionsTotal.Add(context.Background(), int64(evicted))
+	}
+}
+
+// remove forgets key. It is not an eviction.
+func (c *liveDedupCache) remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.items[key]; ok {
+		c.order.Remove(elem)
+		delete(c.items, key)
+	}
+}
+
+// len is the number of objects remembered.
+func (c *liveDedupCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
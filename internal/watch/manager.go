@@ -15,6 +15,7 @@ import (
 	"github.com/go-logr/logr"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
+	"golang.org/x/time/rate"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -27,6 +28,7 @@ import (
 	v1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
 	"github.com/ConfigButler/gitops-reverser/internal/git"
 	"github.com/ConfigButler/gitops-reverser/internal/rulestore"
+	"github.com/ConfigButler/gitops-reverser/internal/sanitize"
 	"github.com/ConfigButler/gitops-reverser/internal/telemetry"
 	"github.com/ConfigButler/gitops-reverser/internal/types"
 )
@@ -73,6 +75,22 @@ type Manager struct {
 	// builds its observations, so each TypeRecord carries the right Sensitive fact. The
 	// zero value still treats core Secrets as sensitive.
 	SensitiveResources types.SensitiveResourcePolicy
+	// ReconcileInterval is how often Start re-runs ReconcileForRuleChange to pick up CRDs and
+	// rule changes it was not told about. Zero means DefaultReconcileInterval.
+	ReconcileInterval time.Duration
+	// HeartbeatInterval is how often Start logs its liveness heartbeat. Zero means
+	// DefaultHeartbeatInterval.
+	HeartbeatInterval time.Duration
+	// ExcludeAnnotationKey is the annotation that, set to "true" on a watched object, keeps it out
+	// of Git. Empty means v1alpha3.ExcludeAnnotation.
+	ExcludeAnnotationKey string
+	// StripAnnotations are annotation keys, or prefixes ending in "*", removed from every object
+	// written to Git on top of the operational annotations sanitize always removes. Empty adds
+	// nothing to those defaults.
+	StripAnnotations []string
+	// DedupCacheSize caps how many objects the live dedup cache remembers across every GitTarget
+	// stream; the least recently seen are evicted first. Zero means DefaultDedupCacheSize.
+	DedupCacheSize int
 
 	// dynamicClient overrides the config-built dynamic client when non-nil.
 	// Used in tests to inject a fake client without a real REST config.
@@ -94,14 +112,18 @@ type Manager struct {
 		opts metav1.ListOptions,
 	) (*unstructured.UnstructuredList, error)
 
-	// liveContentDedup caches, per (gitDest, object), the hash of the last sanitized
-	// content routed to a branch worker. A live UPDATE whose sanitized content is
-	// unchanged (the classic /status-only churn, which carries no git-writable change)
-	// is dropped before routing, so it cannot split an open commit window by arriving
-	// unattributed against a named window author. Keyed by gitDest+gvr+namespace+uid;
-	// entries are cleared on delete. Cross-session by design: a reconnect keeps deduping
-	// against what git already holds. See routeLiveTargetWatchEvent.
-	liveContentDedup sync.Map
+	// liveDedup caches, per (gitDest, object), the hash of the last sanitized content
+	// routed to a branch worker and its metadata.resourceVersion. A live UPDATE whose
+	// sanitized content is unchanged (the classic /status-only churn, which carries no
+	// git-writable change) is dropped before routing, so it cannot split an open commit
+	// window by arriving unattributed against a named window author. The resourceVersion
+	// is the fast path of the ResourceVersion and Both dedup strategies; see
+	// dedup_strategy.go. Keyed by gitDest+gvr+uid; entries are cleared on delete and
+	// evicted least-recently-seen first past DedupCacheSize. Cross-session by design: a
+	// reconnect keeps deduping against what git already holds. See
+	// routeLiveTargetWatchEvent. Built on first use (liveDedupCache).
+	liveDedup     *liveDedupCache
+	liveDedupOnce sync.Once
 
 	// SourceClusters resolves a GitTarget's source cluster — a ClusterProvider NAME — into a
 	// rest.Config, reading the kubeconfig Secret the provider names from the config plane. It is
@@ -215,6 +237,28 @@ type Manager struct {
 	gitTargetPruneModesMu sync.Mutex
 	gitTargetPruneModes   map[string]v1alpha3.PruneMode
 
+	// gitTargetThrottles holds each GitTarget's live-UPDATE token buckets, keyed by GitTarget key
+	// and then by "[group/]version/resource" type key, as declared in spec.perGVRThrottle. See
+	// event_throttle.go. Guarded by gitTargetThrottlesMu.
+	gitTargetThrottlesMu sync.Mutex
+	gitTargetThrottles   map[string]map[string]*rate.Limiter
+
+	// gitTargetDedupStrategies maps a GitTarget key to its effective spec.dedupStrategy, captured
+	// on Declare like its throttles. See dedup_strategy.go. Guarded by gitTargetDedupStrategiesMu.
+	gitTargetDedupStrategiesMu sync.Mutex
+	gitTargetDedupStrategies   map[string]v1alpha3.DedupStrategy
+
+	// gitTargetSanitizeRules holds each GitTarget's spec.sanitizePerGVR, keyed by GitTarget key
+	// and then by type key. See sanitize_rules.go. Guarded by gitTargetSanitizeRulesMu.
+	gitTargetSanitizeRulesMu sync.Mutex
+	gitTargetSanitizeRules   map[string]map[string]*sanitize.Rules
+	// gitTargetSanitizeOptions holds each GitTarget's non-default sanitize.Options (today only
+	// spec.preserveManagedFields), keyed by GitTarget key. Guarded by gitTargetSanitizeRulesMu.
+	gitTargetSanitizeOptions map[string]sanitize.Options
+	// declaredPreserveManagedFields maps a GitTarget key to the spec.preserveManagedFields of its
+	// last successful Declare, so toggling it forces a replay. Guarded by gitTargetSanitizeRulesMu.
+	declaredPreserveManagedFields map[string]bool
+
 	// targetRetention holds each GitTarget's per-scope retained-document counts, epoch-keyed so a
 	// scope that leaves the watch plan takes its count with it. Projected onto status.retention.
 	// See retention_rollup.go. Guarded by targetRetentionMu.
@@ -250,8 +294,13 @@ type GitPathAcceptanceStatus struct {
 }
 
 const (
-	heartbeatInterval         = 30 * time.Second
-	periodicReconcileInterval = 30 * time.Second
+	// DefaultReconcileInterval is the default Manager.ReconcileInterval.
+	DefaultReconcileInterval = 30 * time.Second
+	// DefaultHeartbeatInterval is the default Manager.HeartbeatInterval.
+	DefaultHeartbeatInterval = 30 * time.Second
+	// MinTickerInterval is the floor for ReconcileInterval and HeartbeatInterval: each periodic
+	// reconcile refreshes discovery, so a shorter cadence would only load the API server.
+	MinTickerInterval = 5 * time.Second
 )
 
 // Start begins the watch ingestion manager and blocks until context cancellation.
@@ -277,11 +326,11 @@ func (m *Manager) Start(ctx context.Context) error {
 	}
 
 	// Periodic reconciliation for CRD detection and missed changes
-	periodicTicker := time.NewTicker(periodicReconcileInterval)
+	periodicTicker := time.NewTicker(intervalOrDefault(m.ReconcileInterval, DefaultReconcileInterval))
 	defer periodicTicker.Stop()
 
 	// Heartbeat ticker to make liveness observable in logs and tests.
-	heartbeatTicker := time.NewTicker(heartbeatInterval)
+	heartbeatTicker := time.NewTicker(intervalOrDefault(m.HeartbeatInterval, DefaultHeartbeatInterval))
 	defer heartbeatTicker.Stop()
 
 	for {
@@ -307,6 +356,14 @@ func (m *Manager) Start(ctx context.Context) error {
 	}
 }
 
+// intervalOrDefault returns configured, or fallback when it is unset.
+func intervalOrDefault(configured, fallback time.Duration) time.Duration {
+	if configured <= 0 {
+		return fallback
+	}
+	return configured
+}
+
 func (m *Manager) initializeManagerState() {
 	if m.catalogRefreshCh == nil {
 		m.catalogRefreshCh = make(chan struct{}, 1)
@@ -6,43 +6,67 @@ import (
 	"context"
 
 	v1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
+	"github.com/ConfigButler/gitops-reverser/internal/sanitize"
 	"github.com/ConfigButler/gitops-reverser/internal/types"
 )
 
+// GitTargetDeclaration is what the GitTarget controller declares for one GitTarget's data plane.
+type GitTargetDeclaration struct {
+	// ClusterID is (api/v1alpha3).GitTarget.SourceCluster() — the referenced ClusterProvider's
+	// name, "default" for the cluster the operator runs in. It is captured on Declare, the same
+	// pattern as the UID: because spec.clusterProviderRef is immutable it is learned once and
+	// never changes, so there is no per-rule propagation and no cross-rule disagreement window.
+	ClusterID string
+	// AuditRoute is the source cluster's audit route, under which author facts are read.
+	AuditRoute string
+	// PruneMode is (api/v1alpha3).GitTarget.EffectivePruneMode(). Unlike the cluster it is
+	// mutable, and widening it to a sweeping mode forces a fresh replay — see
+	// prune_declaration.go for why the edge, and only that edge, has to be the trigger.
+	PruneMode v1alpha3.PruneMode
+	// Throttles is spec.perGVRThrottle. It is mutable too, but needs no replay: the live event
+	// path reads it on every UPDATE, so a change takes effect on the next event.
+	Throttles map[string]v1alpha3.RateLimitSpec
+	// DedupStrategy is (api/v1alpha3).GitTarget.EffectiveDedupStrategy(), read the same way.
+	DedupStrategy v1alpha3.DedupStrategy
+	// SanitizePerGVR is spec.sanitizePerGVR, applied to every object this data plane hands the
+	// writer.
+	SanitizePerGVR map[string]v1alpha3.GVRSanitizeSpec
+	// PreserveManagedFields is spec.preserveManagedFields. Toggling it forces a fresh replay:
+	// see preserveManagedFieldsRequiresReplay.
+	PreserveManagedFields bool
+	// ForceRecheck forces a fresh replay regardless, as after a refused Git path was fixed.
+	ForceRecheck bool
+}
+
 // DeclareForGitTarget ensures the GitTarget's watch-first data plane is running against the
-// source cluster it mirrors from. clusterID is (api/v1alpha3).GitTarget.SourceCluster() — the
-// referenced ClusterProvider's name, "default" for the cluster the operator runs in. It is
-// captured here, the same capture-on-Declare pattern as the UID: because spec.clusterProviderRef
-// is immutable it is learned once and never changes, so there is no per-rule propagation and no
-// cross-rule disagreement window.
-//
-// pruneMode is (api/v1alpha3).GitTarget.EffectivePruneMode(). Unlike the other two it is mutable,
-// and widening it to a sweeping mode forces a fresh replay — see prune_declaration.go for why the
-// edge, and only that edge, has to be the trigger.
+// source cluster it mirrors from, as decl describes it.
 func (m *Manager) DeclareForGitTarget(
 	ctx context.Context,
 	gitDest types.ResourceReference,
-	clusterID string,
-	auditRoute string,
-	pruneMode v1alpha3.PruneMode,
-	forceRecheck ...bool,
+	decl GitTargetDeclaration,
 ) error {
 	// Capture the UID, the source cluster, and that cluster's audit route before starting watches:
 	// the data plane keys its resume cursors by GitTarget UID, resolves rules/opens watches against
 	// the captured cluster's context, and reads author facts under the captured route — none of
 	// which the rule-derived watch tables carry.
 	m.rememberGitTargetUID(gitDest)
-	m.rememberGitTargetCluster(gitDest, clusterID)
-	m.rememberClusterAuditRoute(clusterID, auditRoute)
-	force := (len(forceRecheck) > 0 && forceRecheck[0]) || m.pruneModeRequiresReplay(gitDest, pruneMode)
+	m.rememberGitTargetCluster(gitDest, decl.ClusterID)
+	m.rememberClusterAuditRoute(decl.ClusterID, decl.AuditRoute)
+	m.rememberGitTargetThrottles(gitDest, decl.Throttles)
+	m.rememberGitTargetDedupStrategy(gitDest, decl.DedupStrategy)
+	m.rememberGitTargetSanitizeRules(gitDest, decl.SanitizePerGVR)
+	m.rememberGitTargetSanitizeOptions(gitDest, sanitize.Options{PreserveManagedFields: decl.PreserveManagedFields})
+	force := decl.ForceRecheck || m.pruneModeRequiresReplay(gitDest, decl.PruneMode) ||
+		m.preserveManagedFieldsRequiresReplay(gitDest, decl.PreserveManagedFields)
 	if err := m.EnsureGitTargetWatches(ctx, gitDest, force); err != nil {
 		m.Log.Info("watch-first declare skipped; surface not observable",
-			"gitDest", gitDest.String(), "clusterID", describeCluster(clusterID), "err", err.Error())
+			"gitDest", gitDest.String(), "clusterID", describeCluster(decl.ClusterID), "err", err.Error())
 		return err
 	}
 	// Only once the watches are actually in place: a failed declare must leave the pending force
 	// standing for the next reconcile rather than consuming it.
-	m.rememberGitTargetPruneMode(gitDest, pruneMode)
+	m.rememberGitTargetPruneMode(gitDest, decl.PruneMode)
+	m.rememberDeclaredPreserveManagedFields(gitDest, decl.PreserveManagedFields)
 	return nil
 }
 
@@ -53,6 +77,11 @@ func (m *Manager) ForgetGitTargetDeclaration(gitDest types.ResourceReference) {
 	m.forgetGitTargetUID(gitDest)
 	m.forgetGitTargetCluster(gitDest)
 	m.forgetGitTargetPruneMode(gitDest)
+	m.forgetGitTargetThrottles(gitDest)
+	m.forgetGitTargetDedupStrategy(gitDest)
+	m.forgetGitTargetSanitizeRules(gitDest)
+	m.forgetGitTargetSanitizeOptions(gitDest)
+	m.forgetDeclaredPreserveManagedFields(gitDest)
 	m.declaredGVRsMu.Lock()
 	defer m.declaredGVRsMu.Unlock()
 	delete(m.declaredGVRs, gitDest.String())
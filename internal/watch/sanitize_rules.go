@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package watch
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	v1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
+	"github.com/ConfigButler/gitops-reverser/internal/manifestanalyzer"
+	"github.com/ConfigButler/gitops-reverser/internal/sanitize"
+	"github.com/ConfigButler/gitops-reverser/internal/types"
+)
+
+// A GitTarget's spec.sanitizePerGVR is captured on Declare like its throttles, and applied to
+// every object this data plane hands the writer: live events, list snapshots and replay folds
+// alike, so a resync never writes a document the live path would have stripped. A change needs
+// no replay; each document picks it up on its object's next event or the next resync.
+
+// rememberGitTargetSanitizeRules records the per-type rules a GitTarget declared. A nil or empty
+// map removes every rule for the target.
+func (m *Manager) rememberGitTargetSanitizeRules(
+	gitDest types.ResourceReference,
+	rules map[string]v1alpha3.GVRSanitizeSpec,
+) {
+	m.gitTargetSanitizeRulesMu.Lock()
+	defer m.gitTargetSanitizeRulesMu.Unlock()
+	if len(rules) == 0 {
+		delete(m.gitTargetSanitizeRules, gitDest.Key())
+		return
+	}
+	if m.gitTargetSanitizeRules == nil {
+		m.gitTargetSanitizeRules = map[string]map[string]*sanitize.Rules{}
+	}
+	compiled := make(map[string]*sanitize.Rules, len(rules))
+	for typeKey, spec := range rules {
+		compiled[typeKey] = &sanitize.Rules{
+			KeepAnnotations:     spec.KeepAnnotations,
+			KeepLabels:          spec.KeepLabels,
+			AnnotationAllowlist: spec.AnnotationAllowlist,
+			AnnotationBlocklist: spec.AnnotationBlocklist,
+			LabelAllowlist:      spec.LabelAllowlist,
+			LabelBlocklist:      spec.LabelBlocklist,
+			StripFields:         spec.StripFields,
+			SortListFields:      spec.SortListFields,
+			RedactFields:        spec.RedactFields,
+		}
+	}
+	m.gitTargetSanitizeRules[gitDest.Key()] = compiled
+}
+
+// forgetGitTargetSanitizeRules drops a deleted GitTarget's rules.
+func (m *Manager) forgetGitTargetSanitizeRules(gitDest types.ResourceReference) {
+	m.gitTargetSanitizeRulesMu.Lock()
+	defer m.gitTargetSanitizeRulesMu.Unlock()
+	delete(m.gitTargetSanitizeRules, gitDest.Key())
+}
+
+// rememberGitTargetSanitizeOptions records what the GitTarget keeps beyond sanitize's desired
+// state. The zero Options is the default and is not stored.
+func (m *Manager) rememberGitTargetSanitizeOptions(gitDest types.ResourceReference, opts sanitize.Options) {
+	m.gitTargetSanitizeRulesMu.Lock()
+	defer m.gitTargetSanitizeRulesMu.Unlock()
+	if opts.IsZero() {
+		delete(m.gitTargetSanitizeOptions, gitDest.Key())
+		return
+	}
+	if m.gitTargetSanitizeOptions == nil {
+		m.gitTargetSanitizeOptions = map[string]sanitize.Options{}
+	}
+	m.gitTargetSanitizeOptions[gitDest.Key()] = opts
+}
+
+// forgetGitTargetSanitizeOptions drops a deleted GitTarget's options.
+func (m *Manager) forgetGitTargetSanitizeOptions(gitDest types.ResourceReference) {
+	m.gitTargetSanitizeRulesMu.Lock()
+	defer m.gitTargetSanitizeRulesMu.Unlock()
+	delete(m.gitTargetSanitizeOptions, gitDest.Key())
+}
+
+// preserveManagedFieldsRequiresReplay reports whether declaring preserve for gitDest must force a
+// fresh replay. Unlike a sanitizePerGVR rule, which touches a few fields of one type, toggling
+// spec.preserveManagedFields changes every document the target mirrors; left to each object's next
+// event, a quiet mirror would stay half with and half without managedFields indefinitely. Like the
+// prune mode it is edge-triggered against the value the running watches were declared with, and a
+// target with none remembered never forces: its first watch set replays anyway.
+func (m *Manager) preserveManagedFieldsRequiresReplay(gitDest types.ResourceReference, preserve bool) bool {
+	m.gitTargetSanitizeRulesMu.Lock()
+	defer m.gitTargetSanitizeRulesMu.Unlock()
+	previous, known := m.declaredPreserveManagedFields[gitDest.Key()]
+	return known && previous != preserve
+}
+
+// rememberDeclaredPreserveManagedFields records the spec.preserveManagedFields a Declare
+// succeeded under. Called only after the watches are in place, for the same reason as
+// rememberGitTargetPruneMode.
+func (m *Manager) rememberDeclaredPreserveManagedFields(gitDest types.ResourceReference, preserve bool) {
+	m.gitTargetSanitizeRulesMu.Lock()
+	defer m.gitTargetSanitizeRulesMu.Unlock()
+	if m.declaredPreserveManagedFields == nil {
+		m.declaredPreserveManagedFields = map[string]bool{}
+	}
+	m.declaredPreserveManagedFields[gitDest.Key()] = preserve
+}
+
+// forgetDeclaredPreserveManagedFields drops a deleted GitTarget's declared value.
+func (m *Manager) forgetDeclaredPreserveManagedFields(gitDest types.ResourceReference) {
+	m.gitTargetSanitizeRulesMu.Lock()
+	defer m.gitTargetSanitizeRulesMu.Unlock()
+	delete(m.declaredPreserveManagedFields, gitDest.Key())
+}
+
+// sanitizeOptionsFor returns the options every object bound for gitDest is sanitized with.
+func (m *Manager) sanitizeOptionsFor(gitDest types.ResourceReference) sanitize.Options {
+	m.gitTargetSanitizeRulesMu.Lock()
+	defer m.gitTargetSanitizeRulesMu.Unlock()
+	return m.gitTargetSanitizeOptions[gitDest.Key()]
+}
+
+// sanitizeOptionsForStream is sanitizeOptionsFor with the stream scope's includeStatus, which the
+// GitTarget's WatchRules decide per type rather than the GitTarget itself, and the keep lists of
+// the GitTarget's spec.sanitizePerGVR rules for the stream's type.
+func (m *Manager) sanitizeOptionsForStream(gitDest types.ResourceReference, key targetWatchKey) sanitize.Options {
+	opts := m.sanitizeOptionsFor(gitDest)
+	opts.IncludeStatus = m.residentWatchedTypeTable(gitDest).includeStatusFor(key)
+	if rules := m.sanitizeRulesFor(gitDest, key.GVR); rules != nil {
+		opts.KeepAnnotations = rules.KeepAnnotations
+		opts.KeepLabels = rules.KeepLabels
+	}
+	return opts
+}
+
+// sanitizeRulesFor returns the GitTarget's rules for gvr, or nil.
+func (m *Manager) sanitizeRulesFor(gitDest types.ResourceReference, gvr schema.GroupVersionResource) *sanitize.Rules {
+	typeKey := manifestanalyzer.PlacementTypeKey(gvr.Group, gvr.Version, gvr.Resource)
+	m.gitTargetSanitizeRulesMu.Lock()
+	defer m.gitTargetSanitizeRulesMu.Unlock()
+	return m.gitTargetSanitizeRules[gitDest.Key()][typeKey]
+}
+
+// applySanitizeRules applies the install-wide StripAnnotations and then the GitTarget's rules for
+// gvr to an already-sanitized object, in place. A type without rules is left as it is.
+func (m *Manager) applySanitizeRules(
+	gitDest types.ResourceReference,
+	gvr schema.GroupVersionResource,
+	obj *unstructured.Unstructured,
+) {
+	sanitize.StripAnnotations(obj, m.StripAnnotations)
+	m.sanitizeRulesFor(gitDest, gvr).Apply(obj)
+}
@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package watch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	configv1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
+	"github.com/ConfigButler/gitops-reverser/internal/types"
+)
+
+func TestApplySanitizeRules_PerTargetAndType(t *testing.T) {
+	m := &Manager{}
+	dest := types.NewResourceReference("gt", "ns")
+	other := types.NewResourceReference("other", "ns")
+	m.rememberGitTargetSanitizeRules(dest, map[string]configv1alpha3.GVRSanitizeSpec{
+		"apps/v1/deployments": {StripFields: []string{"spec.replicas"}},
+	})
+
+	object := func() *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": map[string]interface{}{"replicas": int64(3)},
+		}}
+	}
+	replicas := func(obj *unstructured.Unstructured) bool {
+		_, found, _ := unstructured.NestedFieldNoCopy(obj.Object, "spec", "replicas")
+		return found
+	}
+
+	stripped := object()
+	m.applySanitizeRules(dest, dedupGVR(), stripped)
+	assert.False(t, replicas(stripped))
+
+	otherTarget := object()
+	m.applySanitizeRules(other, dedupGVR(), otherTarget)
+	assert.True(t, replicas(otherTarget), "rules are per GitTarget")
+
+	m.rememberGitTargetSanitizeRules(dest, nil)
+	cleared := object()
+	m.applySanitizeRules(dest, dedupGVR(), cleared)
+	assert.True(t, replicas(cleared), "declaring no rules removes them")
+}
+
+func TestSanitizeOptionsForStream_CarriesTheTypesKeepLists(t *testing.T) {
+	m := &Manager{}
+	dest := types.NewResourceReference("gt", "ns")
+	m.rememberGitTargetSanitizeRules(dest, map[string]configv1alpha3.GVRSanitizeSpec{
+		"apps/v1/deployments": {KeepAnnotations: []string{"deployment.kubernetes.io/revision"}},
+	})
+
+	opts := m.sanitizeOptionsForStream(dest, targetWatchKey{GVR: dedupGVR()})
+	assert.Equal(t, []string{"deployment.kubernetes.io/revision"}, opts.KeepAnnotations)
+
+	other := m.sanitizeOptionsForStream(types.NewResourceReference("other", "ns"), targetWatchKey{GVR: dedupGVR()})
+	assert.Empty(t, other.KeepAnnotations, "keep lists are per GitTarget")
+}
+
+func TestApplySanitizeRules_StripAnnotationsBeforeTargetRules(t *testing.T) {
+	m := &Manager{StripAnnotations: []string{"operator.foo/*"}}
+	dest := types.NewResourceReference("gt", "ns")
+	m.rememberGitTargetSanitizeRules(dest, map[string]configv1alpha3.GVRSanitizeSpec{
+		"apps/v1/deployments": {AnnotationBlocklist: []string{"example.com/build"}},
+	})
+
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	obj.SetAnnotations(map[string]string{
+		"operator.foo/generation": "3",
+		"example.com/build":       "42",
+		"example.com/owner":       "alice",
+	})
+	m.applySanitizeRules(dest, dedupGVR(), obj)
+	assert.Equal(t, map[string]string{"example.com/owner": "alice"}, obj.GetAnnotations())
+
+	untyped := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	untyped.SetAnnotations(map[string]string{"operator.foo/generation": "3", "example.com/build": "42"})
+	m.applySanitizeRules(types.NewResourceReference("other", "ns"), dedupGVR(), untyped)
+	assert.Equal(t, map[string]string{"example.com/build": "42"}, untyped.GetAnnotations(),
+		"the install-wide list applies to a target without rules")
+}
+
+// Toggling spec.preserveManagedFields, in either direction, forces one replay against the value
+// the running watches were declared with; re-declaring it unchanged, or a first declare, does not.
+func TestPreserveManagedFieldsRequiresReplay_OnlyOnAToggle(t *testing.T) {
+	m := &Manager{}
+	dest := types.NewResourceReference("gt", "ns")
+
+	assert.False(t, m.preserveManagedFieldsRequiresReplay(dest, true), "a first declare replays anyway")
+	m.rememberDeclaredPreserveManagedFields(dest, false)
+	assert.False(t, m.preserveManagedFieldsRequiresReplay(dest, false), "an unchanged value is level, not an edge")
+	assert.True(t, m.preserveManagedFieldsRequiresReplay(dest, true))
+
+	m.rememberDeclaredPreserveManagedFields(dest, true)
+	assert.False(t, m.preserveManagedFieldsRequiresReplay(dest, true))
+	assert.True(t, m.preserveManagedFieldsRequiresReplay(dest, false), "turning it off rewrites every document too")
+
+	m.forgetDeclaredPreserveManagedFields(dest)
+	assert.False(t, m.preserveManagedFieldsRequiresReplay(dest, false))
+}
@@ -83,15 +83,18 @@ func gvkListSummary(gvks []schema.GroupVersionKind) string {
 // desiredFromObject converts a materialized object into a desired resource, pairing the
 // GVR-derived API identity with the sanitized object the writer will materialise. It is shared
 // by the splice's scope projection (splice_snapshot.go) so a reconcile's desired set is shaped
-// identically however the object was sourced.
+// identically however the object was sourced. An object carrying excludeKey set to "true" is not
+// desired at all. opts is the GitTarget's sanitize.Options.
 func desiredFromObject(
 	gvr schema.GroupVersionResource,
 	obj interface{},
+	excludeKey string,
+	opts sanitize.Options,
 ) (manifestanalyzer.DesiredResource, bool) {
 	u, ok := obj.(*unstructured.Unstructured)
-	if !ok || u == nil {
+	if !ok || u == nil || excludedByAnnotation(u, excludeKey) {
 		return manifestanalyzer.DesiredResource{}, false
 	}
 	id := types.NewResourceIdentifier(gvr.Group, gvr.Version, gvr.Resource, u.GetNamespace(), u.GetName())
-	return manifestanalyzer.DesiredResource{Resource: id, Object: sanitize.Sanitize(u)}, true
+	return manifestanalyzer.DesiredResource{Resource: id, Object: sanitize.SanitizeWithOptions(u, opts)}, true
 }
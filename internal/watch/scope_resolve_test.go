@@ -7,8 +7,12 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	configv1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
+	"github.com/ConfigButler/gitops-reverser/internal/sanitize"
 )
 
 var configMapGVR = schema.GroupVersionResource{Group: "", Version: "v1", Resource: "configmaps"}
@@ -27,12 +31,32 @@ func streamedCM(namespace, name, rv string) *unstructured.Unstructured {
 }
 
 func TestDesiredFromObject(t *testing.T) {
-	dr, ok := desiredFromObject(configMapGVR, streamedCM("default", "app", "3"))
+	dr, ok := desiredFromObject(configMapGVR, streamedCM("default", "app", "3"), configv1alpha3.ExcludeAnnotation,
+		sanitize.Options{})
 	require.True(t, ok)
 	assert.Equal(t, "configmaps", dr.Resource.Resource)
 	assert.Equal(t, "app", dr.Resource.Name)
 	assert.Equal(t, "default", dr.Resource.Namespace)
 
-	_, ok = desiredFromObject(configMapGVR, (*unstructured.Unstructured)(nil))
+	_, ok = desiredFromObject(configMapGVR, (*unstructured.Unstructured)(nil), configv1alpha3.ExcludeAnnotation,
+		sanitize.Options{})
 	assert.False(t, ok, "a nil object is not a desired entry")
+
+	excluded := streamedCM("default", "app", "4")
+	excluded.SetAnnotations(map[string]string{configv1alpha3.ExcludeAnnotation: "true"})
+	_, ok = desiredFromObject(configMapGVR, excluded, configv1alpha3.ExcludeAnnotation, sanitize.Options{})
+	assert.False(t, ok, "an object carrying the exclude annotation is not desired")
+
+	_, ok = desiredFromObject(configMapGVR, excluded, "example.com/skip", sanitize.Options{})
+	assert.True(t, ok, "only the configured exclude key opts an object out")
+
+	owned := streamedCM("default", "app", "5")
+	owned.SetManagedFields([]metav1.ManagedFieldsEntry{{Manager: "kubectl", Operation: metav1.ManagedFieldsOperationApply}})
+	dr, ok = desiredFromObject(configMapGVR, owned, configv1alpha3.ExcludeAnnotation, sanitize.Options{})
+	require.True(t, ok)
+	assert.Empty(t, dr.Object.GetManagedFields(), "managedFields are stripped by default")
+	dr, ok = desiredFromObject(configMapGVR, owned, configv1alpha3.ExcludeAnnotation,
+		sanitize.Options{PreserveManagedFields: true})
+	require.True(t, ok)
+	assert.Len(t, dr.Object.GetManagedFields(), 1, "a GitTarget preserving managedFields keeps them")
 }
@@ -195,6 +195,35 @@ func TestCompileWatchRule_TerminalRefusalRemovesAnAlreadyCompiledRule(t *testing
 		"a revoked rule must be removed from the store, not left running with a bad condition")
 }
 
+// TestCompileWatchRule_InvalidObjectSelectorRemovesAnAlreadyCompiledRule verifies that an edit
+// introducing a malformed objectSelector refuses the rule terminally and stops its data plane,
+// rather than leaving the previous spec running or compiling the item without its selector.
+func TestCompileWatchRule_InvalidObjectSelectorRemovesAnAlreadyCompiledRule(t *testing.T) {
+	ctx := context.Background()
+	m := snbManager(t,
+		snbGitTarget(&configv1alpha3.NamespaceMatcher{Names: []string{snbSourceNS}}),
+		snbGitProvider(), snbClusterProvider(true),
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: snbTenantNS}},
+	)
+
+	rule := *snbWatchRule(snbSourceNS)
+	target := *snbGitTarget(&configv1alpha3.NamespaceMatcher{Names: []string{snbSourceNS}})
+	provider := *snbGitProvider()
+
+	_, err := CompileWatchRule(ctx, m.Client, m.RuleStore, m, rule, target, provider)
+	require.NoError(t, err)
+	require.Len(t, m.RuleStore.SnapshotWatchRules(), 1, "precondition: the rule is compiled")
+
+	rule.Spec.Rules[0].ObjectSelector = &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tier", Operator: "Matches"}},
+	}
+
+	_, err = CompileWatchRule(ctx, m.Client, m.RuleStore, m, rule, target, provider)
+
+	require.ErrorIs(t, err, ErrInvalidObjectSelector)
+	assert.Empty(t, m.RuleStore.SnapshotWatchRules())
+}
+
 // TestCompileWatchRule_RetainsScopeWhenPolicyBecomesUnevaluatable is the MAINTAINING half of the
 // establishing/maintaining contract, and the one that protects a tenant's Git content.
 //
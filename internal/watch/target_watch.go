@@ -150,7 +150,8 @@ func (m *Manager) replaceGitTargetWatches(
 	log := m.Log.WithName("target-watch").WithValues("gitDest", table.GitDest.String())
 	for _, watchKey := range keys {
 		ops := table.operationsFor(watchKey)
-		go m.runTargetWatch(childCtx, log, table.GitDest, watchKey, ops)
+		selectors := table.selectorsFor(watchKey)
+		go m.runTargetWatch(childCtx, log, table.GitDest, watchKey, ops, selectors)
 	}
 	// Name every declared stream, not just the count. A GVR appearing twice — once
 	// cluster-wide ("") and once under a named namespace — means the same object is
@@ -230,14 +231,15 @@ func (m *Manager) forgetGitTargetWatches(gitDest types.ResourceReference) {
 
 func targetWatchSpecs(table WatchedTypeTable) map[targetWatchKey]string {
 	out := map[targetWatchKey]string{}
-	// One stream per scope, each carrying that scope's own operation filters. A
-	// cluster-wide scope ("") is a peer of any named namespace on the same GVR, never a
-	// replacement for it: collapsing them widened the named rule's stream and dropped its
-	// operation set (pr2-stream-scope-collapse.md).
+	// One stream per scope, each carrying that scope's own operation filters and object
+	// selectors. A cluster-wide scope ("") is a peer of any named namespace on the same GVR,
+	// never a replacement for it: collapsing them widened the named rule's stream and dropped
+	// its operation set (pr2-stream-scope-collapse.md).
 	for _, wt := range table.Types {
 		for _, ns := range wt.WatchScopes() {
 			key := targetWatchKey{GVR: wt.GVR, Namespace: ns}
-			out[key] = operationSpec(wt.NamespaceOps[ns])
+			out[key] = operationSpec(wt.NamespaceOps[ns]) + selectorSpec(wt.NamespaceSelectors[ns]) +
+				statusSpec(wt.NamespaceIncludeStatus[ns]) + aggregateSpec(wt.NamespaceAggregateByKind[ns])
 		}
 	}
 	return out
@@ -264,6 +266,36 @@ func operationSpec(ops OperationSet) string {
 	return fmt.Sprint(ops.Sorted())
 }
 
+// selectorSpec renders a scope's object filters into its stream spec, so a selector or name-glob
+// change redeclares the stream: the replay then writes objects that now match and the sweep removes
+// those that no longer do. A scope that selects every object adds nothing, keeping its spec
+// unchanged.
+func selectorSpec(selectors ObjectSelectorSet) string {
+	sorted := selectors.Sorted()
+	if len(sorted) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" filters=%q", sorted)
+}
+
+// statusSpec marks a scope that keeps status, so switching includeStatus redeclares the stream and
+// its replay rewrites every document with (or without) status at once.
+func statusSpec(includeStatus bool) string {
+	if !includeStatus {
+		return ""
+	}
+	return " status"
+}
+
+// aggregateSpec marks a scope that aggregates by kind, so switching aggregateByKind redeclares the
+// stream and its replay places the scope's new objects by the new setting.
+func aggregateSpec(aggregateByKind bool) string {
+	if !aggregateByKind {
+		return ""
+	}
+	return " aggregate"
+}
+
 func equalTargetWatchSpecs(a, b map[targetWatchKey]string) bool {
 	if len(a) != len(b) {
 		return false
@@ -291,12 +323,64 @@ func (t WatchedTypeTable) operationsFor(key targetWatchKey) OperationSet {
 	return nil
 }
 
+// selectorsFor returns the object selectors of the scope a stream key names, resolved the same way
+// as operationsFor.
+func (t WatchedTypeTable) selectorsFor(key targetWatchKey) ObjectSelectorSet {
+	for _, wt := range t.Types {
+		if wt.GVR != key.GVR {
+			continue
+		}
+		if selectors := wt.NamespaceSelectors[key.Namespace]; selectors != nil {
+			return selectors
+		}
+		if key.Namespace != "" {
+			return wt.NamespaceSelectors[""]
+		}
+	}
+	return nil
+}
+
+// includeStatusFor reports whether the scope a stream key names keeps status, resolved the same
+// way as operationsFor.
+func (t WatchedTypeTable) includeStatusFor(key targetWatchKey) bool {
+	for _, wt := range t.Types {
+		if wt.GVR != key.GVR {
+			continue
+		}
+		if _, ok := wt.NamespaceOps[key.Namespace]; ok {
+			return wt.NamespaceIncludeStatus[key.Namespace]
+		}
+		if key.Namespace != "" {
+			return wt.NamespaceIncludeStatus[""]
+		}
+	}
+	return false
+}
+
+// aggregateByKindFor reports whether the scope a stream key names aggregates its new objects into
+// a List document, resolved the same way as operationsFor.
+func (t WatchedTypeTable) aggregateByKindFor(key targetWatchKey) bool {
+	for _, wt := range t.Types {
+		if wt.GVR != key.GVR {
+			continue
+		}
+		if _, ok := wt.NamespaceOps[key.Namespace]; ok {
+			return wt.NamespaceAggregateByKind[key.Namespace]
+		}
+		if key.Namespace != "" {
+			return wt.NamespaceAggregateByKind[""]
+		}
+	}
+	return false
+}
+
 func (m *Manager) runTargetWatch(
 	ctx context.Context,
 	log logr.Logger,
 	gitDest types.ResourceReference,
 	key targetWatchKey,
 	ops OperationSet,
+	selectors ObjectSelectorSet,
 ) {
 	// A target-watch declaration defines the fidelity epoch. Its first session must replay even
 	// when a durable cursor exists: a replacement can add a sibling scope, and resuming an unchanged
@@ -304,7 +388,7 @@ func (m *Manager) runTargetWatch(
 	// resume from their cursors because they stay within the same declaration and epoch.
 	resumeFromCursor := false
 	for ctx.Err() == nil {
-		err := m.targetWatchReplayAndStream(ctx, log, gitDest, key, ops, resumeFromCursor)
+		err := m.targetWatchReplayAndStream(ctx, log, gitDest, key, ops, selectors, resumeFromCursor)
 		resumeFromCursor = true
 		if ctx.Err() != nil {
 			return
@@ -326,11 +410,12 @@ func (m *Manager) targetWatchReplayAndStream(
 	gitDest types.ResourceReference,
 	key targetWatchKey,
 	ops OperationSet,
+	selectors ObjectSelectorSet,
 	resumeFromCursor bool,
 ) error {
 	cursorExpired := false
 	if cursor, ok := m.lookupTargetWatchCursor(ctx, gitDest, key); resumeFromCursor && ok {
-		err := m.targetWatchResumeAndStream(ctx, log, gitDest, key, ops, cursor)
+		err := m.targetWatchResumeAndStream(ctx, log, gitDest, key, ops, selectors, cursor)
 		if !errors.Is(err, errTargetWatchExpired) {
 			return err
 		}
@@ -353,6 +438,7 @@ func (m *Manager) targetWatchReplayAndStream(
 		SendInitialEvents:    ptr.To(true),
 		ResourceVersionMatch: metav1.ResourceVersionMatchNotOlderThan,
 		AllowWatchBookmarks:  true,
+		LabelSelector:        selectors.ListSelector(),
 	}
 	reason := StreamReasonInitialReplay
 	if cursorExpired {
@@ -371,7 +457,7 @@ func (m *Manager) targetWatchReplayAndStream(
 		if watchListUnsupported(err) {
 			log.Error(err, "WARNING: sendInitialEvents unsupported; falling back to LIST plus buffered WATCH",
 				"gvr", key.GVR.String(), "namespace", key.Namespace, "err", err.Error())
-			return m.targetWatchListAndStream(ctx, log, gitDest, key, ops)
+			return m.targetWatchListAndStream(ctx, log, gitDest, key, ops, selectors)
 		}
 		if ctx.Err() != nil {
 			return nil
@@ -397,7 +483,7 @@ func (m *Manager) targetWatchReplayAndStream(
 				return targetWatchClosedErr(ctx)
 			}
 			nextReplaying, err := m.handleTargetWatchSessionEvent(
-				ctx, log, gitDest, key, ops, ev, replaying, &replay,
+				ctx, log, gitDest, key, ops, selectors, ev, replaying, &replay,
 			)
 			if err != nil {
 				return err
@@ -413,11 +499,13 @@ func (m *Manager) targetWatchResumeAndStream(
 	gitDest types.ResourceReference,
 	key targetWatchKey,
 	ops OperationSet,
+	selectors ObjectSelectorSet,
 	cursor string,
 ) error {
 	w, err := m.openTargetWatch(ctx, m.clusterIDForGitTarget(gitDest), key.GVR, key.Namespace, metav1.ListOptions{
 		ResourceVersion:     cursor,
 		AllowWatchBookmarks: true,
+		LabelSelector:       selectors.ListSelector(),
 	})
 	if err != nil {
 		if watchOpenExpired(err) {
@@ -447,7 +535,7 @@ func (m *Manager) targetWatchResumeAndStream(
 		"target watch resumed from durable cursor",
 	)
 	m.recordTargetReconcileCompleted(gitDest, "cursor_resume")
-	return m.streamLiveTargetWatchEvents(ctx, log, gitDest, key, ops, w.ResultChan())
+	return m.streamLiveTargetWatchEvents(ctx, log, gitDest, key, ops, selectors, w.ResultChan())
 }
 
 func (m *Manager) targetWatchListAndStream(
@@ -456,10 +544,12 @@ func (m *Manager) targetWatchListAndStream(
 	gitDest types.ResourceReference,
 	key targetWatchKey,
 	ops OperationSet,
+	selectors ObjectSelectorSet,
 ) error {
 	clusterID := m.clusterIDForGitTarget(gitDest)
 	w, err := m.openTargetWatch(ctx, clusterID, key.GVR, key.Namespace, metav1.ListOptions{
 		AllowWatchBookmarks: true,
+		LabelSelector:       selectors.ListSelector(),
 	})
 	if err != nil {
 		if ctx.Err() != nil {
@@ -479,7 +569,9 @@ func (m *Manager) targetWatchListAndStream(
 	buffered := make(chan watch.Event, targetWatchBufferCapacity)
 	go bufferTargetWatchEvents(ctx, w.ResultChan(), buffered)
 
-	list, err := m.openTargetList(ctx, clusterID, key.GVR, key.Namespace, metav1.ListOptions{})
+	list, err := m.openTargetList(ctx, clusterID, key.GVR, key.Namespace, metav1.ListOptions{
+		LabelSelector: selectors.ListSelector(),
+	})
 	if err != nil {
 		if ctx.Err() != nil {
 			return nil
@@ -493,7 +585,13 @@ func (m *Manager) targetWatchListAndStream(
 		)
 		return fmt.Errorf("list target watch snapshot %s/%q: %w", key.GVR.String(), key.Namespace, err)
 	}
-	desired := desiredFromList(key.GVR, list)
+	desired := desiredFromList(
+		key.GVR, list, selectors, m.excludeAnnotationKey(), m.sanitizeOptionsForStream(gitDest, key))
+	aggregate := m.residentWatchedTypeTable(gitDest).aggregateByKindFor(key)
+	for i := range desired {
+		m.applySanitizeRules(gitDest, key.GVR, desired[i].Object)
+		desired[i].AggregateByKind = aggregate
+	}
 	revision := list.GetResourceVersion()
 	if err := m.enqueueReplayResync(ctx, log, gitDest, key, desired, revision); err != nil {
 		return err
@@ -511,7 +609,7 @@ func (m *Manager) targetWatchListAndStream(
 		StreamReasonAllStreamsReady,
 		"target watch list fallback complete",
 	)
-	return m.streamLiveTargetWatchEvents(ctx, log, gitDest, key, ops, buffered, revision)
+	return m.streamLiveTargetWatchEvents(ctx, log, gitDest, key, ops, selectors, buffered, revision)
 }
 
 func (m *Manager) handleTargetWatchSessionEvent(
@@ -520,18 +618,19 @@ func (m *Manager) handleTargetWatchSessionEvent(
 	gitDest types.ResourceReference,
 	key targetWatchKey,
 	ops OperationSet,
+	selectors ObjectSelectorSet,
 	ev watch.Event,
 	replaying bool,
 	replay *[]manifestanalyzer.DesiredResource,
 ) (bool, error) {
 	if !replaying {
-		rv, err := m.routeLiveTargetWatchEvent(ctx, log, gitDest, key, ops, ev)
+		rv, err := m.routeLiveTargetWatchEvent(ctx, log, gitDest, key, ops, selectors, ev)
 		if err != nil {
 			return false, err
 		}
 		return false, m.recordTargetWatchCursor(ctx, gitDest, key, rv)
 	}
-	done, rv, err := m.foldTargetReplayEvent(log, gitDest, key, ev, replay)
+	done, rv, err := m.foldTargetReplayEvent(log, gitDest, key, selectors, ev, replay)
 	if err != nil || !done {
 		return true, err
 	}
@@ -556,6 +655,7 @@ func (m *Manager) foldTargetReplayEvent(
 	log logr.Logger,
 	gitDest types.ResourceReference,
 	key targetWatchKey,
+	selectors ObjectSelectorSet,
 	ev watch.Event,
 	replay *[]manifestanalyzer.DesiredResource,
 ) (bool, string, error) {
@@ -577,7 +677,15 @@ func (m *Manager) foldTargetReplayEvent(
 		if !ok {
 			return false, "", fmt.Errorf("target replay event carried %T for %s", ev.Object, key.GVR.String())
 		}
-		if desired, ok := desiredFromObject(key.GVR, u); ok {
+		// An object the scope's selectors or name globs leave out stays out of the replay, so the
+		// sweep that follows removes it from Git if an earlier, wider filter had written it.
+		if !selectors.Match(u.GetName(), u.GetLabels()) {
+			return false, "", nil
+		}
+		desired, ok := desiredFromObject(key.GVR, u, m.excludeAnnotationKey(), m.sanitizeOptionsForStream(gitDest, key))
+		if ok {
+			m.applySanitizeRules(gitDest, key.GVR, desired.Object)
+			desired.AggregateByKind = m.residentWatchedTypeTable(gitDest).aggregateByKindFor(key)
 			*replay = append(*replay, desired)
 		}
 		return false, "", nil
@@ -643,6 +751,7 @@ func (m *Manager) streamLiveTargetWatchEvents(
 	gitDest types.ResourceReference,
 	key targetWatchKey,
 	ops OperationSet,
+	selectors ObjectSelectorSet,
 	events <-chan watch.Event,
 	floors ...string,
 ) error {
@@ -661,7 +770,7 @@ func (m *Manager) streamLiveTargetWatchEvents(
 			if targetWatchEventAtOrBeforeFloor(ev, floor) {
 				continue
 			}
-			if err := m.processLiveTargetWatchEvent(ctx, log, gitDest, key, ops, ev); err != nil {
+			if err := m.processLiveTargetWatchEvent(ctx, log, gitDest, key, ops, selectors, ev); err != nil {
 				return err
 			}
 		}
@@ -674,6 +783,7 @@ func (m *Manager) processLiveTargetWatchEvent(
 	gitDest types.ResourceReference,
 	key targetWatchKey,
 	ops OperationSet,
+	selectors ObjectSelectorSet,
 	ev watch.Event,
 ) error {
 	if targetWatchExpired(ev) {
@@ -682,7 +792,7 @@ func (m *Manager) processLiveTargetWatchEvent(
 		// fresh replay (overwriting the stale cursor); no explicit delete needed.
 		return errTargetWatchExpired
 	}
-	rv, err := m.routeLiveTargetWatchEvent(ctx, log, gitDest, key, ops, ev)
+	rv, err := m.routeLiveTargetWatchEvent(ctx, log, gitDest, key, ops, selectors, ev)
 	if err != nil {
 		return err
 	}
@@ -695,6 +805,7 @@ func (m *Manager) routeLiveTargetWatchEvent(
 	gitDest types.ResourceReference,
 	key targetWatchKey,
 	ops OperationSet,
+	selectors ObjectSelectorSet,
 	ev watch.Event,
 ) (string, error) {
 	rv := targetWatchEventResourceVersion(ev)
@@ -709,13 +820,35 @@ func (m *Manager) routeLiveTargetWatchEvent(
 			return rv, nil
 		}
 		op := operationForLiveTargetWatchEvent(ev.Type, u)
+		// An object whose labels have left the scope's selectors, or whose name a glob excludes, is
+		// gone from the mirror, the same as a deletion. A DELETED event always routes whatever
+		// labels it carries: on a server-filtered stream it is how the API server reports an object
+		// leaving the selector, with the labels that no longer match, and a removal of a path that
+		// was never written is a no-op.
+		if op != string(configv1alpha3.OperationDelete) && !selectors.Match(u.GetName(), u.GetLabels()) {
+			op = string(configv1alpha3.OperationDelete)
+		}
+		// An object that opted out with the exclude annotation leaves the mirror the same way, so
+		// setting the annotation on an object already in Git removes its document.
+		if op != string(configv1alpha3.OperationDelete) && excludedByAnnotation(u, m.excludeAnnotationKey()) {
+			countExcludedByAnnotation(key.GVR)
+			log.V(1).Info("target watch routing excluded object as delete",
+				"gitDest", gitDest.String(), "gvr", key.GVR.String(),
+				"namespace", u.GetNamespace(), "name", u.GetName())
+			op = string(configv1alpha3.OperationDelete)
+		}
 		if !ops.Match(op) {
 			return rv, nil
 		}
-		event := targetWatchGitEvent(key.GVR, u, op)
+		event := targetWatchGitEvent(key.GVR, u, op, m.sanitizeOptionsForStream(gitDest, key))
+		event.ReceivedAt = time.Now()
+		event.AggregateByKind = m.residentWatchedTypeTable(gitDest).aggregateByKindFor(key)
+		// Before the dedup below, so an update that only touches a stripped field is a no-op.
+		m.applySanitizeRules(gitDest, key.GVR, event.Object)
 		// Carry the source cluster so the git writer resolves this document's GVK->GVR
 		// against the cluster it was watched on, never a union of all clusters.
 		event.SourceCluster = m.clusterIDForGitTarget(gitDest)
+		dedupBaseline := m.snapshotLiveDedup(gitDest, key.GVR, u)
 		// Drop a no-op UPDATE before it reaches the worker: a /status-only change
 		// sanitizes to identical git content but ships unattributed (its /status audit
 		// is dropped), so routing it would split an open commit window on the author
@@ -726,6 +859,15 @@ func (m *Manager) routeLiveTargetWatchEvent(
 				"resource", event.Identifier.String())
 			return rv, nil
 		}
+		// Throttle after the dedup, so a no-op never spends a token, and put the dedup entry
+		// back when the update is dropped: the baseline stays what was last routed.
+		if op == string(configv1alpha3.OperationUpdate) && m.throttleLiveUpdate(gitDest, key.GVR) {
+			m.restoreLiveDedup(dedupBaseline)
+			log.V(1).Info("target watch throttled update",
+				"gitDest", gitDest.String(), "gvr", key.GVR.String(),
+				"resource", event.Identifier.String())
+			return rv, nil
+		}
 		m.attachAuthor(ctx, &event, key.GVR, u)
 		if err := m.EventRouter.RouteToGitTargetEventStream(event, gitDest); err != nil {
 			log.V(1).Info("target watch route failed",
@@ -785,14 +927,16 @@ func (m *Manager) attachAuthor(
 }
 
 // skipUnchangedLiveUpdate reports whether a live event carries no git-writable change
-// from the last event routed for the same object, and maintains the dedup cache:
-//   - DELETE clears the entry and never skips (a removal always routes).
-//   - CREATE/UPDATE store the sanitized-content hash; an UPDATE whose hash equals the
-//     stored one is a no-op (e.g. a /status-only change) and is skipped.
+// from the last event routed for the same object, and maintains the dedup caches:
+//   - DELETE clears the entries and never skips (a removal always routes).
+//   - CREATE/UPDATE store the resourceVersion and, unless the GitTarget's strategy is
+//     ResourceVersion, the sanitized-content hash. An UPDATE is a no-op and is skipped when
+//     its resourceVersion equals the stored one (ResourceVersion and Both), or when its hash
+//     equals the stored one (ContentHash and Both; e.g. a /status-only change).
 //
-// Only UPDATE is ever skipped — a CREATE always routes and seeds the cache, so a later
+// Only UPDATE is ever skipped — a CREATE always routes and seeds the caches, so a later
 // /status-only UPDATE dedups against it. When the content cannot be hashed the event is
-// routed and the cache is left untouched (fail open, never drop a real change).
+// routed and the hash cache is left untouched (fail open, never drop a real change).
 func (m *Manager) skipUnchangedLiveUpdate(
 	gitDest types.ResourceReference,
 	gvr schema.GroupVersionResource,
@@ -801,22 +945,33 @@ func (m *Manager) skipUnchangedLiveUpdate(
 	op string,
 ) bool {
 	key := liveContentDedupKey(gitDest, gvr, u)
+	cache := m.liveDedupCache()
 	if op == string(configv1alpha3.OperationDelete) {
-		m.liveContentDedup.Delete(key)
+		cache.remove(key)
+		return false
+	}
+	isUpdate := op == string(configv1alpha3.OperationUpdate)
+	strategy := m.dedupStrategyFor(gitDest)
+	// The sanitized event has lost its resourceVersion; the live object still carries it.
+	rv := u.GetResourceVersion()
+	if strategy != configv1alpha3.DedupContentHash && isUpdate && m.sameLiveResourceVersion(key, rv) {
+		return true
+	}
+	m.recordLiveResourceVersion(key, rv)
+	if strategy == configv1alpha3.DedupResourceVersion {
+		cache.update(key, func(entry *liveDedupEntry) { entry.contentHash = "" })
 		return false
 	}
 	hash, ok := sanitizedContentHash(event)
 	if !ok {
 		return false
 	}
-	if op == string(configv1alpha3.OperationUpdate) {
-		if prev, loaded := m.liveContentDedup.Load(key); loaded {
-			if prevHash, isStr := prev.(string); isStr && prevHash == hash {
-				return true
-			}
+	if isUpdate {
+		if prev, loaded := cache.get(key); loaded && prev.contentHash == hash {
+			return true
 		}
 	}
-	m.liveContentDedup.Store(key, hash)
+	cache.update(key, func(entry *liveDedupEntry) { entry.contentHash = hash })
 	return false
 }
 
@@ -845,13 +1000,18 @@ func sanitizedContentHash(event *git.Event) (string, bool) {
 	return string(sum[:]), true
 }
 
-func targetWatchGitEvent(gvr schema.GroupVersionResource, u *unstructured.Unstructured, op string) git.Event {
+func targetWatchGitEvent(
+	gvr schema.GroupVersionResource,
+	u *unstructured.Unstructured,
+	op string,
+	opts sanitize.Options,
+) git.Event {
 	event := git.Event{
 		Identifier: types.NewResourceIdentifier(gvr.Group, gvr.Version, gvr.Resource, u.GetNamespace(), u.GetName()),
 		Operation:  op,
 	}
 	if op != string(configv1alpha3.OperationDelete) {
-		event.Object = sanitize.Sanitize(u)
+		event.Object = sanitize.SanitizeWithOptions(u, opts)
 	}
 	return event
 }
@@ -1039,13 +1199,19 @@ func bufferTargetWatchEvents(ctx context.Context, in <-chan watch.Event, out cha
 func desiredFromList(
 	gvr schema.GroupVersionResource,
 	list *unstructured.UnstructuredList,
+	selectors ObjectSelectorSet,
+	excludeKey string,
+	opts sanitize.Options,
 ) []manifestanalyzer.DesiredResource {
 	if list == nil {
 		return nil
 	}
 	desired := make([]manifestanalyzer.DesiredResource, 0, len(list.Items))
 	for i := range list.Items {
-		if item, ok := desiredFromObject(gvr, &list.Items[i]); ok {
+		if !selectors.Match(list.Items[i].GetName(), list.Items[i].GetLabels()) {
+			continue
+		}
+		if item, ok := desiredFromObject(gvr, &list.Items[i], excludeKey, opts); ok {
 			desired = append(desired, item)
 		}
 	}
@@ -0,0 +1,210 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package watch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/ConfigButler/gitops-reverser/internal/manifestanalyzer"
+	"github.com/ConfigButler/gitops-reverser/internal/reconcile"
+	"github.com/ConfigButler/gitops-reverser/internal/rulestore"
+	"github.com/ConfigButler/gitops-reverser/internal/types"
+)
+
+func exportedSelectors() ObjectSelectorSet {
+	selectors := ObjectSelectorSet{}
+	selectors.add(labels.SelectorFromSet(labels.Set{"gitops.io/export": "true"}), nil)
+	return selectors
+}
+
+func namedSelectors(t *testing.T, includes, excludes []string) ObjectSelectorSet {
+	t.Helper()
+	names, err := rulestore.CompileNameFilter(includes, excludes)
+	require.NoError(t, err)
+	selectors := ObjectSelectorSet{}
+	selectors.add(nil, names)
+	return selectors
+}
+
+func labeledConfigMapObject(rv string, objLabels map[string]string) *unstructured.Unstructured {
+	obj := configMapObject(rv)
+	obj.SetLabels(objLabels)
+	return obj
+}
+
+// A selector change must change the stream spec, or the stream keeps running under the old
+// selector and neither writes newly matching objects nor sweeps the ones that left.
+func TestTargetWatchSpecs_ObjectSelectorIsPartOfTheSpec(t *testing.T) {
+	table := WatchedTypeTable{
+		GitDest: types.NewResourceReference("target", "default"),
+		Types: []WatchedType{{
+			GVR:                configmapsGVR,
+			NamespaceOps:       map[string]OperationSet{"apps": {"*": struct{}{}}},
+			NamespaceSelectors: map[string]ObjectSelectorSet{"apps": exportedSelectors()},
+		}},
+	}
+	key := targetWatchKey{GVR: configmapsGVR, Namespace: "apps"}
+
+	selected := targetWatchSpecs(table)[key]
+	table.Types[0].NamespaceSelectors = map[string]ObjectSelectorSet{"apps": {"": ObjectFilter{}}}
+	unselected := targetWatchSpecs(table)[key]
+
+	assert.Equal(t, "[*]", unselected, "a scope without a selector keeps its plain operation spec")
+	assert.NotEqual(t, unselected, selected)
+}
+
+func TestRouteLiveTargetWatchEvent_ObjectLeavingSelectorRendersAsDelete(t *testing.T) {
+	gitDest := types.NewResourceReference("target", "default")
+	enqueuer := &recordingEnqueuer{}
+	stream := reconcile.NewGitTargetEventStream(gitDest.Name, gitDest.Namespace, enqueuer, logr.Discard())
+	router := &EventRouter{
+		Log:              logr.Discard(),
+		gitTargetStreams: map[string]*reconcile.GitTargetEventStream{gitDest.Key(): stream},
+	}
+	manager := &Manager{EventRouter: router}
+	key := targetWatchKey{GVR: configmapsGVR, Namespace: "apps"}
+	selectors := exportedSelectors()
+
+	_, err := manager.routeLiveTargetWatchEvent(context.Background(), logr.Discard(), gitDest, key, nil, selectors,
+		watch.Event{Type: watch.Added, Object: labeledConfigMapObject("10", map[string]string{"gitops.io/export": "true"})})
+	require.NoError(t, err)
+	_, err = manager.routeLiveTargetWatchEvent(context.Background(), logr.Discard(), gitDest, key, nil, selectors,
+		watch.Event{Type: watch.Modified, Object: labeledConfigMapObject("11", nil)})
+	require.NoError(t, err)
+	_, err = manager.routeLiveTargetWatchEvent(context.Background(), logr.Discard(), gitDest, key, nil, selectors,
+		watch.Event{Type: watch.Deleted, Object: labeledConfigMapObject("12", nil)})
+	require.NoError(t, err)
+
+	require.Len(t, enqueuer.events, 3)
+	assert.Equal(t, "CREATE", enqueuer.events[0].Operation, "a matching object is written")
+	assert.Equal(t, "DELETE", enqueuer.events[1].Operation, "an object whose labels stop matching is removed")
+	assert.Nil(t, enqueuer.events[1].Object)
+	assert.Equal(t, "DELETE", enqueuer.events[2].Operation,
+		"a DELETED event routes whatever labels it carries; removing an unwritten path is a no-op")
+}
+
+// An object whose name an exclude glob matches is removed like one whose labels stop matching, so
+// adding an exclude deletes a file that was already written.
+func TestRouteLiveTargetWatchEvent_NameExcludedObjectRendersAsDelete(t *testing.T) {
+	gitDest := types.NewResourceReference("target", "default")
+	enqueuer := &recordingEnqueuer{}
+	stream := reconcile.NewGitTargetEventStream(gitDest.Name, gitDest.Namespace, enqueuer, logr.Discard())
+	router := &EventRouter{
+		Log:              logr.Discard(),
+		gitTargetStreams: map[string]*reconcile.GitTargetEventStream{gitDest.Key(): stream},
+	}
+	manager := &Manager{EventRouter: router}
+	key := targetWatchKey{GVR: configmapsGVR, Namespace: "apps"}
+
+	_, err := manager.routeLiveTargetWatchEvent(context.Background(), logr.Discard(), gitDest, key, nil,
+		namedSelectors(t, []string{"de*"}, nil),
+		watch.Event{Type: watch.Added, Object: configMapObject("10")})
+	require.NoError(t, err)
+	_, err = manager.routeLiveTargetWatchEvent(context.Background(), logr.Discard(), gitDest, key, nil,
+		namedSelectors(t, []string{"de*"}, []string{"demo"}),
+		watch.Event{Type: watch.Modified, Object: configMapObject("11")})
+	require.NoError(t, err)
+
+	require.Len(t, enqueuer.events, 2)
+	assert.Equal(t, "CREATE", enqueuer.events[0].Operation, "an included name is written")
+	assert.Equal(t, "DELETE", enqueuer.events[1].Operation, "an exclude wins over an include and removes the file")
+	assert.Nil(t, enqueuer.events[1].Object)
+}
+
+func TestRouteLiveTargetWatchEvent_ObjectOutsideSelectorIsNotWritten(t *testing.T) {
+	gitDest := types.NewResourceReference("target", "default")
+	enqueuer := &recordingEnqueuer{}
+	stream := reconcile.NewGitTargetEventStream(gitDest.Name, gitDest.Namespace, enqueuer, logr.Discard())
+	router := &EventRouter{
+		Log:              logr.Discard(),
+		gitTargetStreams: map[string]*reconcile.GitTargetEventStream{gitDest.Key(): stream},
+	}
+	manager := &Manager{EventRouter: router}
+	key := targetWatchKey{GVR: configmapsGVR, Namespace: "apps"}
+
+	_, err := manager.routeLiveTargetWatchEvent(context.Background(), logr.Discard(), gitDest, key,
+		OperationSet{"CREATE": struct{}{}, "UPDATE": struct{}{}}, exportedSelectors(),
+		watch.Event{Type: watch.Added, Object: labeledConfigMapObject("10", map[string]string{"tier": "dev"})})
+
+	require.NoError(t, err)
+	assert.Empty(t, enqueuer.events, "a non-matching object is a removal, which this operation set does not follow")
+}
+
+func TestFoldTargetReplayEvent_SkipsObjectsOutsideSelector(t *testing.T) {
+	manager := &Manager{}
+	gitDest := types.NewResourceReference("target", "default")
+	key := targetWatchKey{GVR: configmapsGVR, Namespace: "apps"}
+	var desired []manifestanalyzer.DesiredResource
+
+	_, _, err := manager.foldTargetReplayEvent(logr.Discard(), gitDest, key, exportedSelectors(),
+		watch.Event{Type: watch.Added, Object: labeledConfigMapObject("10", nil)}, &desired)
+	require.NoError(t, err)
+	assert.Empty(t, desired, "a non-matching object stays out of the replay, so the sweep removes it")
+
+	_, _, err = manager.foldTargetReplayEvent(logr.Discard(), gitDest, key, exportedSelectors(),
+		watch.Event{Type: watch.Added, Object: labeledConfigMapObject("11", map[string]string{"gitops.io/export": "true"})},
+		&desired)
+	require.NoError(t, err)
+	assert.Len(t, desired, 1)
+}
+
+func TestTargetWatchReplayAndStream_PushesSingleSelectorToAPIServer(t *testing.T) {
+	tests := []struct {
+		name      string
+		selectors ObjectSelectorSet
+		want      string
+	}{
+		{name: "single selector", selectors: exportedSelectors(), want: "gitops.io/export=true"},
+		{
+			name: "differing selectors",
+			selectors: ObjectSelectorSet{
+				"gitops.io/export=true": {Labels: labels.SelectorFromSet(labels.Set{"gitops.io/export": "true"})},
+				"tier=prod":             {Labels: labels.SelectorFromSet(labels.Set{"tier": "prod"})},
+			},
+			want: "",
+		},
+		{name: "name globs only", selectors: namedSelectors(t, []string{"demo"}, nil), want: ""},
+		{name: "no selector", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fw := watch.NewFake()
+			opened := make(chan metav1.ListOptions, 1)
+			manager := &Manager{
+				Log: logr.Discard(),
+				targetWatchOpen: func(
+					_ context.Context,
+					_ schema.GroupVersionResource,
+					_ string,
+					opts metav1.ListOptions,
+				) (watch.Interface, error) {
+					opened <- opts
+					return fw, nil
+				},
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan error, 1)
+			go func() {
+				done <- manager.targetWatchReplayAndStream(ctx, logr.Discard(),
+					types.NewResourceReference("target", "default"),
+					targetWatchKey{GVR: configmapsGVR, Namespace: "apps"}, nil, tt.selectors, false)
+			}()
+
+			opts := <-opened
+			cancel()
+			require.NoError(t, <-done)
+			assert.Equal(t, tt.want, opts.LabelSelector)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package watch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/ConfigButler/gitops-reverser/internal/git"
+	"github.com/ConfigButler/gitops-reverser/internal/reconcile"
+	"github.com/ConfigButler/gitops-reverser/internal/types"
+)
+
+func configMapWithStatus(rv, phase string) *unstructured.Unstructured {
+	obj := configMapObject(rv)
+	obj.Object["status"] = map[string]interface{}{"phase": phase}
+	return obj
+}
+
+// routeStatusOnlyUpdate routes a create and then an update that changes only status, and returns
+// what the GitTarget's stream received.
+func routeStatusOnlyUpdate(t *testing.T, includeStatus bool) []git.Event {
+	t.Helper()
+	gitDest := types.NewResourceReference("target", "default")
+	enqueuer := &recordingEnqueuer{}
+	stream := reconcile.NewGitTargetEventStream(gitDest.Name, gitDest.Namespace, enqueuer, logr.Discard())
+	manager := &Manager{EventRouter: &EventRouter{
+		Log:              logr.Discard(),
+		gitTargetStreams: map[string]*reconcile.GitTargetEventStream{gitDest.Key(): stream},
+	}}
+	key := targetWatchKey{GVR: configmapsGVR, Namespace: "apps"}
+	manager.ensureWatchedTypeStore()
+	manager.watchedTypes.tables = map[string]WatchedTypeTable{gitDest.Key(): {
+		GitDest: gitDest,
+		Types: []WatchedType{{
+			GVR:                    configmapsGVR,
+			NamespaceOps:           map[string]OperationSet{"apps": {"*": struct{}{}}},
+			NamespaceIncludeStatus: map[string]bool{"apps": includeStatus},
+		}},
+	}}
+
+	for _, ev := range []watch.Event{
+		{Type: watch.Added, Object: configMapWithStatus("10", "Pending")},
+		{Type: watch.Modified, Object: configMapWithStatus("11", "Ready")},
+	} {
+		_, err := manager.routeLiveTargetWatchEvent(context.Background(), logr.Discard(), gitDest, key, nil, nil, ev)
+		require.NoError(t, err)
+	}
+	return enqueuer.events
+}
+
+func TestRouteLiveTargetWatchEvent_StatusOnlyUpdateIsDedupedByDefault(t *testing.T) {
+	events := routeStatusOnlyUpdate(t, false)
+
+	require.Len(t, events, 1, "a status-only change sanitizes to the same content and is dropped")
+	_, found := events[0].Object.Object["status"]
+	assert.False(t, found, "status is stripped")
+}
+
+func TestRouteLiveTargetWatchEvent_IncludeStatusRoutesStatusOnlyUpdate(t *testing.T) {
+	events := routeStatusOnlyUpdate(t, true)
+
+	require.Len(t, events, 2, "with includeStatus the status is hashed, so its change routes")
+	assert.Equal(t, "UPDATE", events[1].Operation)
+	assert.Equal(t, map[string]interface{}{"phase": "Ready"}, events[1].Object.Object["status"])
+}
+
+// Switching includeStatus must change the stream spec, so the replay rewrites every document with
+// or without status instead of waiting for each object's next event.
+func TestTargetWatchSpecs_IncludeStatusIsPartOfTheSpec(t *testing.T) {
+	table := WatchedTypeTable{Types: []WatchedType{{
+		GVR:          configmapsGVR,
+		NamespaceOps: map[string]OperationSet{"apps": {"*": struct{}{}}},
+	}}}
+	key := targetWatchKey{GVR: configmapsGVR, Namespace: "apps"}
+
+	stripped := targetWatchSpecs(table)[key]
+	table.Types[0].NamespaceIncludeStatus = map[string]bool{"apps": true}
+
+	assert.Equal(t, "[*]", stripped)
+	assert.NotEqual(t, stripped, targetWatchSpecs(table)[key])
+	assert.True(t, table.includeStatusFor(key))
+}
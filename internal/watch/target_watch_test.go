@@ -18,6 +18,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/watch"
 
+	configv1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
 	"github.com/ConfigButler/gitops-reverser/internal/git"
 	"github.com/ConfigButler/gitops-reverser/internal/manifestanalyzer"
 	"github.com/ConfigButler/gitops-reverser/internal/queue"
@@ -157,6 +158,7 @@ func TestRouteLiveTargetWatchEvent_ForwardsObjectEventsAsCommitter(t *testing.T)
 		gitDest,
 		targetWatchKey{GVR: configmapsGVR, Namespace: "apps"},
 		OperationSet{"CREATE": struct{}{}},
+		nil,
 		watch.Event{Type: watch.Added, Object: obj},
 	)
 
@@ -169,6 +171,7 @@ func TestRouteLiveTargetWatchEvent_ForwardsObjectEventsAsCommitter(t *testing.T)
 	assert.Empty(t, event.UserInfo.Username, "configured-author watch events leave the actor empty")
 	assert.NotNil(t, event.Object)
 	assert.Empty(t, event.Object.GetResourceVersion(), "live events are sanitized before entering Git")
+	assert.False(t, event.ReceivedAt.IsZero(), "live events carry their receive time for event_to_commit_seconds")
 }
 
 func TestRouteLiveTargetWatchEvent_RespectsOperationFilters(t *testing.T) {
@@ -187,6 +190,7 @@ func TestRouteLiveTargetWatchEvent_RespectsOperationFilters(t *testing.T) {
 		gitDest,
 		targetWatchKey{GVR: configmapsGVR, Namespace: "apps"},
 		OperationSet{"DELETE": struct{}{}},
+		nil,
 		watch.Event{Type: watch.Modified, Object: configMapObject("13")},
 	)
 
@@ -194,6 +198,78 @@ func TestRouteLiveTargetWatchEvent_RespectsOperationFilters(t *testing.T) {
 	assert.Empty(t, enqueuer.events)
 }
 
+func TestRouteLiveTargetWatchEvent_RoutesExcludedObjectAsDelete(t *testing.T) {
+	gitDest := types.NewResourceReference("target", "default")
+	enqueuer := &recordingEnqueuer{}
+	stream := reconcile.NewGitTargetEventStream(gitDest.Name, gitDest.Namespace, enqueuer, logr.Discard())
+	router := &EventRouter{
+		Log:              logr.Discard(),
+		gitTargetStreams: map[string]*reconcile.GitTargetEventStream{gitDest.Key(): stream},
+	}
+	manager := &Manager{EventRouter: router}
+	key := targetWatchKey{GVR: configmapsGVR, Namespace: "apps"}
+	ops := OperationSet{"UPDATE": struct{}{}, "DELETE": struct{}{}}
+
+	excluded := configMapObject("30")
+	excluded.SetAnnotations(map[string]string{configv1alpha3.ExcludeAnnotation: "true"})
+	_, err := manager.routeLiveTargetWatchEvent(context.Background(), logr.Discard(), gitDest, key, ops, nil,
+		watch.Event{Type: watch.Modified, Object: excluded})
+	require.NoError(t, err)
+	require.Len(t, enqueuer.events, 1, "an excluded object's update is routed as a removal")
+	assert.Equal(t, "DELETE", enqueuer.events[0].Operation)
+	assert.Nil(t, enqueuer.events[0].Object, "the excluded object's content never reaches Git")
+
+	deleted := configMapObject("31")
+	deleted.SetAnnotations(map[string]string{configv1alpha3.ExcludeAnnotation: "true"})
+	_, err = manager.routeLiveTargetWatchEvent(context.Background(), logr.Discard(), gitDest, key, ops, nil,
+		watch.Event{Type: watch.Deleted, Object: deleted})
+	require.NoError(t, err)
+	require.Len(t, enqueuer.events, 2, "the delete still routes")
+	assert.Equal(t, "DELETE", enqueuer.events[1].Operation)
+}
+
+func TestRouteLiveTargetWatchEvent_TogglingExcludeAnnotationRemovesAndRestoresDocument(t *testing.T) {
+	const skipKey = "example.com/skip"
+	gitDest := types.NewResourceReference("target", "default")
+	enqueuer := &recordingEnqueuer{}
+	stream := reconcile.NewGitTargetEventStream(gitDest.Name, gitDest.Namespace, enqueuer, logr.Discard())
+	router := &EventRouter{
+		Log:              logr.Discard(),
+		gitTargetStreams: map[string]*reconcile.GitTargetEventStream{gitDest.Key(): stream},
+	}
+	manager := &Manager{EventRouter: router, ExcludeAnnotationKey: skipKey}
+	key := targetWatchKey{GVR: configmapsGVR, Namespace: "apps"}
+	route := func(evType watch.EventType, obj *unstructured.Unstructured) {
+		t.Helper()
+		_, err := manager.routeLiveTargetWatchEvent(context.Background(), logr.Discard(), gitDest, key,
+			nil, nil, watch.Event{Type: evType, Object: obj})
+		require.NoError(t, err)
+	}
+
+	route(watch.Added, configMapObject("40"))
+	require.Len(t, enqueuer.events, 1)
+	assert.Equal(t, "CREATE", enqueuer.events[0].Operation)
+
+	// The default key no longer opts out once another key is configured.
+	defaultKeyed := configMapObject("41")
+	defaultKeyed.SetAnnotations(map[string]string{configv1alpha3.ExcludeAnnotation: "true"})
+	route(watch.Modified, defaultKeyed)
+	require.Len(t, enqueuer.events, 2)
+	assert.Equal(t, "UPDATE", enqueuer.events[1].Operation)
+
+	skipped := configMapObject("42")
+	skipped.SetAnnotations(map[string]string{skipKey: "true"})
+	route(watch.Modified, skipped)
+	require.Len(t, enqueuer.events, 3, "setting the annotation on a written object removes its document")
+	assert.Equal(t, "DELETE", enqueuer.events[2].Operation)
+	assert.Equal(t, enqueuer.events[0].Identifier, enqueuer.events[2].Identifier)
+
+	route(watch.Modified, configMapObject("43"))
+	require.Len(t, enqueuer.events, 4, "removing the annotation writes the object again")
+	assert.Equal(t, "UPDATE", enqueuer.events[3].Operation)
+	assert.NotNil(t, enqueuer.events[3].Object)
+}
+
 func TestRouteLiveTargetWatchEvent_AttributesAuthorFromResolver(t *testing.T) {
 	gitDest := types.NewResourceReference("target", "default")
 	enqueuer := &recordingEnqueuer{}
@@ -222,6 +298,7 @@ func TestRouteLiveTargetWatchEvent_AttributesAuthorFromResolver(t *testing.T) {
 		gitDest,
 		targetWatchKey{GVR: configmapsGVR, Namespace: "apps"},
 		OperationSet{"CREATE": struct{}{}},
+		nil,
 		watch.Event{Type: watch.Added, Object: configMapObject("12")},
 	)
 
@@ -251,6 +328,7 @@ func TestRouteLiveTargetWatchEvent_DeletionTimestampRendersAsDelete(t *testing.T
 		gitDest,
 		targetWatchKey{GVR: configmapsGVR, Namespace: "apps"},
 		OperationSet{"DELETE": struct{}{}},
+		nil,
 		watch.Event{Type: watch.Modified, Object: terminatingConfigMapObject("20")},
 	)
 
@@ -280,6 +358,7 @@ func TestRouteLiveTargetWatchEvent_ModifiedWithoutDeletionTimestampRendersAsUpda
 		gitDest,
 		targetWatchKey{GVR: configmapsGVR, Namespace: "apps"},
 		OperationSet{"UPDATE": struct{}{}},
+		nil,
 		watch.Event{Type: watch.Modified, Object: configMapObject("21")},
 	)
 
@@ -305,10 +384,10 @@ func TestRouteLiveTargetWatchEvent_TerminatingThenDeletedAreIdenticalRemovals(t
 	key := targetWatchKey{GVR: configmapsGVR, Namespace: "apps"}
 	ops := OperationSet{"DELETE": struct{}{}}
 
-	_, err := manager.routeLiveTargetWatchEvent(context.Background(), logr.Discard(), gitDest, key, ops,
+	_, err := manager.routeLiveTargetWatchEvent(context.Background(), logr.Discard(), gitDest, key, ops, nil,
 		watch.Event{Type: watch.Modified, Object: terminatingConfigMapObject("20")})
 	require.NoError(t, err)
-	_, err = manager.routeLiveTargetWatchEvent(context.Background(), logr.Discard(), gitDest, key, ops,
+	_, err = manager.routeLiveTargetWatchEvent(context.Background(), logr.Discard(), gitDest, key, ops, nil,
 		watch.Event{Type: watch.Deleted, Object: configMapObject("22")})
 	require.NoError(t, err)
 
@@ -331,6 +410,7 @@ func TestHandleTargetWatchSessionEvent_CompletesReplayWithoutRouter(t *testing.T
 		gitDest,
 		key,
 		nil,
+		nil,
 		watch.Event{Type: watch.Added, Object: configMapObject("10")},
 		true,
 		&replay,
@@ -348,6 +428,7 @@ func TestHandleTargetWatchSessionEvent_CompletesReplayWithoutRouter(t *testing.T
 		gitDest,
 		key,
 		nil,
+		nil,
 		watch.Event{Type: watch.Bookmark, Object: bookmark},
 		true,
 		&replay,
@@ -379,6 +460,7 @@ func TestTargetWatchReplayAndStream_ReturnsWhenContextCancels(t *testing.T) {
 			types.NewResourceReference("target", "default"),
 			targetWatchKey{GVR: configmapsGVR, Namespace: "apps"},
 			nil,
+			nil,
 			false,
 		)
 	}()
@@ -433,6 +515,7 @@ func TestTargetWatchReplayAndStream_FallsBackWhenReplayWatchIsForbidden(t *testi
 			gitDest,
 			targetWatchKey{GVR: configmapsGVR, Namespace: "apps"},
 			nil,
+			nil,
 			true,
 		)
 	}()
@@ -494,6 +577,7 @@ func TestTargetWatchReplayAndStream_ResumesFromStoredCursor(t *testing.T) {
 			gitDest,
 			targetWatchKey{GVR: configmapsGVR, Namespace: "apps"},
 			nil,
+			nil,
 			true,
 		)
 	}()
@@ -583,6 +667,7 @@ func TestFoldTargetReplayEvent_AccumulatesUntilInitialEventsBookmark(t *testing.
 		logr.Discard(),
 		gitDest,
 		key,
+		nil,
 		watch.Event{Type: watch.Added, Object: configMapObject("10")},
 		&desired,
 	)
@@ -598,6 +683,7 @@ func TestFoldTargetReplayEvent_AccumulatesUntilInitialEventsBookmark(t *testing.
 		logr.Discard(),
 		gitDest,
 		key,
+		nil,
 		watch.Event{Type: watch.Bookmark, Object: bookmark},
 		&desired,
 	)
@@ -667,7 +753,7 @@ func TestTargetWatchReplayAndStream_ExpiredCursorFallsBackToFreshReplay(t *testi
 	go func() {
 		done <- manager.targetWatchReplayAndStream(
 			ctx, logr.Discard(), gitDest,
-			targetWatchKey{GVR: configmapsGVR, Namespace: "apps"}, nil, true,
+			targetWatchKey{GVR: configmapsGVR, Namespace: "apps"}, nil, nil, true,
 		)
 	}()
 
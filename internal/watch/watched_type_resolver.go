@@ -12,6 +12,7 @@ import (
 	"github.com/cespare/xxhash/v2"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	configv1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
@@ -312,6 +313,8 @@ func (m *Manager) collectWatchRuleSelections(
 				for _, namespace := range rr.SourceNamespaces {
 					ts.selections = append(ts.selections, watchSelection{
 						record: rec, namespace: namespace, ops: rr.Operations,
+						selector: rr.ObjectSelector, names: rr.NameFilter, includeStatus: rr.IncludeStatus,
+						aggregateByKind: rr.AggregateByKind,
 					})
 				}
 			}
@@ -516,10 +519,11 @@ func watchRuleFingerprint(rule rulestore.CompiledRule) string {
 		rule.GitTargetNamespace, rule.GitTargetRef,
 		watchPlanDest(rule.GitProviderNamespace, rule.GitProviderRef, rule.Branch, rule.Path))
 	for _, rr := range rule.ResourceRules {
-		fmt.Fprintf(&b, "|rr[g=%s;v=%s;r=%s;op=%s;src=%s]",
+		fmt.Fprintf(&b, "|rr[g=%s;v=%s;r=%s;op=%s;src=%s;sel=%s;names=%s;status=%t;aggregate=%t]",
 			strings.Join(rr.APIGroups, ","), strings.Join(rr.APIVersions, ","),
 			strings.Join(rr.Resources, ","), operationsString(rr.Operations),
-			strings.Join(rr.SourceNamespaces, ","))
+			strings.Join(rr.SourceNamespaces, ","), selectorString(rr.ObjectSelector), rr.NameFilter.String(),
+			rr.IncludeStatus, rr.AggregateByKind)
 	}
 	return b.String()
 }
@@ -540,6 +544,14 @@ func clusterWatchRuleFingerprint(rule rulestore.CompiledClusterRule) string {
 	return b.String()
 }
 
+// selectorString renders a compiled object selector for a fingerprint; nil (every object) is "".
+func selectorString(sel labels.Selector) string {
+	if sel == nil {
+		return ""
+	}
+	return sel.String()
+}
+
 func operationsString(ops []configv1alpha3.OperationType) string {
 	if len(ops) == 0 {
 		return ""
@@ -5,9 +5,11 @@ package watch
 import (
 	"sort"
 
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	configv1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
+	"github.com/ConfigButler/gitops-reverser/internal/rulestore"
 	"github.com/ConfigButler/gitops-reverser/internal/types"
 	"github.com/ConfigButler/gitops-reverser/internal/typeset"
 )
@@ -47,6 +49,94 @@ func (s OperationSet) Sorted() []string {
 	return out
 }
 
+// ObjectFilter is one rule item's object filter: its label selector ANDed with its name globs. A
+// nil field selects every object on that axis.
+type ObjectFilter struct {
+	Labels labels.Selector
+	Names  *rulestore.NameFilter
+}
+
+// key is the filter's canonical string. A label-only filter keys on the selector alone, and one
+// that selects every object keys on "".
+func (f ObjectFilter) key() string {
+	key := ""
+	if f.Labels != nil {
+		key = f.Labels.String()
+	}
+	if f.Names != nil {
+		key += " names=" + f.Names.String()
+	}
+	return key
+}
+
+// matches reports whether an object with this name and these labels passes both halves.
+func (f ObjectFilter) matches(name string, objLabels map[string]string) bool {
+	if f.Labels != nil && !f.Labels.Matches(labels.Set(objLabels)) {
+		return false
+	}
+	return f.Names.Matches(name)
+}
+
+// ObjectSelectorSet is the union of the object filters recorded for a watched type in one
+// namespace, keyed by each filter's canonical string. The sentinel "" means every object and
+// subsumes the rest, because a selection without a selector or name globs asks for everything.
+type ObjectSelectorSet map[string]ObjectFilter
+
+// add folds a selection's selector and name globs into the set, normalising a nil or empty
+// selector to every label set, and a filter that then selects everything to the "" sentinel.
+func (s ObjectSelectorSet) add(sel labels.Selector, names *rulestore.NameFilter) {
+	if sel != nil && sel.Empty() {
+		sel = nil
+	}
+	filter := ObjectFilter{Labels: sel, Names: names}
+	s[filter.key()] = filter
+}
+
+// Match reports whether an object with this name and these labels is selected. A nil or empty
+// set, or one holding the "" sentinel, selects every object; otherwise the filters are ORed.
+func (s ObjectSelectorSet) Match(name string, objLabels map[string]string) bool {
+	if _, all := s[""]; all || len(s) == 0 {
+		return true
+	}
+	for _, filter := range s {
+		if filter.matches(name, objLabels) {
+			return true
+		}
+	}
+	return false
+}
+
+// ListSelector returns the label selector to hand the API server for this scope's list and
+// watch, or "" when it cannot narrow the stream. Only a single filter's selector can be pushed
+// down: the API server has no OR, so a scope with several is streamed whole and filtered by Match.
+// Name globs are never pushed down; Match applies them to whatever the stream delivers.
+func (s ObjectSelectorSet) ListSelector() string {
+	if len(s) != 1 {
+		return ""
+	}
+	for _, filter := range s {
+		if filter.Labels == nil {
+			return ""
+		}
+		return filter.Labels.String()
+	}
+	return ""
+}
+
+// Sorted returns the filters' canonical strings in a stable order, collapsing to nil when the
+// every-object sentinel is present.
+func (s ObjectSelectorSet) Sorted() []string {
+	if _, all := s[""]; all {
+		return nil
+	}
+	out := make([]string, 0, len(s))
+	for key := range s {
+		out = append(out, key)
+	}
+	sort.Strings(out)
+	return out
+}
+
 // WatchedType is one followable type a GitTarget watches: a (GVK, GVR, scope) triple
 // plus the namespace scope and served-version metadata, projected straight from the
 // type registry's followable set. The registry owns identity (GVK<->GVR is 1:1 there),
@@ -65,6 +155,18 @@ type WatchedType struct {
 	// stream: a cluster-scoped resource, or a namespaced resource a ClusterWatchRule
 	// follows across every namespace.
 	NamespaceOps map[string]OperationSet
+
+	// NamespaceSelectors maps each watched namespace to the union of object filters for this
+	// type in that namespace, keyed like NamespaceOps.
+	NamespaceSelectors map[string]ObjectSelectorSet
+
+	// NamespaceIncludeStatus marks the namespaces, keyed like NamespaceOps, in which a rule asked
+	// to keep this type's status in Git. An absent namespace strips it.
+	NamespaceIncludeStatus map[string]bool
+
+	// NamespaceAggregateByKind marks the namespaces, keyed like NamespaceOps, in which a rule
+	// asked to write this type's new objects as items of one List document.
+	NamespaceAggregateByKind map[string]bool
 }
 
 // ClusterWide reports whether this type is gathered under a cluster-wide scope: true for a
@@ -117,23 +219,32 @@ type WatchedTypeTable struct {
 }
 
 // watchSelection is one followable registry record a rule selected for a GitTarget,
-// with the namespace it was selected under ("" = cluster-wide stream) and the rule's
-// operation filters.
+// with the namespace it was selected under ("" = cluster-wide stream), the rule's
+// operation filters, its object selector and name globs (nil = every object), and whether
+// it keeps status and aggregates by kind.
 type watchSelection struct {
-	record    typeset.TypeRecord
-	namespace string
-	ops       []configv1alpha3.OperationType
+	record          typeset.TypeRecord
+	namespace       string
+	ops             []configv1alpha3.OperationType
+	selector        labels.Selector
+	names           *rulestore.NameFilter
+	includeStatus   bool
+	aggregateByKind bool
 }
 
-// watchedTypeAccum accumulates one followable record's namespace/operation scope while
-// folding a GitTarget's selections.
+// watchedTypeAccum accumulates one followable record's namespace/operation/selector scope
+// while folding a GitTarget's selections.
 type watchedTypeAccum struct {
-	record       typeset.TypeRecord
-	namespaceOps map[string]OperationSet
+	record             typeset.TypeRecord
+	namespaceOps       map[string]OperationSet
+	namespaceSelectors map[string]ObjectSelectorSet
+	includeStatus      map[string]bool
+	aggregateByKind    map[string]bool
 }
 
 // buildWatchedTypeTable folds a GitTarget's selected followable records into its
-// watched-type table, unioning each record's per-namespace operation filters. Identity
+// watched-type table, unioning each record's per-namespace operation filters and object
+// selectors. Identity
 // and followability are already settled by the registry, so this is a pure fold with no
 // catalog lookup and no conflict decision.
 func buildWatchedTypeTable(
@@ -146,7 +257,13 @@ func buildWatchedTypeTable(
 		gvr := sel.record.Identity.GVR
 		acc := byGVR[gvr]
 		if acc == nil {
-			acc = &watchedTypeAccum{record: sel.record, namespaceOps: map[string]OperationSet{}}
+			acc = &watchedTypeAccum{
+				record:             sel.record,
+				namespaceOps:       map[string]OperationSet{},
+				namespaceSelectors: map[string]ObjectSelectorSet{},
+				includeStatus:      map[string]bool{},
+				aggregateByKind:    map[string]bool{},
+			}
 			byGVR[gvr] = acc
 		}
 		opSet := acc.namespaceOps[sel.namespace]
@@ -155,27 +272,50 @@ func buildWatchedTypeTable(
 			acc.namespaceOps[sel.namespace] = opSet
 		}
 		opSet.add(sel.ops)
+		selectorSet := acc.namespaceSelectors[sel.namespace]
+		if selectorSet == nil {
+			selectorSet = ObjectSelectorSet{}
+			acc.namespaceSelectors[sel.namespace] = selectorSet
+		}
+		selectorSet.add(sel.selector, sel.names)
+		if sel.includeStatus {
+			acc.includeStatus[sel.namespace] = true
+		}
+		if sel.aggregateByKind {
+			acc.aggregateByKind[sel.namespace] = true
+		}
 	}
 
 	table := WatchedTypeTable{GitDest: gitDest, ResolvedAt: generation}
 	for _, acc := range byGVR {
-		table.Types = append(table.Types, watchedTypeFromRecord(acc.record, acc.namespaceOps))
+		table.Types = append(table.Types, watchedTypeFromRecord(
+			acc.record, acc.namespaceOps, acc.namespaceSelectors, acc.includeStatus, acc.aggregateByKind))
 	}
 	sortWatchedTypes(table.Types)
 	return table
 }
 
 // watchedTypeFromRecord copies a followable registry record's identity into a
-// WatchedType, attaching the per-namespace operation scope the rules folded.
-func watchedTypeFromRecord(rec typeset.TypeRecord, namespaceOps map[string]OperationSet) WatchedType {
+// WatchedType, attaching the per-namespace operation, selector, status and aggregation scope the
+// rules folded.
+func watchedTypeFromRecord(
+	rec typeset.TypeRecord,
+	namespaceOps map[string]OperationSet,
+	namespaceSelectors map[string]ObjectSelectorSet,
+	namespaceIncludeStatus map[string]bool,
+	namespaceAggregateByKind map[string]bool,
+) WatchedType {
 	return WatchedType{
-		GVK:           rec.Identity.GVK,
-		GVR:           rec.Identity.GVR,
-		Namespaced:    rec.Identity.Scope == typeset.ScopeNamespaced,
-		Scope:         resourceScopeFor(rec.Identity.Scope),
-		ServedVersion: rec.Identity.GVR.Version,
-		Preferred:     rec.Preferred,
-		NamespaceOps:  namespaceOps,
+		GVK:                      rec.Identity.GVK,
+		GVR:                      rec.Identity.GVR,
+		Namespaced:               rec.Identity.Scope == typeset.ScopeNamespaced,
+		Scope:                    resourceScopeFor(rec.Identity.Scope),
+		ServedVersion:            rec.Identity.GVR.Version,
+		Preferred:                rec.Preferred,
+		NamespaceOps:             namespaceOps,
+		NamespaceSelectors:       namespaceSelectors,
+		NamespaceIncludeStatus:   namespaceIncludeStatus,
+		NamespaceAggregateByKind: namespaceAggregateByKind,
 	}
 }
 
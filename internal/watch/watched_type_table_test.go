@@ -7,9 +7,11 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	configv1alpha3 "github.com/ConfigButler/gitops-reverser/api/v1alpha3"
+	"github.com/ConfigButler/gitops-reverser/internal/rulestore"
 	"github.com/ConfigButler/gitops-reverser/internal/types"
 	"github.com/ConfigButler/gitops-reverser/internal/typeset"
 )
@@ -127,6 +129,98 @@ func TestBuildWatchedTypeTable_EmptyOperationsAreAllOperations(t *testing.T) {
 	assert.Equal(t, []string{"*"}, table.Types[0].NamespaceOps["team-a"].Sorted())
 }
 
+// Object selectors fold per namespace like operations: differing selectors are kept side by side
+// and ORed, and a selection without one widens its scope to every object.
+func TestBuildWatchedTypeTable_ObjectSelectorsUnionPerNamespace(t *testing.T) {
+	cm := nsRecord("", "configmaps", "ConfigMap")
+	exported := labels.SelectorFromSet(labels.Set{"gitops.io/export": "true"})
+	prod := labels.SelectorFromSet(labels.Set{"tier": "prod"})
+	selections := []watchSelection{
+		{record: cm, namespace: "team-a", selector: exported},
+		{record: cm, namespace: "team-a", selector: prod},
+		{record: cm, namespace: "team-b", selector: exported},
+		{record: cm, namespace: "team-c", selector: exported},
+		{record: cm, namespace: "team-c"},
+	}
+
+	table := buildWatchedTypeTable(testGitDest(), 1, selections)
+
+	require.Len(t, table.Types, 1)
+	wt := table.Types[0]
+
+	teamA := wt.NamespaceSelectors["team-a"]
+	assert.Equal(t, []string{"gitops.io/export=true", "tier=prod"}, teamA.Sorted())
+	assert.Empty(t, teamA.ListSelector(), "the API server has no OR, so two selectors are filtered locally")
+	assert.True(t, teamA.Match("demo", map[string]string{"tier": "prod"}))
+	assert.False(t, teamA.Match("demo", map[string]string{"tier": "dev"}))
+
+	teamB := wt.NamespaceSelectors["team-b"]
+	assert.Equal(t, "gitops.io/export=true", teamB.ListSelector(), "a single selector is pushed down")
+	assert.False(t, teamB.Match("demo", nil))
+
+	teamC := wt.NamespaceSelectors["team-c"]
+	assert.Nil(t, teamC.Sorted(), "a selection without a selector asks for every object")
+	assert.Empty(t, teamC.ListSelector())
+	assert.True(t, teamC.Match("demo", nil))
+}
+
+// Name globs are part of an item's filter: they AND with its selector, and items with different
+// globs stay side by side and are ORed like differing selectors.
+func TestBuildWatchedTypeTable_NameGlobsFoldWithSelectors(t *testing.T) {
+	cm := nsRecord("", "configmaps", "ConfigMap")
+	exported := labels.SelectorFromSet(labels.Set{"gitops.io/export": "true"})
+	apps, err := rulestore.CompileNameFilter([]string{"app-*"}, []string{"app-secret*"})
+	require.NoError(t, err)
+	legacy, err := rulestore.CompileNameFilter([]string{"legacy-?"}, nil)
+	require.NoError(t, err)
+	selections := []watchSelection{
+		{record: cm, namespace: "team-a", selector: exported, names: apps},
+		{record: cm, namespace: "team-a", names: legacy},
+	}
+
+	table := buildWatchedTypeTable(testGitDest(), 1, selections)
+
+	require.Len(t, table.Types, 1)
+	teamA := table.Types[0].NamespaceSelectors["team-a"]
+	assert.Len(t, teamA.Sorted(), 2)
+	assert.Empty(t, teamA.ListSelector(), "two filters cannot be pushed down")
+	assert.True(t, teamA.Match("app-web", map[string]string{"gitops.io/export": "true"}))
+	assert.False(t, teamA.Match("app-web", nil), "the include only applies alongside its item's selector")
+	assert.False(t, teamA.Match("app-secret-1", map[string]string{"gitops.io/export": "true"}),
+		"an exclude wins over the include")
+	assert.True(t, teamA.Match("legacy-1", nil))
+	assert.False(t, teamA.Match("legacy-10", nil))
+}
+
+// Status is kept per namespace when any selection there asks for it.
+func TestBuildWatchedTypeTable_IncludeStatusPerNamespace(t *testing.T) {
+	cm := nsRecord("", "configmaps", "ConfigMap")
+	selections := []watchSelection{
+		{record: cm, namespace: "team-a"},
+		{record: cm, namespace: "team-a", includeStatus: true},
+		{record: cm, namespace: "team-b"},
+	}
+
+	table := buildWatchedTypeTable(testGitDest(), 1, selections)
+
+	require.Len(t, table.Types, 1)
+	assert.Equal(t, map[string]bool{"team-a": true}, table.Types[0].NamespaceIncludeStatus)
+}
+
+// Aggregation is decided per namespace, like status: any selection there that asks for it.
+func TestBuildWatchedTypeTable_AggregateByKindPerNamespace(t *testing.T) {
+	cm := nsRecord("", "configmaps", "ConfigMap")
+	selections := []watchSelection{
+		{record: cm, namespace: "team-a", aggregateByKind: true},
+		{record: cm, namespace: "team-b"},
+	}
+
+	table := buildWatchedTypeTable(testGitDest(), 1, selections)
+
+	require.Len(t, table.Types, 1)
+	assert.Equal(t, map[string]bool{"team-a": true}, table.Types[0].NamespaceAggregateByKind)
+}
+
 func TestBuildWatchedTypeTable_ClusterScopedType(t *testing.T) {
 	selections := []watchSelection{
 		{record: namespaceRecord(), namespace: ""},
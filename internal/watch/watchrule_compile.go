@@ -4,6 +4,7 @@ package watch
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	k8stypes "k8s.io/apimachinery/pkg/types"
@@ -35,6 +36,16 @@ type SourceScopeService interface {
 	ForgetSourceScopeGrant(rule k8stypes.NamespacedName)
 }
 
+// ErrInvalidObjectSelector is returned by CompileWatchRule for a rule item whose objectSelector
+// does not compile. It is terminal, unlike the transient errors CompileWatchRule otherwise returns:
+// the rule is removed from the store, and only an edit can fix it.
+var ErrInvalidObjectSelector = errors.New("invalid objectSelector")
+
+// ErrInvalidNameFilter is returned by CompileWatchRule for a rule item whose nameIncludes or
+// nameExcludes holds a malformed glob. Admission rejects the characters that could make one, so
+// this is a backstop; it is terminal in the same way as ErrInvalidObjectSelector.
+var ErrInvalidNameFilter = errors.New("invalid name glob")
+
 // CompileWatchRule is THE ONLY PATH from a WatchRule to a compiled rule. It resolves the whole
 // per-item source-namespace scope first and compiles only on an admitted verdict.
 //
@@ -60,6 +71,10 @@ type SourceScopeService interface {
 //     sweep, so failing closed while maintaining would delete a tenant's Git content over a
 //     transient outage.
 //
+// A rule item whose objectSelector or name globs do not compile is refused before any of this: the
+// rule is removed from the store and the returned error wraps ErrInvalidObjectSelector or
+// ErrInvalidNameFilter.
+//
 // Bootstrap cannot publish status (it runs before controllers start), so a rule denied there is
 // simply not compiled and the first reconcile writes the terminal condition. That ordering — fail
 // closed first, explain second — is correct, not a limitation.
@@ -75,6 +90,14 @@ func CompileWatchRule(
 	key := k8stypes.NamespacedName{Name: rule.Name, Namespace: rule.Namespace}
 	specHash := SourceScopeSpecHash(&rule)
 
+	if err := validateObjectFilters(rule); err != nil {
+		store.Delete(key)
+		if scope != nil {
+			scope.ForgetSourceScopeGrant(key)
+		}
+		return authz.ResolvedSourceScope{}, err
+	}
+
 	resolved, err := authz.ResolveWatchRuleSourceScope(ctx, reader, &rule, &target, resolverOf(scope))
 	if err != nil {
 		// Transient: leave whatever is compiled alone and let the caller requeue. Tearing down a
@@ -116,6 +139,21 @@ func CompileWatchRule(
 	return resolved, nil
 }
 
+// validateObjectFilters reports the first rule item whose objectSelector or name globs do not
+// compile, wrapped in ErrInvalidObjectSelector or ErrInvalidNameFilter.
+func validateObjectFilters(rule configv1alpha3.WatchRule) error {
+	for i := range rule.Spec.Rules {
+		item := &rule.Spec.Rules[i]
+		if _, err := item.CompileObjectSelector(); err != nil {
+			return fmt.Errorf("%w: rules[%d]: %w", ErrInvalidObjectSelector, i, err)
+		}
+		if _, err := rulestore.CompileNameFilter(item.NameIncludes, item.NameExcludes); err != nil {
+			return fmt.Errorf("%w: rules[%d]: %w", ErrInvalidNameFilter, i, err)
+		}
+	}
+	return nil
+}
+
 // itemNamespaces projects the resolved scope into the per-item slice the store compiles from.
 func itemNamespaces(resolved authz.ResolvedSourceScope) [][]string {
 	out := make([][]string, 0, len(resolved.Items))
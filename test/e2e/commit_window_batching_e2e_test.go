@@ -149,6 +149,16 @@ var _ = Describe("Commit Window Batching",
 
 			assertBurstFilesAreGroupedIntoLatestCommit(repo.CheckoutDir, burstNames, basePath, testNs)
 
+			By("checking the push recorded a CommitPushed Event on the GitTarget")
+			Eventually(func(g Gomega) {
+				out, err := kubectlRunInNamespace(testNs, "get", "events",
+					"--field-selector",
+					"involvedObject.kind=GitTarget,involvedObject.name="+gitTargetName+",reason=CommitPushed",
+					"-o", "jsonpath={.items[*].message}")
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(out).To(ContainSubstring(" to main"))
+			}, 30*time.Second, 2*time.Second).Should(Succeed())
+
 			By("cleaning up burst ConfigMaps")
 			for _, name := range burstNames {
 				_, _ = kubectlRunInNamespace(testNs, "delete", "configmap", name, "--ignore-not-found=true")